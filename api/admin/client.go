@@ -5,6 +5,7 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
@@ -66,12 +67,94 @@ func (c *Client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.Requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
 
+func (c *Client) CreateDBSnapshot(ctx context.Context, name string, options ...rpc.Option) (string, error) {
+	res := &CreateDBSnapshotReply{}
+	err := c.Requester.SendRequest(ctx, "admin.createDBSnapshot", &CreateDBSnapshotArgs{
+		Name: name,
+	}, res, options...)
+	return res.Path, err
+}
+
 func (c *Client) LoadVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error) {
 	res := &LoadVMsReply{}
 	err := c.Requester.SendRequest(ctx, "admin.loadVMs", struct{}{}, res, options...)
 	return res.NewVMs, res.FailedVMs, err
 }
 
+func (c *Client) ReloadConfig(ctx context.Context, options ...rpc.Option) ([]string, map[string]string, error) {
+	res := &ReloadConfigReply{}
+	err := c.Requester.SendRequest(ctx, "admin.reloadConfig", struct{}{}, res, options...)
+	return res.Applied, res.Rejected, err
+}
+
+func (c *Client) TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.trackSubnet", &TrackSubnetArgs{
+		SubnetID: subnetID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) UntrackSubnet(ctx context.Context, subnetID ids.ID, deleteData bool, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.untrackSubnet", &UntrackSubnetArgs{
+		SubnetID:   subnetID,
+		DeleteData: deleteData,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) StopChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.stopChain", &StopChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) StartChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.startChain", &StartChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) RestartChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.restartChain", &RestartChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) BanPeer(ctx context.Context, nodeID ids.NodeID, ipRange string, duration time.Duration, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.banPeer", &BanPeerArgs{
+		NodeID:   nodeID,
+		IPRange:  ipRange,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) UnbanPeer(ctx context.Context, nodeID ids.NodeID, ipRange string, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.unbanPeer", &UnbanPeerArgs{
+		NodeID:  nodeID,
+		IPRange: ipRange,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) AllowPeer(ctx context.Context, nodeID ids.NodeID, ipRange string, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.allowPeer", &AllowPeerArgs{
+		NodeID:  nodeID,
+		IPRange: ipRange,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) DisallowPeer(ctx context.Context, nodeID ids.NodeID, ipRange string, options ...rpc.Option) error {
+	return c.Requester.SendRequest(ctx, "admin.disallowPeer", &DisallowPeerArgs{
+		NodeID:  nodeID,
+		IPRange: ipRange,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *Client) GetPeerReputation(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) (*GetPeerReputationReply, error) {
+	res := &GetPeerReputationReply{}
+	err := c.Requester.SendRequest(ctx, "admin.getPeerReputation", &GetPeerReputationArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res, err
+}
+
 func (c *Client) SetLoggerLevel(
 	ctx context.Context,
 	loggerName,
@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/config/node"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
@@ -56,15 +58,24 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *api.EmptyReply:
 		response := mc.response.(*api.EmptyReply)
 		*p = *response
+	case *CreateDBSnapshotReply:
+		response := mc.response.(*CreateDBSnapshotReply)
+		*p = *response
 	case *GetChainAliasesReply:
 		response := mc.response.(*GetChainAliasesReply)
 		*p = *response
+	case *GetPeerReputationReply:
+		response := mc.response.(*GetPeerReputationReply)
+		*p = *response
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
+	case *ReloadConfigReply:
+		response := mc.response.(*ReloadConfigReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -165,6 +176,142 @@ func TestStacktrace(t *testing.T) {
 	}
 }
 
+func TestCreateDBSnapshot(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&CreateDBSnapshotReply{Path: "path"}, test.expectedErr)}
+			path, err := mockClient.CreateDBSnapshot(t.Context(), "name")
+			require.ErrorIs(t, err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(t, "path", path)
+			}
+		})
+	}
+}
+
+func TestTrackSubnet(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.TrackSubnet(t.Context(), ids.GenerateTestID())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestUntrackSubnet(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.UntrackSubnet(t.Context(), ids.GenerateTestID(), true)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestStopChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.StopChain(t.Context(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestStartChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.StartChain(t.Context(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRestartChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.RestartChain(t.Context(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestBanPeer(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.BanPeer(t.Context(), ids.GenerateTestNodeID(), "", time.Hour)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestUnbanPeer(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.UnbanPeer(t.Context(), ids.EmptyNodeID, "10.0.0.0/8")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAllowPeer(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.AllowPeer(t.Context(), ids.GenerateTestNodeID(), "")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestDisallowPeer(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := Client{Requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.DisallowPeer(t.Context(), ids.EmptyNodeID, "10.1.2.3")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestGetPeerReputation(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := &GetPeerReputationReply{
+			Peers: []PeerReputation{
+				{
+					NodeID:  ids.GenerateTestNodeID(),
+					Score:   -1.5,
+					Allowed: true,
+				},
+			},
+			IPRanges: []IPRangeReputation{
+				{
+					IPRange: "10.0.0.0/8",
+					Banned:  true,
+				},
+			},
+		}
+		mockClient := Client{Requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetPeerReputation(t.Context(), nil)
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := Client{Requester: NewMockClient(&GetPeerReputationReply{}, errTest)}
+		_, err := mockClient.GetPeerReputation(t.Context(), nil)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestReloadInstalledVMs(t *testing.T) {
 	t.Run("successful", func(t *testing.T) {
 		require := require.New(t)
@@ -195,6 +342,34 @@ func TestReloadInstalledVMs(t *testing.T) {
 	})
 }
 
+func TestReloadConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedApplied := []string{"log-level"}
+		expectedRejected := map[string]string{
+			"track-subnets": "changing this key requires a restart",
+		}
+		mockClient := Client{Requester: NewMockClient(&ReloadConfigReply{
+			ReloadResult: node.ReloadResult{
+				Applied:  expectedApplied,
+				Rejected: expectedRejected,
+			},
+		}, nil)}
+
+		applied, rejected, err := mockClient.ReloadConfig(t.Context())
+		require.NoError(err)
+		require.Equal(expectedApplied, applied)
+		require.Equal(expectedRejected, rejected)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := Client{Requester: NewMockClient(&ReloadConfigReply{}, errTest)}
+		_, _, err := mockClient.ReloadConfig(t.Context())
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestSetLoggerLevel(t *testing.T) {
 	type test struct {
 		name            string
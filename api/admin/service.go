@@ -4,10 +4,18 @@
 package admin
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/netip"
+	"os"
 	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -15,9 +23,11 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/config/node"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -41,13 +51,34 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errNoName       = errors.New("need to specify a name")
+	errNoPeer       = errors.New("need to specify exactly one of nodeID and ipRange")
+
+	errNegativeBanDuration = errors.New("ban duration must not be negative")
+	errInvalidSnapshotName = errors.New("snapshot name must be a local path")
+	errNoSnapshotDir       = errors.New("no database snapshot directory configured")
 )
 
+// ConfigReloader re-reads the configuration of the node.
+type ConfigReloader interface {
+	ReloadConfig() (node.ReloadResult, error)
+}
+
+// SubnetTracker starts and stops running the chains of subnets.
+type SubnetTracker interface {
+	TrackSubnet(subnetID ids.ID) error
+	UntrackSubnet(ctx context.Context, subnetID ids.ID, deleteData bool) error
+}
+
 type Config struct {
 	Log          logging.Logger
 	ProfileDir   string
+	SnapshotDir  string
 	LogFactory   logging.Factory
 	NodeConfig   interface{}
+	Reloader     ConfigReloader
+	Tracker      SubnetTracker
+	Reputation   reputation.Manager
 	DB           database.Database
 	ChainManager chains.Manager
 	HTTPServer   server.PathAdderWithReadLock
@@ -60,6 +91,10 @@ type Admin struct {
 	Config
 	lock     sync.RWMutex
 	profiler profiler.Profiler
+
+	// snapshotLock ensures that only one database snapshot is written at a
+	// time, without blocking the other admin calls while it is written.
+	snapshotLock sync.Mutex
 }
 
 // NewService returns a new admin API service.
@@ -320,6 +355,380 @@ func (a *Admin) GetConfig(_ *http.Request, _ *struct{}, reply *interface{}) erro
 	return nil
 }
 
+// ReloadConfigReply reports the keys whose values changed since the config
+// was last read.
+type ReloadConfigReply struct {
+	node.ReloadResult
+}
+
+// ReloadConfig re-reads the config file and applies the changes that do not
+// require restarting the node.
+func (a *Admin) ReloadConfig(_ *http.Request, _ *struct{}, reply *ReloadConfigReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "reloadConfig"),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// Tracking and untracking subnets creates and stops chains, which adds
+	// and removes their routes, which requires the http read lock to be
+	// released.
+	return a.HTTPServer.CallWithoutReadLock(func() error {
+		var err error
+		reply.ReloadResult, err = a.Reloader.ReloadConfig()
+		return err
+	})
+}
+
+type TrackSubnetArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// TrackSubnet starts running the chains of the provided subnet.
+func (a *Admin) TrackSubnet(_ *http.Request, args *TrackSubnetArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "trackSubnet"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Tracker.TrackSubnet(args.SubnetID)
+}
+
+type UntrackSubnetArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// DeleteData removes the databases and data directories of the chains of
+	// the subnet.
+	DeleteData bool `json:"deleteData"`
+}
+
+// UntrackSubnet stops the chains of the provided subnet.
+func (a *Admin) UntrackSubnet(r *http.Request, args *UntrackSubnetArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "untrackSubnet"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Bool("deleteData", args.DeleteData),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// Stopping the chains of the subnet removes their routes, which requires
+	// the http read lock to be released.
+	return a.HTTPServer.CallWithoutReadLock(func() error {
+		return a.Tracker.UntrackSubnet(r.Context(), args.SubnetID, args.DeleteData)
+	})
+}
+
+type StopChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// StopChain stops the provided chain without deleting its data.
+func (a *Admin) StopChain(r *http.Request, args *StopChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "stopChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.HTTPServer.CallWithoutReadLock(func() error {
+		return a.ChainManager.StopChain(r.Context(), chainID)
+	})
+}
+
+type StartChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// StartChain creates the provided chain again after it was stopped or failed
+// to be created.
+func (a *Admin) StartChain(_ *http.Request, args *StartChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "startChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.HTTPServer.CallWithoutReadLock(func() error {
+		return a.ChainManager.StartChain(chainID)
+	})
+}
+
+type RestartChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// RestartChain stops the provided chain and creates it again.
+func (a *Admin) RestartChain(r *http.Request, args *RestartChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "restartChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.HTTPServer.CallWithoutReadLock(func() error {
+		return a.ChainManager.RestartChain(r.Context(), chainID)
+	})
+}
+
+type BanPeerArgs struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	IPRange string     `json:"ipRange"`
+	// Duration of the ban, for example "24h". If empty or zero, the peer is
+	// banned until it is unbanned.
+	Duration string `json:"duration"`
+}
+
+// BanPeer bans the provided node or range of IPs and disconnects from the
+// banned peers.
+func (a *Admin) BanPeer(_ *http.Request, args *BanPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "banPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ipRange", args.IPRange),
+		logging.UserString("duration", args.Duration),
+	)
+
+	var duration time.Duration
+	if args.Duration != "" {
+		var err error
+		duration, err = time.ParseDuration(args.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		if duration < 0 {
+			return errNegativeBanDuration
+		}
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return updatePeer(
+		args.NodeID,
+		args.IPRange,
+		func(nodeID ids.NodeID) error {
+			return a.Reputation.BanNode(nodeID, duration)
+		},
+		func(ips netip.Prefix) error {
+			return a.Reputation.BanIPs(ips, duration)
+		},
+	)
+}
+
+type UnbanPeerArgs struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	IPRange string     `json:"ipRange"`
+}
+
+// UnbanPeer removes the ban of the provided node or range of IPs. Unbanning a
+// node also resets its reputation score.
+func (a *Admin) UnbanPeer(_ *http.Request, args *UnbanPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbanPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ipRange", args.IPRange),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return updatePeer(
+		args.NodeID,
+		args.IPRange,
+		a.Reputation.UnbanNode,
+		a.Reputation.UnbanIPs,
+	)
+}
+
+type AllowPeerArgs struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	IPRange string     `json:"ipRange"`
+}
+
+// AllowPeer allows the provided node or range of IPs, even if they are banned.
+// Allowed nodes are never banned automatically and are connected to whenever
+// their IPs are learned.
+func (a *Admin) AllowPeer(_ *http.Request, args *AllowPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "allowPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ipRange", args.IPRange),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return updatePeer(
+		args.NodeID,
+		args.IPRange,
+		a.Reputation.AllowNode,
+		a.Reputation.AllowIPs,
+	)
+}
+
+type DisallowPeerArgs struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	IPRange string     `json:"ipRange"`
+}
+
+// DisallowPeer removes the provided node or range of IPs from the allowed
+// peers.
+func (a *Admin) DisallowPeer(_ *http.Request, args *DisallowPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "disallowPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ipRange", args.IPRange),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return updatePeer(
+		args.NodeID,
+		args.IPRange,
+		a.Reputation.DisallowNode,
+		a.Reputation.DisallowIPs,
+	)
+}
+
+type GetPeerReputationArgs struct {
+	// NodeIDs to return the reputation of. If empty, every node that has a
+	// score, is banned or is allowed is returned.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+type PeerReputation struct {
+	NodeID  ids.NodeID   `json:"nodeID"`
+	Score   json.Float64 `json:"score"`
+	Allowed bool         `json:"allowed"`
+	Banned  bool         `json:"banned"`
+	// BannedUntil is omitted if the node isn't banned or is banned until it
+	// is unbanned.
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+type IPRangeReputation struct {
+	IPRange string `json:"ipRange"`
+	Allowed bool   `json:"allowed"`
+	Banned  bool   `json:"banned"`
+	// BannedUntil is omitted if the range isn't banned or is banned until it
+	// is unbanned.
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+type GetPeerReputationReply struct {
+	Peers    []PeerReputation    `json:"peers"`
+	IPRanges []IPRangeReputation `json:"ipRanges"`
+}
+
+// GetPeerReputation returns the reputation of the provided nodes and the
+// banned and allowed ranges of IPs.
+func (a *Admin) GetPeerReputation(_ *http.Request, args *GetPeerReputationArgs, reply *GetPeerReputationReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getPeerReputation"),
+		zap.Int("numNodeIDs", len(args.NodeIDs)),
+	)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	nodes := a.Reputation.Nodes(args.NodeIDs)
+	reply.Peers = make([]PeerReputation, len(nodes))
+	for i, node := range nodes {
+		reply.Peers[i] = PeerReputation{
+			NodeID:      node.NodeID,
+			Score:       json.Float64(node.Score),
+			Allowed:     node.Allowed,
+			Banned:      node.Banned,
+			BannedUntil: bannedUntil(node.BannedUntil),
+		}
+	}
+	slices.SortFunc(reply.Peers, func(a, b PeerReputation) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+
+	ipRanges := a.Reputation.IPRanges()
+	reply.IPRanges = make([]IPRangeReputation, len(ipRanges))
+	for i, ipRange := range ipRanges {
+		reply.IPRanges[i] = IPRangeReputation{
+			IPRange:     ipRange.IPs.String(),
+			Allowed:     ipRange.Allowed,
+			Banned:      ipRange.Banned,
+			BannedUntil: bannedUntil(ipRange.BannedUntil),
+		}
+	}
+	slices.SortFunc(reply.IPRanges, func(a, b IPRangeReputation) int {
+		return strings.Compare(a.IPRange, b.IPRange)
+	})
+	return nil
+}
+
+// updatePeer calls [updateNode] if only [nodeID] is provided and [updateIPs]
+// if only [ipRange] is provided.
+func updatePeer(
+	nodeID ids.NodeID,
+	ipRange string,
+	updateNode func(ids.NodeID) error,
+	updateIPs func(netip.Prefix) error,
+) error {
+	switch {
+	case nodeID != ids.EmptyNodeID && ipRange == "":
+		return updateNode(nodeID)
+	case nodeID == ids.EmptyNodeID && ipRange != "":
+		ips, err := reputation.ParseIPRange(ipRange)
+		if err != nil {
+			return fmt.Errorf("invalid IP range %q: %w", ipRange, err)
+		}
+		return updateIPs(ips)
+	default:
+		return errNoPeer
+	}
+}
+
+func bannedUntil(expiry time.Time) *time.Time {
+	if expiry.IsZero() {
+		return nil
+	}
+	return &expiry
+}
+
 // LoadVMsReply contains the response metadata for LoadVMs
 type LoadVMsReply struct {
 	// VMs and their aliases which were successfully loaded
@@ -413,3 +822,54 @@ func (a *Admin) DbGet(_ *http.Request, args *DBGetArgs, reply *DBGetReply) error
 	reply.Value, err = formatting.Encode(formatting.HexNC, value)
 	return err
 }
+
+type CreateDBSnapshotArgs struct {
+	// Name is the directory, relative to the configured snapshot directory,
+	// that the snapshot is written to. It must not already exist.
+	Name string `json:"name"`
+}
+
+type CreateDBSnapshotReply struct {
+	// Path is the directory that the snapshot was written to.
+	Path string `json:"path"`
+}
+
+// CreateDBSnapshot writes a consistent, point-in-time copy of the node's
+// database into the configured snapshot directory while the node is running.
+func (a *Admin) CreateDBSnapshot(_ *http.Request, args *CreateDBSnapshotArgs, reply *CreateDBSnapshotReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "createDBSnapshot"),
+		logging.UserString("name", args.Name),
+	)
+
+	if len(args.Name) == 0 {
+		return errNoName
+	}
+	if !filepath.IsLocal(args.Name) {
+		return fmt.Errorf("%w: %q", errInvalidSnapshotName, args.Name)
+	}
+	if len(a.SnapshotDir) == 0 {
+		return errNoSnapshotDir
+	}
+
+	snapshotPath := filepath.Join(a.SnapshotDir, args.Name)
+	if err := os.MkdirAll(filepath.Dir(snapshotPath), perms.ReadWriteExecute); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	a.snapshotLock.Lock()
+	defer a.snapshotLock.Unlock()
+
+	start := time.Now()
+	if err := database.Checkpoint(a.DB, snapshotPath); err != nil {
+		return err
+	}
+
+	a.Log.Info("created database snapshot",
+		zap.String("path", snapshotPath),
+		zap.Duration("duration", time.Since(start)),
+	)
+	reply.Path = snapshotPath
+	return nil
+}
@@ -5,13 +5,24 @@ package admin
 
 import (
 	"net/http"
+	"net/netip"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/config/node"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/corruptabledb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/reputation"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
@@ -179,3 +190,185 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceCreateDBSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := pebbledb.New(t.TempDir(), nil, logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+	defer db.Close()
+
+	snapshotDir := t.TempDir()
+	a := &Admin{Config: Config{
+		Log:         logging.NoLog{},
+		DB:          corruptabledb.New(db, logging.NoLog{}),
+		SnapshotDir: snapshotDir,
+	}}
+
+	key := []byte("hello")
+	value := []byte("world")
+	require.NoError(a.DB.Put(key, value))
+
+	reply := &CreateDBSnapshotReply{}
+	err = a.CreateDBSnapshot(nil, &CreateDBSnapshotArgs{}, reply)
+	require.ErrorIs(err, errNoName)
+
+	for _, name := range []string{
+		filepath.Join(t.TempDir(), "snapshot"),
+		filepath.Join("..", "snapshot"),
+	} {
+		err = a.CreateDBSnapshot(nil, &CreateDBSnapshotArgs{Name: name}, reply)
+		require.ErrorIs(err, errInvalidSnapshotName)
+	}
+
+	require.NoError(a.CreateDBSnapshot(nil, &CreateDBSnapshotArgs{Name: "snapshot"}, reply))
+	require.Equal(filepath.Join(snapshotDir, "snapshot"), reply.Path)
+
+	snapshot, err := pebbledb.New(reply.Path, nil, logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+	defer snapshot.Close()
+
+	gotValue, err := snapshot.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+
+	a.DB = memdb.New()
+	err = a.CreateDBSnapshot(nil, &CreateDBSnapshotArgs{Name: "memdb"}, reply)
+	require.ErrorIs(err, database.ErrCheckpointNotSupported)
+
+	a.SnapshotDir = ""
+	err = a.CreateDBSnapshot(nil, &CreateDBSnapshotArgs{Name: "snapshot"}, reply)
+	require.ErrorIs(err, errNoSnapshotDir)
+}
+
+func TestServicePeerReputation(t *testing.T) {
+	require := require.New(t)
+
+	reputationManager, err := reputation.NewManager(
+		logging.NoLog{},
+		memdb.New(),
+		validators.NewManager(),
+		validators.NewManager(),
+		reputation.Config{
+			Halflife:            reputation.DefaultHalflife,
+			SlowResponseLatency: reputation.DefaultSlowResponseLatency,
+			BanScore:            reputation.DefaultBanScore,
+			BanDuration:         reputation.DefaultBanDuration,
+			MaxBannedPortion:    reputation.DefaultMaxBannedPortion,
+		},
+	)
+	require.NoError(err)
+
+	a := &Admin{Config: Config{
+		Log:        logging.NoLog{},
+		Reputation: reputationManager,
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	err = a.BanPeer(nil, &BanPeerArgs{}, nil)
+	require.ErrorIs(err, errNoPeer)
+	err = a.BanPeer(nil, &BanPeerArgs{NodeID: nodeID, IPRange: "10.0.0.0/8"}, nil)
+	require.ErrorIs(err, errNoPeer)
+	err = a.BanPeer(nil, &BanPeerArgs{NodeID: nodeID, Duration: "-1h"}, nil)
+	require.ErrorIs(err, errNegativeBanDuration)
+
+	require.NoError(a.BanPeer(nil, &BanPeerArgs{NodeID: nodeID}, nil))
+	require.NoError(a.BanPeer(nil, &BanPeerArgs{IPRange: "10.0.0.0/8", Duration: "1h"}, nil))
+	require.NoError(a.AllowPeer(nil, &AllowPeerArgs{IPRange: "10.1.2.3"}, nil))
+	require.False(reputationManager.IsNodeAllowed(nodeID))
+	require.False(reputationManager.IsIPAllowed(netip.MustParseAddr("10.0.0.1")))
+	require.True(reputationManager.IsIPAllowed(netip.MustParseAddr("10.1.2.3")))
+
+	reply := &GetPeerReputationReply{}
+	require.NoError(a.GetPeerReputation(nil, &GetPeerReputationArgs{}, reply))
+	require.Equal(
+		[]PeerReputation{
+			{
+				NodeID: nodeID,
+				Banned: true,
+			},
+		},
+		reply.Peers,
+	)
+	require.Len(reply.IPRanges, 2)
+	require.Equal("10.0.0.0/8", reply.IPRanges[0].IPRange)
+	require.True(reply.IPRanges[0].Banned)
+	require.NotNil(reply.IPRanges[0].BannedUntil)
+	require.Equal("10.1.2.3/32", reply.IPRanges[1].IPRange)
+	require.True(reply.IPRanges[1].Allowed)
+
+	require.NoError(a.UnbanPeer(nil, &UnbanPeerArgs{NodeID: nodeID}, nil))
+	err = a.UnbanPeer(nil, &UnbanPeerArgs{NodeID: nodeID}, nil)
+	require.ErrorIs(err, reputation.ErrNotBanned)
+	require.True(reputationManager.IsNodeAllowed(nodeID))
+
+	require.NoError(a.DisallowPeer(nil, &DisallowPeerArgs{IPRange: "10.1.2.3"}, nil))
+	require.False(reputationManager.IsIPAllowed(netip.MustParseAddr("10.1.2.3")))
+}
+
+// routeLock mimics the api server, whose routes can only be modified while
+// the http read lock isn't held.
+type routeLock struct {
+	lock   sync.RWMutex
+	routes int
+}
+
+func (*routeLock) AddRouteWithReadLock(http.Handler, string, string) error {
+	return nil
+}
+
+func (*routeLock) AddAliasesWithReadLock(string, ...string) error {
+	return nil
+}
+
+func (r *routeLock) CallWithoutReadLock(f func() error) error {
+	r.lock.RUnlock()
+	defer r.lock.RLock()
+	return f()
+}
+
+func (r *routeLock) addRoute() error {
+	if !r.lock.TryLock() {
+		return errTest
+	}
+	defer r.lock.Unlock()
+
+	r.routes++
+	return nil
+}
+
+// trackSubnetsReloader reloads a track-subnets change, which creates a chain
+// and registers its routes.
+type trackSubnetsReloader struct {
+	server *routeLock
+}
+
+func (t *trackSubnetsReloader) ReloadConfig() (node.ReloadResult, error) {
+	if err := t.server.addRoute(); err != nil {
+		return node.ReloadResult{}, err
+	}
+	return node.ReloadResult{
+		Applied: []string{config.TrackSubnetsKey},
+	}, nil
+}
+
+func TestServiceReloadConfigTrackSubnets(t *testing.T) {
+	require := require.New(t)
+
+	server := &routeLock{}
+	admin := &Admin{Config: Config{
+		Log:        logging.NoLog{},
+		HTTPServer: server,
+		Reloader:   &trackSubnetsReloader{server: server},
+	}}
+
+	// API handlers are called with the http read lock held.
+	server.lock.RLock()
+	reply := &ReloadConfigReply{}
+	err := admin.ReloadConfig(nil, nil, reply)
+	server.lock.RUnlock()
+	require.NoError(err)
+
+	require.Equal([]string{config.TrackSubnetsKey}, reply.Applied)
+	require.Equal(1, server.routes)
+}
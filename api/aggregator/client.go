@@ -0,0 +1,65 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// Client for the aggregator API of a chain.
+type Client struct {
+	Requester rpc.EndpointRequester
+}
+
+// NewClient returns a client of the aggregator API of [chain].
+func NewClient(uri string, chain string) *Client {
+	return &Client{Requester: rpc.NewEndpointRequester(
+		uri + "/ext/bc/" + chain + "/aggregator",
+	)}
+}
+
+// AggregateSignatures returns [message] signed by [quorumPercentage] of the
+// weight of [subnetID]. If [subnetID] is empty, the subnet that validates the
+// source chain of [message] is used.
+func (c *Client) AggregateSignatures(
+	ctx context.Context,
+	message *warp.UnsignedMessage,
+	justification []byte,
+	subnetID ids.ID,
+	quorumPercentage uint64,
+	options ...rpc.Option,
+) (*warp.Message, error) {
+	messageStr, err := formatting.Encode(formatting.Hex, message.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	justificationStr, err := formatting.Encode(formatting.Hex, justification)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AggregateSignaturesReply{}
+	err = c.Requester.SendRequest(ctx, "aggregator.aggregateSignatures", &AggregateSignaturesArgs{
+		Message:          messageStr,
+		Justification:    justificationStr,
+		Encoding:         formatting.Hex,
+		SubnetID:         subnetID,
+		QuorumPercentage: json.Uint64(quorumPercentage),
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	signedMessageBytes, err := formatting.Decode(res.Encoding, res.Message)
+	if err != nil {
+		return nil, err
+	}
+	return warp.ParseMessage(signedMessageBytes)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	defaultQuorumPercentage = 67
+	maxQuorumPercentage     = 100
+)
+
+var (
+	errUnknownSourceChain      = errors.New("unknown source chain")
+	errWrongSubnet             = errors.New("subnet does not validate the source chain")
+	errInvalidQuorumPercentage = errors.New("invalid quorum percentage")
+	errNoValidators            = errors.New("subnet has no warp validators")
+	errQuorumNotReached        = errors.New("quorum not reached")
+)
+
+// SignatureAggregator aggregates validator signatures for warp messages.
+type SignatureAggregator interface {
+	AggregateSignatures(
+		ctx context.Context,
+		message *warp.Message,
+		justification []byte,
+		validators []*validators.Warp,
+		quorumNum uint64,
+		quorumDen uint64,
+	) (*warp.Message, *big.Int, *big.Int, error)
+}
+
+// Service is the API service for aggregating signatures of warp messages.
+type Service struct {
+	log        logging.Logger
+	state      validators.State
+	aggregator SignatureAggregator
+}
+
+// NewService returns a handler of the aggregator API. The source chain and
+// signing subnet of every message are validated against [state].
+func NewService(
+	log logging.Logger,
+	state validators.State,
+	aggregator SignatureAggregator,
+) (http.Handler, error) {
+	server := rpc.NewServer()
+	codec := json.NewCodec()
+	server.RegisterCodec(codec, "application/json")
+	server.RegisterCodec(codec, "application/json;charset=UTF-8")
+	return server, server.RegisterService(
+		&Service{
+			log:        log,
+			state:      state,
+			aggregator: aggregator,
+		},
+		"aggregator",
+	)
+}
+
+type AggregateSignaturesArgs struct {
+	// Message is the encoded unsigned warp message.
+	Message string `json:"message"`
+	// Justification is the encoded justification sent to validators alongside
+	// the message. It is optional.
+	Justification string              `json:"justification"`
+	Encoding      formatting.Encoding `json:"encoding"`
+	// SubnetID is the subnet whose validators sign the message. Defaults to
+	// the subnet that validates the source chain of the message. Only messages
+	// sent by the P-chain may be signed by a different subnet.
+	SubnetID ids.ID `json:"subnetID"`
+	// QuorumPercentage is the percentage of the subnet's weight that must sign
+	// the message. Defaults to 67.
+	QuorumPercentage json.Uint64 `json:"quorumPercentage"`
+}
+
+type AggregateSignaturesReply struct {
+	// Message is the encoded signed warp message.
+	Message      string              `json:"message"`
+	Encoding     formatting.Encoding `json:"encoding"`
+	SignedWeight json.Uint64         `json:"signedWeight"`
+	TotalWeight  json.Uint64         `json:"totalWeight"`
+}
+
+// AggregateSignatures collects signatures of a warp message until
+// [QuorumPercentage] of the subnet's weight has signed the message.
+func (s *Service) AggregateSignatures(r *http.Request, args *AggregateSignaturesArgs, reply *AggregateSignaturesReply) error {
+	s.log.Debug("API called",
+		zap.String("service", "aggregator"),
+		zap.String("method", "aggregateSignatures"),
+	)
+
+	messageBytes, err := formatting.Decode(args.Encoding, args.Message)
+	if err != nil {
+		return fmt.Errorf("couldn't decode message: %w", err)
+	}
+	message, err := warp.ParseUnsignedMessage(messageBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse message: %w", err)
+	}
+
+	var justification []byte
+	if args.Justification != "" {
+		justification, err = formatting.Decode(args.Encoding, args.Justification)
+		if err != nil {
+			return fmt.Errorf("couldn't decode justification: %w", err)
+		}
+	}
+
+	quorumPercentage := uint64(args.QuorumPercentage)
+	if quorumPercentage == 0 {
+		quorumPercentage = defaultQuorumPercentage
+	}
+	if quorumPercentage > maxQuorumPercentage {
+		return fmt.Errorf("%w: %d > %d", errInvalidQuorumPercentage, quorumPercentage, maxQuorumPercentage)
+	}
+
+	ctx := r.Context()
+	sourceChainID := message.SourceChainID
+	sourceSubnetID, err := s.state.GetSubnetID(ctx, sourceChainID)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", errUnknownSourceChain, sourceChainID, err)
+	}
+
+	// Messages sent by the P-chain are verified against the validators of the
+	// receiving subnet, so they may be signed by any subnet.
+	subnetID := args.SubnetID
+	switch {
+	case subnetID == ids.Empty:
+		subnetID = sourceSubnetID
+	case subnetID != sourceSubnetID && sourceChainID != constants.PlatformChainID:
+		return fmt.Errorf("%w: %s is validated by %s, not %s", errWrongSubnet, sourceChainID, sourceSubnetID, subnetID)
+	}
+
+	height, err := s.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't get current P-chain height: %w", err)
+	}
+	validatorSets, err := s.state.GetWarpValidatorSets(ctx, height)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator sets at height %d: %w", height, err)
+	}
+	validatorSet, ok := validatorSets[subnetID]
+	if !ok || len(validatorSet.Validators) == 0 {
+		return fmt.Errorf("%w: %s at height %d", errNoValidators, subnetID, height)
+	}
+
+	unsignedMessage, err := warp.NewMessage(message, &warp.BitSetSignature{})
+	if err != nil {
+		return fmt.Errorf("couldn't create message: %w", err)
+	}
+	signedMessage, signedWeight, _, err := s.aggregator.AggregateSignatures(
+		ctx,
+		unsignedMessage,
+		justification,
+		validatorSet.Validators,
+		quorumPercentage,
+		maxQuorumPercentage,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't aggregate signatures: %w", err)
+	}
+
+	// Warp verification requires the quorum of the total weight, including
+	// the weight of validators without a public key.
+	totalWeight := new(big.Int).SetUint64(validatorSet.TotalWeight)
+	minWeight := new(big.Int).Mul(totalWeight, new(big.Int).SetUint64(quorumPercentage))
+	if new(big.Int).Mul(signedWeight, big.NewInt(maxQuorumPercentage)).Cmp(minWeight) < 0 {
+		return fmt.Errorf("%w: %s of %d weight signed", errQuorumNotReached, signedWeight, validatorSet.TotalWeight)
+	}
+
+	reply.Message, err = formatting.Encode(args.Encoding, signedMessage.Bytes())
+	if err != nil {
+		return fmt.Errorf("couldn't encode message: %w", err)
+	}
+	reply.Encoding = args.Encoding
+	reply.SignedWeight = json.Uint64(signedWeight.Uint64())
+	reply.TotalWeight = json.Uint64(validatorSet.TotalWeight)
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+type aggregatorFunc func(
+	ctx context.Context,
+	message *warp.Message,
+	justification []byte,
+	validators []*validators.Warp,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, *big.Int, *big.Int, error)
+
+func (f aggregatorFunc) AggregateSignatures(
+	ctx context.Context,
+	message *warp.Message,
+	justification []byte,
+	validators []*validators.Warp,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, *big.Int, *big.Int, error) {
+	return f(ctx, message, justification, validators, quorumNum, quorumDen)
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	var (
+		chainID     = ids.GenerateTestID()
+		subnetID    = ids.GenerateTestID()
+		otherSubnet = ids.GenerateTestID()
+		vdrs        = []*validators.Warp{
+			{Weight: 3},
+		}
+	)
+	newMessage := func(sourceChainID ids.ID) (*warp.UnsignedMessage, string) {
+		message, err := warp.NewUnsignedMessage(
+			1,
+			sourceChainID,
+			[]byte("payload"),
+		)
+		require.NoError(t, err)
+		messageHex, err := formatting.Encode(formatting.Hex, message.Bytes())
+		require.NoError(t, err)
+		return message, messageHex
+	}
+	message, messageHex := newMessage(chainID)
+	pChainMessage, pChainMessageHex := newMessage(constants.PlatformChainID)
+	unknownChainMessage, unknownChainMessageHex := newMessage(ids.GenerateTestID())
+
+	tests := []struct {
+		name                 string
+		message              *warp.UnsignedMessage
+		args                 AggregateSignaturesArgs
+		signedWeight         uint64
+		expectedQuorumNum    uint64
+		expectedSignedWeight uint64
+		expectedErr          error
+	}{
+		{
+			name:    "default quorum and subnet",
+			message: message,
+			args: AggregateSignaturesArgs{
+				Message: messageHex,
+			},
+			signedWeight:         3,
+			expectedQuorumNum:    defaultQuorumPercentage,
+			expectedSignedWeight: 3,
+		},
+		{
+			name:    "P-chain message signed by another subnet",
+			message: pChainMessage,
+			args: AggregateSignaturesArgs{
+				Message:  pChainMessageHex,
+				SubnetID: subnetID,
+			},
+			signedWeight:         3,
+			expectedQuorumNum:    defaultQuorumPercentage,
+			expectedSignedWeight: 3,
+		},
+		{
+			name:    "quorum not reached",
+			message: message,
+			args: AggregateSignaturesArgs{
+				Message:          messageHex,
+				QuorumPercentage: 100,
+			},
+			signedWeight:      3,
+			expectedQuorumNum: 100,
+			expectedErr:       errQuorumNotReached,
+		},
+		{
+			name:    "unknown source chain",
+			message: unknownChainMessage,
+			args: AggregateSignaturesArgs{
+				Message: unknownChainMessageHex,
+			},
+			expectedErr: errUnknownSourceChain,
+		},
+		{
+			name:    "wrong subnet",
+			message: message,
+			args: AggregateSignaturesArgs{
+				Message:  messageHex,
+				SubnetID: otherSubnet,
+			},
+			expectedErr: errWrongSubnet,
+		},
+		{
+			name:    "invalid quorum percentage",
+			message: message,
+			args: AggregateSignaturesArgs{
+				Message:          messageHex,
+				QuorumPercentage: 101,
+			},
+			expectedErr: errInvalidQuorumPercentage,
+		},
+		{
+			name:    "no validators",
+			message: pChainMessage,
+			args: AggregateSignaturesArgs{
+				Message:  pChainMessageHex,
+				SubnetID: otherSubnet,
+			},
+			expectedErr: errNoValidators,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			state := &validatorstest.State{
+				GetCurrentHeightF: func(context.Context) (uint64, error) {
+					return 10, nil
+				},
+				GetSubnetIDF: func(_ context.Context, gotChainID ids.ID) (ids.ID, error) {
+					switch gotChainID {
+					case chainID:
+						return subnetID, nil
+					case constants.PlatformChainID:
+						return constants.PrimaryNetworkID, nil
+					default:
+						return ids.Empty, database.ErrNotFound
+					}
+				},
+				GetWarpValidatorSetsF: func(_ context.Context, height uint64) (map[ids.ID]validators.WarpSet, error) {
+					require.Equal(uint64(10), height)
+					return map[ids.ID]validators.WarpSet{
+						subnetID: {
+							Validators: vdrs,
+							// Includes weight of validators without a public
+							// key.
+							TotalWeight: 4,
+						},
+					}, nil
+				},
+			}
+			aggregator := aggregatorFunc(func(
+				_ context.Context,
+				msg *warp.Message,
+				_ []byte,
+				gotValidators []*validators.Warp,
+				quorumNum uint64,
+				quorumDen uint64,
+			) (*warp.Message, *big.Int, *big.Int, error) {
+				require.Equal(test.message.ID(), msg.UnsignedMessage.ID())
+				require.Equal(vdrs, gotValidators)
+				require.Equal(test.expectedQuorumNum, quorumNum)
+				require.Equal(uint64(maxQuorumPercentage), quorumDen)
+				return msg, new(big.Int).SetUint64(test.signedWeight), big.NewInt(3), nil
+			})
+
+			service := &Service{
+				log:        logging.NoLog{},
+				state:      state,
+				aggregator: aggregator,
+			}
+			request, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "", nil)
+			require.NoError(err)
+
+			reply := &AggregateSignaturesReply{}
+			err = service.AggregateSignatures(request, &test.args, reply)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.Equal(json.Uint64(test.expectedSignedWeight), reply.SignedWeight)
+			require.Equal(json.Uint64(4), reply.TotalWeight)
+
+			signedMessageBytes, err := formatting.Decode(reply.Encoding, reply.Message)
+			require.NoError(err)
+			signedMessage, err := warp.ParseMessage(signedMessageBytes)
+			require.NoError(err)
+			require.Equal(test.message.ID(), signedMessage.UnsignedMessage.ID())
+		})
+	}
+}
@@ -126,3 +126,34 @@ type GetUTXOsReply struct {
 	// Encoding specifies the encoding format the UTXOs are returned in
 	Encoding formatting.Encoding `json:"encoding"`
 }
+
+// AddressTx is a transaction and the height of the block it was accepted in.
+// Marks a starting or stopping point when fetching the transactions of an
+// address. Used for pagination.
+type AddressTx struct {
+	Height avajson.Uint64 `json:"height"`
+	TxID   ids.ID         `json:"txID"`
+}
+
+// GetAddressTxsArgs are arguments for passing into GetAddressTxs.
+// Gets the accepted transactions that consumed or produced UTXOs owned by
+// [Address], ordered by height.
+// If [Limit] == 0 or > [maxPageSize], fetches up to [maxPageSize].
+// If specified, only transactions after [StartIndex] are returned.
+type GetAddressTxsArgs struct {
+	Address    string         `json:"address"`
+	Limit      avajson.Uint32 `json:"limit"`
+	StartIndex AddressTx      `json:"startIndex"`
+}
+
+// GetAddressTxsReply defines the GetAddressTxs replies returned from the API
+type GetAddressTxsReply struct {
+	// Number of transactions returned
+	NumFetched avajson.Uint64 `json:"numFetched"`
+	// The transactions
+	Txs []AddressTx `json:"txs"`
+	// The last transaction that was returned. Used for pagination. To get the
+	// rest of the transactions, call GetAddressTxs again and set [StartIndex]
+	// to this value.
+	EndIndex AddressTx `json:"endIndex"`
+}
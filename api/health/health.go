@@ -49,6 +49,10 @@ type Registerer interface {
 	RegisterReadinessCheck(name string, checker Checker, tags ...string) error
 	RegisterHealthCheck(name string, checker Checker, tags ...string) error
 	RegisterLivenessCheck(name string, checker Checker, tags ...string) error
+
+	// DeregisterHealthCheck removes the health check with [name]. Returns true
+	// if the check was registered.
+	DeregisterHealthCheck(name string) bool
 }
 
 // Reporter returns the current health status.
@@ -93,6 +97,10 @@ func (h *health) RegisterLivenessCheck(name string, checker Checker, tags ...str
 	return h.liveness.RegisterCheck(name, checker, tags...)
 }
 
+func (h *health) DeregisterHealthCheck(name string) bool {
+	return h.health.DeregisterCheck(name)
+}
+
 func (h *health) Readiness(tags ...string) (map[string]Result, bool) {
 	results, healthy := h.readiness.Results(tags...)
 	if !healthy {
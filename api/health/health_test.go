@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/utils"
@@ -390,3 +391,52 @@ func TestTags(t *testing.T) {
 		require.False(health)
 	}
 }
+
+func TestDeregister(t *testing.T) {
+	require := require.New(t)
+
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(h.RegisterHealthCheck("check1", check, "tag1"))
+	require.NoError(h.RegisterHealthCheck("check2", check, ApplicationTag))
+
+	w := h.(*health).health
+	numFailing := func(tag string) float64 {
+		return testutil.ToFloat64(w.failingChecks.With(prometheus.Labels{
+			CheckLabel: w.name,
+			TagLabel:   tag,
+		}))
+	}
+	require.Equal(float64(2), numFailing(AllTag))
+	require.Equal(float64(2), numFailing("tag1"))
+
+	require.True(h.DeregisterHealthCheck("check1"))
+	require.False(h.DeregisterHealthCheck("check1"))
+
+	healthResult, health := h.Health()
+	require.Len(healthResult, 1)
+	require.Contains(healthResult, "check2")
+	require.False(health)
+	require.Equal(float64(1), numFailing(AllTag))
+	require.Zero(numFailing("tag1"))
+
+	// The check can be registered again.
+	require.NoError(h.RegisterHealthCheck("check1", check, "tag1"))
+	require.Equal(float64(2), numFailing(AllTag))
+	require.Equal(float64(2), numFailing("tag1"))
+
+	require.True(h.DeregisterHealthCheck("check2"))
+	require.Equal(float64(1), numFailing(AllTag))
+	require.Equal(float64(1), numFailing("tag1"))
+
+	h.Start(t.Context(), checkFreq)
+	defer h.Stop()
+
+	awaitHealthy(t, h, true)
+	require.Zero(numFailing(AllTag))
+	require.Zero(numFailing("tag1"))
+}
@@ -124,6 +124,55 @@ func (w *worker) RegisterCheck(name string, check Checker, tags ...string) error
 	return nil
 }
 
+// DeregisterCheck removes the check with [name]. Returns true if the check was
+// registered.
+func (w *worker) DeregisterCheck(name string) bool {
+	w.checksLock.Lock()
+	defer w.checksLock.Unlock()
+
+	tc, ok := w.checks[name]
+	if !ok {
+		return false
+	}
+
+	w.resultsLock.Lock()
+	defer w.resultsLock.Unlock()
+
+	// A failing check no longer counts towards the failing checks of its tags.
+	if w.results[name].Error != nil {
+		w.updateMetrics(tc, true /*=healthy*/, false /*=register*/)
+	}
+
+	delete(w.checks, name)
+	delete(w.results, name)
+	for tag, names := range w.tags {
+		if !names.Contains(name) {
+			continue
+		}
+		names.Remove(name)
+		if names.Len() > 0 || tag == AllTag {
+			continue
+		}
+
+		// The tag no longer has any checks, so it no longer reports the
+		// failing application-wide checks it was initialized with.
+		delete(w.tags, tag)
+		if tag != ApplicationTag {
+			w.failingChecks.With(prometheus.Labels{
+				CheckLabel: w.name,
+				TagLabel:   tag,
+			}).Sub(float64(w.numFailingApplicationChecks))
+		}
+	}
+
+	w.log.Info("deregistered check",
+		zap.String("name", w.name),
+		zap.String("name", name),
+		zap.Strings("tags", tc.tags),
+	)
+	return true
+}
+
 func (w *worker) RegisterMonotonicCheck(name string, checker Checker, tags ...string) error {
 	var result utils.Atomic[any]
 	return w.RegisterCheck(name, CheckerFunc(func(ctx context.Context) (any, error) {
@@ -236,7 +285,11 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 
 	w.resultsLock.Lock()
 	defer w.resultsLock.Unlock()
-	prevResult := w.results[name]
+	prevResult, ok := w.results[name]
+	if !ok {
+		// The check was deregistered while it was running.
+		return
+	}
 	if err != nil {
 		errString := err.Error()
 		result.Error = &errString
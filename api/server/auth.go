@@ -0,0 +1,169 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	jwtAlgorithm = "HS256"
+)
+
+var _ Middleware = (*authMiddleware)(nil)
+
+// AuthConfig specifies which credentials are required to access the APIs.
+//
+// Routes are the bases that APIs are registered at, such as "admin", "info",
+// "index", or a chain's name such as "X". A route also grants access to every
+// route nested beneath it, so "index" grants access to "index/X/tx". The
+// wildcard route "*" grants access to every API.
+type AuthConfig struct {
+	// Tokens maps bearer tokens to the routes they may access.
+	Tokens map[string][]string `json:"tokens"`
+	// JWTSecret, if non-empty, allows requests to be authenticated with HS256
+	// JWTs signed with it. The "routes" claim of the JWT lists the routes it
+	// may access and the optional "exp" claim specifies when it expires.
+	JWTSecret string `json:"jwtSecret"`
+	// PublicRoutes may be accessed without authentication.
+	PublicRoutes []string `json:"publicRoutes"`
+}
+
+// Enabled returns true if requests must be authenticated.
+func (c *AuthConfig) Enabled() bool {
+	return len(c.Tokens) > 0 || len(c.JWTSecret) > 0
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Routes    []string `json:"routes"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// authMiddleware rejects requests that do not include a bearer token, or JWT,
+// that grants access to the requested API.
+type authMiddleware struct {
+	config    AuthConfig
+	jwtSecret []byte
+	clock     mockable.Clock
+}
+
+// NewAuthMiddleware returns middleware that authenticates requests according
+// to [config].
+func NewAuthMiddleware(config AuthConfig) Middleware {
+	return &authMiddleware{
+		config:    config,
+		jwtSecret: []byte(config.JWTSecret),
+	}
+}
+
+func (a *authMiddleware) Check(base string, r *http.Request) error {
+	for _, route := range a.config.PublicRoutes {
+		if matchesRoute(route, base) {
+			return nil
+		}
+	}
+
+	authorization := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(authorization, bearerPrefix)
+	if !ok || len(token) == 0 {
+		return fmt.Errorf("%w: missing bearer token", ErrUnauthorized)
+	}
+
+	routes, err := a.routes(token)
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		if matchesRoute(route, base) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: token does not grant access to %q", ErrForbidden, base)
+}
+
+// routes returns the routes that [token] grants access to.
+func (a *authMiddleware) routes(token string) ([]string, error) {
+	// Every token is compared to avoid leaking which tokens are valid through
+	// timing.
+	var (
+		tokenBytes = []byte(token)
+		routes     []string
+		found      bool
+	)
+	for allowedToken, allowedRoutes := range a.config.Tokens {
+		if subtle.ConstantTimeCompare(tokenBytes, []byte(allowedToken)) == 1 {
+			routes = allowedRoutes
+			found = true
+		}
+	}
+	if found {
+		return routes, nil
+	}
+
+	if len(a.jwtSecret) == 0 {
+		return nil, fmt.Errorf("%w: invalid bearer token", ErrUnauthorized)
+	}
+	return a.verifyJWT(token)
+}
+
+// verifyJWT verifies that [token] is a JWT signed with the configured secret
+// and returns the routes it grants access to.
+func (a *authMiddleware) verifyJWT(token string) ([]string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: invalid bearer token", ErrUnauthorized)
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != jwtAlgorithm {
+		return nil, fmt.Errorf("%w: unsupported JWT algorithm %q", ErrUnauthorized, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid JWT signature encoding: %w", ErrUnauthorized, err)
+	}
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	_, _ = mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: invalid JWT signature", ErrUnauthorized)
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt != 0 && a.clock.Time().Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("%w: JWT expired", ErrUnauthorized)
+	}
+	return claims.Routes, nil
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: invalid JWT encoding: %w", ErrUnauthorized, err)
+	}
+	if err := json.Unmarshal(bytes, v); err != nil {
+		return fmt.Errorf("%w: invalid JWT: %w", ErrUnauthorized, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "secret"
+
+func newTestJWT(t *testing.T, secret string, header jwtHeader, claims jwtClaims) string {
+	require := require.New(t)
+
+	headerBytes, err := json.Marshal(header)
+	require.NoError(err)
+	claimsBytes, err := json.Marshal(claims)
+	require.NoError(err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(claimsBytes)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	now := time.Unix(1_000, 0)
+	validHeader := jwtHeader{Algorithm: jwtAlgorithm}
+
+	tests := []struct {
+		name        string
+		base        string
+		token       string
+		expectedErr error
+	}{
+		{
+			name:        "public route",
+			base:        "health",
+			expectedErr: nil,
+		},
+		{
+			name:        "missing token",
+			base:        "info",
+			expectedErr: ErrUnauthorized,
+		},
+		{
+			name:        "unknown token",
+			base:        "info",
+			token:       "unknown",
+			expectedErr: ErrUnauthorized,
+		},
+		{
+			name:        "admin token can access admin",
+			base:        "admin",
+			token:       "admin-token",
+			expectedErr: nil,
+		},
+		{
+			name:        "wildcard token can access chain",
+			base:        "X",
+			token:       "admin-token",
+			expectedErr: nil,
+		},
+		{
+			name:        "user token can not access admin",
+			base:        "admin",
+			token:       "user-token",
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:        "user token can access nested route",
+			base:        "index/X/tx",
+			token:       "user-token",
+			expectedErr: nil,
+		},
+		{
+			name: "valid jwt",
+			base: "info",
+			token: newTestJWT(t, testJWTSecret, validHeader, jwtClaims{
+				Routes:    []string{"info"},
+				ExpiresAt: now.Unix() + 1,
+			}),
+			expectedErr: nil,
+		},
+		{
+			name: "jwt without access",
+			base: "admin",
+			token: newTestJWT(t, testJWTSecret, validHeader, jwtClaims{
+				Routes: []string{"info"},
+			}),
+			expectedErr: ErrForbidden,
+		},
+		{
+			name: "expired jwt",
+			base: "info",
+			token: newTestJWT(t, testJWTSecret, validHeader, jwtClaims{
+				Routes:    []string{"info"},
+				ExpiresAt: now.Unix(),
+			}),
+			expectedErr: ErrUnauthorized,
+		},
+		{
+			name: "jwt signed with wrong secret",
+			base: "info",
+			token: newTestJWT(t, "wrong", validHeader, jwtClaims{
+				Routes: []string{"info"},
+			}),
+			expectedErr: ErrUnauthorized,
+		},
+		{
+			name: "jwt with unsupported algorithm",
+			base: "info",
+			token: newTestJWT(t, testJWTSecret, jwtHeader{Algorithm: "none"}, jwtClaims{
+				Routes: []string{"info"},
+			}),
+			expectedErr: ErrUnauthorized,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewAuthMiddleware(AuthConfig{
+				Tokens: map[string][]string{
+					"admin-token": {wildcard},
+					"user-token":  {"info", "index"},
+				},
+				JWTSecret:    testJWTSecret,
+				PublicRoutes: []string{"health"},
+			}).(*authMiddleware)
+			m.clock.Set(now)
+
+			r := httptest.NewRequest(http.MethodPost, "/ext/"+test.base, nil)
+			if len(test.token) > 0 {
+				r.Header.Set("Authorization", bearerPrefix+test.token)
+			}
+
+			err := m.Check(test.base, r)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -15,6 +15,7 @@ type metrics struct {
 	numProcessing *prometheus.GaugeVec
 	numCalls      *prometheus.CounterVec
 	totalDuration *prometheus.GaugeVec
+	numRejected   *prometheus.CounterVec
 }
 
 func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
@@ -40,12 +41,20 @@ func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
 			},
 			[]string{"base"},
 		),
+		numRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "calls_rejected",
+				Help: "The number of calls this API has rejected before handling them",
+			},
+			[]string{"base", "reason"},
+		),
 	}
 
 	err := errors.Join(
 		registerer.Register(m.numProcessing),
 		registerer.Register(m.numCalls),
 		registerer.Register(m.totalDuration),
+		registerer.Register(m.numRejected),
 	)
 	return m, err
 }
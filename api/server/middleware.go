@@ -0,0 +1,70 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Middleware decides whether API requests should be handled.
+type Middleware interface {
+	// Check returns an error if the request to the API registered at [base]
+	// should be rejected. The returned error should wrap ErrUnauthorized,
+	// ErrForbidden, or ErrRateLimited.
+	//
+	// Check may read the body of [r], but must replace it so that it can be
+	// read again by the handler.
+	Check(base string, r *http.Request) error
+}
+
+func (s *server) applyMiddleware(base string, handler http.Handler) http.Handler {
+	if len(s.middleware) == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, m := range s.middleware {
+			err := m.Check(base, r)
+			if err == nil {
+				continue
+			}
+
+			statusCode, reason := rejection(err)
+			s.metrics.numRejected.WithLabelValues(base, reason).Inc()
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// rejection returns the status code and metrics label that should be reported
+// for a request that was rejected with [err].
+func rejection(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized, "unauthorized"
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests, "rate_limited"
+	default:
+		return http.StatusForbidden, "forbidden"
+	}
+}
+
+// matchesRoute returns true if [route] grants access to the API registered at
+// [base]. A route matches its own base, every base nested beneath it, and, if
+// it is the wildcard, every base.
+func matchesRoute(route string, base string) bool {
+	return route == wildcard ||
+		route == base ||
+		strings.HasPrefix(base, route+"/")
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMiddlewareRejection(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := newMetrics(prometheus.NewRegistry())
+	require.NoError(err)
+
+	s := &server{
+		metrics: metrics,
+		middleware: []Middleware{
+			NewAuthMiddleware(AuthConfig{
+				Tokens: map[string][]string{
+					"token": {"info"},
+				},
+			}),
+		},
+	}
+	handler := s.applyMiddleware("admin", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ext/admin", nil))
+	require.Equal(http.StatusUnauthorized, w.Code)
+	require.InDelta(1, testutil.ToFloat64(metrics.numRejected.WithLabelValues("admin", "unauthorized")), 0)
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/admin", nil)
+	r.Header.Set("Authorization", bearerPrefix+"token")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(http.StatusForbidden, w.Code)
+	require.InDelta(1, testutil.ToFloat64(metrics.numRejected.WithLabelValues("admin", "forbidden")), 0)
+
+	s.middleware = nil
+	handler = s.applyMiddleware("admin", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ext/admin", nil))
+	require.Equal(http.StatusTeapot, w.Code)
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/cache/lru"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// DefaultMaxClients is the default number of clients whose rate limiters
+	// are tracked.
+	DefaultMaxClients = 16_384
+	// DefaultMaxBodySize is the default number of bytes of a request body that
+	// are read to count its JSON-RPC calls.
+	DefaultMaxBodySize = 16 * units.MiB
+)
+
+var _ Middleware = (*rateLimitMiddleware)(nil)
+
+// RateLimit specifies a token bucket that is refilled at [Rate] tokens per
+// second and holds at most [Burst] tokens. Each request consumes one token. If
+// [Rate] is not positive, requests are not limited.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// Enabled returns true if requests should be limited.
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0
+}
+
+// RateLimitConfig specifies the rate at which each client may call the APIs.
+// Clients are identified by their IP address.
+type RateLimitConfig struct {
+	// PerIP limits the number of requests each client may make.
+	PerIP RateLimit `json:"perIP"`
+	// PerMethod limits the number of calls each client may make to each
+	// JSON-RPC method. Every call included in a batch request is counted.
+	PerMethod map[string]RateLimit `json:"perMethod"`
+	// MaxClients is the maximum number of clients whose rate limiters are
+	// tracked. If more clients make requests, the least recently seen clients
+	// have their limits reset. Defaults to DefaultMaxClients.
+	MaxClients int `json:"maxClients"`
+	// MaxBodySize is the maximum size, in bytes, of the body of a request
+	// when PerMethod is set. Larger requests are rejected without being
+	// handled. Defaults to DefaultMaxBodySize.
+	MaxBodySize int64 `json:"maxBodySize"`
+}
+
+// Enabled returns true if requests should be limited.
+func (c *RateLimitConfig) Enabled() bool {
+	if c.PerIP.Enabled() {
+		return true
+	}
+	for _, limit := range c.PerMethod {
+		if limit.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitKey identifies a limiter. Per-IP and per-method limiters are kept in
+// separate namespaces, so that a method name can never collide with the per-IP
+// limiter of the same client.
+type rateLimitKey struct {
+	ip        string
+	perMethod bool
+	method    string
+}
+
+type jsonRPCCall struct {
+	Method string `json:"method"`
+}
+
+// rateLimitMiddleware rejects requests from clients that have exceeded their
+// configured rate limits.
+type rateLimitMiddleware struct {
+	config RateLimitConfig
+	clock  mockable.Clock
+
+	// lock ensures that a single limiter is created for each key.
+	lock     sync.Mutex
+	limiters *lru.Cache[rateLimitKey, *rate.Limiter]
+}
+
+// NewRateLimitMiddleware returns middleware that limits requests according to
+// [config].
+func NewRateLimitMiddleware(config RateLimitConfig) Middleware {
+	maxClients := config.MaxClients
+	if maxClients <= 0 {
+		maxClients = DefaultMaxClients
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = DefaultMaxBodySize
+	}
+	return &rateLimitMiddleware{
+		config:   config,
+		limiters: lru.NewCache[rateLimitKey, *rate.Limiter](maxClients),
+	}
+}
+
+func (m *rateLimitMiddleware) Check(_ string, r *http.Request) error {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		// No port was specified
+		ip = r.RemoteAddr
+	}
+
+	now := m.clock.Time()
+	if m.config.PerIP.Enabled() {
+		limiter := m.limiter(rateLimitKey{ip: ip}, m.config.PerIP)
+		if !limiter.AllowN(now, 1) {
+			return fmt.Errorf("%w: too many requests from %s", ErrRateLimited, ip)
+		}
+	}
+
+	if len(m.config.PerMethod) == 0 {
+		return nil
+	}
+
+	methods, err := jsonRPCMethods(r, m.config.MaxBodySize)
+	if err != nil {
+		return err
+	}
+	for method, numCalls := range methods {
+		limit, ok := m.config.PerMethod[method]
+		if !ok || !limit.Enabled() {
+			continue
+		}
+
+		limiter := m.limiter(rateLimitKey{ip: ip, perMethod: true, method: method}, limit)
+		// A batch that exceeds the burst could never be allowed, so it is
+		// rejected without consuming any tokens.
+		if burst := limiter.Burst(); numCalls > burst {
+			return fmt.Errorf("%w: %d calls to %s in a single request exceeds the burst of %d", ErrRateLimited, numCalls, method, burst)
+		}
+		if !limiter.AllowN(now, numCalls) {
+			return fmt.Errorf("%w: too many calls to %s from %s", ErrRateLimited, method, ip)
+		}
+	}
+	return nil
+}
+
+func (m *rateLimitMiddleware) limiter(key rateLimitKey, limit RateLimit) *rate.Limiter {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	limiter, ok := m.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.Rate), max(limit.Burst, 1))
+		m.limiters.Put(key, limiter)
+	}
+	return limiter
+}
+
+// jsonRPCMethods returns the number of calls made to each JSON-RPC method in
+// the body of [r]. The body of [r] is replaced so that it can be read again.
+//
+// If the body is larger than [maxBodySize] bytes, an error is returned. If the
+// body is not a JSON-RPC request, no methods are returned and the handler is
+// left to report the error.
+func jsonRPCMethods(r *http.Request, maxBodySize int64) (map[string]int, error) {
+	if r.Body == nil || r.Method != http.MethodPost {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return nil, fmt.Errorf("%w: request body exceeds %d bytes", ErrForbidden, maxBytesErr.Limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read request body: %w", ErrForbidden, err)
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	body = bytes.TrimSpace(body)
+	var calls []jsonRPCCall
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return nil, nil //nolint:nilerr // The handler reports invalid requests
+		}
+	} else {
+		var call jsonRPCCall
+		if err := json.Unmarshal(body, &call); err != nil {
+			return nil, nil //nolint:nilerr // The handler reports invalid requests
+		}
+		calls = []jsonRPCCall{call}
+	}
+
+	methods := make(map[string]int, len(calls))
+	for _, call := range calls {
+		methods[call.Method]++
+	}
+	return methods, nil
+}
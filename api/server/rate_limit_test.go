@@ -0,0 +1,155 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitRequest(remoteAddr string, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func TestRateLimitMiddlewarePerIP(t *testing.T) {
+	require := require.New(t)
+
+	m := NewRateLimitMiddleware(RateLimitConfig{
+		PerIP: RateLimit{
+			Rate:  1,
+			Burst: 2,
+		},
+	}).(*rateLimitMiddleware)
+	now := time.Unix(1_000, 0)
+	m.clock.Set(now)
+
+	require.NoError(m.Check("info", newRateLimitRequest("1.1.1.1:1", "")))
+	require.NoError(m.Check("info", newRateLimitRequest("1.1.1.1:2", "")))
+	require.ErrorIs(m.Check("info", newRateLimitRequest("1.1.1.1:3", "")), ErrRateLimited)
+
+	// Other clients should not be limited
+	require.NoError(m.Check("info", newRateLimitRequest("2.2.2.2:1", "")))
+
+	// The bucket should be refilled over time
+	m.clock.Set(now.Add(time.Second))
+	require.NoError(m.Check("info", newRateLimitRequest("1.1.1.1:1", "")))
+	require.ErrorIs(m.Check("info", newRateLimitRequest("1.1.1.1:1", "")), ErrRateLimited)
+}
+
+func TestRateLimitMiddlewarePerMethod(t *testing.T) {
+	require := require.New(t)
+
+	m := NewRateLimitMiddleware(RateLimitConfig{
+		PerMethod: map[string]RateLimit{
+			"info.peers": {
+				Rate:  1,
+				Burst: 2,
+			},
+		},
+	}).(*rateLimitMiddleware)
+	m.clock.Set(time.Unix(1_000, 0))
+
+	const (
+		peersCall   = `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`
+		nodeIDCall  = `{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`
+		remoteAddr  = "1.1.1.1:1"
+		invalidJSON = "{"
+	)
+
+	// The body must be readable after it is inspected
+	r := newRateLimitRequest(remoteAddr, peersCall)
+	require.NoError(m.Check("info", r))
+	body, err := io.ReadAll(r.Body)
+	require.NoError(err)
+	require.Equal(peersCall, string(body))
+
+	// Unlimited methods and invalid requests should not be limited
+	require.NoError(m.Check("info", newRateLimitRequest(remoteAddr, nodeIDCall)))
+	require.NoError(m.Check("info", newRateLimitRequest(remoteAddr, invalidJSON)))
+
+	// Every call in a batch is counted
+	batch := "[" + peersCall + "," + peersCall + "]"
+	require.ErrorIs(m.Check("info", newRateLimitRequest(remoteAddr, batch)), ErrRateLimited)
+	require.NoError(m.Check("info", newRateLimitRequest(remoteAddr, peersCall)))
+	require.ErrorIs(m.Check("info", newRateLimitRequest(remoteAddr, peersCall)), ErrRateLimited)
+
+	// Other clients should not be limited
+	require.NoError(m.Check("info", newRateLimitRequest("2.2.2.2:1", batch)))
+}
+
+func TestRateLimitMiddlewareBatchExceedsBurst(t *testing.T) {
+	require := require.New(t)
+
+	m := NewRateLimitMiddleware(RateLimitConfig{
+		PerMethod: map[string]RateLimit{
+			"info.peers": {
+				Rate:  1,
+				Burst: 2,
+			},
+		},
+	}).(*rateLimitMiddleware)
+	m.clock.Set(time.Unix(1_000, 0))
+
+	const (
+		peersCall  = `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`
+		remoteAddr = "1.1.1.1:1"
+	)
+
+	// A batch larger than the burst can never be allowed
+	batch := "[" + peersCall + "," + peersCall + "," + peersCall + "]"
+	require.ErrorIs(m.Check("info", newRateLimitRequest(remoteAddr, batch)), ErrRateLimited)
+
+	// Rejecting the batch must not consume any tokens
+	batch = "[" + peersCall + "," + peersCall + "]"
+	require.NoError(m.Check("info", newRateLimitRequest(remoteAddr, batch)))
+}
+
+func TestRateLimitMiddlewareSeparateNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	m := NewRateLimitMiddleware(RateLimitConfig{
+		PerIP: RateLimit{
+			Rate:  1,
+			Burst: 1,
+		},
+		PerMethod: map[string]RateLimit{
+			"": {
+				Rate:  1,
+				Burst: 1,
+			},
+		},
+	}).(*rateLimitMiddleware)
+	m.clock.Set(time.Unix(1_000, 0))
+
+	// A call without a method must not share the per-IP limiter
+	require.NoError(m.Check("info", newRateLimitRequest("1.1.1.1:1", `{"jsonrpc":"2.0","id":1}`)))
+}
+
+func TestRateLimitMiddlewareMaxBodySize(t *testing.T) {
+	require := require.New(t)
+
+	m := NewRateLimitMiddleware(RateLimitConfig{
+		PerMethod: map[string]RateLimit{
+			"info.peers": {
+				Rate:  1,
+				Burst: 1,
+			},
+		},
+		MaxBodySize: 64,
+	}).(*rateLimitMiddleware)
+
+	const peersCall = `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`
+	require.NoError(m.Check("info", newRateLimitRequest("1.1.1.1:1", peersCall)))
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"info.getNodeID","params":{"padding":"` + strings.Repeat("a", 64) + `"}}`
+	require.ErrorIs(m.Check("info", newRateLimitRequest("2.2.2.2:1", body)), ErrForbidden)
+}
@@ -150,6 +150,38 @@ func (r *router) forceAddRouter(base, endpoint string, handler http.Handler) err
 	return err
 }
 
+// RemoveRouter removes all the endpoints of [base], including the endpoints
+// that were added to its aliases. The aliases remain reserved.
+func (r *router) RemoveRouter(base string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	delete(r.routes, base)
+	for _, alias := range r.aliases[base] {
+		delete(r.routes, alias)
+	}
+
+	// The mux router doesn't support removing routes, so it is rebuilt from
+	// the remaining routes.
+	r.router = mux.NewRouter()
+	for base, endpoints := range r.routes {
+		for endpoint, handler := range endpoints {
+			url := base + endpoint
+			r.router.Handle(url, handler).Name(url)
+		}
+	}
+}
+
+// RemoveHeaderRoute removes the handler of [route].
+func (r *router) RemoveHeaderRoute(route string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.headerRoutes, route)
+}
+
 func (r *router) AddAlias(base string, aliases ...string) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -5,6 +5,7 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +59,38 @@ func TestBlock(t *testing.T) {
 	err := r.AddRouter("1", "", handler1)
 	require.ErrorIs(err, errAlreadyReserved)
 }
+
+func TestRemoveRouter(t *testing.T) {
+	require := require.New(t)
+	r := newRouter()
+
+	require.NoError(r.AddAlias("/1", "/2"))
+
+	handler1 := &testHandler{}
+	require.NoError(r.AddRouter("/1", "/rpc", handler1))
+	handler3 := &testHandler{}
+	require.NoError(r.AddRouter("/3", "/rpc", handler3))
+
+	r.RemoveRouter("/1")
+
+	_, err := r.GetHandler("/1", "/rpc")
+	require.ErrorIs(err, errUnknownBaseURL)
+	_, err = r.GetHandler("/2", "/rpc")
+	require.ErrorIs(err, errUnknownBaseURL)
+
+	for _, url := range []string{"/1/rpc", "/2/rpc"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, url, nil))
+		require.Equal(http.StatusNotFound, w.Code)
+	}
+	require.False(handler1.called)
+
+	// The remaining routes are still served.
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/3/rpc", nil))
+	require.True(handler3.called)
+
+	// The aliases are kept, so the routes can be added again.
+	require.NoError(r.AddRouter("/1", "/rpc", handler1))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/2/rpc", nil))
+	require.True(handler1.called)
+}
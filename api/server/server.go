@@ -54,6 +54,11 @@ type PathAdderWithReadLock interface {
 	// AddAliasesWithReadLock registers aliases to the server assuming the http read
 	// lock is currently held.
 	AddAliasesWithReadLock(endpoint string, aliases ...string) error
+
+	// CallWithoutReadLock calls [f] with the http read lock released, assuming
+	// the http read lock is currently held. This allows [f] to register and
+	// remove routes, such as when chains are created or stopped.
+	CallWithoutReadLock(f func() error) error
 }
 
 // Server maintains the HTTP router
@@ -66,6 +71,10 @@ type Server interface {
 	// That is, add <route, handler> pairs to server so that API calls can be
 	// made to the VM.
 	RegisterChain(chainName string, ctx *snow.ConsensusContext, vm common.VM)
+	// DeregisterChain removes the API endpoints associated with [chainID].
+	// Aliases of the chain's endpoints remain reserved, so the chain can be
+	// registered again.
+	DeregisterChain(chainID ids.ID)
 	// Shutdown this server
 	Shutdown() error
 }
@@ -88,6 +97,9 @@ type server struct {
 
 	metrics *metrics
 
+	// middleware is applied to every registered handler
+	middleware []Middleware
+
 	// Maps endpoints to handlers
 	router *router
 
@@ -109,6 +121,7 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	middleware []Middleware,
 ) (Server, error) {
 	m, err := newMetrics(registerer)
 	if err != nil {
@@ -140,6 +153,7 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		middleware:      middleware,
 		router:          router,
 		srv:             httpServer,
 		listener:        listener,
@@ -211,6 +225,15 @@ func (s *server) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 	}
 }
 
+func (s *server) DeregisterChain(chainID ids.ID) {
+	s.log.Info("removing chain routes",
+		zap.Stringer("chainID", chainID),
+	)
+	defaultEndpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
+	s.router.RemoveRouter(fmt.Sprintf("%s/%s", baseURL, defaultEndpoint))
+	s.router.RemoveHeaderRoute(chainID.String())
+}
+
 func (s *server) addChainRoute(chainName string, handler http.Handler, ctx *snow.ConsensusContext, base, endpoint string) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route",
@@ -227,6 +250,7 @@ func (s *server) wrapMiddleware(chainName string, handler http.Handler, ctx *sno
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	handler = rejectMiddleware(handler, ctx)
+	handler = s.applyMiddleware(chainName, handler)
 	return s.metrics.wrapHandler(chainName, handler)
 }
 
@@ -251,6 +275,7 @@ func (s *server) addRoute(handler http.Handler, base, endpoint string) error {
 		handler = api.TraceHandler(handler, url, s.tracer)
 	}
 
+	handler = s.applyMiddleware(base, handler)
 	handler = s.metrics.wrapHandler(base, handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
@@ -286,6 +311,13 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 	return s.AddAliases(endpoint, aliases...)
 }
 
+func (s *server) CallWithoutReadLock(f func() error) error {
+	s.router.lock.RUnlock()
+	defer s.router.lock.RLock()
+
+	return f()
+}
+
 func (s *server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 
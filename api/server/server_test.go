@@ -65,3 +65,30 @@ func TestHTTPHeaderRouteIsCanonical(t *testing.T) {
 	wantHeaderKey := http.CanonicalHeaderKey(HTTPHeaderRoute)
 	require.Equal(t, wantHeaderKey, HTTPHeaderRoute)
 }
+
+func TestCallWithoutReadLock(t *testing.T) {
+	require := require.New(t)
+
+	s := &server{
+		router: newRouter(),
+	}
+	require.NoError(s.router.AddRouter("/chain", "", http.NotFoundHandler()))
+
+	// Handlers are called with the read lock held, so removing routes from a
+	// handler requires the read lock to be released.
+	var removed bool
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		require.NoError(s.CallWithoutReadLock(func() error {
+			s.router.RemoveRouter("/chain")
+			removed = true
+			return nil
+		}))
+	})
+	require.NoError(s.router.AddRouter("/admin", "", handler))
+
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/admin", nil))
+	require.True(removed)
+
+	_, err := s.router.GetHandler("/chain", "")
+	require.ErrorIs(err, errUnknownBaseURL)
+}
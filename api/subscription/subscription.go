@@ -0,0 +1,93 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package subscription implements the connection handling shared by the APIs
+// that stream updates to WebSocket subscribers.
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// WriteTimeout is the maximum amount of time a subscriber may take to read
+	// a message before it is disconnected.
+	WriteTimeout = 10 * time.Second
+	// PongTimeout is the maximum amount of time between receiving messages
+	// from a subscriber before it is disconnected.
+	PongTimeout = time.Minute
+	// PingPeriod is the frequency at which pings are sent to subscribers. Must
+	// be less than [PongTimeout].
+	PingPeriod = PongTimeout * 9 / 10
+	// Subscribers are not expected to send anything other than control
+	// messages.
+	maxReadSize = 512
+)
+
+// KeepAlive processes control messages from, and periodically pings, the
+// subscriber on [conn]. The returned context is cancelled once the subscriber
+// disconnects or [ctx] is cancelled.
+func KeepAlive(ctx context.Context, conn *websocket.Conn) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go readLoop(conn, cancel)
+	go pingLoop(ctx, conn)
+	return ctx, cancel
+}
+
+// WriteJSON sends [v] to the subscriber on [conn], failing if the subscriber
+// doesn't read it within [WriteTimeout].
+func WriteJSON(conn *websocket.Conn, v any) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(WriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(v)
+}
+
+// Close attempts to notify the subscriber on [conn] that the subscription
+// ended with [code] because of [err].
+func Close(conn *websocket.Conn, code int, err error) {
+	deadline := time.Now().Add(WriteTimeout)
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, err.Error()),
+		deadline,
+	)
+}
+
+// readLoop processes control messages from the subscriber and calls [cancel]
+// once the connection is closed.
+func readLoop(conn *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+
+	conn.SetReadLimit(maxReadSize)
+	_ = conn.SetReadDeadline(time.Now().Add(PongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(PongTimeout))
+	})
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// pingLoop periodically pings the subscriber until [ctx] is cancelled.
+func pingLoop(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(PingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deadline := time.Now().Add(WriteTimeout)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subscription
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestKeepAlive(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		upgrader     websocket.Upgrader
+		disconnected = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := KeepAlive(context.Background(), conn)
+		defer cancel()
+
+		if err := WriteJSON(conn, "hello"); err != nil {
+			return
+		}
+
+		// The context is cancelled once the subscriber disconnects.
+		<-ctx.Done()
+		close(disconnected)
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+
+	var msg string
+	require.NoError(conn.ReadJSON(&msg))
+	require.Equal("hello", msg)
+
+	require.NoError(conn.Close())
+	<-disconnected
+}
+
+func TestClose(t *testing.T) {
+	require := require.New(t)
+
+	var upgrader websocket.Upgrader
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		Close(conn, websocket.CloseGoingAway, errTest)
+	}))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.ErrorAs(err, &closeErr)
+	require.Equal(websocket.CloseGoingAway, closeErr.Code)
+	require.Equal(errTest.Error(), closeErr.Text)
+}
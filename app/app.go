@@ -43,9 +43,14 @@ type App interface {
 	// ExitCode should only be called after [Start] returns. It
 	// should block until the application finishes
 	ExitCode() int
+
+	// ReloadConfig re-reads the configuration and applies the changes that do
+	// not require a restart.
+	// ReloadConfig should only be called after [Start].
+	ReloadConfig()
 }
 
-func New(config nodeconfig.Config) (App, error) {
+func New(config nodeconfig.Config, configReloader nodeconfig.Reloader) (App, error) {
 	// Set the data directory permissions to be read write.
 	if err := perms.ChmodR(config.DatabaseConfig.Path, true, perms.ReadWriteExecute); err != nil {
 		return nil, fmt.Errorf("failed to restrict the permissions of the database directory with: %w", err)
@@ -71,7 +76,7 @@ func New(config nodeconfig.Config) (App, error) {
 		return nil, err
 	}
 
-	n, err := node.New(&config, logFactory, log)
+	n, err := node.New(&config, configReloader, logFactory, log)
 	if err != nil {
 		log.Fatal("failed to initialize node", zap.Error(err))
 		log.Stop()
@@ -97,6 +102,9 @@ func Run(app App) int {
 	stackTraceSignal := make(chan os.Signal, 1)
 	signal.Notify(stackTraceSignal, syscall.SIGABRT)
 
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+
 	// start up a new go routine to handle attempts to kill the application
 	go func() {
 		for range terminationSignals {
@@ -113,6 +121,14 @@ func Run(app App) int {
 		}
 	}()
 
+	// start a goroutine to listen on SIGHUP signals,
+	// to reload the config.
+	go func() {
+		for range reloadSignal {
+			app.ReloadConfig()
+		}
+	}()
+
 	// wait for the app to exit and get the exit code response
 	exitCode := app.ExitCode()
 
@@ -124,6 +140,10 @@ func Run(app App) int {
 	signal.Stop(stackTraceSignal)
 	close(stackTraceSignal)
 
+	// shut down the config reload go routine
+	signal.Stop(reloadSignal)
+	close(reloadSignal)
+
 	// return the exit code that the application reported
 	return exitCode
 }
@@ -170,6 +190,12 @@ func (a *app) Stop() {
 	a.node.Shutdown(0)
 }
 
+// ReloadConfig re-reads the node's configuration. The result of the reload is
+// logged by the node.
+func (a *app) ReloadConfig() {
+	_, _ = a.node.ReloadConfig()
+}
+
 // ExitCode returns the exit code that the node is reporting. This function
 // blocks until the node has been shut down.
 func (a *app) ExitCode() int {
@@ -53,6 +53,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/fx"
 	"github.com/ava-labs/avalanchego/vms/metervm"
@@ -80,6 +81,14 @@ const (
 	defaultChannelSize = 1
 	initialQueueSize   = 3
 
+	deleteChainDataWriteSize = units.MiB
+
+	// Chains whose VM process exits unexpectedly are restarted, waiting
+	// between failed attempts for a duration that doubles up to the maximum.
+	initialChainRestartBackoff = time.Second
+	maxChainRestartBackoff     = time.Minute
+	stopCrashedChainTimeout    = 30 * time.Second
+
 	avalancheNamespace    = constants.PlatformName + metric.NamespaceSeparator + "avalanche"
 	handlerNamespace      = constants.PlatformName + metric.NamespaceSeparator + "handler"
 	meterchainvmNamespace = constants.PlatformName + metric.NamespaceSeparator + "meterchainvm"
@@ -107,6 +116,12 @@ var (
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errStopPrimaryNetwork      = errors.New("the primary network can't be stopped")
+	errStopCriticalChain       = errors.New("critical chains can't be stopped")
+	errUnknownChain            = errors.New("unknown chain")
+	errChainRunning            = errors.New("chain is running")
+	errChainNotRunning         = errors.New("chain is not running")
+	errVMProcessExited         = errors.New("VM process exited unexpectedly")
 
 	fxs = map[ids.ID]fx.Factory{
 		secp256k1fx.ID: &secp256k1fx.Factory{},
@@ -149,6 +164,28 @@ type Manager interface {
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
 
+	// StopSubnet stops and removes all the chains of [subnetID], including
+	// their API endpoints, health checks, and metrics. If [deleteData] is true,
+	// the chains' databases and data directories are deleted.
+	//
+	// Chains of the subnet that are queued for creation are not created. The
+	// subnet's chains can be created again by queueing them.
+	StopSubnet(ctx context.Context, subnetID ids.ID, deleteData bool) error
+
+	// StopChain stops [chainID] and removes its API endpoints, health checks,
+	// and metrics. The chain's data is kept so that it can be started again.
+	// Critical chains can't be stopped.
+	StopChain(ctx context.Context, chainID ids.ID) error
+
+	// StartChain creates [chainID] again from its persisted state. The chain
+	// must have been created, or failed to be created, and must not be
+	// running.
+	StartChain(chainID ids.ID) error
+
+	// RestartChain stops [chainID] and creates it again from its persisted
+	// state. Critical chains can't be restarted.
+	RestartChain(ctx context.Context, chainID ids.ID) error
+
 	Shutdown()
 }
 
@@ -173,6 +210,17 @@ type chain struct {
 	Context *snow.ConsensusContext
 	VM      common.VM
 	Handler handler.Handler
+	// Exited is closed once the VM's process has exited. It is nil if the VM
+	// doesn't run in a separate process.
+	Exited <-chan struct{}
+}
+
+// processVM is implemented by VMs that run in a separate process, such as
+// rpcchainvm plugins.
+type processVM interface {
+	// Exited returns a channel that is closed once the VM's process has
+	// exited.
+	Exited() <-chan struct{}
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -267,6 +315,20 @@ type manager struct {
 	chainCreatorShutdownCh chan struct{}
 	chainCreatorExited     sync.WaitGroup
 
+	// createLock is held while chains are being created or stopped.
+	createLock sync.Mutex
+	// Key: Chain's ID
+	// Value: The parameters the chain was last created with, including chains
+	// that failed to be created
+	//
+	// Invariant: [createLock] is held when accessing [chainParams].
+	chainParams map[ids.ID]ChainParameters
+	// Chains whose VM process exited unexpectedly and that are being
+	// restarted.
+	//
+	// Invariant: [createLock] is held when accessing [crashedChains].
+	crashedChains set.Set[ids.ID]
+
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
@@ -332,6 +394,7 @@ func New(config *ManagerConfig) (Manager, error) {
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
 		chains:                 make(map[ids.ID]handler.Handler),
+		chainParams:            make(map[ids.ID]ChainParameters),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -376,14 +439,51 @@ func (m *manager) QueueChainCreation(chainParams ChainParameters) {
 // Note: it is expected for the subnet to already have the chain registered as
 // bootstrapping before this function is called
 func (m *manager) createChain(chainParams ChainParameters) {
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	sb, ok := m.Subnets.Get(chainParams.SubnetID)
+	if !ok {
+		m.Log.Info("skipping chain creation",
+			zap.String("reason", "subnet was stopped"),
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+		)
+		return
+	}
+
+	m.chainsLock.Lock()
+	_, exists := m.chains[chainParams.ID]
+	m.chainsLock.Unlock()
+	if exists {
+		// The chain may have been queued again after its subnet was stopped
+		// and restarted.
+		m.Log.Debug("skipping chain creation",
+			zap.String("reason", "chain already running"),
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+		)
+		return
+	}
+
+	_ = m.startChain(chainParams, sb)
+}
+
+// startChain creates and starts the chain. If the chain fails to be created, a
+// failing health check is registered for it and the error is returned.
+//
+// Invariant: [m.createLock] is held and the chain isn't running.
+func (m *manager) startChain(chainParams ChainParameters, sb subnets.Subnet) error {
+	m.chainParams[chainParams.ID] = chainParams
+
 	m.Log.Info("creating chain",
 		zap.Stringer("subnetID", chainParams.SubnetID),
 		zap.Stringer("chainID", chainParams.ID),
 		zap.Stringer("vmID", chainParams.VMID),
 	)
 
-	sb, _ := m.Subnets.GetOrCreate(chainParams.SubnetID)
-
 	// Note: buildChain builds all chain's relevant objects (notably engine and handler)
 	// but does not start their operations. Starting of the handler (which could potentially
 	// issue some internal messages), is delayed until chain dispatching is started and
@@ -400,7 +500,7 @@ func (m *manager) createChain(chainParams ChainParameters) {
 				zap.Error(err),
 			)
 			go m.ShutdownNodeFunc(1)
-			return
+			return err
 		}
 
 		chainAlias := m.PrimaryAliasOrDefault(chainParams.ID)
@@ -433,21 +533,24 @@ func (m *manager) createChain(chainParams ChainParameters) {
 				zap.Error(err),
 			)
 		}
-		return
+		return healthCheckErr
 	}
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
 	m.chainsLock.Unlock()
 
-	// Associate the newly created chain with its default alias
-	if err := m.Alias(chainParams.ID, chainParams.ID.String()); err != nil {
-		m.Log.Error("failed to alias the new chain with itself",
-			zap.Stringer("subnetID", chainParams.SubnetID),
-			zap.Stringer("chainID", chainParams.ID),
-			zap.Stringer("vmID", chainParams.VMID),
-			zap.Error(err),
-		)
+	// Associate the newly created chain with its default alias. Chains that
+	// are created again keep their aliases.
+	if _, err := m.Lookup(chainParams.ID.String()); err != nil {
+		if err := m.Alias(chainParams.ID, chainParams.ID.String()); err != nil {
+			m.Log.Error("failed to alias the new chain with itself",
+				zap.Stringer("subnetID", chainParams.SubnetID),
+				zap.Stringer("chainID", chainParams.ID),
+				zap.Stringer("vmID", chainParams.VMID),
+				zap.Error(err),
+			)
+		}
 	}
 
 	// Notify those who registered to be notified when a new chain is created
@@ -473,6 +576,115 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	// Tell the chain to start processing messages.
 	// If the X, P, or C Chain panics, do not attempt to recover
 	chain.Handler.Start(context.TODO(), !m.CriticalChains.Contains(chainParams.ID))
+
+	// Critical chains are not restarted, as the node shuts down if they stop.
+	if chain.Exited != nil && !m.CriticalChains.Contains(chainParams.ID) {
+		go m.superviseChain(chainParams.ID, chain.Handler, chain.Exited)
+	}
+	return nil
+}
+
+// superviseChain restarts [chainID] if its VM process exits while [chain] is
+// running.
+func (m *manager) superviseChain(chainID ids.ID, chain handler.Handler, exited <-chan struct{}) {
+	<-exited
+
+	m.createLock.Lock()
+	m.chainsLock.Lock()
+	running := m.chains[chainID] == chain
+	if running {
+		delete(m.chains, chainID)
+	}
+	m.chainsLock.Unlock()
+
+	// The VM process is expected to exit when the chain is stopped.
+	if !running || m.isShuttingDown() {
+		m.createLock.Unlock()
+		return
+	}
+
+	chainCtx := chain.Context()
+	m.Log.Error("chain's VM process exited unexpectedly",
+		zap.Stringer("subnetID", chainCtx.SubnetID),
+		zap.Stringer("chainID", chainID),
+		zap.String("chainAlias", chainCtx.PrimaryAlias),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopCrashedChainTimeout)
+	err := m.stopChain(ctx, chain, false)
+	cancel()
+	if err != nil {
+		m.Log.Warn("failed to stop crashed chain",
+			zap.Stringer("chainID", chainID),
+			zap.Error(err),
+		)
+	}
+
+	// Report the chain as unhealthy until it is restarted.
+	m.crashedChains.Add(chainID)
+	healthCheckErr := fmt.Errorf("%w: restarting chain", errVMProcessExited)
+	err = m.Health.RegisterHealthCheck(
+		chainCtx.PrimaryAlias,
+		health.CheckerFunc(func(context.Context) (interface{}, error) {
+			return nil, healthCheckErr
+		}),
+		chainCtx.SubnetID.String(),
+	)
+	if err != nil {
+		m.Log.Error("failed to register failing health check",
+			zap.Stringer("chainID", chainID),
+			zap.Error(err),
+		)
+	}
+	m.createLock.Unlock()
+
+	m.restartCrashedChain(chainID)
+}
+
+// restartCrashedChain attempts to create [chainID] again, with exponential
+// backoff, until it succeeds or the chain is stopped or started through the
+// Manager.
+func (m *manager) restartCrashedChain(chainID ids.ID) {
+	backoff := initialChainRestartBackoff
+	for {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-m.chainCreatorShutdownCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		m.createLock.Lock()
+		if !m.crashedChains.Contains(chainID) {
+			m.createLock.Unlock()
+			return
+		}
+		err := m.recreateChain(chainID)
+		m.createLock.Unlock()
+		if err == nil {
+			m.Log.Info("restarted crashed chain",
+				zap.Stringer("chainID", chainID),
+			)
+			return
+		}
+
+		backoff = min(2*backoff, maxChainRestartBackoff)
+		m.Log.Warn("failed to restart crashed chain",
+			zap.Stringer("chainID", chainID),
+			zap.Duration("retryIn", backoff),
+			zap.Error(err),
+		)
+	}
+}
+
+func (m *manager) isShuttingDown() bool {
+	select {
+	case <-m.chainCreatorShutdownCh:
+		return true
+	default:
+		return false
+	}
 }
 
 // Create a chain
@@ -585,6 +797,9 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 	default:
 		return nil, errUnknownVMType
 	}
+	if vm, ok := vm.(processVM); ok {
+		chain.Exited = vm.Exited()
+	}
 
 	// Register the chain with the timeout manager
 	if err := m.TimeoutManager.RegisterChain(ctx); err != nil {
@@ -1554,6 +1769,199 @@ func (m *manager) dispatchChainCreator() {
 	}
 }
 
+func (m *manager) StopSubnet(ctx context.Context, subnetID ids.ID, deleteData bool) error {
+	if subnetID == constants.PrimaryNetworkID {
+		return errStopPrimaryNetwork
+	}
+
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	// Removing the subnet prevents its queued chains from being created.
+	m.Subnets.Remove(subnetID)
+
+	m.chainsLock.Lock()
+	var chains []handler.Handler
+	for chainID, chain := range m.chains {
+		if chain.Context().SubnetID == subnetID {
+			chains = append(chains, chain)
+			delete(m.chains, chainID)
+		}
+	}
+	m.chainsLock.Unlock()
+
+	// Chains that failed to be created only registered a health check.
+	for chainID, chainParams := range m.chainParams {
+		if chainParams.SubnetID == subnetID {
+			m.Health.DeregisterHealthCheck(m.PrimaryAliasOrDefault(chainID))
+			delete(m.chainParams, chainID)
+			m.crashedChains.Remove(chainID)
+		}
+	}
+
+	m.Log.Info("stopping subnet",
+		zap.Stringer("subnetID", subnetID),
+		zap.Int("numChains", len(chains)),
+		zap.Bool("deleteData", deleteData),
+	)
+
+	var errs []error
+	for _, chain := range chains {
+		if err := m.stopChain(ctx, chain, deleteData); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *manager) StopChain(ctx context.Context, chainID ids.ID) error {
+	if m.CriticalChains.Contains(chainID) {
+		return errStopCriticalChain
+	}
+
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	// Stopping a crashed chain cancels its restart.
+	if m.crashedChains.Contains(chainID) {
+		m.crashedChains.Remove(chainID)
+		m.Health.DeregisterHealthCheck(m.PrimaryAliasOrDefault(chainID))
+		return nil
+	}
+	return m.stopRunningChain(ctx, chainID)
+}
+
+func (m *manager) StartChain(chainID ids.ID) error {
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	return m.recreateChain(chainID)
+}
+
+func (m *manager) RestartChain(ctx context.Context, chainID ids.ID) error {
+	if m.CriticalChains.Contains(chainID) {
+		return errStopCriticalChain
+	}
+
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	// Crashed chains have already been stopped.
+	if !m.crashedChains.Contains(chainID) {
+		if err := m.stopRunningChain(ctx, chainID); err != nil {
+			return err
+		}
+	}
+	return m.recreateChain(chainID)
+}
+
+// stopRunningChain removes [chainID] from the running chains and stops it.
+//
+// Invariant: [m.createLock] is held.
+func (m *manager) stopRunningChain(ctx context.Context, chainID ids.ID) error {
+	m.chainsLock.Lock()
+	chain, ok := m.chains[chainID]
+	delete(m.chains, chainID)
+	m.chainsLock.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", errChainNotRunning, chainID)
+	}
+	return m.stopChain(ctx, chain, false)
+}
+
+// recreateChain creates [chainID] again with the parameters it was last
+// created with.
+//
+// Invariant: [m.createLock] is held.
+func (m *manager) recreateChain(chainID ids.ID) error {
+	chainParams, ok := m.chainParams[chainID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	m.chainsLock.Lock()
+	_, running := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if running {
+		return fmt.Errorf("%w: %s", errChainRunning, chainID)
+	}
+
+	sb, ok := m.Subnets.Get(chainParams.SubnetID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	// Remove the failing health check of a chain that failed to be created
+	// or whose VM process crashed.
+	m.Health.DeregisterHealthCheck(m.PrimaryAliasOrDefault(chainID))
+	if err := m.startChain(chainParams, sb); err != nil {
+		return err
+	}
+	m.crashedChains.Remove(chainID)
+	return nil
+}
+
+// stopChain shuts down [chain] and removes everything that was registered for
+// it when it was created, so that it can be created again.
+//
+// Invariant: [m.createLock] is held and [chain] was removed from [m.chains].
+func (m *manager) stopChain(ctx context.Context, chain handler.Handler, deleteData bool) error {
+	chainCtx := chain.Context()
+	chainID := chainCtx.ChainID
+	primaryAlias := chainCtx.PrimaryAlias
+
+	// Stop routing API calls to the chain before it is shut down.
+	m.Server.DeregisterChain(chainID)
+
+	// The router removes the chain once the handler has stopped.
+	chain.Stop(ctx)
+	if _, err := chain.AwaitStopped(ctx); err != nil {
+		return fmt.Errorf("failed to stop chain %s: %w", chainID, err)
+	}
+
+	m.Health.DeregisterHealthCheck(primaryAlias)
+	for _, gatherer := range []metrics.MultiGatherer{
+		m.MeterDBMetrics,
+		m.avalancheGatherer,
+		m.handlerGatherer,
+		m.meterChainVMGatherer,
+		m.meterDAGVMGatherer,
+		m.proposervmGatherer,
+		m.p2pGatherer,
+		m.snowmanGatherer,
+		m.stakeGatherer,
+	} {
+		gatherer.Deregister(primaryAlias)
+	}
+	for _, vmGatherer := range m.vmGatherer {
+		vmGatherer.Deregister(primaryAlias)
+	}
+	m.LogFactory.Remove(primaryAlias)
+
+	m.Log.Info("stopped chain",
+		zap.Stringer("subnetID", chainCtx.SubnetID),
+		zap.Stringer("chainID", chainID),
+		zap.String("chainAlias", primaryAlias),
+	)
+	if !deleteData {
+		return nil
+	}
+
+	chainDB := prefixdb.New(chainID[:], m.DB)
+	if err := database.Clear(chainDB, deleteChainDataWriteSize); err != nil {
+		return fmt.Errorf("failed to delete database of chain %s: %w", chainID, err)
+	}
+	chainDataDir := filepath.Join(m.ChainDataDir, chainID.String())
+	if err := os.RemoveAll(chainDataDir); err != nil {
+		return fmt.Errorf("failed to delete data directory of chain %s: %w", chainID, err)
+	}
+	m.Log.Info("deleted chain data",
+		zap.Stringer("chainID", chainID),
+		zap.String("chainDataDir", chainDataDir),
+	)
+	return nil
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
@@ -46,6 +46,23 @@ func (s *Subnets) GetOrCreate(subnetID ids.ID) (subnets.Subnet, bool) {
 	return subnet, true
 }
 
+// Get returns the subnet running on this node, if any.
+func (s *Subnets) Get(subnetID ids.ID) (subnets.Subnet, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	subnet, ok := s.subnets[subnetID]
+	return subnet, ok
+}
+
+// Remove marks the subnet as no longer running on this node.
+func (s *Subnets) Remove(subnetID ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.subnets, subnetID)
+}
+
 // Bootstrapping returns the subnetIDs of any chains that are still
 // bootstrapping.
 func (s *Subnets) Bootstrapping() []ids.ID {
@@ -167,3 +167,40 @@ func TestSubnetsBootstrapping(t *testing.T) {
 	subnet.Bootstrapped(chainID)
 	require.Empty(subnets.Bootstrapping())
 }
+
+func TestSubnetsRemove(t *testing.T) {
+	require := require.New(t)
+
+	config := map[ids.ID]subnets.Config{
+		constants.PrimaryNetworkID: {},
+	}
+
+	subnets, err := NewSubnets(ids.EmptyNodeID, config)
+	require.NoError(err)
+
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+
+	_, ok := subnets.Get(subnetID)
+	require.False(ok)
+
+	subnet, ok := subnets.GetOrCreate(subnetID)
+	require.True(ok)
+	subnet.AddChain(chainID)
+
+	got, ok := subnets.Get(subnetID)
+	require.True(ok)
+	require.Equal(subnet, got)
+	require.Contains(subnets.Bootstrapping(), subnetID)
+
+	// A removed subnet is no longer reported as bootstrapping
+	subnets.Remove(subnetID)
+	_, ok = subnets.Get(subnetID)
+	require.False(ok)
+	require.Empty(subnets.Bootstrapping())
+
+	// Re-creating the subnet starts from scratch
+	subnet, ok = subnets.GetOrCreate(subnetID)
+	require.True(ok)
+	require.True(subnet.AddChain(chainID))
+}
@@ -3,7 +3,11 @@
 
 package chains
 
-import "github.com/ava-labs/avalanchego/ids"
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -39,6 +43,22 @@ func (testManager) StartChainCreator(ChainParameters) error {
 	return nil
 }
 
+func (testManager) StopSubnet(context.Context, ids.ID, bool) error {
+	return nil
+}
+
+func (testManager) StopChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (testManager) StartChain(ids.ID) error {
+	return nil
+}
+
+func (testManager) RestartChain(context.Context, ids.ID) error {
+	return nil
+}
+
 func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
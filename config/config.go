@@ -4,6 +4,7 @@
 package config
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
@@ -22,8 +23,10 @@ import (
 	"github.com/ava-labs/avalanchego/config/node"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/indexer"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/consensus/simplex"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -301,6 +304,23 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	// Unknown fields are rejected so that a misspelled field doesn't silently
+	// leave routes unprotected.
+	var authConfig server.AuthConfig
+	if err := getStrictJSONConfig(v, HTTPAuthConfigContentKey, HTTPAuthConfigFileKey, &authConfig); err != nil {
+		return node.HTTPConfig{}, fmt.Errorf("couldn't read API auth config: %w", err)
+	}
+
+	var rateLimitConfig server.RateLimitConfig
+	if err := getJSONConfig(v, HTTPRateLimitConfigContentKey, HTTPRateLimitConfigFileKey, &rateLimitConfig); err != nil {
+		return node.HTTPConfig{}, fmt.Errorf("couldn't read API rate limit config: %w", err)
+	}
+
+	var retentionConfig indexer.RetentionConfig
+	if err := getJSONConfig(v, IndexRetentionConfigContentKey, IndexRetentionConfigFileKey, &retentionConfig); err != nil {
+		return node.HTTPConfig{}, fmt.Errorf("couldn't read index retention config: %w", err)
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
@@ -312,24 +332,69 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexRetentionConfig: retentionConfig,
 			},
 			AdminAPIEnabled:   v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:    v.GetBool(InfoAPIEnabledKey),
 			MetricsAPIEnabled: v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:  v.GetBool(HealthAPIEnabledKey),
 		},
-		HTTPHost:           v.GetString(HTTPHostKey),
-		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
-		HTTPSEnabled:       v.GetBool(HTTPSEnabledKey),
-		HTTPSKey:           httpsKey,
-		HTTPSCert:          httpsCert,
-		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
-		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
-		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
-		ShutdownWait:       v.GetDuration(HTTPShutdownWaitKey),
+		HTTPHost:            v.GetString(HTTPHostKey),
+		HTTPPort:            uint16(v.GetUint(HTTPPortKey)),
+		HTTPSEnabled:        v.GetBool(HTTPSEnabledKey),
+		HTTPSKey:            httpsKey,
+		HTTPSCert:           httpsCert,
+		HTTPAllowedOrigins:  v.GetStringSlice(HTTPAllowedOrigins),
+		HTTPAllowedHosts:    v.GetStringSlice(HTTPAllowedHostsKey),
+		HTTPAuthConfig:      authConfig,
+		HTTPRateLimitConfig: rateLimitConfig,
+		ShutdownTimeout:     v.GetDuration(HTTPShutdownTimeoutKey),
+		ShutdownWait:        v.GetDuration(HTTPShutdownWaitKey),
 	}, nil
 }
 
+// getJSONConfig unmarshals the JSON provided by either [contentKey], as base64
+// encoded content, or [fileKey] into [config]. If neither is specified,
+// [config] is left unmodified.
+func getJSONConfig(v *viper.Viper, contentKey string, fileKey string, config any) error {
+	configBytes, err := readJSONConfig(v, contentKey, fileKey)
+	if err != nil || configBytes == nil {
+		return err
+	}
+	return json.Unmarshal(configBytes, config)
+}
+
+// getStrictJSONConfig is like [getJSONConfig], but errors if the JSON contains
+// fields that [config] doesn't define.
+func getStrictJSONConfig(v *viper.Viper, contentKey string, fileKey string, config any) error {
+	configBytes, err := readJSONConfig(v, contentKey, fileKey)
+	if err != nil || configBytes == nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(configBytes))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(config)
+}
+
+// readJSONConfig returns the JSON provided by either [contentKey], as base64
+// encoded content, or [fileKey]. If neither is specified, nil is returned.
+func readJSONConfig(v *viper.Viper, contentKey string, fileKey string) ([]byte, error) {
+	switch {
+	case v.IsSet(contentKey):
+		rawContent := v.GetString(contentKey)
+		configBytes, err := base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+		return configBytes, nil
+	case v.IsSet(fileKey):
+		configFilePath := getExpandedArg(v, fileKey)
+		return os.ReadFile(filepath.Clean(configFilePath))
+	default:
+		return nil, nil
+	}
+}
+
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
 	config := router.HealthConfig{
 		MaxDropRate:            v.GetFloat64(RouterHealthMaxDropRateKey),
@@ -571,6 +636,21 @@ func getBenchlistConfig(v *viper.Viper, snowballParameters *snowball.Parameters)
 	}, nil
 }
 
+func getReputationConfig(v *viper.Viper) (reputation.Config, error) {
+	config := reputation.Config{
+		Halflife:            v.GetDuration(ReputationHalflifeKey),
+		SlowResponseLatency: v.GetDuration(ReputationSlowResponseLatencyKey),
+		AutoBanEnabled:      v.GetBool(ReputationAutoBanEnabledKey),
+		BanScore:            v.GetFloat64(ReputationBanScoreKey),
+		BanDuration:         v.GetDuration(ReputationBanDurationKey),
+		MaxBannedPortion:    v.GetFloat64(ReputationMaxBannedPortionKey),
+	}
+	if err := config.Verify(); err != nil {
+		return reputation.Config{}, fmt.Errorf("invalid reputation config: %w", err)
+	}
+	return config, nil
+}
+
 func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
 	var (
 		config       = node.StateSyncConfig{}
@@ -680,6 +760,7 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		PublicIPResolutionFreq:    v.GetDuration(PublicIPResolutionFreqKey),
 		ListenHost:                v.GetString(StakingHostKey),
 		ListenPort:                uint16(v.GetUint(StakingPortKey)),
+		QUICEnabled:               v.GetBool(NetworkQUICEnabledKey),
 	}
 	if ipConfig.PublicIPResolutionFreq <= 0 {
 		return node.IPConfig{}, fmt.Errorf("%q must be > 0", PublicIPResolutionFreqKey)
@@ -1000,7 +1081,8 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 			getExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		SnapshotDir: getExpandedArg(v, DBSnapshotDirKey),
+		Config:      configBytes,
 	}, nil
 }
 
@@ -1475,6 +1557,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Reputation
+	nodeConfig.ReputationConfig, err = getReputationConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// File Descriptor Limit
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
@@ -1003,6 +1003,44 @@ func TestGetDiskSpaceConfig(t *testing.T) {
 	}
 }
 
+func TestGetHTTPConfigAuth(t *testing.T) {
+	tests := []struct {
+		name           string
+		authConfig     string
+		expectedTokens map[string][]string
+		expectedErr    string
+	}{
+		{
+			name:       "valid config",
+			authConfig: `{"tokens": {"secret": ["/ext/admin"]}}`,
+			expectedTokens: map[string][]string{
+				"secret": {"/ext/admin"},
+			},
+		},
+		{
+			name:        "misspelled field",
+			authConfig:  `{"token": {"secret": ["/ext/admin"]}}`,
+			expectedErr: `unknown field "token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			v := setupViperFlags()
+			v.Set(HTTPAuthConfigContentKey, base64.StdEncoding.EncodeToString([]byte(tt.authConfig)))
+
+			config, err := getHTTPConfig(v)
+			if tt.expectedErr != "" {
+				require.ErrorContains(err, tt.expectedErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expectedTokens, config.HTTPAuthConfig.Tokens)
+		})
+	}
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow/consensus/simplex"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -45,6 +46,7 @@ var (
 	// [defaultUnexpandedDataDir] will be expanded when reading the flags
 	defaultDataDir              = filepath.Join("$HOME", ".avalanchego")
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
+	defaultDBSnapshotDir        = filepath.Join(defaultUnexpandedDataDir, "db-snapshots")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
@@ -131,6 +133,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Must be one of {%s, %s, %s}", leveldb.Name, memdb.Name, pebbledb.Name))
 	fs.Bool(DBReadOnlyKey, false, "If true, database writes are to memory and never persisted. May still initialize database directory/files on disk if they don't exist")
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
+	fs.String(DBSnapshotDirKey, defaultDBSnapshotDir, "Path to the directory that admin.createDBSnapshot writes database snapshots to")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
 
@@ -191,6 +194,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// a timeout of 0 should generally not be provided.
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
+	fs.Bool(NetworkQUICEnabledKey, constants.DefaultNetworkQUICEnabled, "If true, accepts P2P connections over QUIC on the UDP port with the same number as the staking port and connects over QUIC to peers that accept QUIC connections")
+
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
 	// Benchlist
@@ -199,6 +204,14 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Float64(BenchlistBenchProbabilityKey, benchlist.DefaultBenchProbability, "EWMA failure probability above which a node is benched")
 	fs.Duration(BenchlistDurationKey, benchlist.DefaultBenchDuration, "Max amount of time a peer is benchlisted")
 
+	// Reputation
+	fs.Duration(ReputationHalflifeKey, reputation.DefaultHalflife, "Halflife of the decay of peer reputation scores towards 0")
+	fs.Duration(ReputationSlowResponseLatencyKey, reputation.DefaultSlowResponseLatency, "Responses slower than this lower the reputation score of a peer")
+	fs.Bool(ReputationAutoBanEnabledKey, false, "If true, peers are banned when their reputation score drops to the ban score. Otherwise, peers are only banned manually")
+	fs.Float64(ReputationBanScoreKey, reputation.DefaultBanScore, "Reputation score at which a peer is banned. Must be in (-100, 0)")
+	fs.Duration(ReputationBanDurationKey, reputation.DefaultBanDuration, "Amount of time a peer is banned for when its reputation score drops to the ban score")
+	fs.Float64(ReputationMaxBannedPortionKey, reputation.DefaultMaxBannedPortion, "Validators are only banned automatically if the banned validators hold at most this portion of the primary network stake. Must be in [0, 1)")
+
 	// Router
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
@@ -235,6 +248,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(HTTPReadHeaderTimeoutKey, 30*time.Second, fmt.Sprintf("Maximum duration to read request headers. The connection's read deadline is reset after reading the headers. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPReadHeaderTimeoutKey, HTTPReadTimeoutKey))
 	fs.Duration(HTTPWriteTimeoutKey, 30*time.Second, "Maximum duration before timing out writes of the response. It is reset whenever a new request's header is read. A zero or negative value means there will be no timeout.")
 	fs.Duration(HTTPIdleTimeoutKey, 120*time.Second, fmt.Sprintf("Maximum duration to wait for the next request when keep-alives are enabled. If %s is zero, the value of %s is used. If both are zero, there is no timeout.", HTTPIdleTimeoutKey, HTTPReadTimeoutKey))
+	fs.String(HTTPAuthConfigFileKey, "", fmt.Sprintf("Specifies a JSON file that configures the bearer tokens and JWT secret required to call the APIs. Ignored if %s is specified", HTTPAuthConfigContentKey))
+	fs.String(HTTPAuthConfigContentKey, "", "Specifies base64 encoded JSON that configures the bearer tokens and JWT secret required to call the APIs")
+	fs.String(HTTPRateLimitConfigFileKey, "", fmt.Sprintf("Specifies a JSON file that configures per-IP and per-method rate limits for API calls. Ignored if %s is specified", HTTPRateLimitConfigContentKey))
+	fs.String(HTTPRateLimitConfigContentKey, "", "Specifies base64 encoded JSON that configures per-IP and per-method rate limits for API calls")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
@@ -335,6 +352,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.String(IndexRetentionConfigFileKey, "", fmt.Sprintf("Specifies a JSON file that configures which chains are indexed and which containers each index retains. Ignored if %s is specified", IndexRetentionConfigContentKey))
+	fs.String(IndexRetentionConfigContentKey, "", "Specifies base64 encoded JSON that configures which chains are indexed and which containers each index retains")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
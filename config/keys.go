@@ -51,6 +51,7 @@ const (
 	DBTypeKey                                = "db-type"
 	DBReadOnlyKey                            = "db-read-only"
 	DBPathKey                                = "db-dir"
+	DBSnapshotDirKey                         = "db-snapshot-dir"
 	DBConfigFileKey                          = "db-config-file"
 	DBConfigContentKey                       = "db-config-file-content"
 	PublicIPKey                              = "public-ip"
@@ -71,6 +72,10 @@ const (
 	HTTPReadTimeoutKey                                   = "http-read-timeout"
 	HTTPReadHeaderTimeoutKey                             = "http-read-header-timeout"
 	HTTPIdleTimeoutKey                                   = "http-idle-timeout"
+	HTTPAuthConfigFileKey                                = "http-auth-config-file"
+	HTTPAuthConfigContentKey                             = "http-auth-config-file-content"
+	HTTPRateLimitConfigFileKey                           = "http-rate-limit-config-file"
+	HTTPRateLimitConfigContentKey                        = "http-rate-limit-config-file-content"
 	StateSyncIPsKey                                      = "state-sync-ips"
 	StateSyncIDsKey                                      = "state-sync-ids"
 	BootstrapIPsKey                                      = "bootstrap-ips"
@@ -118,6 +123,7 @@ const (
 	NetworkPeerWriteBufferSizeKey                        = "network-peer-write-buffer-size"
 	NetworkTCPProxyEnabledKey                            = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                        = "network-tcp-proxy-read-timeout"
+	NetworkQUICEnabledKey                                = "network-quic-enabled"
 	NetworkTLSKeyLogFileKey                              = "network-tls-key-log-file-unsafe"
 	NetworkInboundConnUpgradeThrottlerCooldownKey        = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey             = "network-inbound-connection-throttling-max-conns-per-sec"
@@ -128,6 +134,12 @@ const (
 	BenchlistUnbenchProbabilityKey                       = "benchlist-unbench-probability"
 	BenchlistBenchProbabilityKey                         = "benchlist-bench-probability"
 	BenchlistDurationKey                                 = "benchlist-duration"
+	ReputationHalflifeKey                                = "reputation-halflife"
+	ReputationSlowResponseLatencyKey                     = "reputation-slow-response-latency"
+	ReputationAutoBanEnabledKey                          = "reputation-auto-ban-enabled"
+	ReputationBanScoreKey                                = "reputation-ban-score"
+	ReputationBanDurationKey                             = "reputation-ban-duration"
+	ReputationMaxBannedPortionKey                        = "reputation-max-banned-portion"
 	LogsDirKey                                           = "log-dir"
 	LogLevelKey                                          = "log-level"
 	LogDisplayLevelKey                                   = "log-display-level"
@@ -161,6 +173,8 @@ const (
 	FdLimitKey                                           = "fd-limit"
 	IndexEnabledKey                                      = "index-enabled"
 	IndexAllowIncompleteKey                              = "index-allow-incomplete"
+	IndexRetentionConfigFileKey                          = "index-retention-config-file"
+	IndexRetentionConfigContentKey                       = "index-retention-config-file-content"
 	RouterHealthMaxDropRateKey                           = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey                = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                   = "health-check-frequency"
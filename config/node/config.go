@@ -12,7 +12,9 @@ import (
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/indexer"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -26,8 +28,9 @@ import (
 )
 
 type APIIndexerConfig struct {
-	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
-	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexAPIEnabled      bool                    `json:"indexAPIEnabled"`
+	IndexAllowIncomplete bool                    `json:"indexAllowIncomplete"`
+	IndexRetentionConfig indexer.RetentionConfig `json:"indexRetentionConfig"`
 }
 
 type HTTPConfig struct {
@@ -43,6 +46,10 @@ type HTTPConfig struct {
 	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
 	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
 
+	// HTTPAuthConfig is not serialized because it contains secrets
+	HTTPAuthConfig      server.AuthConfig      `json:"-"`
+	HTTPRateLimitConfig server.RateLimitConfig `json:"httpRateLimitConfig"`
+
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
 }
@@ -68,6 +75,9 @@ type IPConfig struct {
 	// - If populated, listen only on the specified address.
 	ListenHost string `json:"listenHost"`
 	ListenPort uint16 `json:"listenPort"`
+	// If true, QUIC connections are accepted on the UDP port with the same
+	// number as the bound staking port.
+	QUICEnabled bool `json:"quicEnabled"`
 }
 
 type StakingConfig struct {
@@ -119,6 +129,9 @@ type DatabaseConfig struct {
 	// Path to database
 	Path string `json:"path"`
 
+	// Path to the directory that database snapshots are written to
+	SnapshotDir string `json:"snapshotDir"`
+
 	// Name of the database type to use
 	Name string `json:"name"`
 
@@ -155,6 +168,8 @@ type Config struct {
 
 	BenchlistConfig benchlist.Config `json:"benchlistConfig"`
 
+	ReputationConfig reputation.Config `json:"reputationConfig"`
+
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
 	LoggingConfig logging.Config `json:"loggingConfig"`
@@ -0,0 +1,50 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// Reloader re-reads the configuration of a running node.
+type Reloader interface {
+	// Reload re-reads the configuration and applies the values that changed
+	// since the last reload to [node]. If the configuration is invalid, no
+	// values are applied.
+	Reload(node Reloadable) (ReloadResult, error)
+}
+
+// Reloadable is the part of a running node that can be reconfigured without
+// restarting it.
+type Reloadable interface {
+	// SetLogLevels sets the log and display levels of all loggers.
+	SetLogLevels(logLevel, displayLevel logging.Level) error
+	// SetNetworkHealthConfig replaces the network layer health thresholds.
+	SetNetworkHealthConfig(healthConfig network.HealthConfig)
+	// SetRouterHealthConfig replaces the router health thresholds.
+	SetRouterHealthConfig(healthConfig router.HealthConfig)
+	// SetMsgThrottlerConfig resizes the byte allocations of the inbound and
+	// outbound message throttlers.
+	SetMsgThrottlerConfig(inbound, outbound throttling.MsgByteThrottlerConfig)
+	// SetBenchlistConfig replaces the benchlist config of all chains.
+	SetBenchlistConfig(config benchlist.Config) error
+	// UpdateTrackedSubnets starts tracking the subnets in [track] and stops
+	// tracking the subnets in [untrack].
+	UpdateTrackedSubnets(track, untrack set.Set[ids.ID]) error
+}
+
+// ReloadResult reports the keys whose values changed during a reload.
+type ReloadResult struct {
+	// Applied are the keys whose new values are in effect.
+	Applied []string `json:"applied"`
+	// Rejected maps the keys whose new values were not applied to the reason
+	// they were rejected.
+	Rejected map[string]string `json:"rejected"`
+}
@@ -0,0 +1,264 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/config/node"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// errRequiresRestart is the reason reported for keys that can only be changed
+// by restarting the node.
+const errRequiresRestart = "changing this key requires a restart"
+
+var (
+	_ node.Reloader = (*reloader)(nil)
+
+	errTrackSubnetsWithoutSybilProtection = errors.New("tracked subnets can't be changed when sybil protection is disabled")
+)
+
+// reloadableConfig is a part of the node config that can be changed while the
+// node is running.
+type reloadableConfig struct {
+	// keys that the part of the config is read from.
+	keys []string
+	// update copies the part of the config from [src] to [dst].
+	update func(dst, src *node.Config)
+	// verify, if set, returns an error if the part of [config] can't be
+	// applied.
+	verify func(config *node.Config) error
+	// apply reconfigures [n] to use the part of [config] rather than the part
+	// of [oldConfig].
+	apply func(n node.Reloadable, oldConfig, config *node.Config) error
+}
+
+var reloadableConfigs = []reloadableConfig{
+	{
+		keys: []string{
+			LogLevelKey,
+			LogDisplayLevelKey,
+		},
+		update: func(dst, src *node.Config) {
+			dst.LoggingConfig.LogLevel = src.LoggingConfig.LogLevel
+			dst.LoggingConfig.DisplayLevel = src.LoggingConfig.DisplayLevel
+		},
+		apply: func(n node.Reloadable, _, config *node.Config) error {
+			return n.SetLogLevels(config.LoggingConfig.LogLevel, config.LoggingConfig.DisplayLevel)
+		},
+	},
+	{
+		keys: []string{
+			NetworkHealthMinPeersKey,
+			NetworkHealthMaxTimeSinceMsgReceivedKey,
+			NetworkHealthMaxTimeSinceMsgSentKey,
+			NetworkHealthMaxSendFailRateKey,
+		},
+		update: func(dst, src *node.Config) {
+			dst.NetworkConfig.HealthConfig.MinConnectedPeers = src.NetworkConfig.HealthConfig.MinConnectedPeers
+			dst.NetworkConfig.HealthConfig.MaxTimeSinceMsgReceived = src.NetworkConfig.HealthConfig.MaxTimeSinceMsgReceived
+			dst.NetworkConfig.HealthConfig.MaxTimeSinceMsgSent = src.NetworkConfig.HealthConfig.MaxTimeSinceMsgSent
+			dst.NetworkConfig.HealthConfig.MaxSendFailRate = src.NetworkConfig.HealthConfig.MaxSendFailRate
+		},
+		apply: func(n node.Reloadable, _, config *node.Config) error {
+			n.SetNetworkHealthConfig(config.NetworkConfig.HealthConfig)
+			return nil
+		},
+	},
+	{
+		keys: []string{
+			RouterHealthMaxOutstandingRequestsKey,
+			NetworkHealthMaxOutstandingDurationKey,
+		},
+		update: func(dst, src *node.Config) {
+			dst.RouterHealthConfig.MaxOutstandingRequests = src.RouterHealthConfig.MaxOutstandingRequests
+			dst.RouterHealthConfig.MaxOutstandingDuration = src.RouterHealthConfig.MaxOutstandingDuration
+		},
+		apply: func(n node.Reloadable, _, config *node.Config) error {
+			n.SetRouterHealthConfig(config.RouterHealthConfig)
+			return nil
+		},
+	},
+	{
+		keys: []string{
+			InboundThrottlerAtLargeAllocSizeKey,
+			InboundThrottlerVdrAllocSizeKey,
+			InboundThrottlerNodeMaxAtLargeBytesKey,
+			OutboundThrottlerAtLargeAllocSizeKey,
+			OutboundThrottlerVdrAllocSizeKey,
+			OutboundThrottlerNodeMaxAtLargeBytesKey,
+		},
+		update: func(dst, src *node.Config) {
+			dst.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.MsgByteThrottlerConfig = src.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.MsgByteThrottlerConfig
+			dst.NetworkConfig.ThrottlerConfig.OutboundMsgThrottlerConfig = src.NetworkConfig.ThrottlerConfig.OutboundMsgThrottlerConfig
+		},
+		apply: func(n node.Reloadable, _, config *node.Config) error {
+			n.SetMsgThrottlerConfig(
+				config.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.MsgByteThrottlerConfig,
+				config.NetworkConfig.ThrottlerConfig.OutboundMsgThrottlerConfig,
+			)
+			return nil
+		},
+	},
+	{
+		keys: []string{
+			BenchlistHalflifeKey,
+			BenchlistUnbenchProbabilityKey,
+			BenchlistBenchProbabilityKey,
+			BenchlistDurationKey,
+		},
+		update: func(dst, src *node.Config) {
+			dst.BenchlistConfig.Halflife = src.BenchlistConfig.Halflife
+			dst.BenchlistConfig.UnbenchProbability = src.BenchlistConfig.UnbenchProbability
+			dst.BenchlistConfig.BenchProbability = src.BenchlistConfig.BenchProbability
+			dst.BenchlistConfig.BenchDuration = src.BenchlistConfig.BenchDuration
+		},
+		verify: func(config *node.Config) error {
+			return config.BenchlistConfig.Verify()
+		},
+		apply: func(n node.Reloadable, _, config *node.Config) error {
+			return n.SetBenchlistConfig(config.BenchlistConfig)
+		},
+	},
+	{
+		keys: []string{
+			TrackSubnetsKey,
+		},
+		update: func(dst, src *node.Config) {
+			dst.TrackedSubnets = src.TrackedSubnets
+		},
+		verify: func(config *node.Config) error {
+			if !config.SybilProtectionEnabled {
+				return errTrackSubnetsWithoutSybilProtection
+			}
+			return nil
+		},
+		apply: func(n node.Reloadable, oldConfig, config *node.Config) error {
+			var (
+				track   = set.Of(config.TrackedSubnets.List()...)
+				untrack = set.Of(oldConfig.TrackedSubnets.List()...)
+			)
+			track.Difference(oldConfig.TrackedSubnets)
+			untrack.Difference(config.TrackedSubnets)
+			return n.UpdateTrackedSubnets(track, untrack)
+		},
+	},
+}
+
+type reloader struct {
+	args []string
+
+	lock sync.Mutex
+	// config is the config that the node is running with.
+	config node.Config
+	// values maps each key to the value that [config] was read from.
+	values map[string]string
+}
+
+// NewReloader returns a reloader for a node that was started with the command
+// line [args], which were parsed into [v] and [config].
+//
+// Reloading re-reads the config file, the environment, and [args]. Changes to
+// keys that can not be applied while the node is running are rejected until
+// the node is restarted. Changes that fail to be applied are rejected, and are
+// retried by the next reload.
+func NewReloader(args []string, v *viper.Viper, config node.Config) node.Reloader {
+	return &reloader{
+		args:   args,
+		config: config,
+		values: getValues(v),
+	}
+}
+
+func (r *reloader) Reload(n node.Reloadable) (node.ReloadResult, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	v, err := BuildViper(BuildFlagSet(), r.args)
+	if err != nil {
+		return node.ReloadResult{}, fmt.Errorf("couldn't read config: %w", err)
+	}
+	newConfig, err := GetNodeConfig(v)
+	if err != nil {
+		return node.ReloadResult{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	newValues := getValues(v)
+	changed := set.NewSet[string](0)
+	for key, value := range newValues {
+		if r.values[key] != value {
+			changed.Add(key)
+		}
+	}
+
+	var (
+		config   = r.config
+		reloaded []reloadableConfig
+	)
+	for _, reloadable := range reloadableConfigs {
+		if !changed.Overlaps(set.Of(reloadable.keys...)) {
+			continue
+		}
+
+		reloadable.update(&config, &newConfig)
+		reloaded = append(reloaded, reloadable)
+	}
+
+	// Every change is verified before any is applied, so that an invalid change
+	// doesn't leave the node partially reconfigured.
+	for _, reloadable := range reloaded {
+		if reloadable.verify == nil {
+			continue
+		}
+		if err := reloadable.verify(&config); err != nil {
+			return node.ReloadResult{}, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	result := node.ReloadResult{
+		Applied:  []string{},
+		Rejected: make(map[string]string),
+	}
+	for _, reloadable := range reloaded {
+		keys := set.Intersect(set.Of(reloadable.keys...), changed)
+		changed.Difference(keys)
+
+		// Only the parts of the config that were applied are recorded, so that
+		// the parts that failed are retried by the next reload.
+		if err := reloadable.apply(n, &r.config, &config); err != nil {
+			for key := range keys {
+				result.Rejected[key] = err.Error()
+			}
+			continue
+		}
+
+		reloadable.update(&r.config, &config)
+		for key := range keys {
+			result.Applied = append(result.Applied, key)
+			r.values[key] = newValues[key]
+		}
+	}
+	for key := range changed {
+		result.Rejected[key] = errRequiresRestart
+	}
+	slices.Sort(result.Applied)
+	return result, nil
+}
+
+// getValues returns the values of all the flags in [v], formatted so that
+// values read from different sources can be compared.
+func getValues(v *viper.Viper) map[string]string {
+	values := make(map[string]string)
+	BuildFlagSet().VisitAll(func(f *pflag.Flag) {
+		values[f.Name] = fmt.Sprint(v.Get(f.Name))
+	})
+	return values
+}
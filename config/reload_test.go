@@ -0,0 +1,291 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/config/node"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	_ node.Reloadable = (*testReloadable)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+type testReloadable struct {
+	logLevel               *logging.Level
+	displayLevel           *logging.Level
+	networkHealthConfig    *network.HealthConfig
+	routerHealthConfig     *router.HealthConfig
+	inboundThrottlerConfig *throttling.MsgByteThrottlerConfig
+	benchlistConfig        *benchlist.Config
+	benchlistErr           error
+	trackedSubnets         set.Set[ids.ID]
+	untrackedSubnets       set.Set[ids.ID]
+}
+
+func (r *testReloadable) SetLogLevels(logLevel, displayLevel logging.Level) error {
+	r.logLevel = &logLevel
+	r.displayLevel = &displayLevel
+	return nil
+}
+
+func (r *testReloadable) SetNetworkHealthConfig(healthConfig network.HealthConfig) {
+	r.networkHealthConfig = &healthConfig
+}
+
+func (r *testReloadable) SetRouterHealthConfig(healthConfig router.HealthConfig) {
+	r.routerHealthConfig = &healthConfig
+}
+
+func (r *testReloadable) SetMsgThrottlerConfig(inbound, _ throttling.MsgByteThrottlerConfig) {
+	r.inboundThrottlerConfig = &inbound
+}
+
+func (r *testReloadable) SetBenchlistConfig(config benchlist.Config) error {
+	if r.benchlistErr != nil {
+		return r.benchlistErr
+	}
+	r.benchlistConfig = &config
+	return nil
+}
+
+func (r *testReloadable) UpdateTrackedSubnets(track, untrack set.Set[ids.ID]) error {
+	r.trackedSubnets = track
+	r.untrackedSubnets = untrack
+	return nil
+}
+
+// newTestReloader returns a reloader of the config file at the returned path,
+// which initially contains [config].
+func newTestReloader(t *testing.T, config map[string]any) (node.Reloader, string) {
+	t.Helper()
+	require := require.New(t)
+
+	config[NetworkNameKey] = constants.UnitTestName
+	config[DataDirKey] = t.TempDir()
+	configFilePath := filepath.Join(t.TempDir(), "config.json")
+	writeTestConfig(t, configFilePath, config)
+
+	args := []string{"--" + ConfigFileKey + "=" + configFilePath}
+	v, err := BuildViper(BuildFlagSet(), args)
+	require.NoError(err)
+	nodeConfig, err := GetNodeConfig(v)
+	require.NoError(err)
+	return NewReloader(args, v, nodeConfig), configFilePath
+}
+
+func writeTestConfig(t *testing.T, path string, config map[string]any) {
+	t.Helper()
+	require := require.New(t)
+
+	configBytes, err := json.Marshal(config)
+	require.NoError(err)
+	require.NoError(os.WriteFile(path, configBytes, 0o600))
+}
+
+func TestReload(t *testing.T) {
+	require := require.New(t)
+
+	config := map[string]any{
+		LogLevelKey: "info",
+	}
+	reloader, configFilePath := newTestReloader(t, config)
+
+	// Reloading an unchanged config doesn't apply anything.
+	n := &testReloadable{}
+	result, err := reloader.Reload(n)
+	require.NoError(err)
+	require.Empty(result.Applied)
+	require.Empty(result.Rejected)
+	require.Equal(&testReloadable{}, n)
+
+	config[LogLevelKey] = "debug"
+	config[NetworkHealthMinPeersKey] = 5
+	config[HTTPPortKey] = 1234
+	writeTestConfig(t, configFilePath, config)
+
+	result, err = reloader.Reload(n)
+	require.NoError(err)
+	require.Equal(
+		node.ReloadResult{
+			Applied: []string{
+				LogLevelKey,
+				NetworkHealthMinPeersKey,
+			},
+			Rejected: map[string]string{
+				HTTPPortKey: errRequiresRestart,
+			},
+		},
+		result,
+	)
+
+	// The display level defaults to the log level.
+	require.Equal(logging.Debug, *n.logLevel)
+	require.Equal(logging.Debug, *n.displayLevel)
+	require.Equal(uint(5), n.networkHealthConfig.MinConnectedPeers)
+	require.Equal(constants.DefaultNetworkHealthMaxSendFailRate, n.networkHealthConfig.MaxSendFailRate)
+	require.Nil(n.routerHealthConfig)
+
+	// Rejected keys are reported until the node is restarted.
+	n = &testReloadable{}
+	result, err = reloader.Reload(n)
+	require.NoError(err)
+	require.Empty(result.Applied)
+	require.Equal(
+		map[string]string{
+			HTTPPortKey: errRequiresRestart,
+		},
+		result.Rejected,
+	)
+	require.Equal(&testReloadable{}, n)
+}
+
+func TestReloadInvalidConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := map[string]any{
+		LogLevelKey: "info",
+	}
+	reloader, configFilePath := newTestReloader(t, config)
+
+	config[NetworkHealthMinPeersKey] = 5
+	config[LogDisplayLevelKey] = "invalid"
+	writeTestConfig(t, configFilePath, config)
+
+	n := &testReloadable{}
+	_, err := reloader.Reload(n)
+	require.ErrorIs(err, logging.ErrUnknownLevel)
+	require.Equal(&testReloadable{}, n)
+
+	// Fixing the config applies the valid changes.
+	delete(config, LogDisplayLevelKey)
+	writeTestConfig(t, configFilePath, config)
+
+	result, err := reloader.Reload(n)
+	require.NoError(err)
+	require.Equal(
+		[]string{
+			NetworkHealthMinPeersKey,
+		},
+		result.Applied,
+	)
+	require.Empty(result.Rejected)
+	require.Equal(uint(5), n.networkHealthConfig.MinConnectedPeers)
+	require.Nil(n.logLevel)
+}
+
+func TestReloadTrackedSubnets(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID0 = ids.GenerateTestID()
+		subnetID1 = ids.GenerateTestID()
+		config    = map[string]any{
+			TrackSubnetsKey: subnetID0.String(),
+		}
+	)
+	reloader, configFilePath := newTestReloader(t, config)
+
+	config[TrackSubnetsKey] = subnetID1.String()
+	config[InboundThrottlerAtLargeAllocSizeKey] = 1024
+	writeTestConfig(t, configFilePath, config)
+
+	n := &testReloadable{}
+	result, err := reloader.Reload(n)
+	require.NoError(err)
+	require.Equal(
+		[]string{
+			InboundThrottlerAtLargeAllocSizeKey,
+			TrackSubnetsKey,
+		},
+		result.Applied,
+	)
+	require.Empty(result.Rejected)
+	require.Equal(set.Of(subnetID1), n.trackedSubnets)
+	require.Equal(set.Of(subnetID0), n.untrackedSubnets)
+	require.Equal(uint64(1024), n.inboundThrottlerConfig.AtLargeAllocSize)
+}
+
+func TestReloadTrackedSubnetsWithoutSybilProtection(t *testing.T) {
+	require := require.New(t)
+
+	config := map[string]any{
+		SybilProtectionEnabledKey: false,
+	}
+	reloader, configFilePath := newTestReloader(t, config)
+
+	config[TrackSubnetsKey] = ids.GenerateTestID().String()
+	config[LogLevelKey] = "debug"
+	writeTestConfig(t, configFilePath, config)
+
+	// No changes are applied if any change is invalid.
+	n := &testReloadable{}
+	_, err := reloader.Reload(n)
+	require.ErrorIs(err, errTrackSubnetsWithoutSybilProtection)
+	require.Equal(&testReloadable{}, n)
+}
+
+func TestReloadApplyFailure(t *testing.T) {
+	require := require.New(t)
+
+	config := map[string]any{}
+	reloader, configFilePath := newTestReloader(t, config)
+
+	config[BenchlistDurationKey] = "1m"
+	config[NetworkHealthMinPeersKey] = 5
+	writeTestConfig(t, configFilePath, config)
+
+	// A change that fails to be applied doesn't prevent the other changes from
+	// being applied.
+	n := &testReloadable{
+		benchlistErr: errTest,
+	}
+	result, err := reloader.Reload(n)
+	require.NoError(err)
+	require.Equal(
+		node.ReloadResult{
+			Applied: []string{
+				NetworkHealthMinPeersKey,
+			},
+			Rejected: map[string]string{
+				BenchlistDurationKey: errTest.Error(),
+			},
+		},
+		result,
+	)
+	require.Equal(uint(5), n.networkHealthConfig.MinConnectedPeers)
+	require.Nil(n.benchlistConfig)
+
+	// The change that failed is retried by the next reload.
+	n = &testReloadable{}
+	result, err = reloader.Reload(n)
+	require.NoError(err)
+	require.Equal(
+		[]string{
+			BenchlistDurationKey,
+		},
+		result.Applied,
+	)
+	require.Empty(result.Rejected)
+	require.Equal(time.Minute, n.benchlistConfig.BenchDuration)
+	require.Nil(n.networkHealthConfig)
+}
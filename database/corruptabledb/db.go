@@ -15,8 +15,9 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Checkpointer = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -78,6 +79,18 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
 
+// Checkpoint writes a consistent copy of the underlying database to [dir], if
+// the underlying database supports it.
+//
+// Errors returned by Checkpoint do not mark the database as corrupted, as they
+// are typically caused by the destination rather than the database.
+func (db *Database) Checkpoint(dir string) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return database.Checkpoint(db.Database, dir)
+}
+
 func (db *Database) Close() error {
 	return db.handleError(db.Database.Close())
 }
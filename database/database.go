@@ -84,6 +84,19 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Checkpointer wraps the Checkpoint method of a backing data store.
+type Checkpointer interface {
+	// Checkpoint writes a consistent, point-in-time copy of the data store to
+	// the directory [dir]. The copy can be opened by the same data store
+	// implementation, independently of the original data store.
+	//
+	// Writes performed concurrently with Checkpoint may or may not be included
+	// in the copy, but the copy will never include a partially applied batch.
+	//
+	// [dir] must not already exist.
+	Checkpoint(dir string) error
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
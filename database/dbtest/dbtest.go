@@ -8,6 +8,7 @@ import (
 	"io"
 	"math"
 	"math/rand"
+	"path/filepath"
 	"slices"
 	"testing"
 
@@ -1185,6 +1186,91 @@ func runConcurrentBatches(
 	return eg.Wait()
 }
 
+// TestCheckpoint tests to make sure that a checkpoint contains the state of
+// the database when the checkpoint was created and is unaffected by later
+// writes. [open] must open the database written at the provided directory.
+func TestCheckpoint(
+	t *testing.T,
+	db database.Database,
+	open func(t *testing.T, dir string) database.Database,
+) {
+	require := require.New(t)
+
+	checkpointer, ok := db.(database.Checkpointer)
+	require.True(ok)
+
+	var (
+		key1   = []byte("hello1")
+		value1 = []byte("world1")
+		key2   = []byte("hello2")
+		value2 = []byte("world2")
+	)
+	require.NoError(db.Put(key1, value1))
+
+	dir := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(checkpointer.Checkpoint(dir))
+
+	// Writes after the checkpoint must not be included in it.
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Delete(key1))
+
+	checkpoint := open(t, dir)
+	defer func() {
+		require.NoError(checkpoint.Close())
+	}()
+
+	value, err := checkpoint.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := checkpoint.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	// A checkpoint can not overwrite an existing database.
+	require.Error(checkpointer.Checkpoint(dir)) //nolint:forbidigo // the error is implementation specific
+
+	require.NoError(db.Close())
+	require.ErrorIs(checkpointer.Checkpoint(filepath.Join(t.TempDir(), "closed")), database.ErrClosed)
+}
+
+// TestReadOnly tests to make sure that a database reopened in read-only mode
+// contains the previously written state and rejects writes. [openReadOnly] must
+// open the database at the provided directory in read-only mode.
+func TestReadOnly(
+	t *testing.T,
+	open func(t *testing.T, dir string) database.Database,
+	openReadOnly func(t *testing.T, dir string) database.Database,
+) {
+	require := require.New(t)
+
+	var (
+		dir   = t.TempDir()
+		key   = []byte("hello")
+		value = []byte("world")
+	)
+	db := open(t, dir)
+	require.NoError(db.Put(key, value))
+	require.NoError(db.Close())
+
+	db = openReadOnly(t, dir)
+	defer func() {
+		require.NoError(db.Close())
+	}()
+
+	gotValue, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+
+	require.Error(db.Put(key, value))   //nolint:forbidigo // the error is implementation specific
+	require.Error(db.Delete(key))       //nolint:forbidigo // the error is implementation specific
+	require.Error(db.Compact(nil, nil)) //nolint:forbidigo // the error is implementation specific
+
+	gotValue, err = db.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+}
+
 func TestPutGetEmpty(t *testing.T, db database.KeyValueReaderWriterDeleter) {
 	require := require.New(t)
 
@@ -9,4 +9,6 @@ import "errors"
 var (
 	ErrClosed   = errors.New("closed")
 	ErrNotFound = errors.New("not found")
+
+	ErrCheckpointNotSupported = errors.New("checkpoint not supported")
 )
@@ -239,3 +239,13 @@ func ClearPrefix(db Database, prefix []byte, writeSize int) error {
 	}
 	return it.Error()
 }
+
+// Checkpoint writes a consistent copy of [db] to [dir]. If [db] does not
+// implement Checkpointer, ErrCheckpointNotSupported is returned.
+func Checkpoint(db any, dir string) error {
+	checkpointer, ok := db.(Checkpointer)
+	if !ok {
+		return ErrCheckpointNotSupported
+	}
+	return checkpointer.Checkpoint(dir)
+}
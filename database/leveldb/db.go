@@ -60,12 +60,17 @@ const (
 	// levelDBByteOverhead is the number of bytes of constant overhead that
 	// should be added to a batch size per operation.
 	levelDBByteOverhead = 8
+
+	// checkpointBatchSize is the number of bytes to buffer before writing to
+	// the checkpoint database.
+	checkpointBatchSize = 4 * opt.MiB
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Checkpointer = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Iterator     = (*iter)(nil)
 
 	ErrInvalidConfig = errors.New("invalid config")
 	ErrCouldNotOpen  = errors.New("could not open")
@@ -183,6 +188,12 @@ type config struct {
 	// MetricUpdateFrequency is the frequency to poll LevelDB metrics.
 	// If <= 0, LevelDB metrics aren't polled.
 	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+
+	// ReadOnly opens the database without modifying any of its files. All
+	// writes return an error, and a corrupted database is not recovered.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
 }
 
 // New returns a wrapped LevelDB object.
@@ -223,8 +234,9 @@ func New(file string, configBytes []byte, log logging.Logger, reg prometheus.Reg
 		WriteBuffer:                   parsedConfig.WriteBuffer,
 		Filter:                        filter.NewBloomFilter(parsedConfig.FilterBitsPerKey),
 		MaxManifestFileSize:           parsedConfig.MaxManifestFileSize,
+		ReadOnly:                      parsedConfig.ReadOnly,
 	})
-	if _, corrupted := err.(*errors.ErrCorrupted); corrupted {
+	if _, corrupted := err.(*errors.ErrCorrupted); corrupted && !parsedConfig.ReadOnly {
 		db, err = leveldb.RecoverFile(file, nil)
 	}
 	if err != nil {
@@ -352,6 +364,65 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+// Checkpoint copies a snapshot of the database into a new leveldb database at
+// [dir]. Writes that occur after the snapshot is taken are not included in the
+// copy.
+func (db *Database) Checkpoint(dir string) error {
+	if db.closed.Get() {
+		return database.ErrClosed
+	}
+
+	snapshot, err := db.DB.GetSnapshot()
+	if err != nil {
+		return updateError(err)
+	}
+	defer snapshot.Release()
+
+	checkpoint, err := leveldb.OpenFile(dir, &opt.Options{
+		ErrorIfExist: true,
+		Filter:       filter.NewBloomFilter(DefaultBitsPerKey),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCouldNotOpen, err)
+	}
+
+	if err := copySnapshot(snapshot, checkpoint); err != nil {
+		// Drop any close error to report the original error
+		_ = checkpoint.Close()
+		return err
+	}
+	return checkpoint.Close()
+}
+
+func copySnapshot(snapshot *leveldb.Snapshot, checkpoint *leveldb.DB) error {
+	it := snapshot.NewIterator(nil, nil)
+	defer it.Release()
+
+	var (
+		batch leveldb.Batch
+		size  int
+	)
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		batch.Put(key, value)
+		size += len(key) + len(value) + levelDBByteOverhead
+		if size < checkpointBatchSize {
+			continue
+		}
+
+		if err := checkpoint.Write(&batch, nil); err != nil {
+			return err
+		}
+		batch.Reset()
+		size = 0
+	}
+	if err := it.Error(); err != nil {
+		return updateError(err)
+	}
+	return checkpoint.Write(&batch, nil)
+}
+
 func (db *Database) Close() error {
 	db.closed.Set(true)
 	db.closeOnce.Do(func() {
@@ -29,6 +29,14 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestCheckpoint(t *testing.T) {
+	dbtest.TestCheckpoint(t, newDB(t), func(t *testing.T, dir string) database.Database {
+		db, err := New(dir, nil, logging.NoLog{}, prometheus.NewRegistry())
+		require.NoError(t, err)
+		return db
+	})
+}
+
 func newDB(t testing.TB) database.Database {
 	folder := t.TempDir()
 	db, err := New(folder, nil, logging.NoLog{}, prometheus.NewRegistry())
@@ -36,6 +44,22 @@ func newDB(t testing.TB) database.Database {
 	return db
 }
 
+func TestReadOnly(t *testing.T) {
+	dbtest.TestReadOnly(
+		t,
+		func(t *testing.T, dir string) database.Database {
+			db, err := New(dir, nil, logging.NoLog{}, prometheus.NewRegistry())
+			require.NoError(t, err)
+			return db
+		},
+		func(t *testing.T, dir string) database.Database {
+			db, err := New(dir, []byte(`{"readOnly":true}`), logging.NoLog{}, prometheus.NewRegistry())
+			require.NoError(t, err)
+			return db
+		},
+	)
+}
+
 func FuzzKeyValue(f *testing.F) {
 	db := newDB(f)
 	defer db.Close()
@@ -16,9 +16,10 @@ import (
 const methodLabel = "method"
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Checkpointer = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 
 	methodLabels = []string{methodLabel}
 	hasLabel     = prometheus.Labels{
@@ -42,6 +43,9 @@ var (
 	compactLabel = prometheus.Labels{
 		methodLabel: "compact",
 	}
+	checkpointLabel = prometheus.Labels{
+		methodLabel: "checkpoint",
+	}
 	closeLabel = prometheus.Labels{
 		methodLabel: "close",
 	}
@@ -227,6 +231,16 @@ func (db *Database) Compact(start, limit []byte) error {
 	return err
 }
 
+func (db *Database) Checkpoint(dir string) error {
+	start := time.Now()
+	err := database.Checkpoint(db.db, dir)
+	duration := time.Since(start)
+
+	db.calls.With(checkpointLabel).Inc()
+	db.duration.With(checkpointLabel).Add(float64(duration))
+	return err
+}
+
 func (db *Database) Close() error {
 	start := time.Now()
 	err := db.db.Close()
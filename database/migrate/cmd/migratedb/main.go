@@ -0,0 +1,196 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/factory"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/migrate"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	srcTypeKey      = "src-type"
+	srcPathKey      = "src-path"
+	srcConfigKey    = "src-config-file"
+	dstTypeKey      = "dst-type"
+	dstPathKey      = "dst-path"
+	dstConfigKey    = "dst-config-file"
+	batchSizeKey    = "batch-size"
+	progressFileKey = "progress-file"
+	skipVerifyKey   = "skip-verify"
+)
+
+var errSameDatabase = errors.New("source and destination databases must be different")
+
+// migratedb copies every key/value pair of an offline node's database into a
+// new database, which may use a different database backend.
+//
+// The node must be stopped while the migration is running. The source database
+// is opened read-only, so it is left unmodified.
+func main() {
+	cmd := &cobra.Command{
+		Use:   "migratedb",
+		Short: "Copies a node's database between database backends",
+		RunE:  migrateFunc,
+	}
+	flags := cmd.Flags()
+	flags.String(srcTypeKey, leveldb.Name, "Database type of the source database")
+	flags.String(srcPathKey, "", "Path of the source database")
+	flags.String(srcConfigKey, "", "Path to the config file of the source database")
+	flags.String(dstTypeKey, pebbledb.Name, "Database type of the destination database")
+	flags.String(dstPathKey, "", "Path of the destination database")
+	flags.String(dstConfigKey, "", "Path to the config file of the destination database")
+	flags.Int(batchSizeKey, migrate.DefaultBatchSize, "Number of bytes to write to the destination database per batch")
+	flags.String(progressFileKey, "", "File used to record progress so that an interrupted migration can be resumed. Defaults to [dst-path].migration")
+	flags.Bool(skipVerifyKey, false, "Skip comparing the key counts and hashes of the databases after copying")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "command failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func migrateFunc(c *cobra.Command, _ []string) error {
+	flags := c.Flags()
+	srcType, err := flags.GetString(srcTypeKey)
+	if err != nil {
+		return err
+	}
+	srcPath, err := flags.GetString(srcPathKey)
+	if err != nil {
+		return err
+	}
+	srcConfigFile, err := flags.GetString(srcConfigKey)
+	if err != nil {
+		return err
+	}
+	dstType, err := flags.GetString(dstTypeKey)
+	if err != nil {
+		return err
+	}
+	dstPath, err := flags.GetString(dstPathKey)
+	if err != nil {
+		return err
+	}
+	dstConfigFile, err := flags.GetString(dstConfigKey)
+	if err != nil {
+		return err
+	}
+	batchSize, err := flags.GetInt(batchSizeKey)
+	if err != nil {
+		return err
+	}
+	progressFile, err := flags.GetString(progressFileKey)
+	if err != nil {
+		return err
+	}
+	skipVerify, err := flags.GetBool(skipVerifyKey)
+	if err != nil {
+		return err
+	}
+
+	if srcPath == dstPath {
+		return errSameDatabase
+	}
+	if len(progressFile) == 0 {
+		progressFile = dstPath + ".migration"
+	}
+
+	log := logging.NewLogger(
+		"migratedb",
+		logging.NewWrappedCore(
+			logging.Info,
+			os.Stdout,
+			logging.Colors.ConsoleEncoder(),
+		),
+	)
+
+	src, err := openDB(srcType, srcPath, srcConfigFile, true, log)
+	if err != nil {
+		return fmt.Errorf("failed to open source database: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := openDB(dstType, dstPath, dstConfigFile, false, log)
+	if err != nil {
+		return fmt.Errorf("failed to open destination database: %w", err)
+	}
+	defer dst.Close()
+
+	ctx := c.Context()
+	_, err = migrate.Copy(ctx, log, src, dst, migrate.Config{
+		BatchSize:    batchSize,
+		ProgressFile: progressFile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy database: %w", err)
+	}
+
+	if !skipVerify {
+		log.Info("verifying database")
+		if err := migrate.Verify(ctx, src, dst); err != nil {
+			return fmt.Errorf("failed to verify database: %w", err)
+		}
+		log.Info("verified database")
+	}
+
+	if err := os.Remove(progressFile); err != nil && !os.IsNotExist(err) {
+		log.Warn("failed to remove progress file",
+			zap.String("path", progressFile),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// openDB opens the database at [path]. If [readOnly] is true, the database is
+// opened without modifying any of its files.
+func openDB(dbType, path, configFile string, readOnly bool, log logging.Logger) (database.Database, error) {
+	var config []byte
+	if len(configFile) > 0 {
+		var err error
+		config, err = os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if readOnly {
+		var err error
+		config, err = withReadOnly(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configFile, err)
+		}
+	}
+	return factory.New(dbType, path, false, config, prometheus.NewRegistry(), log)
+}
+
+// withReadOnly sets the readOnly option of the leveldb or pebbledb [config].
+func withReadOnly(config []byte) ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &fields); err != nil {
+			return nil, err
+		}
+	}
+	fields["readOnly"] = json.RawMessage("true")
+	return json.Marshal(fields)
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migrate copies the contents of one database into another, which
+// allows a node's database to be moved between database backends without
+// resyncing.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// DefaultBatchSize is the default number of bytes written to the
+	// destination database per batch.
+	DefaultBatchSize = 4 * units.MiB
+
+	progressLogFrequency = 30 * time.Second
+)
+
+var (
+	ErrKeyCountMismatch = errors.New("key count mismatch")
+	ErrHashMismatch     = errors.New("hash mismatch")
+)
+
+// Config specifies how a migration is performed.
+type Config struct {
+	// BatchSize is the number of bytes written to the destination database
+	// per batch.
+	BatchSize int
+	// ProgressFile is where the last copied key is recorded after each batch
+	// is written. If ProgressFile contains a key when Copy is called, copying
+	// resumes after that key. If empty, progress is not recorded.
+	ProgressFile string
+}
+
+// Copy writes every key/value pair in [src] into [dst].
+//
+// Keys are copied in order and written in batches. If Copy is interrupted, it
+// can be resumed by calling Copy again with the same ProgressFile.
+//
+// Returns the number of keys copied by this call.
+func Copy(
+	ctx context.Context,
+	log logging.Logger,
+	src database.Iteratee,
+	dst database.Batcher,
+	config Config,
+) (uint64, error) {
+	start, err := readProgress(config.ProgressFile)
+	if err != nil {
+		return 0, err
+	}
+	if start != nil {
+		log.Info("resuming migration",
+			zap.Binary("lastKey", start),
+		)
+	}
+
+	var (
+		it    = src.NewIteratorWithStart(start)
+		batch = dst.NewBatch()
+
+		startTime   = time.Now()
+		lastLogTime = startTime
+		numCopied   uint64
+		lastKey     []byte
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		// The resumed key was already copied.
+		if start != nil && slices.Equal(key, start) {
+			continue
+		}
+
+		if err := batch.Put(key, it.Value()); err != nil {
+			return numCopied, err
+		}
+		numCopied++
+		lastKey = slices.Clone(key)
+
+		if batch.Size() < config.BatchSize {
+			continue
+		}
+
+		if err := writeBatch(batch, config.ProgressFile, lastKey); err != nil {
+			return numCopied, err
+		}
+		batch.Reset()
+
+		if err := ctx.Err(); err != nil {
+			return numCopied, err
+		}
+
+		if now := time.Now(); now.Sub(lastLogTime) > progressLogFrequency {
+			lastLogTime = now
+			log.Info("migrating database",
+				zap.Uint64("numCopied", numCopied),
+				zap.Binary("lastKey", lastKey),
+				zap.Duration("duration", now.Sub(startTime)),
+			)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return numCopied, err
+	}
+
+	if err := writeBatch(batch, config.ProgressFile, lastKey); err != nil {
+		return numCopied, err
+	}
+
+	log.Info("finished migrating database",
+		zap.Uint64("numCopied", numCopied),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return numCopied, nil
+}
+
+// writeBatch writes [batch] and then records [lastKey] as the last copied key.
+//
+// The progress is recorded after the batch is written, so a crash between
+// the two can only cause an already copied batch to be copied again.
+func writeBatch(batch database.Batch, progressFile string, lastKey []byte) error {
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if len(progressFile) == 0 || lastKey == nil {
+		return nil
+	}
+	return perms.WriteFile(progressFile, lastKey, perms.ReadWrite)
+}
+
+func readProgress(progressFile string) ([]byte, error) {
+	if len(progressFile) == 0 {
+		return nil, nil
+	}
+
+	lastKey, err := os.ReadFile(progressFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return lastKey, err
+}
+
+// Summary describes the contents of a database.
+type Summary struct {
+	// NumKeys is the number of keys in the database.
+	NumKeys uint64
+	// Hash commits to every key/value pair in the database, in order.
+	Hash [sha256.Size]byte
+}
+
+// Summarize iterates over [db] to calculate its Summary.
+func Summarize(ctx context.Context, db database.Iteratee) (Summary, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		summary Summary
+		hasher  = sha256.New()
+	)
+	for it.Next() {
+		writeLengthPrefixed(hasher, it.Key())
+		writeLengthPrefixed(hasher, it.Value())
+		summary.NumKeys++
+
+		// Checking the context on every key would be needlessly expensive.
+		if summary.NumKeys%units.KiB == 0 {
+			if err := ctx.Err(); err != nil {
+				return Summary{}, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return Summary{}, err
+	}
+
+	hasher.Sum(summary.Hash[:0])
+	return summary, nil
+}
+
+func writeLengthPrefixed(hasher hash.Hash, b []byte) {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(b)))
+	_, _ = hasher.Write(length[:n])
+	_, _ = hasher.Write(b)
+}
+
+// Verify returns an error if [src] and [dst] do not contain the same key/value
+// pairs, as determined by comparing their key counts and hashes.
+func Verify(ctx context.Context, src, dst database.Iteratee) error {
+	srcSummary, err := Summarize(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to summarize source database: %w", err)
+	}
+	dstSummary, err := Summarize(ctx, dst)
+	if err != nil {
+		return fmt.Errorf("failed to summarize destination database: %w", err)
+	}
+
+	if srcSummary.NumKeys != dstSummary.NumKeys {
+		return fmt.Errorf("%w: source has %d keys but destination has %d keys",
+			ErrKeyCountMismatch,
+			srcSummary.NumKeys,
+			dstSummary.NumKeys,
+		)
+	}
+	if srcSummary.Hash != dstSummary.Hash {
+		return fmt.Errorf("%w: source has hash %x but destination has hash %x",
+			ErrHashMismatch,
+			srcSummary.Hash,
+			dstSummary.Hash,
+		)
+	}
+	return nil
+}
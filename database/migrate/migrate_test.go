@@ -0,0 +1,152 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newDB(t *testing.T, numKeys int) database.Database {
+	db := memdb.New()
+	for i := range numKeys {
+		require.NoError(t, db.Put(
+			fmt.Appendf(nil, "key%03d", i),
+			fmt.Appendf(nil, "value%03d", i),
+		))
+	}
+	return db
+}
+
+func TestCopy(t *testing.T) {
+	require := require.New(t)
+
+	src := newDB(t, 100)
+	dst := memdb.New()
+
+	numCopied, err := Copy(t.Context(), logging.NoLog{}, src, dst, Config{
+		BatchSize: 1,
+	})
+	require.NoError(err)
+	require.Equal(uint64(100), numCopied)
+	require.NoError(Verify(t.Context(), src, dst))
+}
+
+func TestCopyResume(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		src          = newDB(t, 100)
+		dst          = memdb.New()
+		progressFile = filepath.Join(t.TempDir(), "progress")
+	)
+
+	// Simulate an interrupted migration which copied the first 50 keys.
+	for i := range 50 {
+		key := fmt.Appendf(nil, "key%03d", i)
+		value, err := src.Get(key)
+		require.NoError(err)
+		require.NoError(dst.Put(key, value))
+	}
+	require.NoError(os.WriteFile(progressFile, []byte("key049"), 0o600))
+
+	numCopied, err := Copy(t.Context(), logging.NoLog{}, src, dst, Config{
+		BatchSize:    DefaultBatchSize,
+		ProgressFile: progressFile,
+	})
+	require.NoError(err)
+	require.Equal(uint64(50), numCopied)
+	require.NoError(Verify(t.Context(), src, dst))
+
+	lastKey, err := os.ReadFile(progressFile)
+	require.NoError(err)
+	require.Equal([]byte("key099"), lastKey)
+}
+
+func TestCopyCancelled(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		src          = newDB(t, 100)
+		dst          = memdb.New()
+		progressFile = filepath.Join(t.TempDir(), "progress")
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	numCopied, err := Copy(ctx, logging.NoLog{}, src, dst, Config{
+		BatchSize:    1,
+		ProgressFile: progressFile,
+	})
+	require.ErrorIs(err, context.Canceled)
+	require.Equal(uint64(1), numCopied)
+
+	// The interrupted migration should be resumed.
+	numCopied, err = Copy(t.Context(), logging.NoLog{}, src, dst, Config{
+		BatchSize:    1,
+		ProgressFile: progressFile,
+	})
+	require.NoError(err)
+	require.Equal(uint64(99), numCopied)
+	require.NoError(Verify(t.Context(), src, dst))
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(db database.Database) error
+		expectedErr error
+	}{
+		{
+			name: "equal",
+			modify: func(database.Database) error {
+				return nil
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "missing key",
+			modify: func(db database.Database) error {
+				return db.Delete([]byte("key000"))
+			},
+			expectedErr: ErrKeyCountMismatch,
+		},
+		{
+			name: "extra key",
+			modify: func(db database.Database) error {
+				return db.Put([]byte("extra"), nil)
+			},
+			expectedErr: ErrKeyCountMismatch,
+		},
+		{
+			name: "different value",
+			modify: func(db database.Database) error {
+				return db.Put([]byte("key000"), []byte("different"))
+			},
+			expectedErr: ErrHashMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			src := newDB(t, 10)
+			dst := newDB(t, 10)
+			require.NoError(test.modify(dst))
+
+			err := Verify(t.Context(), src, dst)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -31,7 +31,8 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Checkpointer = (*Database)(nil)
 
 	errInvalidOperation = errors.New("invalid operation")
 
@@ -64,6 +65,9 @@ type Config struct {
 	MaxOpenFiles                int    `json:"maxOpenFiles"`
 	MaxConcurrentCompactions    int    `json:"maxConcurrentCompactions"`
 	Sync                        bool   `json:"sync"`
+	// ReadOnly opens the database without modifying any of its files. All
+	// writes return an error.
+	ReadOnly bool `json:"readOnly"`
 }
 
 // TODO: Add metrics
@@ -86,6 +90,7 @@ func New(file string, configBytes []byte, log logging.Logger, _ prometheus.Regis
 		MaxConcurrentCompactions:    func() int { return cfg.MaxConcurrentCompactions },
 	}
 	opts.Experimental.ReadSamplingMultiplier = -1 // Disable seek compaction
+	opts.ReadOnly = cfg.ReadOnly
 
 	log.Info(
 		"opening pebble",
@@ -120,6 +125,19 @@ func (db *Database) Close() error {
 	return updateError(db.pebbleDB.Close())
 }
 
+// Checkpoint uses a pebble checkpoint to write a consistent copy of the
+// database to [dir]. The WAL is flushed prior to creating the checkpoint so
+// that the copy does not require WAL replay when opened.
+func (db *Database) Checkpoint(dir string) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Checkpoint(dir, pebble.WithFlushedWAL()))
+}
+
 func (db *Database) HealthCheck(_ context.Context) (interface{}, error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
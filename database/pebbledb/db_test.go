@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/dbtest"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -31,6 +32,30 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestCheckpoint(t *testing.T) {
+	dbtest.TestCheckpoint(t, newDB(t), func(t *testing.T, dir string) database.Database {
+		db, err := New(dir, nil, logging.NoLog{}, prometheus.NewRegistry())
+		require.NoError(t, err)
+		return db
+	})
+}
+
+func TestReadOnly(t *testing.T) {
+	dbtest.TestReadOnly(
+		t,
+		func(t *testing.T, dir string) database.Database {
+			db, err := New(dir, nil, logging.NoLog{}, prometheus.NewRegistry())
+			require.NoError(t, err)
+			return db
+		},
+		func(t *testing.T, dir string) database.Database {
+			db, err := New(dir, []byte(`{"readOnly":true}`), logging.NoLog{}, prometheus.NewRegistry())
+			require.NoError(t, err)
+			return db
+		},
+	)
+}
+
 func FuzzKeyValue(f *testing.F) {
 	db := newDB(f)
 	dbtest.FuzzKeyValue(f, db)
@@ -6,6 +6,10 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/websocket"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -15,6 +19,7 @@ import (
 
 type Client struct {
 	Requester rpc.EndpointRequester
+	uri       string
 }
 
 // NewClient creates a client that can interact with an index via HTTP API
@@ -26,6 +31,7 @@ type Client struct {
 func NewClient(uri string) *Client {
 	return &Client{
 		Requester: rpc.NewEndpointRequester(uri),
+		uri:       uri,
 	}
 }
 
@@ -141,3 +147,67 @@ func (c *Client) GetContainerByID(ctx context.Context, id ids.ID, options ...rpc
 		Bytes:     containerBytes,
 	}, uint64(fc.Index), nil
 }
+
+// Subscribe calls [onContainer] with each container, and its index, in the
+// order they were accepted, starting at [startIndex]. Once all previously
+// accepted containers have been delivered, newly accepted containers are
+// delivered as they are accepted.
+//
+// Subscribe blocks until [ctx] is cancelled, the connection is closed, or
+// [onContainer] returns an error. If the subscription ends, it can be resumed
+// from the index after the last delivered container.
+func (c *Client) Subscribe(
+	ctx context.Context,
+	startIndex uint64,
+	onContainer func(container Container, index uint64) error,
+) error {
+	uri, err := url.Parse(c.uri + subscribeEndpointSuffix)
+	if err != nil {
+		return err
+	}
+	switch uri.Scheme {
+	case "https":
+		uri.Scheme = "wss"
+	default:
+		uri.Scheme = "ws"
+	}
+	uri.RawQuery = url.Values{
+		"startIndex": []string{strconv.FormatUint(startIndex, 10)},
+		"encoding":   []string{formatting.Hex.String()},
+	}.Encode()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, uri.String(), nil)
+	if err != nil {
+		return fmt.Errorf("couldn't subscribe to %s: %w", uri, err)
+	}
+	defer conn.Close()
+
+	// Unblock reading from the connection once [ctx] is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	for {
+		var fc FormattedContainer
+		if err := conn.ReadJSON(&fc); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+		if err != nil {
+			return fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+		}
+		container := Container{
+			ID:        fc.ID,
+			Timestamp: fc.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+		if err := onContainer(container, uint64(fc.Index)); err != nil {
+			return err
+		}
+	}
+}
@@ -14,4 +14,7 @@ type Container struct {
 	Bytes []byte `serialize:"true"`
 	// Unix time, in nanoseconds, at which this container was accepted by this node
 	Timestamp int64 `serialize:"true"`
+
+	// True if the container was indexed without its bytes
+	bytesOmitted bool
 }
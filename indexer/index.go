@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -28,9 +29,13 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
+	firstIndexKey          = []byte{0x03} // Maps to the first index that hasn't been pruned
+	bytesOmittedPrefix     = []byte{0x04}
 	errNoneAccepted        = errors.New("no containers have been accepted")
 	errNumToFetchInvalid   = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
 	errNoContainerAtIndex  = errors.New("no container at index")
+	errIndexClosed         = errors.New("index closed")
+	errPruned              = errors.New("pruned")
 
 	_ snow.Acceptor = (*index)(nil)
 )
@@ -45,15 +50,30 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// The index of the first container that hasn't been pruned
+	firstIndex uint64
+	retention  RetentionPolicy
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
-	// Both [indexToContainer] and [containerToIndex] have [vDB] underneath
+	// [indexToContainer], [containerToIndex] and [bytesOmitted] have [vDB]
+	// underneath
 	// Index --> Container
 	indexToContainer database.Database
 	// Container ID --> Index
 	containerToIndex database.Database
-	log              logging.Logger
+	// Index --> Nothing. Contains the indices of containers that were accepted
+	// without their bytes.
+	bytesOmitted database.Database
+	log          logging.Logger
+	// Closed and replaced whenever a container is accepted, or when the index
+	// is closed, to wake up goroutines waiting in [waitForContainer].
+	accepted chan struct{}
+	closed   bool
+
+	// Closed to stop [pruneLoop]
+	stopPruning chan struct{}
+	pruningDone sync.WaitGroup
 }
 
 // Create a new thread-safe index.
@@ -63,10 +83,12 @@ func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	clock mockable.Clock,
+	retention RetentionPolicy,
 ) (*index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
+	bytesOmitted := prefixdb.New(bytesOmittedPrefix, vDB)
 
 	i := &index{
 		clock:            clock,
@@ -74,7 +96,11 @@ func newIndex(
 		vDB:              vDB,
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
+		bytesOmitted:     bytesOmitted,
 		log:              log,
+		retention:        retention,
+		accepted:         make(chan struct{}),
+		stopPruning:      make(chan struct{}),
 	}
 
 	// Get next accepted index from db
@@ -88,8 +114,21 @@ func newIndex(
 		return nil, fmt.Errorf("couldn't get next accepted index from database: %w", err)
 	}
 
+	// Get first retained index from db
+	firstIndex, err := database.WithDefault(
+		database.GetUInt64,
+		i.vDB,
+		firstIndexKey,
+		0,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get first index from database: %w", err)
+	}
+
 	i.nextAcceptedIndex = nextAcceptedIndex
+	i.firstIndex = firstIndex
 	i.log.Info("created new index",
+		zap.Uint64("firstIndex", i.firstIndex),
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 	)
 	return i, nil
@@ -97,9 +136,19 @@ func newIndex(
 
 // Close this index
 func (i *index) Close() error {
+	i.lock.Lock()
+	if !i.closed {
+		i.closed = true
+		close(i.accepted)
+		close(i.stopPruning)
+	}
+	i.lock.Unlock()
+	i.pruningDone.Wait()
+
 	return errors.Join(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
+		i.bytesOmitted.Close(),
 		i.vDB.Close(),
 		i.baseDB.Close(),
 	)
@@ -133,6 +182,15 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	)
 	// Persist index --> Container
 	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
+	if i.retention.IDsOnly {
+		containerBytes = nil
+
+		// Whether the bytes were omitted is recorded per container, so that
+		// containers are returned correctly if the retention policy changes.
+		if err := i.bytesOmitted.Put(nextAcceptedIndexBytes, nil); err != nil {
+			return fmt.Errorf("couldn't mark bytes of container %s as omitted: %w", containerID, err)
+		}
+	}
 	bytes, err := Codec.Marshal(CodecVersion, Container{
 		ID:        containerID,
 		Bytes:     containerBytes,
@@ -156,8 +214,16 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
 	}
 
-	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex],
+	// [i.bytesOmitted] to [i.baseDB]
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Notify subscribers of the newly accepted container
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -177,6 +243,9 @@ func (i *index) getContainerByIndex(index uint64) (Container, error) {
 	if !ok || index > lastAcceptedIndex {
 		return Container{}, fmt.Errorf("%w %d", errNoContainerAtIndex, index)
 	}
+	if index < i.firstIndex {
+		return Container{}, i.prunedErr(index)
+	}
 	indexBytes := database.PackUInt64(index)
 	return i.getContainerByIndexBytes(indexBytes)
 }
@@ -195,9 +264,47 @@ func (i *index) getContainerByIndexBytes(indexBytes []byte) (Container, error) {
 	if _, err := Codec.Unmarshal(containerBytes, &container); err != nil {
 		return Container{}, fmt.Errorf("couldn't unmarshal container: %w", err)
 	}
+	container.bytesOmitted, err = i.bytesOmitted.Has(indexBytes)
+	if err != nil {
+		return Container{}, fmt.Errorf("couldn't read from database: %w", err)
+	}
 	return container, nil
 }
 
+// waitForContainer returns the container at [index], blocking until it has
+// been accepted, [ctx] is cancelled, or the index is closed.
+func (i *index) waitForContainer(ctx context.Context, index uint64) (Container, error) {
+	for {
+		i.lock.RLock()
+		if i.closed {
+			i.lock.RUnlock()
+			return Container{}, errIndexClosed
+		}
+		if index < i.nextAcceptedIndex {
+			container, err := i.getContainerByIndex(index)
+			i.lock.RUnlock()
+			return container, err
+		}
+		accepted := i.accepted
+		i.lock.RUnlock()
+
+		select {
+		case <-accepted:
+		case <-ctx.Done():
+			return Container{}, ctx.Err()
+		}
+	}
+}
+
+// Returns the index of the first container that hasn't been pruned and the
+// index that the next accepted container will be given.
+func (i *index) getIndexBounds() (uint64, uint64) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.firstIndex, i.nextAcceptedIndex
+}
+
 // GetContainerRange returns the IDs of containers at indices
 // [startIndex], [startIndex+1], ..., [startIndex+numToFetch-1].
 // [startIndex] should be <= i.lastAcceptedIndex().
@@ -216,6 +323,8 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 		return nil, errNoneAccepted
 	} else if startIndex > lastAcceptedIndex {
 		return nil, fmt.Errorf("start index (%d) > last accepted index (%d)", startIndex, lastAcceptedIndex)
+	} else if startIndex < i.firstIndex {
+		return nil, i.prunedErr(startIndex)
 	}
 
 	// Calculate the last index we will fetch
@@ -236,12 +345,36 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	return containers, nil
 }
 
-// Returns database.ErrNotFound if the container is not indexed as accepted
+// Returns database.ErrNotFound if the container is not indexed as accepted.
+//
+// The IDs of pruned containers are not retained. If any containers have been
+// pruned, the returned error also wraps the pruned error, as the container may
+// have been pruned.
 func (i *index) GetIndex(id ids.ID) (uint64, error) {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
-	return database.GetUInt64(i.containerToIndex, id[:])
+	return i.getIndex(id)
+}
+
+// Assumes [i.lock] is held
+func (i *index) getIndex(id ids.ID) (uint64, error) {
+	index, err := database.GetUInt64(i.containerToIndex, id[:])
+	switch {
+	case err == database.ErrNotFound && i.firstIndex > 0:
+		return 0, fmt.Errorf("%w: %w: container %s isn't indexed, it was either never accepted or was pruned, the first retained index is %d",
+			database.ErrNotFound,
+			errPruned,
+			id,
+			i.firstIndex,
+		)
+	case err != nil:
+		return 0, err
+	case index < i.firstIndex:
+		return 0, i.prunedErr(index)
+	default:
+		return index, nil
+	}
 }
 
 func (i *index) GetContainerByID(id ids.ID) (Container, error) {
@@ -249,11 +382,11 @@ func (i *index) GetContainerByID(id ids.ID) (Container, error) {
 	defer i.lock.RUnlock()
 
 	// Read index from database
-	indexBytes, err := i.containerToIndex.Get(id[:])
+	index, err := i.getIndex(id)
 	if err != nil {
 		return Container{}, err
 	}
-	return i.getContainerByIndexBytes(indexBytes)
+	return i.getContainerByIndexBytes(database.PackUInt64(index))
 }
 
 // GetLastAccepted returns the last accepted container.
@@ -278,3 +411,12 @@ func (i *index) GetLastAccepted() (Container, error) {
 func (i *index) lastAcceptedIndex() (uint64, bool) {
 	return i.nextAcceptedIndex - 1, i.nextAcceptedIndex != 0
 }
+
+// Assumes i.lock is held
+func (i *index) prunedErr(index uint64) error {
+	return fmt.Errorf("%w: container at index %d was pruned, the first retained index is %d",
+		errPruned,
+		index,
+		i.firstIndex,
+	)
+}
@@ -48,6 +48,7 @@ type Config struct {
 	Log                  logging.Logger
 	IndexingEnabled      bool
 	AllowIncompleteIndex bool
+	Retention            RetentionConfig
 	BlockAcceptorGroup   snow.AcceptorGroup
 	TxAcceptorGroup      snow.AcceptorGroup
 	VertexAcceptorGroup  snow.AcceptorGroup
@@ -72,6 +73,7 @@ func NewIndexer(config Config) (Indexer, error) {
 		db:                   config.DB,
 		allowIncompleteIndex: config.AllowIncompleteIndex,
 		indexingEnabled:      config.IndexingEnabled,
+		retention:            config.Retention,
 		blockAcceptorGroup:   config.BlockAcceptorGroup,
 		txAcceptorGroup:      config.TxAcceptorGroup,
 		vertexAcceptorGroup:  config.VertexAcceptorGroup,
@@ -113,6 +115,9 @@ type indexer struct {
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
+	// Specifies which chains are indexed and which containers are retained
+	retention RetentionConfig
+
 	// Chain ID --> index of blocks of that chain (if applicable)
 	blockIndices map[ids.ID]*index
 	// Chain ID --> index of vertices of that chain (if applicable)
@@ -185,7 +190,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 
-	if !i.indexingEnabled { // Indexing is disabled
+	if !i.indexingEnabled || !i.retention.indexes(chainName) { // Indexing is disabled for this chain
 		if previouslyIndexed && !i.allowIncompleteIndex {
 			// We indexed this chain in a previous run but not in this run.
 			// This would create an incomplete index, which is not allowed, so exit.
@@ -316,7 +321,7 @@ func (i *indexer) registerChainHelper(
 	copy(prefix, chainID[:])
 	prefix[ids.IDLen] = prefixEnd
 	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.clock)
+	index, err := newIndex(indexDB, i.log, i.clock, i.retention.policy(name))
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
@@ -341,6 +346,15 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a WebSocket endpoint to stream newly accepted containers
+	subscriptionHandler := newSubscriptionHandler(index, i.log)
+	if err := i.pathAdder.AddRoute(subscriptionHandler, "index/"+name, "/"+endpoint+subscribeEndpointSuffix); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
+
+	index.startPruning()
 	return index, nil
 }
 
@@ -0,0 +1,238 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var errNegativeMaxAge = errors.New("negative maxAge")
+
+const (
+	// Frequency at which indices check for containers to prune
+	pruneFrequency = time.Minute
+	// Maximum number of containers pruned in a single database commit
+	pruneBatchSize = 1024
+)
+
+// RetentionPolicy specifies which containers an index retains. The zero value
+// retains every container.
+type RetentionPolicy struct {
+	// If non-zero, only the [MaxContainers] most recently accepted containers
+	// are retained.
+	MaxContainers uint64 `json:"maxContainers"`
+	// If non-zero, containers accepted more than [MaxAge] ago are pruned.
+	//
+	// Encoded in JSON as a duration string. For example: "72h".
+	MaxAge time.Duration `json:"maxAge"`
+	// If true, the bytes of accepted containers are not stored. Only their
+	// IDs, indices and timestamps are indexed.
+	IDsOnly bool `json:"idsOnly"`
+}
+
+type jsonRetentionPolicy struct {
+	MaxContainers uint64 `json:"maxContainers"`
+	MaxAge        string `json:"maxAge,omitempty"`
+	IDsOnly       bool   `json:"idsOnly"`
+}
+
+func (p RetentionPolicy) MarshalJSON() ([]byte, error) {
+	jsonPolicy := jsonRetentionPolicy{
+		MaxContainers: p.MaxContainers,
+		IDsOnly:       p.IDsOnly,
+	}
+	if p.MaxAge != 0 {
+		jsonPolicy.MaxAge = p.MaxAge.String()
+	}
+	return json.Marshal(jsonPolicy)
+}
+
+func (p *RetentionPolicy) UnmarshalJSON(b []byte) error {
+	var jsonPolicy jsonRetentionPolicy
+	if err := json.Unmarshal(b, &jsonPolicy); err != nil {
+		return err
+	}
+
+	var maxAge time.Duration
+	if jsonPolicy.MaxAge != "" {
+		var err error
+		maxAge, err = time.ParseDuration(jsonPolicy.MaxAge)
+		if err != nil {
+			return fmt.Errorf("couldn't parse maxAge: %w", err)
+		}
+		if maxAge < 0 {
+			return fmt.Errorf("%w: %s", errNegativeMaxAge, maxAge)
+		}
+	}
+	*p = RetentionPolicy{
+		MaxContainers: jsonPolicy.MaxContainers,
+		MaxAge:        maxAge,
+		IDsOnly:       jsonPolicy.IDsOnly,
+	}
+	return nil
+}
+
+func (p RetentionPolicy) prunes() bool {
+	return p.MaxContainers != 0 || p.MaxAge != 0
+}
+
+// RetentionConfig specifies which chains are indexed and which containers are
+// retained for each indexed chain.
+//
+// Chains are identified by their primary alias. For example: "P", "X" or "C".
+type RetentionConfig struct {
+	// If non-empty, only these chains are indexed.
+	IndexedChains []string `json:"indexedChains"`
+	// Default is the retention policy of chains that aren't in [Chains].
+	Default RetentionPolicy `json:"default"`
+	// Chains maps chains to their retention policy.
+	Chains map[string]RetentionPolicy `json:"chains"`
+}
+
+func (c *RetentionConfig) indexes(chainName string) bool {
+	return len(c.IndexedChains) == 0 || slices.Contains(c.IndexedChains, chainName)
+}
+
+func (c *RetentionConfig) policy(chainName string) RetentionPolicy {
+	if policy, ok := c.Chains[chainName]; ok {
+		return policy
+	}
+	return c.Default
+}
+
+// startPruning periodically prunes containers that are no longer retained
+// until the index is closed.
+func (i *index) startPruning() {
+	if !i.retention.prunes() {
+		return
+	}
+
+	i.pruningDone.Add(1)
+	go i.pruneLoop()
+}
+
+func (i *index) pruneLoop() {
+	defer i.pruningDone.Done()
+
+	ticker := time.NewTicker(pruneFrequency)
+	defer ticker.Stop()
+
+	for {
+		// Pruning progress is persisted after every batch, so if the node
+		// was shut down while pruning, pruning resumes here.
+		for {
+			done, err := i.prune(pruneBatchSize)
+			if errors.Is(err, errIndexClosed) {
+				return
+			}
+			if err != nil {
+				// Pruning is retried on the next tick.
+				i.log.Error("failed to prune index",
+					zap.Error(err),
+				)
+				break
+			}
+			if done {
+				break
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-i.stopPruning:
+			return
+		}
+	}
+}
+
+// prune removes up to [maxToPrune] of the oldest containers that are no
+// longer retained by the retention policy.
+//
+// Returns true if there are no more containers to prune.
+func (i *index) prune(maxToPrune int) (bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.closed {
+		return false, errIndexClosed
+	}
+
+	// Containers before [minIndex] exceed [MaxContainers]
+	var minIndex uint64
+	if maxContainers := i.retention.MaxContainers; maxContainers != 0 && i.nextAcceptedIndex > maxContainers {
+		minIndex = i.nextAcceptedIndex - maxContainers
+	}
+	// Containers accepted before [minTimestamp] exceed [MaxAge]
+	minTimestamp := int64(math.MinInt64)
+	if i.retention.MaxAge != 0 {
+		minTimestamp = i.clock.Time().Add(-i.retention.MaxAge).UnixNano()
+	}
+
+	var (
+		firstIndex = i.firstIndex
+		done       bool
+	)
+	for numPruned := 0; numPruned < maxToPrune; numPruned++ {
+		if firstIndex == i.nextAcceptedIndex {
+			done = true
+			break
+		}
+
+		indexBytes := database.PackUInt64(firstIndex)
+		container, err := i.getContainerByIndexBytes(indexBytes)
+		if err != nil {
+			i.vDB.Abort()
+			return false, err
+		}
+		// Containers are pruned in the order they were accepted, so once a
+		// container is retained, all later containers are also retained.
+		if firstIndex >= minIndex && container.Timestamp >= minTimestamp {
+			done = true
+			break
+		}
+
+		if err := i.indexToContainer.Delete(indexBytes); err != nil {
+			i.vDB.Abort()
+			return false, fmt.Errorf("couldn't delete container at index %d: %w", firstIndex, err)
+		}
+		if err := i.containerToIndex.Delete(container.ID[:]); err != nil {
+			i.vDB.Abort()
+			return false, fmt.Errorf("couldn't delete index of container %s: %w", container.ID, err)
+		}
+		if err := i.bytesOmitted.Delete(indexBytes); err != nil {
+			i.vDB.Abort()
+			return false, fmt.Errorf("couldn't delete container at index %d: %w", firstIndex, err)
+		}
+		firstIndex++
+	}
+
+	if firstIndex == i.firstIndex {
+		return done, nil
+	}
+
+	if err := database.PutUInt64(i.vDB, firstIndexKey, firstIndex); err != nil {
+		i.vDB.Abort()
+		return false, fmt.Errorf("couldn't put first index: %w", err)
+	}
+	if err := i.vDB.Commit(); err != nil {
+		i.vDB.Abort()
+		return false, err
+	}
+
+	i.log.Debug("pruned index",
+		zap.Uint64("numPruned", firstIndex-i.firstIndex),
+		zap.Uint64("firstIndex", firstIndex),
+	)
+	i.firstIndex = firstIndex
+	return done, nil
+}
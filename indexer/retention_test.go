@@ -0,0 +1,282 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+func acceptTestContainers(t *testing.T, idx *index, numContainers int) []ids.ID {
+	ctx := snowtest.ConsensusContext(snowtest.Context(t, snowtest.CChainID))
+
+	containerIDs := make([]ids.ID, numContainers)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(t, idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+	return containerIDs
+}
+
+func TestPruneMaxContainers(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	policy := RetentionPolicy{
+		MaxContainers: 4,
+	}
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, policy)
+	require.NoError(err)
+
+	containerIDs := acceptTestContainers(t, idx, 10)
+
+	done, err := idx.prune(3)
+	require.NoError(err)
+	require.False(done)
+	require.Equal(uint64(3), idx.firstIndex)
+
+	// Pruning is resumed after restarting
+	require.NoError(db.Commit())
+	require.NoError(idx.Close())
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, policy)
+	require.NoError(err)
+	require.Equal(uint64(3), idx.firstIndex)
+
+	done, err = idx.prune(pruneBatchSize)
+	require.NoError(err)
+	require.True(done)
+	require.Equal(uint64(6), idx.firstIndex)
+
+	_, err = idx.GetContainerByIndex(5)
+	require.ErrorIs(err, errPruned)
+	_, err = idx.GetContainerRange(5, 2)
+	require.ErrorIs(err, errPruned)
+	_, err = idx.GetContainerByID(containerIDs[5])
+	require.ErrorIs(err, errPruned)
+
+	// The IDs of pruned containers are removed
+	_, err = idx.GetIndex(containerIDs[5])
+	require.ErrorIs(err, errPruned)
+	require.ErrorIs(err, database.ErrNotFound)
+	for _, containerID := range containerIDs[:6] {
+		_, err := idx.containerToIndex.Get(containerID[:])
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	s := &service{index: idx}
+	isAcceptedReply := IsAcceptedResponse{}
+	require.NoError(s.IsAccepted(nil, &IsAcceptedArgs{ID: containerIDs[5]}, &isAcceptedReply))
+	require.False(isAcceptedReply.IsAccepted)
+
+	container, err := idx.GetContainerByIndex(6)
+	require.NoError(err)
+	require.Equal(containerIDs[6], container.ID)
+	index, err := idx.GetIndex(containerIDs[6])
+	require.NoError(err)
+	require.Equal(uint64(6), index)
+
+	// Nothing else should be pruned until more containers are accepted
+	done, err = idx.prune(pruneBatchSize)
+	require.NoError(err)
+	require.True(done)
+	require.Equal(uint64(6), idx.firstIndex)
+
+	acceptTestContainers(t, idx, 1)
+	done, err = idx.prune(pruneBatchSize)
+	require.NoError(err)
+	require.True(done)
+	require.Equal(uint64(7), idx.firstIndex)
+}
+
+func TestPruneMaxAge(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		start  = time.Unix(1_000, 0)
+		maxAge = time.Hour
+	)
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, RetentionPolicy{
+		MaxAge: maxAge,
+	})
+	require.NoError(err)
+
+	idx.clock.Set(start)
+	acceptTestContainers(t, idx, 2)
+	idx.clock.Set(start.Add(time.Minute))
+	acceptTestContainers(t, idx, 2)
+
+	// No containers have expired
+	idx.clock.Set(start.Add(maxAge))
+	done, err := idx.prune(pruneBatchSize)
+	require.NoError(err)
+	require.True(done)
+	require.Zero(idx.firstIndex)
+
+	// Only the first two containers have expired
+	idx.clock.Set(start.Add(maxAge + time.Second))
+	done, err = idx.prune(pruneBatchSize)
+	require.NoError(err)
+	require.True(done)
+	require.Equal(uint64(2), idx.firstIndex)
+
+	// All containers have expired
+	idx.clock.Set(start.Add(maxAge + time.Minute + time.Second))
+	done, err = idx.prune(pruneBatchSize)
+	require.NoError(err)
+	require.True(done)
+	require.Equal(uint64(4), idx.firstIndex)
+
+	_, err = idx.GetLastAccepted()
+	require.ErrorIs(err, errPruned)
+}
+
+func TestIndexIDsOnly(t *testing.T) {
+	require := require.New(t)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, RetentionPolicy{
+		IDsOnly: true,
+	})
+	require.NoError(err)
+
+	containerIDs := acceptTestContainers(t, idx, 1)
+
+	container, err := idx.GetContainerByIndex(0)
+	require.NoError(err)
+	require.Equal(containerIDs[0], container.ID)
+	require.Empty(container.Bytes)
+
+	s := &service{index: idx}
+	reply := FormattedContainer{}
+	require.NoError(s.GetContainerByIndex(nil, &GetContainerByIndexArgs{
+		Index:    0,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(containerIDs[0], reply.ID)
+
+	replyJSON, err := json.Marshal(reply)
+	require.NoError(err)
+	require.NotContains(string(replyJSON), `"bytes"`)
+}
+
+func TestIndexRetentionPolicyChange(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, RetentionPolicy{
+		IDsOnly: true,
+	})
+	require.NoError(err)
+	acceptTestContainers(t, idx, 1)
+	require.NoError(db.Commit())
+	require.NoError(idx.Close())
+
+	// After the policy changes, containers are formatted based on whether
+	// their bytes were stored.
+	idx, err = newIndex(versiondb.New(baseDB), logging.NoLog{}, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+	acceptTestContainers(t, idx, 1)
+
+	s := &service{index: idx}
+	reply := GetContainerRangeResponse{}
+	require.NoError(s.GetContainerRange(nil, &GetContainerRangeArgs{
+		StartIndex: 0,
+		NumToFetch: 2,
+		Encoding:   formatting.Hex,
+	}, &reply))
+	require.Len(reply.Containers, 2)
+	require.Empty(reply.Containers[0].Bytes)
+	require.NotEmpty(reply.Containers[1].Bytes)
+}
+
+func TestRetentionPolicyJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		json           string
+		expectedPolicy RetentionPolicy
+		expectedErr    error
+	}{
+		{
+			name:           "empty",
+			json:           `{}`,
+			expectedPolicy: RetentionPolicy{},
+		},
+		{
+			name: "all fields",
+			json: `{"maxContainers":100,"maxAge":"72h","idsOnly":true}`,
+			expectedPolicy: RetentionPolicy{
+				MaxContainers: 100,
+				MaxAge:        72 * time.Hour,
+				IDsOnly:       true,
+			},
+		},
+		{
+			name:        "negative maxAge",
+			json:        `{"maxAge":"-1h"}`,
+			expectedErr: errNegativeMaxAge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var policy RetentionPolicy
+			err := json.Unmarshal([]byte(test.json), &policy)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedPolicy, policy)
+
+			policyJSON, err := json.Marshal(policy)
+			require.NoError(err)
+
+			var parsedPolicy RetentionPolicy
+			require.NoError(json.Unmarshal(policyJSON, &parsedPolicy))
+			require.Equal(policy, parsedPolicy)
+		})
+	}
+}
+
+func TestRetentionConfig(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		defaultPolicy = RetentionPolicy{
+			MaxContainers: 1,
+		}
+		xPolicy = RetentionPolicy{
+			IDsOnly: true,
+		}
+		config = RetentionConfig{
+			IndexedChains: []string{"P", "X"},
+			Default:       defaultPolicy,
+			Chains: map[string]RetentionPolicy{
+				"X": xPolicy,
+			},
+		}
+	)
+	require.True(config.indexes("P"))
+	require.True(config.indexes("X"))
+	require.False(config.indexes("C"))
+	require.Equal(defaultPolicy, config.policy("P"))
+	require.Equal(xPolicy, config.policy("X"))
+
+	require.True((&RetentionConfig{}).indexes("C"))
+}
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -20,24 +21,28 @@ type service struct {
 
 type FormattedContainer struct {
 	ID        ids.ID              `json:"id"`
-	Bytes     string              `json:"bytes"`
+	Bytes     string              `json:"bytes,omitempty"`
 	Timestamp time.Time           `json:"timestamp"`
 	Encoding  formatting.Encoding `json:"encoding"`
 	Index     json.Uint64         `json:"index"`
 }
 
+// If the container was indexed without its bytes, the bytes are omitted.
 func newFormattedContainer(c Container, index uint64, enc formatting.Encoding) (FormattedContainer, error) {
 	fc := FormattedContainer{
-		Encoding: enc,
-		ID:       c.ID,
-		Index:    json.Uint64(index),
+		Encoding:  enc,
+		ID:        c.ID,
+		Index:     json.Uint64(index),
+		Timestamp: time.Unix(0, c.Timestamp),
+	}
+	if c.bytesOmitted {
+		return fc, nil
 	}
 	bytesStr, err := formatting.Encode(enc, c.Bytes)
 	if err != nil {
 		return fc, err
 	}
 	fc.Bytes = bytesStr
-	fc.Timestamp = time.Unix(0, c.Timestamp)
 	return fc, nil
 }
 
@@ -139,7 +144,7 @@ func (s *service) IsAccepted(_ *http.Request, args *IsAcceptedArgs, reply *IsAcc
 		reply.IsAccepted = true
 		return nil
 	}
-	if err == database.ErrNotFound {
+	if errors.Is(err, database.ErrNotFound) {
 		reply.IsAccepted = false
 		return nil
 	}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/subscription"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const subscribeEndpointSuffix = "/subscribe"
+
+var (
+	_ http.Handler = (*subscriptionHandler)(nil)
+
+	errStartIndexTooHigh = errors.New("start index is greater than the next accepted index")
+)
+
+// subscriptionHandler streams accepted containers to WebSocket clients.
+//
+// Containers are read from the index in order, starting at the requested
+// index. Because each subscriber reads from the database at its own pace,
+// subscribers that fall behind do not cause containers to be buffered in
+// memory. Subscribers that take longer than [subscription.WriteTimeout] to read
+// a container are disconnected and may resume from the last index they
+// received.
+type subscriptionHandler struct {
+	index    *index
+	log      logging.Logger
+	upgrader websocket.Upgrader
+}
+
+func newSubscriptionHandler(index *index, log logging.Logger) *subscriptionHandler {
+	return &subscriptionHandler{
+		index: index,
+		log:   log,
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and streams
+// containers to it.
+//
+// Supported query parameters:
+//   - startIndex: index of the first container to send. Defaults to the index
+//     of the next accepted container.
+//   - encoding: encoding of the container bytes. Defaults to hex.
+func (h *subscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	startIndex, encoding, err := h.parseArgs(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already responded to the client.
+		h.log.Debug("failed to upgrade subscription",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := subscription.KeepAlive(r.Context(), conn)
+	defer cancel()
+
+	for nextIndex := startIndex; ; nextIndex++ {
+		container, err := h.index.waitForContainer(ctx, nextIndex)
+		if err != nil {
+			h.closeConn(conn, err)
+			return
+		}
+
+		fc, err := newFormattedContainer(container, nextIndex, encoding)
+		if err != nil {
+			h.closeConn(conn, err)
+			return
+		}
+
+		if err := subscription.WriteJSON(conn, fc); err != nil {
+			h.log.Debug("dropping subscriber",
+				zap.Uint64("index", nextIndex),
+				zap.Error(err),
+			)
+			return
+		}
+	}
+}
+
+func (h *subscriptionHandler) parseArgs(r *http.Request) (uint64, formatting.Encoding, error) {
+	query := r.URL.Query()
+
+	firstIndex, nextAcceptedIndex := h.index.getIndexBounds()
+	startIndex := nextAcceptedIndex
+	if startIndexStr := query.Get("startIndex"); startIndexStr != "" {
+		var err error
+		startIndex, err = strconv.ParseUint(startIndexStr, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("couldn't parse startIndex: %w", err)
+		}
+		if startIndex > nextAcceptedIndex {
+			return 0, 0, fmt.Errorf("%w: %d > %d", errStartIndexTooHigh, startIndex, nextAcceptedIndex)
+		}
+		if startIndex < firstIndex {
+			return 0, 0, fmt.Errorf("%w: start index %d < first retained index %d", errPruned, startIndex, firstIndex)
+		}
+	}
+
+	encoding := formatting.Hex
+	if encodingStr := query.Get("encoding"); encodingStr != "" {
+		if err := encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			return 0, 0, fmt.Errorf("couldn't parse encoding: %w", err)
+		}
+	}
+	return startIndex, encoding, nil
+}
+
+// closeConn attempts to notify the subscriber of why the subscription ended.
+func (h *subscriptionHandler) closeConn(conn *websocket.Conn, err error) {
+	if errors.Is(err, context.Canceled) {
+		// The subscriber disconnected.
+		return
+	}
+
+	code := websocket.CloseInternalServerErr
+	switch {
+	case errors.Is(err, errIndexClosed):
+		code = websocket.CloseGoingAway
+	case errors.Is(err, errPruned):
+		// The subscriber fell behind the retention policy of the index.
+		code = websocket.ClosePolicyViolation
+	default:
+		h.log.Warn("closing subscription",
+			zap.Error(err),
+		)
+	}
+	subscription.Close(conn, code, err)
+}
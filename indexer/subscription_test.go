@@ -0,0 +1,132 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var errStopSubscription = errors.New("stop subscription")
+
+func newSubscriptionTest(t *testing.T) (*index, *Client) {
+	require := require.New(t)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, RetentionPolicy{})
+	require.NoError(err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/tx"+subscribeEndpointSuffix, newSubscriptionHandler(idx, logging.NoLog{}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		server.Close()
+		require.NoError(idx.Close())
+	})
+	return idx, NewClient(server.URL + "/tx")
+}
+
+type indexedContainer struct {
+	index uint64
+	id    ids.ID
+	bytes []byte
+}
+
+func TestSubscribe(t *testing.T) {
+	require := require.New(t)
+
+	idx, client := newSubscriptionTest(t)
+	ctx := snowtest.ConsensusContext(snowtest.Context(t, snowtest.XChainID))
+
+	expected := make([]indexedContainer, 4)
+	for i := range expected {
+		expected[i] = indexedContainer{
+			index: uint64(i),
+			id:    ids.GenerateTestID(),
+			bytes: utils.RandomBytes(32),
+		}
+	}
+
+	// Accept the first half of the containers before subscribing
+	for _, c := range expected[:2] {
+		require.NoError(idx.Accept(ctx, c.id, c.bytes))
+	}
+
+	var received []indexedContainer
+	onContainer := func(c Container, index uint64) error {
+		received = append(received, indexedContainer{
+			index: index,
+			id:    c.ID,
+			bytes: c.Bytes,
+		})
+		switch {
+		case index == 1:
+			// The subscriber has caught up, so the next containers must be
+			// pushed as they are accepted.
+			for _, c := range expected[2:] {
+				if err := idx.Accept(ctx, c.id, c.bytes); err != nil {
+					return err
+				}
+			}
+		case index == uint64(len(expected)-1):
+			return errStopSubscription
+		}
+		return nil
+	}
+	err := client.Subscribe(t.Context(), 0, onContainer)
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(expected, received)
+
+	// Resume from the middle of the index
+	received = nil
+	err = client.Subscribe(t.Context(), 2, func(c Container, index uint64) error {
+		received = append(received, indexedContainer{
+			index: index,
+			id:    c.ID,
+			bytes: c.Bytes,
+		})
+		if index == uint64(len(expected)-1) {
+			return errStopSubscription
+		}
+		return nil
+	})
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(expected[2:], received)
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	require := require.New(t)
+
+	_, client := newSubscriptionTest(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go cancel()
+
+	err := client.Subscribe(ctx, 0, func(Container, uint64) error {
+		return nil
+	})
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestSubscribeInvalidStartIndex(t *testing.T) {
+	require := require.New(t)
+
+	_, client := newSubscriptionTest(t)
+
+	err := client.Subscribe(t.Context(), 1, func(Container, uint64) error {
+		return nil
+	})
+	require.ErrorContains(err, "bad handshake")
+}
@@ -64,7 +64,8 @@ func main() {
 		fmt.Println(app.Header)
 	}
 
-	nodeApp, err := app.New(nodeConfig)
+	configReloader := config.NewReloader(os.Args[1:], v, nodeConfig)
+	nodeApp, err := app.New(nodeConfig, configReloader)
 	if err != nil {
 		fmt.Printf("couldn't start node: %s\n", err)
 		os.Exit(1)
@@ -62,7 +62,10 @@ type OutboundMessage struct {
 	// any outbound message throttling
 	BypassThrottling bool
 	Op               Op
-	Bytes            []byte
+	// ChainID is the chain the message is sent for. It is [ids.Empty] for
+	// messages that are not sent for a chain.
+	ChainID ids.ID
+	Bytes   []byte
 	// BytesSavedCompression stores the amount of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression int
@@ -228,9 +231,17 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		return nil, err
 	}
 
+	// Network messages are not sent for a chain, so a missing chainID is
+	// expected.
+	var chainID ids.ID
+	if msg, err := Unwrap(m); err == nil {
+		chainID, _ = GetChainID(msg)
+	}
+
 	return &OutboundMessage{
 		BypassThrottling:      bypassThrottling,
 		Op:                    op,
+		ChainID:               chainID,
 		Bytes:                 b,
 		BytesSavedCompression: saved,
 	}, nil
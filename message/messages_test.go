@@ -668,6 +668,9 @@ func TestMessage(t *testing.T) {
 			parsedMsg, err := mb.parseInbound(encodedMsg.Bytes, ids.EmptyNodeID, func() {})
 			require.NoError(err)
 			require.Equal(tv.op, parsedMsg.Op)
+
+			expectedChainID, _ := GetChainID(parsedMsg.Message)
+			require.Equal(expectedChainID, encodedMsg.ChainID)
 		})
 	}
 }
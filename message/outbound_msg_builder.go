@@ -37,6 +37,7 @@ type OutboundMsgBuilder interface {
 		knownPeersFilter []byte,
 		knownPeersSalt []byte,
 		requestAllSubnetIPs bool,
+		quicPort uint16,
 	) (*OutboundMessage, error)
 
 	GetPeerList(
@@ -249,6 +250,7 @@ func (b *outMsgBuilder) Handshake(
 	knownPeersFilter []byte,
 	knownPeersSalt []byte,
 	requestAllSubnetIPs bool,
+	quicPort uint16,
 ) (*OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
@@ -278,6 +280,7 @@ func (b *outMsgBuilder) Handshake(
 					},
 					IpBlsSig:   ipBLSSig,
 					AllSubnets: requestAllSubnetIPs,
+					QuicPort:   uint32(quicPort),
 				},
 			},
 		},
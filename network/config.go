@@ -6,11 +6,13 @@ package network
 import (
 	"crypto"
 	"crypto/tls"
+	"net"
 	"net/netip"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -114,6 +116,11 @@ type Config struct {
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
+	// QUICConn, if set, is used to accept QUIC connections and to dial peers
+	// that advertised a QUIC port in their handshake. The caller must close
+	// the connection after the network has been closed.
+	QUICConn net.PacketConn `json:"-"`
+
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
 	MyNodeID           ids.NodeID                    `json:"myNodeID"`
@@ -187,4 +194,8 @@ type Config struct {
 	// If true, connects to all validators regardless of primary network validator
 	// status or of configured tracked subnets.
 	ConnectToAllValidators bool `json:"connectToAllValidators"`
+
+	// Reputation scores peers and decides which NodeIDs and IPs are banned.
+	// Allowed NodeIDs are connected to whenever their IPs are learned.
+	Reputation reputation.Manager `json:"-"`
 }
@@ -51,7 +51,7 @@ func newIPTracker(
 		return nil, err
 	}
 	tracker := &ipTracker{
-		trackedSubnets: trackedSubnets,
+		trackedSubnets: set.Of(trackedSubnets.List()...),
 		log:            log,
 		numTrackedPeers: prometheus.NewGauge(prometheus.GaugeOpts{
 			Name: "tracked_peers",
@@ -206,7 +206,8 @@ func (s *gossipableSubnet) canDelete() bool {
 }
 
 type ipTracker struct {
-	// trackedSubnets does not include the primary network.
+	// trackedSubnets does not include the primary network. It is guarded by
+	// [lock].
 	trackedSubnets    set.Set[ids.ID]
 	log               logging.Logger
 	numTrackedPeers   prometheus.Gauge
@@ -267,6 +268,41 @@ func (i *ipTracker) ManuallyGossip(subnetID ids.ID, nodeID ids.NodeID) {
 	i.addGossipableID(nodeID, subnetID, true)
 }
 
+// TrackSubnet marks the validators of [subnetID] as being desirable to connect
+// to.
+func (i *ipTracker) TrackSubnet(subnetID ids.ID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.trackedSubnets.Contains(subnetID) {
+		return
+	}
+	i.trackedSubnets.Add(subnetID)
+	for _, node := range i.tracked {
+		if node.validatedSubnets.Contains(subnetID) {
+			node.trackedSubnets.Add(subnetID)
+		}
+	}
+}
+
+// UntrackSubnet stops marking the validators of [subnetID] as being desirable
+// to connect to.
+func (i *ipTracker) UntrackSubnet(subnetID ids.ID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.trackedSubnets.Contains(subnetID) {
+		return
+	}
+	i.trackedSubnets.Remove(subnetID)
+	if i.connectToAllValidators {
+		return
+	}
+	for _, node := range i.tracked {
+		node.trackedSubnets.Remove(subnetID)
+	}
+}
+
 // WantsConnection returns true if any of the following conditions are met:
 //  1. The node has been manually tracked.
 //  2. The node has been manually gossiped on a tracked subnet.
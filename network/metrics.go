@@ -15,10 +15,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
-type metrics struct {
-	// trackedSubnets does not include the primary network ID
-	trackedSubnets set.Set[ids.ID]
+const (
+	transportLabel = "transport"
+	transportTCP   = "tcp"
+	transportQUIC  = "quic"
+)
 
+type metrics struct {
 	numTracked                   prometheus.Gauge
 	numPeers                     prometheus.Gauge
 	numSubnetPeers               *prometheus.GaugeVec
@@ -28,6 +31,9 @@ type metrics struct {
 	connected                    prometheus.Counter
 	disconnected                 prometheus.Counter
 	acceptFailed                 prometheus.Counter
+	dialed                       *prometheus.CounterVec
+	quicDialFailed               prometheus.Counter
+	subnetReconnects             prometheus.Counter
 	inboundConnRateLimited       prometheus.Counter
 	inboundConnAllowed           prometheus.Counter
 	tlsConnRejected              prometheus.Counter
@@ -38,6 +44,11 @@ type metrics struct {
 	lock                         sync.RWMutex
 	peerConnectedStartTimes      map[ids.NodeID]float64
 	peerConnectedStartTimesSum   float64
+	// trackedSubnets does not include the primary network ID
+	trackedSubnets set.Set[ids.ID]
+	// peerSubnets contains the tracked subnets that each connected peer was
+	// counted in [numSubnetPeers] for.
+	peerSubnets map[ids.NodeID]set.Set[ids.ID]
 }
 
 func newMetrics(
@@ -45,7 +56,7 @@ func newMetrics(
 	trackedSubnets set.Set[ids.ID],
 ) (*metrics, error) {
 	m := &metrics{
-		trackedSubnets: trackedSubnets,
+		trackedSubnets: set.Of(trackedSubnets.List()...),
 		numPeers: prometheus.NewGauge(prometheus.GaugeOpts{
 			Name: "peers",
 			Help: "Number of network peers",
@@ -85,6 +96,21 @@ func newMetrics(
 			Name: "accept_failed",
 			Help: "Times this node's listener failed to accept an inbound connection",
 		}),
+		dialed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "times_dialed",
+				Help: "Times this node dialed a peer, by transport. Peers are dialed over TCP until they advertise a QUIC port",
+			},
+			[]string{transportLabel},
+		),
+		quicDialFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "quic_dial_failed",
+			Help: "Times this node failed to dial a peer over QUIC and fell back to TCP",
+		}),
+		subnetReconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "subnet_tracking_reconnects",
+			Help: "Times this node closed a connection to a peer to advertise a subnet it started tracking",
+		}),
 		inboundConnAllowed: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "inbound_conn_throttler_allowed",
 			Help: "Times this node allowed (attempted to upgrade) an inbound connection",
@@ -116,6 +142,7 @@ func newMetrics(
 			},
 		),
 		peerConnectedStartTimes: make(map[ids.NodeID]float64),
+		peerSubnets:             make(map[ids.NodeID]set.Set[ids.ID]),
 	}
 
 	err := errors.Join(
@@ -128,6 +155,9 @@ func newMetrics(
 		registerer.Register(m.connected),
 		registerer.Register(m.disconnected),
 		registerer.Register(m.acceptFailed),
+		registerer.Register(m.dialed),
+		registerer.Register(m.quicDialFailed),
+		registerer.Register(m.subnetReconnects),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.tlsConnRejected),
 		registerer.Register(m.numUselessPeerListBytes),
@@ -151,18 +181,22 @@ func (m *metrics) markConnected(peer *peer.Peer) {
 	m.numPeers.Inc()
 	m.connected.Inc()
 
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	peerID := peer.ID()
 	trackedSubnets := peer.TrackedSubnets()
+	var peerSubnets set.Set[ids.ID]
 	for subnetID := range m.trackedSubnets {
 		if trackedSubnets.Contains(subnetID) {
 			m.numSubnetPeers.WithLabelValues(subnetID.String()).Inc()
+			peerSubnets.Add(subnetID)
 		}
 	}
-
-	m.lock.Lock()
-	defer m.lock.Unlock()
+	m.peerSubnets[peerID] = peerSubnets
 
 	now := float64(time.Now().UnixNano())
-	m.peerConnectedStartTimes[peer.ID()] = now
+	m.peerConnectedStartTimes[peerID] = now
 	m.peerConnectedStartTimesSum += now
 }
 
@@ -170,23 +204,43 @@ func (m *metrics) markDisconnected(peer *peer.Peer) {
 	m.numPeers.Dec()
 	m.disconnected.Inc()
 
-	trackedSubnets := peer.TrackedSubnets()
-	for subnetID := range m.trackedSubnets {
-		if trackedSubnets.Contains(subnetID) {
-			m.numSubnetPeers.WithLabelValues(subnetID.String()).Dec()
-		}
-	}
-
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	peerID := peer.ID()
+	for subnetID := range m.peerSubnets[peerID] {
+		m.numSubnetPeers.WithLabelValues(subnetID.String()).Dec()
+	}
+	delete(m.peerSubnets, peerID)
+
 	start := m.peerConnectedStartTimes[peerID]
 	m.peerConnectedStartTimesSum -= start
 
 	delete(m.peerConnectedStartTimes, peerID)
 }
 
+// trackSubnet starts counting the peers that track [subnetID]. Peers that are
+// already connected are not counted.
+func (m *metrics) trackSubnet(subnetID ids.ID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.trackedSubnets.Add(subnetID)
+	m.numSubnetPeers.WithLabelValues(subnetID.String()).Set(0)
+}
+
+// untrackSubnet stops counting the peers that track [subnetID].
+func (m *metrics) untrackSubnet(subnetID ids.ID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.trackedSubnets.Remove(subnetID)
+	for _, peerSubnets := range m.peerSubnets {
+		peerSubnets.Remove(subnetID)
+	}
+	m.numSubnetPeers.DeleteLabelValues(subnetID.String())
+}
+
 func (m *metrics) updatePeerConnectionLifetimeMetrics() {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -25,11 +25,13 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/quic"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -47,6 +49,8 @@ const (
 	TimeSinceLastMsgReceivedKey      = "timeSinceLastMsgReceived"
 	TimeSinceLastMsgSentKey          = "timeSinceLastMsgSent"
 	SendFailRateKey                  = "sendFailRate"
+
+	reputationFlushFreq = time.Minute
 )
 
 var (
@@ -86,6 +90,26 @@ type Network interface {
 	// info about the peers in [nodeIDs] that have finished the handshake.
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
+	// SetHealthConfig replaces the thresholds used by the health check.
+	SetHealthConfig(healthConfig HealthConfig)
+
+	// SetMsgThrottlerConfig resizes the byte allocations of the inbound and
+	// outbound message throttlers.
+	SetMsgThrottlerConfig(inbound, outbound throttling.MsgByteThrottlerConfig)
+
+	// TrackSubnet starts tracking [subnetID], which must not be the primary
+	// network. The validators of [subnetID] are connected to.
+	//
+	// The subnets a node tracks are only advertised in the handshake, so the
+	// connections to peers that validate or track [subnetID] are closed and
+	// re-established for them to learn that this node tracks it. The number
+	// of closed connections is reported by the subnet_tracking_reconnects
+	// metric.
+	TrackSubnet(subnetID ids.ID)
+
+	// UntrackSubnet stops tracking [subnetID]. Existing connections are kept.
+	UntrackSubnet(subnetID ids.ID)
+
 	// NodeUptime returns given node's primary network UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime() (UptimeResult, error)
@@ -133,6 +157,18 @@ type network struct {
 	// Does TLS handshakes for outbound connections
 	clientUpgrader peer.Upgrader
 
+	// Accepts and dials QUIC connections. If QUIC is disabled, the transport
+	// and the following fields are nil.
+	quicTransport *quic.Transport
+	// Listens for and accepts new inbound QUIC connections
+	quicListener net.Listener
+	// Makes new outbound QUIC connections to peers that accept them
+	quicDialer dialer.Dialer
+	// Opens the streams of inbound QUIC connections
+	quicServerUpgrader peer.Upgrader
+	// Opens the streams of outbound QUIC connections
+	quicClientUpgrader peer.Upgrader
+
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
 	// Cancelled on close
@@ -142,6 +178,14 @@ type network struct {
 
 	sendFailRateCalculator safemath.Averager
 
+	// healthConfig is initialized from [config.HealthConfig] and may be
+	// replaced while the network is running.
+	healthConfigLock sync.RWMutex
+	healthConfig     HealthConfig
+
+	// trackedSubnetsLock serializes changes to the tracked subnets.
+	trackedSubnetsLock sync.Mutex
+
 	// Tracks which peers know about which peers
 	ipTracker *ipTracker
 	peersLock sync.RWMutex
@@ -152,7 +196,11 @@ type network struct {
 	trackedIPs      map[ids.NodeID]*trackedIP
 	connectingPeers *peer.Set
 	connectedPeers  *peer.Set
-	closing         bool
+	// quicPorts contains the UDP ports that peers advertised to accept QUIC
+	// connections on. An entry is kept after disconnecting from the peer so
+	// that the peer is reconnected to over QUIC.
+	quicPorts map[ids.NodeID]uint16
+	closing   bool
 
 	startupTime time.Time
 
@@ -258,6 +306,32 @@ func NewNetwork(
 	for nodeID := range genesis.GetValidators(config.NetworkID) {
 		ipTracker.ManuallyTrack(nodeID)
 	}
+	// Track all allowed nodes to connect to them whenever their IPs are
+	// learned.
+	for _, node := range config.Reputation.Nodes(nil) {
+		if node.Allowed {
+			ipTracker.ManuallyTrack(node.NodeID)
+		}
+	}
+
+	var (
+		quicTransport *quic.Transport
+		quicListener  net.Listener
+		quicPort      uint16
+	)
+	if config.QUICConn != nil {
+		quicAddr, err := ips.ParseAddrPort(config.QUICConn.LocalAddr().String())
+		if err != nil {
+			return nil, fmt.Errorf("parsing QUIC address failed with: %w", err)
+		}
+
+		quicTransport = quic.NewTransport(config.QUICConn, config.TLSConfig)
+		quicListener, err = quicTransport.Listen()
+		if err != nil {
+			return nil, fmt.Errorf("initializing QUIC listener failed with: %w", err)
+		}
+		quicPort = quicAddr.Port()
+	}
 
 	peerConfig := &peer.Config{
 		ReadBufferSize:         config.PeerReadBufferSize,
@@ -270,7 +344,7 @@ func NewNetwork(
 		Router:                 router,
 		VersionCompatibility:   version.GetCompatibility(minCompatibleTime),
 		MyNodeID:               config.MyNodeID,
-		MySubnets:              config.TrackedSubnets,
+		MySubnets:              utils.NewAtomic(set.Of(config.TrackedSubnets.List()...)),
 		Beacons:                config.Beacons,
 		Validators:             config.Validators,
 		NetworkID:              config.NetworkID,
@@ -283,12 +357,14 @@ func NewNetwork(
 		UptimeCalculator:       config.UptimeCalculator,
 		IPSigner:               peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
 		ConnectToAllValidators: config.ConnectToAllValidators,
+		MyQUICPort:             quicPort,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
 		startupTime:          time.Now(),
 		config:               config,
+		healthConfig:         config.HealthConfig,
 		peerConfig:           peerConfig,
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
@@ -299,6 +375,11 @@ func NewNetwork(
 		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
 		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
 
+		quicTransport:      quicTransport,
+		quicListener:       quicListener,
+		quicServerUpgrader: quic.NewServerUpgrader(metrics.tlsConnRejected),
+		quicClientUpgrader: quic.NewClientUpgrader(metrics.tlsConnRejected),
+
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
 
@@ -309,12 +390,17 @@ func NewNetwork(
 		)),
 
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
+		quicPorts:       make(map[ids.NodeID]uint16),
 		ipTracker:       ipTracker,
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
 		router:          router,
 	}
+	if quicTransport != nil {
+		n.quicDialer = quicTransport.NewDialer(config.DialerConfig, log)
+	}
 	n.peerConfig.Network = n
+	config.Reputation.OnChange(n.onReputationChange)
 	return n, nil
 }
 
@@ -356,6 +442,79 @@ func (n *network) Send(
 	return sentTo
 }
 
+func (n *network) SetHealthConfig(healthConfig HealthConfig) {
+	n.healthConfigLock.Lock()
+	defer n.healthConfigLock.Unlock()
+
+	n.healthConfig = healthConfig
+}
+
+func (n *network) SetMsgThrottlerConfig(inbound, outbound throttling.MsgByteThrottlerConfig) {
+	n.peerConfig.InboundMsgThrottler.SetByteThrottlerConfig(inbound)
+	n.outboundMsgThrottler.SetConfig(outbound)
+}
+
+func (n *network) TrackSubnet(subnetID ids.ID) {
+	n.trackedSubnetsLock.Lock()
+	defer n.trackedSubnetsLock.Unlock()
+
+	mySubnets := n.peerConfig.MySubnets.Get()
+	if mySubnets.Contains(subnetID) {
+		return
+	}
+
+	newSubnets := set.Of(mySubnets.List()...)
+	newSubnets.Add(subnetID)
+	n.peerConfig.MySubnets.Set(newSubnets)
+	n.ipTracker.TrackSubnet(subnetID)
+	n.metrics.trackSubnet(subnetID)
+	n.router.Connected(n.config.MyNodeID, version.Current, subnetID)
+
+	// Peers only send messages about the subnets that were advertised in the
+	// handshake, so the peers that may be running the subnet must be
+	// reconnected to. Validators are included even if they didn't advertise
+	// the subnet, as they may have started tracking it after connecting.
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	var numReconnecting int
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		peer, _ := n.connectedPeers.GetByIndex(i)
+		trackedSubnets := peer.TrackedSubnets()
+		_, isValidator := n.config.Validators.GetValidator(subnetID, peer.ID())
+		if isValidator || trackedSubnets.Contains(subnetID) {
+			peer.StartClose()
+			numReconnecting++
+		}
+	}
+	n.metrics.subnetReconnects.Add(float64(numReconnecting))
+
+	n.peerConfig.Log.Info("started tracking subnet",
+		zap.Stringer("subnetID", subnetID),
+		zap.Int("numReconnecting", numReconnecting),
+	)
+}
+
+func (n *network) UntrackSubnet(subnetID ids.ID) {
+	n.trackedSubnetsLock.Lock()
+	defer n.trackedSubnetsLock.Unlock()
+
+	mySubnets := n.peerConfig.MySubnets.Get()
+	if !mySubnets.Contains(subnetID) {
+		return
+	}
+
+	newSubnets := set.Of(mySubnets.List()...)
+	newSubnets.Remove(subnetID)
+	n.peerConfig.MySubnets.Set(newSubnets)
+	n.ipTracker.UntrackSubnet(subnetID)
+	n.metrics.untrackSubnet(subnetID)
+
+	n.peerConfig.Log.Info("stopped tracking subnet",
+		zap.Stringer("subnetID", subnetID),
+	)
+}
+
 // HealthCheck returns information about several network layer health checks.
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
@@ -364,10 +523,14 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	connectedTo := n.connectedPeers.Len()
 	n.peersLock.RUnlock()
 
+	n.healthConfigLock.RLock()
+	healthConfig := n.healthConfig
+	n.healthConfigLock.RUnlock()
+
 	sendFailRate := n.sendFailRateCalculator.Read()
 
 	// Make sure we're connected to at least the minimum number of peers
-	isConnected := connectedTo >= int(n.config.HealthConfig.MinConnectedPeers)
+	isConnected := connectedTo >= int(healthConfig.MinConnectedPeers)
 	healthy := isConnected
 	details := map[string]interface{}{
 		ConnectedPeersKey: connectedTo,
@@ -381,7 +544,7 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	timeSinceLastMsgReceived := time.Duration(0)
 	if msgReceived {
 		timeSinceLastMsgReceived = now.Sub(lastMsgReceivedAt)
-		wasMsgReceivedRecently = timeSinceLastMsgReceived <= n.config.HealthConfig.MaxTimeSinceMsgReceived
+		wasMsgReceivedRecently = timeSinceLastMsgReceived <= healthConfig.MaxTimeSinceMsgReceived
 		details[TimeSinceLastMsgReceivedKey] = timeSinceLastMsgReceived.String()
 		n.metrics.timeSinceLastMsgReceived.Set(float64(timeSinceLastMsgReceived))
 	}
@@ -393,21 +556,21 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	timeSinceLastMsgSent := time.Duration(0)
 	if msgSent {
 		timeSinceLastMsgSent = now.Sub(lastMsgSentAt)
-		wasMsgSentRecently = timeSinceLastMsgSent <= n.config.HealthConfig.MaxTimeSinceMsgSent
+		wasMsgSentRecently = timeSinceLastMsgSent <= healthConfig.MaxTimeSinceMsgSent
 		details[TimeSinceLastMsgSentKey] = timeSinceLastMsgSent.String()
 		n.metrics.timeSinceLastMsgSent.Set(float64(timeSinceLastMsgSent))
 	}
 	healthy = healthy && wasMsgSentRecently
 
 	// Make sure the message send failed rate isn't too high
-	isMsgFailRate := sendFailRate <= n.config.HealthConfig.MaxSendFailRate
+	isMsgFailRate := sendFailRate <= healthConfig.MaxSendFailRate
 	healthy = healthy && isMsgFailRate
 	details[SendFailRateKey] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
 
 	reachablePrimaryNetworkValidator := true
 	// If we're a primary network validator, make sure we have ingress connections
-	if time.Since(n.startupTime) > n.config.NoIngressValidatorConnectionGracePeriod {
+	if time.Since(n.startupTime) > healthConfig.NoIngressValidatorConnectionGracePeriod {
 		connectedPrimaryValidatorInfo, isConnectedPrimaryValidatorErr := checkNoIngressConnections(n.config.MyNodeID, n, n.config.Validators)
 		reachablePrimaryNetworkValidator = isConnectedPrimaryValidatorErr == nil
 		details[PrimaryNetworkValidatorHealthKey] = connectedPrimaryValidatorInfo
@@ -418,27 +581,27 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	n.metrics.updatePeerConnectionLifetimeMetrics()
 
 	// Network layer is healthy
-	if healthy || !n.config.HealthConfig.Enabled {
+	if healthy || !healthConfig.Enabled {
 		return details, nil
 	}
 
 	var errorReasons []string
 	if !isConnected {
-		errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", n.config.HealthConfig.MinConnectedPeers, connectedTo))
+		errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", healthConfig.MinConnectedPeers, connectedTo))
 	}
 	if !msgReceived {
 		errorReasons = append(errorReasons, "no messages received from network")
 	} else if !wasMsgReceivedRecently {
-		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, n.config.HealthConfig.MaxTimeSinceMsgReceived))
+		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, healthConfig.MaxTimeSinceMsgReceived))
 	}
 	if !msgSent {
 		errorReasons = append(errorReasons, "no messages sent to network")
 	} else if !wasMsgSentRecently {
-		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, n.config.HealthConfig.MaxTimeSinceMsgSent))
+		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, healthConfig.MaxTimeSinceMsgSent))
 	}
 
 	if !isMsgFailRate {
-		errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, n.config.HealthConfig.MaxSendFailRate))
+		errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, healthConfig.MaxSendFailRate))
 	}
 
 	if !reachablePrimaryNetworkValidator {
@@ -472,6 +635,11 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	}
 	n.connectingPeers.Remove(nodeID)
 	n.connectedPeers.Add(peer)
+	if quicPort := peer.QUICPort(); quicPort != 0 {
+		n.quicPorts[nodeID] = quicPort
+	} else {
+		delete(n.quicPorts, nodeID)
+	}
 	n.peersLock.Unlock()
 
 	peerIP := peer.IP()
@@ -488,7 +656,7 @@ func (n *network) Connected(nodeID ids.NodeID) {
 
 	peerVersion := peer.Version()
 	n.router.Connected(nodeID, peerVersion, constants.PrimaryNetworkID)
-	for subnetID := range n.peerConfig.MySubnets {
+	for subnetID := range n.peerConfig.MySubnets.Get() {
 		if trackedSubnets.Contains(subnetID) {
 			n.router.Connected(nodeID, peerVersion, subnetID)
 		}
@@ -496,10 +664,13 @@ func (n *network) Connected(nodeID ids.NodeID) {
 }
 
 // AllowConnection returns true if this node should have a connection to the
-// provided nodeID. If the node is attempting to connect to the minimum number
-// of peers, then it should only connect if this node is a validator, or the
-// peer is a validator/beacon.
+// provided nodeID. Banned nodes are never connected to. If the node is
+// attempting to connect to the minimum number of peers, then it should only
+// connect if this node is a validator, or the peer is a validator/beacon.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
+	if !n.config.Reputation.IsNodeAllowed(nodeID) {
+		return false
+	}
 	if !n.config.RequireValidatorToConnect {
 		return true
 	}
@@ -537,6 +708,10 @@ func (n *network) Disconnected(nodeID ids.NodeID) {
 	}
 }
 
+func (n *network) InvalidMessage(nodeID ids.NodeID) {
+	n.config.Reputation.RegisterInvalidMessage(nodeID)
+}
+
 func (n *network) KnownPeers() ([]byte, []byte) {
 	return n.ipTracker.Bloom()
 }
@@ -605,8 +780,30 @@ func (n *network) Peers(
 func (n *network) Dispatch() error {
 	go n.runTimers() // Periodically perform operations
 	go n.inboundConnUpgradeThrottler.Dispatch()
+	if n.quicListener != nil {
+		go n.accept(n.quicListener, n.quicServerUpgrader)
+	}
+	n.accept(n.listener, n.serverUpgrader)
+	n.inboundConnUpgradeThrottler.Stop()
+	n.StartClose()
+
+	n.peersLock.RLock()
+	connecting := n.connectingPeers.Sample(n.connectingPeers.Len(), peer.NoPrecondition)
+	connected := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	errs := wrappers.Errs{}
+	for _, peer := range append(connecting, connected...) {
+		errs.Add(peer.AwaitClosed(context.TODO()))
+	}
+	return errs.Err
+}
+
+// accept upgrades the connections accepted by [listener] with [upgrader] until
+// the network is closed.
+func (n *network) accept(listener net.Listener, upgrader peer.Upgrader) {
 	for n.onCloseCtx.Err() == nil { // Continuously accept new connections
-		conn, err := n.listener.Accept() // Returns error when n.Close() is called
+		conn, err := listener.Accept() // Returns error when n.Close() is called
 		if err != nil {
 			n.peerConfig.Log.Debug("error during server accept", zap.Error(err))
 			// Sleep for a small amount of time to try to wait for the
@@ -634,6 +831,15 @@ func (n *network) Dispatch() error {
 				return
 			}
 
+			if !n.config.Reputation.IsIPAllowed(ip.Addr()) {
+				n.peerConfig.Log.Debug("failed to upgrade connection",
+					zap.String("reason", "banned IP"),
+					zap.Stringer("peerIP", ip),
+				)
+				_ = conn.Close()
+				return
+			}
+
 			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 				n.peerConfig.Log.Debug("failed to upgrade connection",
 					zap.String("reason", "rate-limiting"),
@@ -650,7 +856,7 @@ func (n *network) Dispatch() error {
 				zap.Stringer("peerIP", ip),
 			)
 
-			if err := n.upgrade(conn, n.serverUpgrader, true); err != nil {
+			if err := n.upgrade(conn, upgrader, true); err != nil {
 				n.peerConfig.Log.Verbo("failed to upgrade connection",
 					zap.String("direction", "inbound"),
 					zap.Error(err),
@@ -658,19 +864,6 @@ func (n *network) Dispatch() error {
 			}
 		}()
 	}
-	n.inboundConnUpgradeThrottler.Stop()
-	n.StartClose()
-
-	n.peersLock.RLock()
-	connecting := n.connectingPeers.Sample(n.connectingPeers.Len(), peer.NoPrecondition)
-	connected := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
-	n.peersLock.RUnlock()
-
-	errs := wrappers.Errs{}
-	for _, peer := range append(connecting, connected...) {
-		errs.Add(peer.AwaitClosed(context.TODO()))
-	}
-	return errs.Err
 }
 
 func (n *network) ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort) {
@@ -873,6 +1066,8 @@ func (n *network) disconnectedFromConnected(peer *peer.Peer, nodeID ids.NodeID)
 		tracked := newTrackedIP(ip.AddrPort)
 		n.trackedIPs[nodeID] = tracked
 		n.dial(nodeID, tracked)
+	} else {
+		delete(n.quicPorts, nodeID)
 	}
 
 	n.metrics.markDisconnected(peer)
@@ -932,11 +1127,13 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 					ip.stopTracking()
 					delete(n.trackedIPs, nodeID)
 				}
+				delete(n.quicPorts, nodeID)
 				n.peersLock.Unlock()
 				return
 			}
 			_, connecting := n.connectingPeers.GetByID(nodeID)
 			_, connected := n.connectedPeers.GetByID(nodeID)
+			quicPort := n.quicPorts[nodeID]
 			n.peersLock.Unlock()
 
 			// While it may not be strictly needed to stop attempting to connect
@@ -961,6 +1158,19 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				n.config.MaxReconnectDelay,
 			)
 
+			// Banned peers are retried in case they are unbanned. Like
+			// private IPs below, this is checked inside of the looping
+			// goroutine.
+			if !n.config.Reputation.IsNodeAllowed(nodeID) || !n.config.Reputation.IsIPAllowed(ip.ip.Addr()) {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "peer is banned"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			// If the network is configured to disallow private IPs and the
 			// provided IP is private, we skip all attempts to initiate a
 			// connection.
@@ -980,7 +1190,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
-			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
+			conn, upgrader, err := n.dialIP(ip.ip, quicPort)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
@@ -997,7 +1207,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				zap.Stringer("peerIP", ip.ip),
 			)
 
-			err = n.upgrade(conn, n.clientUpgrader, false)
+			err = n.upgrade(conn, upgrader, false)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
@@ -1012,6 +1222,33 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 	}()
 }
 
+// dialIP dials [quicPort] at the address of [ip] over QUIC if the peer accepts
+// QUIC connections and the dial succeeds. Otherwise, [ip] is dialed over TCP.
+// The returned upgrader must be used to upgrade the returned connection.
+//
+// The QUIC port of a peer is only learned from its handshake, so the first
+// connection to a peer is always made over TCP.
+func (n *network) dialIP(ip netip.AddrPort, quicPort uint16) (net.Conn, peer.Upgrader, error) {
+	if n.quicDialer != nil && quicPort != 0 {
+		quicIP := netip.AddrPortFrom(ip.Addr(), quicPort)
+		n.metrics.dialed.WithLabelValues(transportQUIC).Inc()
+		conn, err := n.quicDialer.Dial(n.onCloseCtx, quicIP)
+		if err == nil {
+			return conn, n.quicClientUpgrader, nil
+		}
+
+		n.metrics.quicDialFailed.Inc()
+		n.peerConfig.Log.Verbo("failed to dial QUIC connection, falling back to TCP",
+			zap.Stringer("peerIP", quicIP),
+			zap.Error(err),
+		)
+	}
+
+	n.metrics.dialed.WithLabelValues(transportTCP).Inc()
+	conn, err := n.dialer.Dial(n.onCloseCtx, ip)
+	return conn, n.clientUpgrader, err
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
 // outbound connection, with the provided [upgrader].
 //
@@ -1146,6 +1383,13 @@ func (n *network) StartClose() {
 				zap.Error(err),
 			)
 		}
+		if n.quicTransport != nil {
+			if err := n.quicTransport.Close(); err != nil {
+				n.peerConfig.Log.Debug("closing the QUIC transport",
+					zap.Error(err),
+				)
+			}
+		}
 
 		n.peersLock.Lock()
 		defer n.peersLock.Unlock()
@@ -1225,13 +1469,46 @@ func (n *network) NodeUptime() (UptimeResult, error) {
 	}, nil
 }
 
+// onReputationChange is called whenever a node or an IP range is banned or
+// allowed. It connects to newly allowed nodes and disconnects from newly banned
+// peers.
+func (n *network) onReputationChange() {
+	for _, node := range n.config.Reputation.Nodes(nil) {
+		if node.Allowed {
+			n.ipTracker.ManuallyTrack(node.NodeID)
+		}
+	}
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	for _, peers := range []*peer.Set{n.connectingPeers, n.connectedPeers} {
+		for i := 0; i < peers.Len(); i++ {
+			peer, _ := peers.GetByIndex(i)
+			nodeID := peer.ID()
+			ip := peer.RemoteIP()
+			if n.config.Reputation.IsNodeAllowed(nodeID) && n.config.Reputation.IsIPAllowed(ip.Addr()) {
+				continue
+			}
+
+			n.peerConfig.Log.Info("disconnecting from banned peer",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("peerIP", ip),
+			)
+			peer.StartClose()
+		}
+	}
+}
+
 func (n *network) runTimers() {
 	pullGossipPeerlists := time.NewTicker(n.config.PeerListPullGossipFreq)
 	resetPeerListBloom := time.NewTicker(n.config.PeerListBloomResetFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	flushReputation := time.NewTicker(reputationFlushFreq)
 	defer func() {
 		resetPeerListBloom.Stop()
 		updateUptimes.Stop()
+		flushReputation.Stop()
 	}()
 
 	for {
@@ -1257,6 +1534,12 @@ func (n *network) runTimers() {
 			}
 			n.metrics.nodeUptimeWeightedAverage.Set(primaryUptime.WeightedAveragePercentage)
 			n.metrics.nodeUptimeRewardingStake.Set(primaryUptime.RewardingStakePercentage)
+		case <-flushReputation.C:
+			if err := n.config.Reputation.Flush(); err != nil {
+				n.peerConfig.Log.Error("failed to persist peer reputations",
+					zap.Error(err),
+				)
+			}
 		}
 	}
 }
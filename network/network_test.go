@@ -6,12 +6,14 @@ package network
 import (
 	"context"
 	"crypto"
+	"net"
 	"net/netip"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
@@ -180,12 +182,16 @@ func newTestNetwork(t *testing.T, count int, baseConfig Config) (*testDialer, []
 		blsKey, err := localsigner.New()
 		require.NoError(t, err)
 
+		reputationManager, err := newTestReputation()
+		require.NoError(t, err)
+
 		config := baseConfig
 		config.TLSConfig = peer.TLSConfig(*tlsCert, nil)
 		config.MyNodeID = nodeID
 		config.MyIPPort = utils.NewAtomic(ip)
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
 		config.BLSKey = blsKey
+		config.Reputation = reputationManager
 
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
@@ -406,8 +412,10 @@ func TestIngressConnCount(t *testing.T) {
 		})
 
 	for _, net := range networks {
-		net.config.NoIngressValidatorConnectionGracePeriod = 0
-		net.config.HealthConfig.Enabled = true
+		healthConfig := net.config.HealthConfig
+		healthConfig.NoIngressValidatorConnectionGracePeriod = 0
+		healthConfig.Enabled = true
+		net.SetHealthConfig(healthConfig)
 	}
 
 	require.Eventually(func() bool {
@@ -745,6 +753,192 @@ func testDialDeletesNonValidators(t *testing.T, connectToAllValidators bool) {
 	require.NoError(eg.Wait())
 }
 
+func TestReconnectOverQUIC(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2, defaultConfig)
+
+	vdrs := validators.NewManager()
+	for _, nodeID := range nodeIDs {
+		require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.GenerateTestID(), 1))
+	}
+
+	var (
+		networks  = make([]*network, len(configs))
+		quicPorts = make([]uint16, len(configs))
+	)
+	for i, config := range configs {
+		// QUIC connections are dialed at the IP of the peer, so the peers must
+		// be reachable over the loopback interface.
+		ip := netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), uint16(i+1))
+		dialer.AddListener(ip, listeners[i])
+		config.MyIPPort = utils.NewAtomic(ip)
+
+		udpConn, err := EnableTestQUIC(config, ip.Addr())
+		require.NoError(err)
+		t.Cleanup(func() {
+			require.NoError(udpConn.Close())
+		})
+		quicPorts[i] = udpConn.LocalAddr().(*net.UDPAddr).AddrPort().Port()
+
+		beacons := validators.NewManager()
+		require.NoError(beacons.AddStaker(constants.PrimaryNetworkID, nodeIDs[0], nil, ids.GenerateTestID(), 1))
+
+		config.Beacons = beacons
+		config.Validators = vdrs
+
+		n, err := NewNetwork(
+			config,
+			upgrade.InitiallyActiveTime,
+			newMessageCreator(t),
+			prometheus.NewRegistry(),
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			&testHandler{},
+		)
+		require.NoError(err)
+		networks[i] = n.(*network)
+	}
+
+	eg := &errgroup.Group{}
+	for _, n := range networks {
+		eg.Go(n.Dispatch)
+	}
+
+	// The first connection is made over TCP, as the QUIC port of the peer is
+	// not known yet.
+	networks[1].ManuallyTrack(nodeIDs[0], configs[0].MyIPPort.Get())
+	require.Eventually(func() bool {
+		infos := networks[1].PeerInfo([]ids.NodeID{nodeIDs[0]})
+		return len(infos) == 1 && infos[0].QUICPort == quicPorts[0]
+	}, 10*time.Second, time.Millisecond)
+
+	networks[1].peersLock.RLock()
+	tcpPeer, ok := networks[1].connectedPeers.GetByID(nodeIDs[0])
+	networks[1].peersLock.RUnlock()
+	require.True(ok)
+
+	// After disconnecting, the peers reconnect over QUIC.
+	tcpPeer.StartClose()
+	require.Eventually(func() bool {
+		infos := networks[1].PeerInfo([]ids.NodeID{nodeIDs[0]})
+		return len(infos) == 1 && infos[0].IP == netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), quicPorts[0])
+	}, 10*time.Second, time.Millisecond)
+
+	// Both peers may redial each other concurrently, in which case one of the
+	// duplicate connections is dropped and redialed.
+	dialed := networks[1].metrics.dialed
+	require.Equal(float64(1), testutil.ToFloat64(dialed.WithLabelValues(transportTCP)))
+	require.GreaterOrEqual(testutil.ToFloat64(dialed.WithLabelValues(transportQUIC)), float64(1))
+	require.Zero(testutil.ToFloat64(networks[1].metrics.quicDialFailed))
+
+	for _, n := range networks {
+		n.StartClose()
+	}
+	require.NoError(eg.Wait())
+}
+
+func TestTrackSubnet(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2, defaultConfig)
+
+	vdrs := validators.NewManager()
+	for _, nodeID := range nodeIDs {
+		require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.GenerateTestID(), 1))
+		require.NoError(vdrs.AddStaker(subnetID, nodeID, nil, ids.GenerateTestID(), 1))
+	}
+
+	var (
+		networks = make([]*network, len(configs))
+
+		lock sync.Mutex
+		// subnetPeers contains the peers that each network is connected to on
+		// the subnet.
+		subnetPeers = make([]set.Set[ids.NodeID], len(configs))
+	)
+	isConnected := func(i int, nodeID ids.NodeID) bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return subnetPeers[i].Contains(nodeID)
+	}
+	for i, config := range configs {
+		beacons := validators.NewManager()
+		require.NoError(beacons.AddStaker(constants.PrimaryNetworkID, nodeIDs[0], nil, ids.GenerateTestID(), 1))
+
+		config.Beacons = beacons
+		config.Validators = vdrs
+
+		n, err := NewNetwork(
+			config,
+			upgrade.InitiallyActiveTime,
+			newMessageCreator(t),
+			prometheus.NewRegistry(),
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			&testHandler{
+				ConnectedF: func(nodeID ids.NodeID, _ *version.Application, connectedSubnetID ids.ID) {
+					if connectedSubnetID != subnetID {
+						return
+					}
+
+					lock.Lock()
+					defer lock.Unlock()
+
+					subnetPeers[i].Add(nodeID)
+				},
+				DisconnectedF: func(nodeID ids.NodeID) {
+					lock.Lock()
+					defer lock.Unlock()
+
+					subnetPeers[i].Remove(nodeID)
+				},
+			},
+		)
+		require.NoError(err)
+		networks[i] = n.(*network)
+	}
+
+	eg := &errgroup.Group{}
+	for _, n := range networks {
+		eg.Go(n.Dispatch)
+	}
+
+	networks[1].ManuallyTrack(nodeIDs[0], configs[0].MyIPPort.Get())
+	require.Eventually(func() bool {
+		return len(networks[0].PeerInfo([]ids.NodeID{nodeIDs[1]})) == 1 &&
+			len(networks[1].PeerInfo([]ids.NodeID{nodeIDs[0]})) == 1
+	}, 10*time.Second, time.Millisecond)
+	require.False(isConnected(0, nodeIDs[1]))
+	require.False(isConnected(1, nodeIDs[0]))
+
+	// Tracking the subnet marks this node as connected to the subnet. The peer
+	// validates the subnet, so the connection to it is re-established.
+	networks[0].TrackSubnet(subnetID)
+	require.True(isConnected(0, nodeIDs[0]))
+	require.Contains(networks[0].peerConfig.MySubnets.Get(), subnetID)
+	require.Equal(float64(1), testutil.ToFloat64(networks[0].metrics.subnetReconnects))
+
+	// Once both nodes track the subnet, they reconnect to each other on it.
+	networks[1].TrackSubnet(subnetID)
+	require.Eventually(func() bool {
+		return isConnected(0, nodeIDs[1]) && isConnected(1, nodeIDs[0])
+	}, 10*time.Second, time.Millisecond)
+
+	networks[1].UntrackSubnet(subnetID)
+	require.NotContains(networks[1].peerConfig.MySubnets.Get(), subnetID)
+	require.Contains(networks[0].peerConfig.MySubnets.Get(), subnetID)
+
+	for _, n := range networks {
+		n.StartClose()
+	}
+	require.NoError(eg.Wait())
+}
+
 func TestDialDeletesNonValidators(t *testing.T) {
 	t.Run("connectToAllValidators=false", func(t *testing.T) {
 		testDialDeletesNonValidators(t, false)
@@ -882,6 +1076,38 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	require.NoError(eg.Wait())
 }
 
+func TestBanPeer(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, eg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+	isConnected := func() bool {
+		return len(networks[0].PeerInfo([]ids.NodeID{nodeIDs[1]})) > 0 &&
+			len(networks[1].PeerInfo([]ids.NodeID{nodeIDs[0]})) > 0
+	}
+	require.True(isConnected())
+
+	reputationManager := networks[0].config.Reputation
+	require.NoError(reputationManager.BanNode(nodeIDs[1], 0))
+	require.Eventually(
+		func() bool {
+			return len(networks[0].PeerInfo(nil)) == 0 && len(networks[1].PeerInfo(nil)) == 0
+		},
+		10*time.Second,
+		time.Millisecond,
+	)
+	require.False(networks[0].AllowConnection(nodeIDs[1]))
+
+	// The banned node keeps attempting to reconnect.
+	require.NoError(reputationManager.UnbanNode(nodeIDs[1]))
+	require.True(networks[0].AllowConnection(nodeIDs[1]))
+	require.Eventually(isConnected, 30*time.Second, 10*time.Millisecond)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	require.NoError(eg.Wait())
+}
+
 func TestGetAllPeers(t *testing.T) {
 	require := require.New(t)
 
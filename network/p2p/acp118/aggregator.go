@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/proto/pb/sdk"
@@ -38,9 +39,21 @@ type result struct {
 
 // NewSignatureAggregator returns an instance of SignatureAggregator
 func NewSignatureAggregator(log logging.Logger, client *p2p.Client) *SignatureAggregator {
+	return NewCachedSignatureAggregator(log, client, emptySignatureStore{})
+}
+
+// NewCachedSignatureAggregator returns an instance of SignatureAggregator that
+// stores the signatures it collects in [store] and does not request signatures
+// that are already in [store].
+func NewCachedSignatureAggregator(
+	log logging.Logger,
+	client *p2p.Client,
+	store SignatureStore,
+) *SignatureAggregator {
 	return &SignatureAggregator{
 		log:    log,
 		client: client,
+		store:  store,
 	}
 }
 
@@ -48,6 +61,7 @@ func NewSignatureAggregator(log logging.Logger, client *p2p.Client) *SignatureAg
 type SignatureAggregator struct {
 	log    logging.Logger
 	client *p2p.Client
+	store  SignatureStore
 }
 
 // AggregateSignatures blocks until quorumNum/quorumDen signatures from
@@ -87,9 +101,13 @@ func (s *SignatureAggregator) AggregateSignatures(
 
 	signerBitSet := set.BitsFromBytes(bitSetSignature.Signers)
 
-	nonSigners := make([]ids.NodeID, 0, len(validators))
-	aggregatedStakeWeight := new(big.Int)
-	totalStakeWeight := new(big.Int)
+	var (
+		messageID             = message.UnsignedMessage.ID()
+		nonSigners            = make([]ids.NodeID, 0, len(validators))
+		storedSignatures      = make([]*bls.Signature, 0, len(validators))
+		aggregatedStakeWeight = new(big.Int)
+		totalStakeWeight      = new(big.Int)
+	)
 	for i, validator := range validators {
 		totalStakeWeight.Add(totalStakeWeight, new(big.Int).SetUint64(validator.Weight))
 
@@ -100,6 +118,18 @@ func (s *SignatureAggregator) AggregateSignatures(
 			continue
 		}
 
+		// Reuse signatures that were collected by previous aggregations
+		signature, err := s.store.GetSignature(messageID, validator.PublicKeyBytes)
+		switch {
+		case err == nil:
+			storedSignatures = append(storedSignatures, signature)
+			signerBitSet.Add(i)
+			aggregatedStakeWeight.Add(aggregatedStakeWeight, new(big.Int).SetUint64(validator.Weight))
+			continue
+		case !errors.Is(err, database.ErrNotFound):
+			return nil, nil, nil, fmt.Errorf("failed to get stored signature: %w", err)
+		}
+
 		v := indexedValidator{
 			Index: i,
 			Warp:  validator,
@@ -113,7 +143,7 @@ func (s *SignatureAggregator) AggregateSignatures(
 	}
 
 	// Account for requested signatures + the signature that was provided
-	signatures := make([]*bls.Signature, 0, len(nonSigners)+1)
+	signatures := make([]*bls.Signature, 0, len(nonSigners)+len(storedSignatures)+1)
 	if bitSetSignature.Signature != [bls.SignatureLen]byte{} {
 		blsSignature, err := bls.SignatureFromBytes(bitSetSignature.Signature[:])
 		if err != nil {
@@ -121,6 +151,21 @@ func (s *SignatureAggregator) AggregateSignatures(
 		}
 		signatures = append(signatures, blsSignature)
 	}
+	signatures = append(signatures, storedSignatures...)
+
+	minThreshold := new(big.Int).Mul(totalStakeWeight, new(big.Int).SetUint64(quorumNum))
+	minThreshold.Div(minThreshold, new(big.Int).SetUint64(quorumDen))
+
+	// Avoid requesting signatures if the stored signatures already reach the
+	// threshold
+	if len(storedSignatures) > 0 && aggregatedStakeWeight.Cmp(minThreshold) != -1 {
+		msg, err := newWarpMessage(message, signerBitSet, signatures)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return msg, aggregatedStakeWeight, totalStakeWeight, nil
+	}
 
 	results := make(chan result)
 	handler := responseHandler{
@@ -133,9 +178,6 @@ func (s *SignatureAggregator) AggregateSignatures(
 		return nil, nil, nil, fmt.Errorf("failed to send aggregation request: %w", err)
 	}
 
-	minThreshold := new(big.Int).Mul(totalStakeWeight, new(big.Int).SetUint64(quorumNum))
-	minThreshold.Div(minThreshold, new(big.Int).SetUint64(quorumDen))
-
 	// Block until:
 	// 1. The context is cancelled
 	// 2. We get responses from all validators
@@ -170,6 +212,14 @@ func (s *SignatureAggregator) AggregateSignatures(
 				continue
 			}
 
+			if err := s.store.PutSignature(messageID, result.Validator.PublicKeyBytes, result.Signature); err != nil {
+				s.log.Warn(
+					"failed to store signature",
+					zap.Stringer("nodeID", result.NodeID),
+					zap.Error(err),
+				)
+			}
+
 			signatures = append(signatures, result.Signature)
 			signerBitSet.Add(result.Validator.Index)
 			aggregatedStakeWeight.Add(aggregatedStakeWeight, new(big.Int).SetUint64(result.Validator.Weight))
@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/network/p2p/p2ptest"
@@ -671,3 +673,110 @@ func TestSignatureAggregator_AggregateSignatures(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedSignatureAggregator(t *testing.T) {
+	require := require.New(t)
+
+	networkID := uint32(123)
+	chainID := ids.GenerateTestID()
+
+	nodeID0 := ids.GenerateTestNodeID()
+	sk0, err := localsigner.New()
+	require.NoError(err)
+	pk0 := sk0.PublicKey()
+	signer0 := warp.NewSigner(sk0, networkID, chainID)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	sk1, err := localsigner.New()
+	require.NoError(err)
+	pk1 := sk1.PublicKey()
+	signer1 := warp.NewSigner(sk1, networkID, chainID)
+
+	unsignedMsg, err := warp.NewUnsignedMessage(
+		networkID,
+		chainID,
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	vdrs := []*validators.Warp{
+		{
+			PublicKey:      pk0,
+			PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk0),
+			Weight:         1,
+			NodeIDs:        []ids.NodeID{nodeID0},
+		},
+		{
+			PublicKey:      pk1,
+			PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk1),
+			Weight:         1,
+			NodeIDs:        []ids.NodeID{nodeID1},
+		},
+	}
+	store, err := NewDBSignatureStore(memdb.New(), DefaultMaxStoredMessages)
+	require.NoError(err)
+
+	aggregate := func(peers map[ids.NodeID]p2p.Handler, quorumNum uint64) (*warp.Message, *big.Int) {
+		client := p2ptest.NewClientWithPeers(
+			t,
+			t.Context(),
+			ids.EmptyNodeID,
+			p2p.NoOpHandler{},
+			peers,
+		)
+		aggregator := NewCachedSignatureAggregator(logging.NoLog{}, client, store)
+
+		msg, aggregatedStake, _, err := aggregator.AggregateSignatures(
+			t.Context(),
+			&warp.Message{
+				UnsignedMessage: *unsignedMsg,
+				Signature:       &warp.BitSetSignature{},
+			},
+			nil,
+			vdrs,
+			quorumNum,
+			2,
+		)
+		require.NoError(err)
+		return msg, aggregatedStake
+	}
+
+	// Only the first validator signs, so the quorum isn't reached.
+	_, aggregatedStake := aggregate(
+		map[ids.NodeID]p2p.Handler{
+			nodeID0: NewHandler(&testVerifier{}, signer0),
+			nodeID1: NewHandler(&testVerifier{Errs: []*common.AppError{common.ErrUndefined}}, signer1),
+		},
+		2,
+	)
+	require.Equal(big.NewInt(1), aggregatedStake)
+
+	// The first validator's signature is reused, so it is not requested again.
+	msg, aggregatedStake := aggregate(
+		map[ids.NodeID]p2p.Handler{
+			nodeID0: NewHandler(&testVerifier{Errs: []*common.AppError{common.ErrUndefined}}, signer0),
+			nodeID1: NewHandler(&testVerifier{}, signer1),
+		},
+		2,
+	)
+	require.Equal(big.NewInt(2), aggregatedStake)
+	require.NoError(msg.Signature.Verify(
+		&msg.UnsignedMessage,
+		networkID,
+		validators.WarpSet{
+			Validators:  vdrs,
+			TotalWeight: 2,
+		},
+		2,
+		2,
+	))
+
+	// Stored signatures that reach the quorum are returned without sending any
+	// requests.
+	_, aggregatedStake = aggregate(map[ids.NodeID]p2p.Handler{}, 2)
+	require.Equal(big.NewInt(2), aggregatedStake)
+
+	require.NoError(store.DeleteSignatures(unsignedMsg.ID()))
+	_, err = store.GetSignature(unsignedMsg.ID(), vdrs[0].PublicKeyBytes)
+	require.ErrorIs(err, database.ErrNotFound)
+}
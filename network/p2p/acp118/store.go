@@ -0,0 +1,253 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+// DefaultMaxStoredMessages is the default number of messages whose signatures
+// are retained by a DBSignatureStore.
+const DefaultMaxStoredMessages = 4096
+
+var (
+	_ SignatureStore = emptySignatureStore{}
+	_ SignatureStore = (*DBSignatureStore)(nil)
+
+	signaturesPrefix = []byte("signatures")
+	messagesPrefix   = []byte("messages")
+	orderPrefix      = []byte("order")
+
+	errInvalidMaxMessages = errors.New("max messages must be positive")
+)
+
+// SignatureStore stores verified signatures of warp messages so that they can
+// be reused across aggregations of the same message.
+type SignatureStore interface {
+	// GetSignature returns the signature of the message with ID [messageID]
+	// by the validator with [publicKey], or [database.ErrNotFound] if the
+	// signature is not known.
+	GetSignature(messageID ids.ID, publicKey []byte) (*bls.Signature, error)
+	// PutSignature stores the signature of the message with ID [messageID] by
+	// the validator with [publicKey]. [signature] must have been verified.
+	PutSignature(messageID ids.ID, publicKey []byte, signature *bls.Signature) error
+}
+
+type emptySignatureStore struct{}
+
+func (emptySignatureStore) GetSignature(ids.ID, []byte) (*bls.Signature, error) {
+	return nil, database.ErrNotFound
+}
+
+func (emptySignatureStore) PutSignature(ids.ID, []byte, *bls.Signature) error {
+	return nil
+}
+
+// NewDBSignatureStore returns a SignatureStore that persists the signatures of
+// at most [maxMessages] messages in [db]. Once full, the signatures of the
+// message that was stored first are deleted to make room for a new message.
+func NewDBSignatureStore(db database.Database, maxMessages int) (*DBSignatureStore, error) {
+	if maxMessages <= 0 {
+		return nil, errInvalidMaxMessages
+	}
+
+	vdb := versiondb.New(db)
+	s := &DBSignatureStore{
+		maxMessages: maxMessages,
+		db:          vdb,
+		signatures:  prefixdb.New(signaturesPrefix, vdb),
+		messages:    prefixdb.New(messagesPrefix, vdb),
+		order:       prefixdb.New(orderPrefix, vdb),
+	}
+
+	it := s.order.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		sequence, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		s.numMessages++
+		s.nextSequence = sequence + 1
+	}
+	return s, it.Error()
+}
+
+// DBSignatureStore persists signatures keyed by the message ID followed by the
+// uncompressed public key of the signer.
+//
+// The number of messages whose signatures are stored is bounded. Messages are
+// evicted in the order they were first stored.
+type DBSignatureStore struct {
+	maxMessages int
+
+	lock         sync.Mutex
+	numMessages  int
+	nextSequence uint64
+	// Changes to the databases below are buffered in [db] and committed
+	// atomically once an operation completes.
+	db *versiondb.Database
+	// Message ID + Public Key --> Signature
+	signatures database.Database
+	// Message ID --> Sequence number
+	messages database.Database
+	// Sequence number --> Message ID
+	order database.Database
+}
+
+func (s *DBSignatureStore) GetSignature(messageID ids.ID, publicKey []byte) (*bls.Signature, error) {
+	signatureBytes, err := s.signatures.Get(signatureKey(messageID, publicKey))
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(signatureBytes)
+}
+
+func (s *DBSignatureStore) PutSignature(messageID ids.ID, publicKey []byte, signature *bls.Signature) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.atomically(func() error {
+		return s.putSignature(messageID, publicKey, signature)
+	})
+}
+
+// Assumes the lock is held.
+func (s *DBSignatureStore) putSignature(messageID ids.ID, publicKey []byte, signature *bls.Signature) error {
+	has, err := s.messages.Has(messageID[:])
+	if err != nil {
+		return err
+	}
+	if !has {
+		if err := s.addMessage(messageID); err != nil {
+			return err
+		}
+	}
+
+	return s.signatures.Put(
+		signatureKey(messageID, publicKey),
+		bls.SignatureToBytes(signature),
+	)
+}
+
+// atomically calls [f] and writes its changes to the database in a single
+// batch. If [f] or the write fails, its changes are discarded.
+//
+// Assumes the lock is held.
+func (s *DBSignatureStore) atomically(f func() error) error {
+	numMessages, nextSequence := s.numMessages, s.nextSequence
+	err := f()
+	if err == nil {
+		err = s.db.Commit()
+	}
+	if err != nil {
+		s.db.Abort()
+		s.numMessages, s.nextSequence = numMessages, nextSequence
+		return err
+	}
+	return nil
+}
+
+// addMessage starts tracking [messageID], evicting the oldest messages if the
+// store is full.
+//
+// Assumes the lock is held.
+func (s *DBSignatureStore) addMessage(messageID ids.ID) error {
+	for s.numMessages >= s.maxMessages {
+		if err := s.evictOldest(); err != nil {
+			return err
+		}
+	}
+
+	sequenceBytes := database.PackUInt64(s.nextSequence)
+	if err := s.messages.Put(messageID[:], sequenceBytes); err != nil {
+		return err
+	}
+	if err := s.order.Put(sequenceBytes, messageID[:]); err != nil {
+		return err
+	}
+	s.numMessages++
+	s.nextSequence++
+	return nil
+}
+
+// evictOldest deletes the signatures of the message that was stored first.
+//
+// Assumes the lock is held.
+func (s *DBSignatureStore) evictOldest() error {
+	it := s.order.NewIterator()
+	defer it.Release()
+
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return err
+		}
+		// The count is out of sync with the database, which should never
+		// happen as changes are written atomically.
+		s.numMessages = 0
+		return nil
+	}
+
+	messageID, err := ids.ToID(it.Value())
+	if err != nil {
+		return err
+	}
+	return s.deleteSignatures(messageID)
+}
+
+// DeleteSignatures removes all of the signatures of the message with ID
+// [messageID].
+func (s *DBSignatureStore) DeleteSignatures(messageID ids.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.atomically(func() error {
+		return s.deleteSignatures(messageID)
+	})
+}
+
+// Assumes the lock is held.
+func (s *DBSignatureStore) deleteSignatures(messageID ids.ID) error {
+	it := s.signatures.NewIteratorWithPrefix(messageID[:])
+	defer it.Release()
+
+	for it.Next() {
+		if err := s.signatures.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	sequenceBytes, err := s.messages.Get(messageID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.order.Delete(sequenceBytes); err != nil {
+		return err
+	}
+	if err := s.messages.Delete(messageID[:]); err != nil {
+		return err
+	}
+	s.numMessages--
+	return nil
+}
+
+func signatureKey(messageID ids.ID, publicKey []byte) []byte {
+	key := make([]byte, 0, ids.IDLen+len(publicKey))
+	key = append(key, messageID[:]...)
+	return append(key, publicKey...)
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+)
+
+func TestDBSignatureStoreEviction(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	pk := bls.PublicKeyToUncompressedBytes(sk.PublicKey())
+	signature, err := sk.Sign([]byte("message"))
+	require.NoError(err)
+
+	db := memdb.New()
+	store, err := NewDBSignatureStore(db, 2)
+	require.NoError(err)
+
+	messageIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	require.NoError(store.PutSignature(messageIDs[0], pk, signature))
+	require.NoError(store.PutSignature(messageIDs[1], pk, signature))
+	// Storing another signature of a known message must not evict anything.
+	require.NoError(store.PutSignature(messageIDs[0], []byte("other key"), signature))
+
+	_, err = store.GetSignature(messageIDs[0], pk)
+	require.NoError(err)
+
+	// The bound is enforced after restarting.
+	store, err = NewDBSignatureStore(db, 2)
+	require.NoError(err)
+	require.NoError(store.PutSignature(messageIDs[2], pk, signature))
+
+	_, err = store.GetSignature(messageIDs[0], pk)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = store.GetSignature(messageIDs[0], []byte("other key"))
+	require.ErrorIs(err, database.ErrNotFound)
+	for _, messageID := range messageIDs[1:] {
+		got, err := store.GetSignature(messageID, pk)
+		require.NoError(err)
+		require.Equal(bls.SignatureToBytes(signature), bls.SignatureToBytes(got))
+	}
+
+	require.NoError(store.DeleteSignatures(messageIDs[1]))
+	_, err = store.GetSignature(messageIDs[1], pk)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Equal(1, store.numMessages)
+}
+
+var errFailedWrite = errors.New("failed write")
+
+// failingWritesDB fails to write batches while [fail] is set.
+type failingWritesDB struct {
+	database.Database
+	fail bool
+}
+
+func (db *failingWritesDB) NewBatch() database.Batch {
+	return &failingWritesBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingWritesBatch struct {
+	database.Batch
+	db *failingWritesDB
+}
+
+func (b *failingWritesBatch) Write() error {
+	if b.db.fail {
+		return errFailedWrite
+	}
+	return b.Batch.Write()
+}
+
+func TestDBSignatureStoreAtomicWrites(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	pk := bls.PublicKeyToUncompressedBytes(sk.PublicKey())
+	signature, err := sk.Sign([]byte("message"))
+	require.NoError(err)
+
+	var (
+		memDB     = memdb.New()
+		db        = &failingWritesDB{Database: memDB}
+		messageID = ids.GenerateTestID()
+	)
+	store, err := NewDBSignatureStore(db, 1)
+	require.NoError(err)
+
+	// A failed write leaves neither the signature nor the message index behind.
+	db.fail = true
+	err = store.PutSignature(messageID, pk, signature)
+	require.ErrorIs(err, errFailedWrite)
+	require.Zero(store.numMessages)
+	require.Zero(store.nextSequence)
+	_, err = store.GetSignature(messageID, pk)
+	require.ErrorIs(err, database.ErrNotFound)
+	it := memDB.NewIterator()
+	require.False(it.Next())
+	it.Release()
+
+	db.fail = false
+	require.NoError(store.PutSignature(messageID, pk, signature))
+
+	// A failed eviction leaves the evicted message intact.
+	db.fail = true
+	err = store.PutSignature(ids.GenerateTestID(), pk, signature)
+	require.ErrorIs(err, errFailedWrite)
+	require.Equal(1, store.numMessages)
+	_, err = store.GetSignature(messageID, pk)
+	require.NoError(err)
+
+	db.fail = false
+	store, err = NewDBSignatureStore(db, 1)
+	require.NoError(err)
+	require.Equal(1, store.numMessages)
+	_, err = store.GetSignature(messageID, pk)
+	require.NoError(err)
+}
+
+func TestNewDBSignatureStoreInvalidMaxMessages(t *testing.T) {
+	_, err := NewDBSignatureStore(memdb.New(), 0)
+	require.ErrorIs(t, err, errInvalidMaxMessages)
+}
@@ -35,6 +35,10 @@ const (
 	unsentType = "unsent"
 	sentType   = "sent"
 
+	resultLabel      = "result"
+	completeResult   = "complete"
+	incompleteResult = "incomplete"
+
 	defaultGossipableCount = 64
 )
 
@@ -67,6 +71,13 @@ var (
 	sentLabels = prometheus.Labels{
 		typeLabel: sentType,
 	}
+	resultLabels   = []string{resultLabel}
+	completeLabels = prometheus.Labels{
+		resultLabel: completeResult,
+	}
+	incompleteLabels = prometheus.Labels{
+		resultLabel: incompleteResult,
+	}
 
 	ErrInvalidNumValidators     = errors.New("num validators cannot be negative")
 	ErrInvalidNumNonValidators  = errors.New("num non-validators cannot be negative")
@@ -111,6 +122,7 @@ type Metrics struct {
 	trackingLifetimeAverage prometheus.Gauge
 	topValidators           *prometheus.GaugeVec
 	bloomFilterHitRate      prometheus.Histogram
+	reconciliations         *prometheus.CounterVec
 }
 
 // NewMetrics returns a common set of metrics
@@ -165,6 +177,14 @@ func NewMetrics(
 			},
 			typeLabels,
 		),
+		reconciliations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reconciliations",
+				Help:      "number of pull requests reconciled with an invertible bloom lookup table",
+			},
+			resultLabels,
+		),
 	}
 	err := errors.Join(
 		metrics.Register(m.bloomFilterHitRate),
@@ -173,6 +193,7 @@ func NewMetrics(
 		metrics.Register(m.tracking),
 		metrics.Register(m.trackingLifetimeAverage),
 		metrics.Register(m.topValidators),
+		metrics.Register(m.reconciliations),
 	)
 	return m, err
 }
@@ -278,6 +299,12 @@ func (p *PullGossiper[_]) handleResponse(
 		return
 	}
 
+	p.addGossip(nodeID, gossip)
+}
+
+// addGossip adds the gossip received from [nodeID] in response to a pull
+// request to the set.
+func (p *PullGossiper[_]) addGossip(nodeID ids.NodeID, gossip [][]byte) {
 	receivedBytes := 0
 	for _, bytes := range gossip {
 		receivedBytes += len(bytes)
@@ -13,7 +13,9 @@ import (
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/iblt"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var _ p2p.Handler = (*Handler[Gossipable])(nil)
@@ -57,12 +59,39 @@ type Handler[T Gossipable] struct {
 }
 
 func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, *common.AppError) {
-	filter, salt, err := ParseAppRequest(requestBytes)
+	request, salt, err := parseAppRequest(requestBytes)
 	if err != nil {
 		return nil, p2p.ErrUnexpected
 	}
 
+	var response []byte
+	if len(request.Table) != 0 {
+		table, err := iblt.Parse(request.Table, salt)
+		if err != nil {
+			return nil, p2p.ErrUnexpected
+		}
+		response, err = h.reconcile(table)
+		if err != nil {
+			return nil, p2p.ErrUnexpected
+		}
+	} else {
+		filter, err := bloom.Parse(request.Filter)
+		if err != nil {
+			return nil, p2p.ErrUnexpected
+		}
+		response, err = h.filter(filter, salt)
+		if err != nil {
+			return nil, p2p.ErrUnexpected
+		}
+	}
+	return response, nil
+}
+
+// filter responds with the gossipables that are not in the requester's bloom
+// filter.
+func (h Handler[T]) filter(filter *bloom.ReadFilter, salt ids.ID) ([]byte, error) {
 	var (
+		err          error
 		hits         float64
 		total        float64
 		responseSize int
@@ -92,7 +121,7 @@ func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, req
 		return responseSize <= h.targetResponseSize
 	})
 	if err != nil {
-		return nil, p2p.ErrUnexpected
+		return nil, err
 	}
 
 	if total > 0 {
@@ -101,15 +130,58 @@ func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, req
 	}
 
 	if err := h.metrics.observeMessage(sentPullLabels, len(gossipBytes), responseSize); err != nil {
-		return nil, p2p.ErrUnexpected
+		return nil, err
 	}
+	return MarshalAppResponse(gossipBytes)
+}
 
-	response, err := MarshalAppResponse(gossipBytes)
-	if err != nil {
-		return nil, p2p.ErrUnexpected
+// reconcile responds with the gossipables that are decoded from the difference
+// between the requester's table and the set.
+func (h Handler[T]) reconcile(table *iblt.Table) ([]byte, error) {
+	h.set.Iterate(func(gossipable T) bool {
+		table.Remove(gossipable.GossipID())
+		return true
+	})
+
+	// IDs that were removed from the table are only known by us.
+	_, unknown, complete := table.Decode()
+	if complete {
+		h.metrics.reconciliations.With(completeLabels).Inc()
+	} else {
+		h.metrics.reconciliations.With(incompleteLabels).Inc()
 	}
 
-	return response, nil
+	var (
+		err          error
+		responseSize int
+		gossipBytes  [][]byte
+	)
+	if len(unknown) > 0 {
+		toSend := set.Of(unknown...)
+		h.set.Iterate(func(gossipable T) bool {
+			if !toSend.Contains(gossipable.GossipID()) {
+				return true
+			}
+
+			var bytes []byte
+			bytes, err = h.marshaller.MarshalGossip(gossipable)
+			if err != nil {
+				return false
+			}
+
+			gossipBytes = append(gossipBytes, bytes)
+			responseSize += len(bytes)
+			return responseSize <= h.targetResponseSize
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.metrics.observeMessage(sentPullLabels, len(gossipBytes), responseSize); err != nil {
+		return nil, err
+	}
+	return MarshalReconcileAppResponse(gossipBytes, !complete)
 }
 
 func (h Handler[_]) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes []byte) {
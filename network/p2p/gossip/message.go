@@ -19,13 +19,18 @@ func MarshalAppRequest(filter, salt []byte) ([]byte, error) {
 	return proto.Marshal(request)
 }
 
-func ParseAppRequest(bytes []byte) (*bloom.ReadFilter, ids.ID, error) {
-	request := &sdk.PullGossipRequest{}
-	if err := proto.Unmarshal(bytes, request); err != nil {
-		return nil, ids.Empty, err
+// MarshalReconcileAppRequest marshals a pull request that is answered by
+// reconciling the responder's set with [table] rather than with a bloom filter.
+func MarshalReconcileAppRequest(table, salt []byte) ([]byte, error) {
+	request := &sdk.PullGossipRequest{
+		Salt:  salt,
+		Table: table,
 	}
+	return proto.Marshal(request)
+}
 
-	salt, err := ids.ToID(request.Salt)
+func ParseAppRequest(bytes []byte) (*bloom.ReadFilter, ids.ID, error) {
+	request, salt, err := parseAppRequest(bytes)
 	if err != nil {
 		return nil, ids.Empty, err
 	}
@@ -34,16 +39,43 @@ func ParseAppRequest(bytes []byte) (*bloom.ReadFilter, ids.ID, error) {
 	return filter, salt, err
 }
 
+func parseAppRequest(bytes []byte) (*sdk.PullGossipRequest, ids.ID, error) {
+	request := &sdk.PullGossipRequest{}
+	if err := proto.Unmarshal(bytes, request); err != nil {
+		return nil, ids.Empty, err
+	}
+
+	salt, err := ids.ToID(request.Salt)
+	return request, salt, err
+}
+
 func MarshalAppResponse(gossip [][]byte) ([]byte, error) {
 	return proto.Marshal(&sdk.PullGossipResponse{
 		Gossip: gossip,
 	})
 }
 
+// MarshalReconcileAppResponse marshals a response to a request marshalled with
+// [MarshalReconcileAppRequest]. [incomplete] should be true if the responder
+// could not decode the full difference between the sets.
+func MarshalReconcileAppResponse(gossip [][]byte, incomplete bool) ([]byte, error) {
+	return proto.Marshal(&sdk.PullGossipResponse{
+		Gossip:     gossip,
+		Incomplete: incomplete,
+	})
+}
+
 func ParseAppResponse(bytes []byte) ([][]byte, error) {
+	gossip, _, err := ParseReconcileAppResponse(bytes)
+	return gossip, err
+}
+
+// ParseReconcileAppResponse parses a response marshalled with
+// [MarshalReconcileAppResponse].
+func ParseReconcileAppResponse(bytes []byte) ([][]byte, bool, error) {
 	response := &sdk.PullGossipResponse{}
 	err := proto.Unmarshal(bytes, response)
-	return response.Gossip, err
+	return response.Gossip, response.Incomplete, err
 }
 
 func MarshalAppGossip(gossip [][]byte) ([]byte, error) {
@@ -0,0 +1,217 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/cache/lru"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/utils/iblt"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	// reconcileNumHashes is the number of cells each gossipable is added to.
+	// With 3 hashes, a table can be decoded with high probability if the
+	// difference between the sets is less than ~80% of the number of cells.
+	reconcileNumHashes = 3
+	// If a response decoded fewer than 1/[reconcileShrinkRatio] of the cells in
+	// the table, the next table is made smaller.
+	reconcileShrinkRatio = 4
+	// bloomPeersCacheSize is the maximum number of peers that are remembered
+	// to not support reconciliation.
+	bloomPeersCacheSize = 1024
+)
+
+var (
+	_ Gossiper = (*ReconcilingPullGossiper[Gossipable])(nil)
+
+	ErrInvalidReconcileCells = errors.New("invalid number of reconciliation cells")
+)
+
+// ReconcilingPullGossiperSet exposes the ability to iterate over the set in
+// addition to the functionality required by [PullGossiper].
+type ReconcilingPullGossiperSet[T Gossipable] interface {
+	PullGossiperSet[T]
+	// Iterate iterates over elements until f returns false.
+	Iterate(f func(v T) bool)
+}
+
+// NewReconcilingPullGossiper returns a [ReconcilingPullGossiper] whose tables
+// contain between [minCells] and [maxCells] cells.
+func NewReconcilingPullGossiper[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set ReconcilingPullGossiperSet[T],
+	client *p2p.Client,
+	sampler p2p.NodeSampler,
+	metrics Metrics,
+	pollSize int,
+	minCells int,
+	maxCells int,
+) (*ReconcilingPullGossiper[T], error) {
+	if minCells < reconcileNumHashes || maxCells < minCells || maxCells > iblt.MaxCells {
+		return nil, fmt.Errorf("%w: min %d, max %d", ErrInvalidReconcileCells, minCells, maxCells)
+	}
+
+	return &ReconcilingPullGossiper[T]{
+		bloom: PullGossiper[T]{
+			log:        log,
+			marshaller: marshaller,
+			set:        set,
+			client:     client,
+			metrics:    metrics,
+			pollSize:   pollSize,
+		},
+		set:        set,
+		sampler:    sampler,
+		minCells:   minCells,
+		maxCells:   maxCells,
+		numCells:   minCells,
+		bloomPeers: lru.NewCache[ids.NodeID, struct{}](bloomPeersCacheSize),
+	}, nil
+}
+
+// ReconcilingPullGossiper requests gossip by sending an invertible bloom lookup
+// table of its set, rather than a bloom filter. The responder subtracts its
+// set from the table and responds with the gossipables that it decodes from
+// the difference.
+//
+// Unlike bloom filters, the size of the table only depends on the size of the
+// difference between the sets and the table never reports false positives.
+// The number of cells is doubled whenever a responder fails to decode the
+// difference, and halved while the difference is small.
+//
+// Peers that fail to handle a reconciliation request, such as peers running a
+// version that only supports bloom filters, are sent bloom filters instead.
+type ReconcilingPullGossiper[T Gossipable] struct {
+	// bloom is used to request gossip from peers that don't support
+	// reconciliation.
+	bloom PullGossiper[T]
+
+	set      ReconcilingPullGossiperSet[T]
+	sampler  p2p.NodeSampler
+	minCells int
+	maxCells int
+
+	lock       sync.Mutex
+	numCells   int
+	bloomPeers *lru.Cache[ids.NodeID, struct{}]
+}
+
+func (p *ReconcilingPullGossiper[_]) Gossip(ctx context.Context) error {
+	var reconcileMsgBytes, bloomMsgBytes []byte
+	for i := 0; i < p.bloom.pollSize; i++ {
+		sampled := p.sampler.Sample(ctx, 1)
+		if len(sampled) != 1 {
+			return nil
+		}
+		nodeID := sampled[0]
+
+		var (
+			msgBytes   []byte
+			onResponse p2p.AppResponseCallback
+			err        error
+		)
+		if _, ok := p.bloomPeers.Get(nodeID); ok {
+			if bloomMsgBytes == nil {
+				bf, salt := p.set.BloomFilter()
+				bloomMsgBytes, err = MarshalAppRequest(bf.Marshal(), salt[:])
+				if err != nil {
+					return err
+				}
+			}
+			msgBytes = bloomMsgBytes
+			onResponse = p.bloom.handleResponse
+		} else {
+			if reconcileMsgBytes == nil {
+				reconcileMsgBytes, err = p.marshalReconcileAppRequest()
+				if err != nil {
+					return err
+				}
+			}
+			msgBytes = reconcileMsgBytes
+			onResponse = p.handleReconcileResponse
+		}
+
+		if err := p.bloom.client.AppRequest(ctx, set.Of(nodeID), msgBytes, onResponse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *ReconcilingPullGossiper[T]) marshalReconcileAppRequest() ([]byte, error) {
+	var salt ids.ID
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	numCells := p.numCells
+	p.lock.Unlock()
+
+	table, err := iblt.New(reconcileNumHashes, numCells, salt)
+	if err != nil {
+		return nil, err
+	}
+	p.set.Iterate(func(gossipable T) bool {
+		table.Add(gossipable.GossipID())
+		return true
+	})
+	return MarshalReconcileAppRequest(table.Marshal(), salt[:])
+}
+
+func (p *ReconcilingPullGossiper[_]) handleReconcileResponse(
+	_ context.Context,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	if errors.Is(err, p2p.ErrUnexpected) {
+		// Handlers that don't support reconciliation fail to parse the
+		// request, so fall back to bloom filters for this peer.
+		p.bloomPeers.Put(nodeID, struct{}{})
+	}
+	if err != nil {
+		p.bloom.log.Debug(
+			"failed reconciliation request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	gossip, incomplete, err := ParseReconcileAppResponse(responseBytes)
+	if err != nil {
+		p.bloom.log.Debug("failed to unmarshal gossip response", zap.Error(err))
+		return
+	}
+
+	p.resize(incomplete, len(gossip))
+	p.bloom.addGossip(nodeID, gossip)
+}
+
+// resize updates the number of cells of future tables based on a response
+// that included [numReceived] gossipables.
+func (p *ReconcilingPullGossiper[_]) resize(incomplete bool, numReceived int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch {
+	case incomplete:
+		p.numCells = min(2*p.numCells, p.maxCells)
+	case numReceived*reconcileShrinkRatio < p.numCells:
+		p.numCells = max(p.numCells/2, p.minCells)
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/utils/iblt"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+type reconcileTest struct {
+	requestSet      *setDouble
+	requestSender   *enginetest.SenderStub
+	requestNetwork  *p2p.Network
+	responseSender  *enginetest.SenderStub
+	responseNetwork *p2p.Network
+	gossiper        *ReconcilingPullGossiper[tx]
+	metrics         Metrics
+}
+
+func newReconcileTest(
+	t *testing.T,
+	requester []tx,
+	responder []tx,
+	targetResponseSize int,
+	minCells int,
+	maxCells int,
+) *reconcileTest {
+	require := require.New(t)
+
+	responseSender := &enginetest.SenderStub{
+		SentAppResponse: make(chan []byte, 1),
+	}
+	responseNetwork, err := p2p.NewNetwork(
+		logging.NoLog{},
+		responseSender,
+		prometheus.NewRegistry(),
+		"",
+	)
+	require.NoError(err)
+
+	responseSet, err := NewBloomSet(&setDouble{}, BloomSetConfig{})
+	require.NoError(err)
+	for _, item := range responder {
+		require.NoError(responseSet.Add(item))
+	}
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	handler := NewHandler[tx](
+		logging.NoLog{},
+		marshaller{},
+		responseSet,
+		metrics,
+		targetResponseSize,
+	)
+	require.NoError(responseNetwork.AddHandler(0x0, handler))
+
+	requestSender := &enginetest.SenderStub{
+		SentAppRequest: make(chan []byte, 1),
+	}
+	peers := &p2p.Peers{}
+	requestNetwork, err := p2p.NewNetwork(
+		logging.NoLog{},
+		requestSender,
+		prometheus.NewRegistry(),
+		"",
+		peers,
+	)
+	require.NoError(err)
+	require.NoError(requestNetwork.Connected(t.Context(), ids.EmptyNodeID, nil))
+
+	requestSet := &setDouble{}
+	requestBloomSet, err := NewBloomSet(requestSet, BloomSetConfig{})
+	require.NoError(err)
+	for _, item := range requester {
+		require.NoError(requestBloomSet.Add(item))
+	}
+
+	sampler := p2p.PeerSampler{Peers: peers}
+	gossiper, err := NewReconcilingPullGossiper[tx](
+		logging.NoLog{},
+		marshaller{},
+		requestBloomSet,
+		requestNetwork.NewClient(0x0, sampler),
+		sampler,
+		metrics,
+		1,
+		minCells,
+		maxCells,
+	)
+	require.NoError(err)
+
+	return &reconcileTest{
+		requestSet:      requestSet,
+		requestSender:   requestSender,
+		requestNetwork:  requestNetwork,
+		responseSender:  responseSender,
+		responseNetwork: responseNetwork,
+		gossiper:        gossiper,
+		metrics:         metrics,
+	}
+}
+
+// gossip runs a round of pull gossip with request ID [requestID] and returns
+// the request that was sent.
+func (r *reconcileTest) gossip(t *testing.T, requestID uint32) *sdk.PullGossipRequest {
+	require := require.New(t)
+	ctx := t.Context()
+
+	require.NoError(r.gossiper.Gossip(ctx))
+	requestBytes := <-r.requestSender.SentAppRequest
+	require.NoError(r.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, requestID, time.Time{}, requestBytes))
+	require.NoError(r.requestNetwork.AppResponse(ctx, ids.EmptyNodeID, requestID, <-r.responseSender.SentAppResponse))
+
+	request := &sdk.PullGossipRequest{}
+	require.NoError(proto.Unmarshal(requestBytes[1:], request))
+	return request
+}
+
+func TestReconcilingGossiperGossip(t *testing.T) {
+	tests := []struct {
+		name                   string
+		targetResponseSize     int
+		requester              []tx // what we have
+		responder              []tx // what the peer we're requesting gossip from has
+		expectedPossibleValues []tx // possible values we can have
+		expectedLen            int
+	}{
+		{
+			name: "no gossip - no one knows anything",
+		},
+		{
+			name:                   "no gossip - requester knows more than responder",
+			targetResponseSize:     1024,
+			requester:              []tx{{0}},
+			expectedPossibleValues: []tx{{0}},
+			expectedLen:            1,
+		},
+		{
+			name:                   "no gossip - requester knows everything responder knows",
+			targetResponseSize:     1024,
+			requester:              []tx{{0}},
+			responder:              []tx{{0}},
+			expectedPossibleValues: []tx{{0}},
+			expectedLen:            1,
+		},
+		{
+			name:                   "gossip - requester knows nothing",
+			targetResponseSize:     1024,
+			responder:              []tx{{0}},
+			expectedPossibleValues: []tx{{0}},
+			expectedLen:            1,
+		},
+		{
+			name:                   "gossip - requester knows less than responder",
+			targetResponseSize:     1024,
+			requester:              []tx{{0}, {2}},
+			responder:              []tx{{0}, {1}},
+			expectedPossibleValues: []tx{{0}, {1}, {2}},
+			expectedLen:            3,
+		},
+		{
+			name:                   "gossip - target response size exceeded",
+			targetResponseSize:     32,
+			responder:              []tx{{0}, {1}, {2}},
+			expectedPossibleValues: []tx{{0}, {1}, {2}},
+			expectedLen:            2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := newReconcileTest(t, tt.requester, tt.responder, tt.targetResponseSize, 64, 64)
+			received := set.Set[tx]{}
+			r.requestSet.onAdd = func(tx tx) {
+				received.Add(tx)
+			}
+
+			request := r.gossip(t, 1)
+			require.Empty(request.Filter)
+			require.NotEmpty(request.Table)
+
+			require.Len(r.requestSet.txs, tt.expectedLen)
+			require.Subset(tt.expectedPossibleValues, r.requestSet.txs)
+
+			// we should not receive anything that we already had before we
+			// requested the gossip
+			for _, tx := range tt.requester {
+				require.NotContains(received, tx)
+			}
+
+			completeCount := testutil.ToFloat64(r.metrics.reconciliations.With(completeLabels))
+			require.Equal(float64(1), completeCount)
+		})
+	}
+}
+
+func TestReconcilingGossiperResize(t *testing.T) {
+	require := require.New(t)
+
+	responder := make([]tx, 1000)
+	for i := range responder {
+		responder[i] = tx(ids.GenerateTestID())
+	}
+
+	const (
+		minCells = 3
+		maxCells = 48
+	)
+	r := newReconcileTest(t, nil, responder, units.MiB, minCells, maxCells)
+
+	// The difference can't be decoded, so the table grows until it reaches
+	// the maximum size.
+	requestID := uint32(1)
+	for _, expectedNumCells := range []int{3, 6, 12, 24, 48, 48} {
+		request := r.gossip(t, requestID)
+		requestID += 2
+
+		table, err := iblt.Parse(request.Table, ids.Empty)
+		require.NoError(err)
+		require.Equal(expectedNumCells, table.NumCells())
+	}
+	incompleteCount := testutil.ToFloat64(r.metrics.reconciliations.With(incompleteLabels))
+	require.Equal(float64(6), incompleteCount)
+
+	// Once the sets are reconciled, the table shrinks again.
+	for _, item := range responder {
+		if !r.requestSet.Has(item.GossipID()) {
+			require.NoError(r.gossiper.set.Add(item))
+		}
+	}
+	for _, expectedNumCells := range []int{48, 24, 12, 6, 3, 3} {
+		request := r.gossip(t, requestID)
+		requestID += 2
+
+		table, err := iblt.Parse(request.Table, ids.Empty)
+		require.NoError(err)
+		require.Equal(expectedNumCells, table.NumCells())
+	}
+}
+
+// unsupportedHandler responds to all requests the same way as handlers that
+// don't support reconciliation.
+type unsupportedHandler struct {
+	p2p.NoOpHandler
+}
+
+func (unsupportedHandler) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	request := &sdk.PullGossipRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil || len(request.Filter) == 0 {
+		return nil, p2p.ErrUnexpected
+	}
+
+	responseBytes, err := MarshalAppResponse(nil)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	return responseBytes, nil
+}
+
+func TestReconcilingGossiperFallback(t *testing.T) {
+	require := require.New(t)
+	ctx := t.Context()
+
+	r := newReconcileTest(t, nil, nil, units.KiB, 64, 64)
+
+	// Replace the handler with one that only supports bloom filters.
+	r.responseSender = &enginetest.SenderStub{
+		SentAppResponse: make(chan []byte, 1),
+		SentAppError:    make(chan *common.AppError, 1),
+	}
+	responseNetwork, err := p2p.NewNetwork(
+		logging.NoLog{},
+		r.responseSender,
+		prometheus.NewRegistry(),
+		"",
+	)
+	require.NoError(err)
+	require.NoError(responseNetwork.AddHandler(0x0, unsupportedHandler{}))
+	r.responseNetwork = responseNetwork
+
+	require.NoError(r.gossiper.Gossip(ctx))
+	requestBytes := <-r.requestSender.SentAppRequest
+	require.NoError(r.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, 1, time.Time{}, requestBytes))
+	require.NoError(r.requestNetwork.AppRequestFailed(ctx, ids.EmptyNodeID, 1, <-r.responseSender.SentAppError))
+
+	// Subsequent requests to the peer use bloom filters.
+	request := r.gossip(t, 3)
+	require.NotEmpty(request.Filter)
+	require.Empty(request.Table)
+}
+
+func TestNewReconcilingPullGossiperErrors(t *testing.T) {
+	tests := []struct {
+		minCells int
+		maxCells int
+	}{
+		{
+			minCells: reconcileNumHashes - 1,
+			maxCells: 64,
+		},
+		{
+			minCells: 64,
+			maxCells: 63,
+		},
+		{
+			minCells: 64,
+			maxCells: iblt.MaxCells + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%d", tt.minCells, tt.maxCells), func(t *testing.T) {
+			_, err := NewReconcilingPullGossiper[tx](
+				logging.NoLog{},
+				marshaller{},
+				nil,
+				nil,
+				nil,
+				Metrics{},
+				1,
+				tt.minCells,
+				tt.maxCells,
+			)
+			require.ErrorIs(t, err, ErrInvalidReconcileCells)
+		})
+	}
+}
+
+// BenchmarkPullRequest compares the size and handling time of pull requests
+// using bloom filters and reconciliation when the requester is missing 10
+// of the responder's gossipables.
+func BenchmarkPullRequest(b *testing.B) {
+	const numMissing = 10
+	for _, setSize := range []int{1_000, 10_000, 100_000} {
+		var (
+			requestSet  = &setDouble{}
+			responseSet = &setDouble{}
+		)
+		requestBloomSet, err := NewBloomSet(requestSet, BloomSetConfig{})
+		require.NoError(b, err)
+		responseBloomSet, err := NewBloomSet(responseSet, BloomSetConfig{})
+		require.NoError(b, err)
+		for i := range setSize {
+			item := tx(ids.GenerateTestID())
+			require.NoError(b, responseBloomSet.Add(item))
+			if i >= numMissing {
+				require.NoError(b, requestBloomSet.Add(item))
+			}
+		}
+
+		metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+		require.NoError(b, err)
+		handler := NewHandler[tx](
+			logging.NoLog{},
+			marshaller{},
+			responseBloomSet,
+			metrics,
+			units.MiB,
+		)
+
+		bf, salt := requestBloomSet.BloomFilter()
+		bloomRequest, err := MarshalAppRequest(bf.Marshal(), salt[:])
+		require.NoError(b, err)
+
+		gossiper, err := NewReconcilingPullGossiper[tx](
+			logging.NoLog{},
+			marshaller{},
+			requestBloomSet,
+			nil,
+			nil,
+			metrics,
+			1,
+			64,
+			64,
+		)
+		require.NoError(b, err)
+		reconcileRequest, err := gossiper.marshalReconcileAppRequest()
+		require.NoError(b, err)
+
+		for _, bench := range []struct {
+			name    string
+			request []byte
+		}{
+			{
+				name:    "bloom",
+				request: bloomRequest,
+			},
+			{
+				name:    "reconcile",
+				request: reconcileRequest,
+			},
+		} {
+			b.Run(fmt.Sprintf("%s-%d", bench.name, setSize), func(b *testing.B) {
+				var numReceived int
+				for b.Loop() {
+					responseBytes, appErr := handler.AppRequest(b.Context(), ids.EmptyNodeID, time.Time{}, bench.request)
+					require.Nil(b, appErr)
+
+					gossip, err := ParseAppResponse(responseBytes)
+					require.NoError(b, err)
+					numReceived = len(gossip)
+				}
+				b.ReportMetric(float64(len(bench.request)), "request-bytes")
+				b.ReportMetric(float64(numReceived), "received")
+			})
+		}
+	}
+}
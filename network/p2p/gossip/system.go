@@ -39,6 +39,14 @@ type SystemConfig struct {
 
 	DiscardedPushCacheSize int           // Defaults to 16,384
 	RegossipPeriod         time.Duration // Defaults to 30 seconds
+
+	// PullReconciliation enables requesting pull gossip with invertible bloom
+	// lookup tables rather than bloom filters. Pull requests sent to peers
+	// that don't support reconciliation on [HandlerID] fall back to bloom
+	// filters.
+	PullReconciliation         bool
+	PullReconciliationMinCells int // Defaults to 64
+	PullReconciliationMaxCells int // Defaults to 4,096
 }
 
 func (c *SystemConfig) setDefaults() {
@@ -74,6 +82,12 @@ func (c *SystemConfig) setDefaults() {
 	if c.RegossipPeriod <= 0 {
 		c.RegossipPeriod = 30 * time.Second
 	}
+	if c.PullReconciliationMinCells <= 0 {
+		c.PullReconciliationMinCells = 64
+	}
+	if c.PullReconciliationMaxCells <= 0 {
+		c.PullReconciliationMaxCells = 4_096
+	}
 }
 
 // SystemSet is the backend interface required to construct a gossip system.
@@ -152,7 +166,7 @@ func NewSystem[T Gossipable](
 
 	client := network.NewClient(c.HandlerID, validatorPeers)
 	const pollSize = 1
-	pullGossiper := NewPullGossiper[T](
+	var pullGossiper Gossiper = NewPullGossiper[T](
 		c.Log,
 		marshaller,
 		set,
@@ -160,6 +174,22 @@ func NewSystem[T Gossipable](
 		metrics,
 		pollSize,
 	)
+	if c.PullReconciliation {
+		pullGossiper, err = NewReconcilingPullGossiper[T](
+			c.Log,
+			marshaller,
+			set,
+			client,
+			validatorPeers,
+			metrics,
+			pollSize,
+			c.PullReconciliationMinCells,
+			c.PullReconciliationMaxCells,
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	pullGossiperWhenValidator := &ValidatorGossiper{
 		Gossiper:   pullGossiper,
 		NodeID:     nodeID,
@@ -0,0 +1,138 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// maxBandwidthChains limits how many chains the bandwidth of a peer is
+// attributed to. Messages for additional chains are attributed to
+// [ids.Empty], which prevents a peer from growing the tracked usage by sending
+// messages for arbitrary chainIDs.
+const maxBandwidthChains = 32
+
+// Bandwidth is the number of message bytes sent to and received from a peer.
+type Bandwidth struct {
+	SentBytes     json.Uint64 `json:"sentBytes"`
+	ReceivedBytes json.Uint64 `json:"receivedBytes"`
+	// Usage is sorted by chainID and then by op.
+	Usage []OpBandwidth `json:"usage"`
+}
+
+// OpBandwidth is the bandwidth used by messages of an op for a chain.
+type OpBandwidth struct {
+	// ChainID is [ids.Empty] for messages that are not sent for a chain, such
+	// as pings and peer lists.
+	ChainID          ids.ID      `json:"chainID"`
+	Op               string      `json:"op"`
+	MessagesSent     json.Uint64 `json:"messagesSent"`
+	SentBytes        json.Uint64 `json:"sentBytes"`
+	MessagesReceived json.Uint64 `json:"messagesReceived"`
+	ReceivedBytes    json.Uint64 `json:"receivedBytes"`
+}
+
+type bandwidthKey struct {
+	chainID ids.ID
+	op      message.Op
+}
+
+type bandwidthUsage struct {
+	messagesSent     uint64
+	sentBytes        uint64
+	messagesReceived uint64
+	receivedBytes    uint64
+}
+
+// bandwidthTracker accounts the bytes of the messages sent to and received
+// from a peer by chain and op.
+type bandwidthTracker struct {
+	lock   sync.Mutex
+	chains set.Set[ids.ID]
+	usage  map[bandwidthKey]*bandwidthUsage
+}
+
+func newBandwidthTracker() *bandwidthTracker {
+	return &bandwidthTracker{
+		chains: set.NewSet[ids.ID](maxBandwidthChains),
+		usage:  make(map[bandwidthKey]*bandwidthUsage),
+	}
+}
+
+// Sent records that a message of [op] for [chainID] was sent.
+func (b *bandwidthTracker) Sent(chainID ids.ID, op message.Op, numBytes int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	usage := b.get(chainID, op)
+	usage.messagesSent++
+	usage.sentBytes += uint64(numBytes)
+}
+
+// Received records that a message of [op] for [chainID] was received.
+func (b *bandwidthTracker) Received(chainID ids.ID, op message.Op, numBytes int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	usage := b.get(chainID, op)
+	usage.messagesReceived++
+	usage.receivedBytes += uint64(numBytes)
+}
+
+// Bandwidth returns the bandwidth used with the peer.
+func (b *bandwidthTracker) Bandwidth() Bandwidth {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	bandwidth := Bandwidth{
+		Usage: make([]OpBandwidth, 0, len(b.usage)),
+	}
+	for key, usage := range b.usage {
+		bandwidth.SentBytes += json.Uint64(usage.sentBytes)
+		bandwidth.ReceivedBytes += json.Uint64(usage.receivedBytes)
+		bandwidth.Usage = append(bandwidth.Usage, OpBandwidth{
+			ChainID:          key.chainID,
+			Op:               key.op.String(),
+			MessagesSent:     json.Uint64(usage.messagesSent),
+			SentBytes:        json.Uint64(usage.sentBytes),
+			MessagesReceived: json.Uint64(usage.messagesReceived),
+			ReceivedBytes:    json.Uint64(usage.receivedBytes),
+		})
+	}
+	slices.SortFunc(bandwidth.Usage, func(a, b OpBandwidth) int {
+		if c := a.ChainID.Compare(b.ChainID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Op, b.Op)
+	})
+	return bandwidth
+}
+
+// get returns the usage of [op] for [chainID].
+//
+// Assumes [b.lock] is held.
+func (b *bandwidthTracker) get(chainID ids.ID, op message.Op) *bandwidthUsage {
+	if !b.chains.Contains(chainID) && b.chains.Len() >= maxBandwidthChains {
+		chainID = ids.Empty
+	}
+
+	key := bandwidthKey{
+		chainID: chainID,
+		op:      op,
+	}
+	usage, ok := b.usage[key]
+	if !ok {
+		usage = &bandwidthUsage{}
+		b.usage[key] = usage
+		b.chains.Add(chainID)
+	}
+	return usage
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func TestBandwidthTracker(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.ID{1}
+	b := newBandwidthTracker()
+	b.Sent(chainID, message.PushQueryOp, 10)
+	b.Sent(chainID, message.PushQueryOp, 20)
+	b.Received(chainID, message.ChitsOp, 5)
+	b.Sent(ids.Empty, message.PingOp, 1)
+	b.Received(ids.Empty, message.PongOp, 2)
+
+	require.Equal(Bandwidth{
+		SentBytes:     31,
+		ReceivedBytes: 7,
+		Usage: []OpBandwidth{
+			{
+				ChainID:      ids.Empty,
+				Op:           message.PingOp.String(),
+				MessagesSent: 1,
+				SentBytes:    1,
+			},
+			{
+				ChainID:          ids.Empty,
+				Op:               message.PongOp.String(),
+				MessagesReceived: 1,
+				ReceivedBytes:    2,
+			},
+			{
+				ChainID:          chainID,
+				Op:               message.ChitsOp.String(),
+				MessagesReceived: 1,
+				ReceivedBytes:    5,
+			},
+			{
+				ChainID:      chainID,
+				Op:           message.PushQueryOp.String(),
+				MessagesSent: 2,
+				SentBytes:    30,
+			},
+		},
+	}, b.Bandwidth())
+}
+
+func TestBandwidthTrackerMaxChains(t *testing.T) {
+	require := require.New(t)
+
+	b := newBandwidthTracker()
+	for i := range maxBandwidthChains + 1 {
+		b.Received(ids.ID{byte(i + 1)}, message.GetOp, 1)
+	}
+
+	bandwidth := b.Bandwidth()
+	require.Len(bandwidth.Usage, maxBandwidthChains+1)
+	require.Equal(OpBandwidth{
+		ChainID:          ids.Empty,
+		Op:               message.GetOp.String(),
+		MessagesReceived: 1,
+		ReceivedBytes:    1,
+	}, bandwidth.Usage[0])
+}
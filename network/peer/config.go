@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -35,8 +36,9 @@ type Config struct {
 	Router               router.InboundHandler
 	VersionCompatibility *version.Compatibility
 	MyNodeID             ids.NodeID
-	// MySubnets does not include the primary network ID
-	MySubnets          set.Set[ids.ID]
+	// MySubnets does not include the primary network ID. It is replaced, rather
+	// than modified, when the tracked subnets change.
+	MySubnets          *utils.Atomic[set.Set[ids.ID]]
 	Beacons            validators.Manager
 	Validators         validators.Manager
 	NetworkID          uint32
@@ -66,4 +68,9 @@ type Config struct {
 	// If true, connects to all validators regardless of primary network validator
 	// status or of configured tracked subnets.
 	ConnectToAllValidators bool
+
+	// MyQUICPort is the UDP port this node accepts QUIC connections on, which is
+	// advertised in the Handshake message. It is 0 if this node does not accept
+	// QUIC connections.
+	MyQUICPort uint16
 }
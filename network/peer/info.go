@@ -24,4 +24,6 @@ type Info struct {
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 	SupportedACPs  set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs   set.Set[uint32] `json:"objectedACPs"`
+	QUICPort       uint16          `json:"quicPort,omitempty"`
+	Bandwidth      Bandwidth       `json:"bandwidth"`
 }
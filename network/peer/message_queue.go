@@ -14,9 +14,35 @@ import (
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
-const initialQueueSize = 64
+const (
+	initialQueueSize = 64
+
+	// quantumBytes is the number of bytes a class of messages with weight 1
+	// may send per round of the fair queue.
+	quantumBytes = 16 * units.KiB
+)
+
+// Classes of outbound messages that share the bandwidth to a peer.
+const (
+	consensusClass = iota
+	bootstrapClass
+	appClass
+
+	numClasses
+)
+
+// classWeights are the relative shares of the bandwidth to a peer that each
+// class of messages receives while the peer is busy. Consensus messages are
+// favored so that polls are answered while bulk bootstrapping responses and
+// application traffic are queued.
+var classWeights = [numClasses]int{
+	consensusClass: 8,
+	bootstrapClass: 1,
+	appClass:       2,
+}
 
 var (
 	_ MessageQueue = (*throttledMessageQueue)(nil)
@@ -66,9 +92,14 @@ type throttledMessageQueue struct {
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue buffer.Deque[*message.OutboundMessage]
+	// queues of the messages, by class, which are popped by deficit round
+	// robin.
+	// [cond.L] must be held while accessing [queues], [deficits], [current]
+	// and [numMessages].
+	queues      [numClasses]buffer.Deque[*message.OutboundMessage]
+	deficits    [numClasses]int
+	current     int
+	numMessages int
 }
 
 func NewThrottledMessageQueue(
@@ -77,14 +108,19 @@ func NewThrottledMessageQueue(
 	log logging.Logger,
 	outboundMsgThrottler throttling.OutboundMsgThrottler,
 ) MessageQueue {
-	return &throttledMessageQueue{
+	q := &throttledMessageQueue{
 		onFailed:             onFailed,
 		id:                   id,
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
 		cond:                 sync.NewCond(&sync.Mutex{}),
-		queue:                buffer.NewUnboundedDeque[*message.OutboundMessage](initialQueueSize),
+		// The first round starts with the consensus class.
+		current: numClasses - 1,
+	}
+	for i := range q.queues {
+		q.queues[i] = buffer.NewUnboundedDeque[*message.OutboundMessage](initialQueueSize)
 	}
+	return q
 }
 
 func (q *throttledMessageQueue) Push(ctx context.Context, msg *message.OutboundMessage) bool {
@@ -130,7 +166,8 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg *message.OutboundM
 		return false
 	}
 
-	q.queue.PushRight(msg)
+	q.queues[opClass(msg.Op)].PushRight(msg)
+	q.numMessages++
 	q.cond.Signal()
 	return true
 }
@@ -143,7 +180,7 @@ func (q *throttledMessageQueue) Pop() (*message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if q.queue.Len() > 0 {
+		if q.numMessages > 0 {
 			// There is a message
 			break
 		}
@@ -158,7 +195,7 @@ func (q *throttledMessageQueue) PopNow() (*message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if q.closed || q.queue.Len() == 0 {
+	if q.closed || q.numMessages == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -166,11 +203,43 @@ func (q *throttledMessageQueue) PopNow() (*message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+// pop returns the next message by deficit round robin over the classes of
+// messages, so that each class is sent bytes in proportion to its weight
+// while other classes have messages queued.
+//
+// Assumes [cond.L] is held and that there is a message.
 func (q *throttledMessageQueue) pop() *message.OutboundMessage {
-	msg, _ := q.queue.PopLeft()
+	for {
+		queue := q.queues[q.current]
+		msg, ok := queue.PeekLeft()
+		if !ok {
+			// An empty class can't bank its share of the bandwidth.
+			q.deficits[q.current] = 0
+			q.next()
+			continue
+		}
+
+		size := len(msg.Bytes)
+		if size > q.deficits[q.current] {
+			q.next()
+			continue
+		}
+
+		_, _ = queue.PopLeft()
+		q.numMessages--
+		q.deficits[q.current] -= size
+		q.outboundMsgThrottler.Release(msg, q.id)
+		return msg
+	}
+}
 
-	q.outboundMsgThrottler.Release(msg, q.id)
-	return msg
+// next moves to the next class of messages and grants it its share of the
+// bandwidth for the round.
+//
+// Assumes [cond.L] is held.
+func (q *throttledMessageQueue) next() {
+	q.current = (q.current + 1) % numClasses
+	q.deficits[q.current] += classWeights[q.current] * quantumBytes
 }
 
 func (q *throttledMessageQueue) Close() {
@@ -183,16 +252,44 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for q.queue.Len() > 0 {
-		msg, _ := q.queue.PopLeft()
-		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+	for i, queue := range q.queues {
+		for queue.Len() > 0 {
+			msg, _ := queue.PopLeft()
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.onFailed.SendFailed(msg)
+		}
+		q.queues[i] = nil
 	}
-	q.queue = nil
+	q.numMessages = 0
 
 	q.cond.Broadcast()
 }
 
+// opClass returns the class of messages that [op] is sent in. Network
+// messages are small and are sent with consensus messages.
+func opClass(op message.Op) int {
+	switch op {
+	case message.GetStateSummaryFrontierOp,
+		message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp,
+		message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp,
+		message.AcceptedFrontierOp,
+		message.GetAcceptedOp,
+		message.AcceptedOp,
+		message.GetAncestorsOp,
+		message.AncestorsOp:
+		return bootstrapClass
+	case message.AppRequestOp,
+		message.AppErrorOp,
+		message.AppResponseOp,
+		message.AppGossipOp:
+		return appClass
+	default:
+		return consensusClass
+	}
+}
+
 type blockingMessageQueue struct {
 	onFailed SendFailedCallback
 	log      logging.Logger
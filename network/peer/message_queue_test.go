@@ -9,8 +9,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestMessageQueue(t *testing.T) {
@@ -73,3 +76,79 @@ func TestMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	require.False(ok)
 }
+
+func TestThrottledMessageQueueFairness(t *testing.T) {
+	require := require.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(*message.OutboundMessage) {
+			require.FailNow("unexpected send failure")
+		}),
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	pushN := func(op message.Op, size int, n int) {
+		for range n {
+			require.True(q.Push(t.Context(), &message.OutboundMessage{
+				Op:    op,
+				Bytes: make([]byte, size),
+			}))
+		}
+	}
+	pushN(message.AncestorsOp, quantumBytes, 4)
+	pushN(message.AppGossipOp, quantumBytes, 4)
+	pushN(message.ChitsOp, units.KiB, 4)
+
+	// Consensus messages are sent first and, afterwards, application messages
+	// are sent at twice the rate of bootstrapping messages.
+	expectedOps := []message.Op{
+		message.ChitsOp,
+		message.ChitsOp,
+		message.ChitsOp,
+		message.ChitsOp,
+		message.AncestorsOp,
+		message.AppGossipOp,
+		message.AppGossipOp,
+		message.AncestorsOp,
+		message.AppGossipOp,
+		message.AppGossipOp,
+		message.AncestorsOp,
+		message.AncestorsOp,
+	}
+	for _, expectedOp := range expectedOps {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(expectedOp, msg.Op)
+	}
+
+	_, ok := q.PopNow()
+	require.False(ok)
+}
+
+func TestThrottledMessageQueueClose(t *testing.T) {
+	require := require.New(t)
+
+	var numFailed int
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(*message.OutboundMessage) {
+			numFailed++
+		}),
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	for _, op := range []message.Op{message.AncestorsOp, message.AppGossipOp, message.ChitsOp} {
+		require.True(q.Push(t.Context(), &message.OutboundMessage{Op: op}))
+	}
+
+	q.Close()
+	require.Equal(3, numFailed)
+
+	_, ok := q.Pop()
+	require.False(ok)
+	require.False(q.Push(t.Context(), &message.OutboundMessage{Op: message.ChitsOp}))
+	require.Equal(4, numFailed)
+}
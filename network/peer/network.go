@@ -30,6 +30,10 @@ type Network interface {
 	// for a given [Peer] object.
 	Disconnected(peerID ids.NodeID)
 
+	// InvalidMessage is called when the peer sends a message that can't be
+	// parsed or that is malformed.
+	InvalidMessage(peerID ids.NodeID)
+
 	// KnownPeers returns the bloom filter of the known peers.
 	KnownPeers() (bloomFilter []byte, salt []byte)
 
@@ -56,6 +56,12 @@ type Peer struct {
 
 	// the connection object that is used to read/write messages from
 	conn net.Conn
+	// streams of [conn] that messages are read from and written to. If [conn]
+	// is not a [MultiStreamConn], this only contains [conn].
+	streams []net.Conn
+	// acquireLock serializes the calls to [InboundMsgThrottler.Acquire] made
+	// by the readers of [streams].
+	acquireLock sync.Mutex
 
 	// [cert] is this peer's certificate, specifically the leaf of the
 	// certificate chain they provided.
@@ -67,6 +73,9 @@ type Peer struct {
 	// queue of messages to send to this peer.
 	messageQueue MessageQueue
 
+	// bandwidth accounts the messages sent to and received from this peer.
+	bandwidth *bandwidthTracker
+
 	// ip is the claimed IP the peer gave us in the Handshake message.
 	ip *SignedIP
 	// version is the claimed version the peer is running that we received in
@@ -81,6 +90,10 @@ type Peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// quicPort is the UDP port the peer accepts QUIC connections on, provided
+	// in the Handshake message. It is 0 if the peer does not accept QUIC
+	// connections.
+	quicPort uint16
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -152,9 +165,11 @@ func Start(
 		isIngress:          isIngress,
 		Config:             config,
 		conn:               conn,
+		streams:            connStreams(conn),
 		cert:               cert,
 		id:                 id,
 		messageQueue:       messageQueue,
+		bandwidth:          newBandwidthTracker(),
 		onFinishHandshake:  make(chan struct{}),
 		numExecuting:       3,
 		onClosingCtx:       onClosingCtx,
@@ -226,9 +241,8 @@ func (p *Peer) AwaitReady(ctx context.Context) error {
 func (p *Peer) Info() Info {
 	primaryUptime := p.ObservedUptime()
 
-	ip, _ := ips.ParseAddrPort(p.conn.RemoteAddr().String())
 	return Info{
-		IP:             ip,
+		IP:             p.RemoteIP(),
 		PublicIP:       p.ip.AddrPort,
 		ID:             p.id,
 		Version:        p.version.String(),
@@ -239,9 +253,18 @@ func (p *Peer) Info() Info {
 		TrackedSubnets: p.trackedSubnets,
 		SupportedACPs:  p.supportedACPs,
 		ObjectedACPs:   p.objectedACPs,
+		QUICPort:       p.quicPort,
+		Bandwidth:      p.bandwidth.Bandwidth(),
 	}
 }
 
+// RemoteIP returns the address of the other end of the connection to this
+// peer.
+func (p *Peer) RemoteIP() netip.AddrPort {
+	ip, _ := ips.ParseAddrPort(p.conn.RemoteAddr().String())
+	return ip
+}
+
 // IP returns the claimed IP and signature provided by this peer during the
 // handshake. It should only be called after [Peer.Ready] returns true.
 func (p *Peer) IP() *SignedIP {
@@ -260,6 +283,13 @@ func (p *Peer) TrackedSubnets() set.Set[ids.ID] {
 	return p.trackedSubnets
 }
 
+// QUICPort returns the UDP port this peer accepts QUIC connections on, or 0 if
+// the peer does not accept QUIC connections. It should only be called after
+// [Peer.Ready] returns true.
+func (p *Peer) QUICPort() uint16 {
+	return p.quicPort
+}
+
 // ObservedUptime returns the local node's primary network uptime according to
 // the peer. The value ranges from [0, 100]. It should only be called after
 // [Peer.Ready] returns true.
@@ -342,18 +372,48 @@ func (p *Peer) close() {
 func (p *Peer) readMessages() {
 	// Track this node with the inbound message throttler.
 	p.InboundMsgThrottler.AddNode(p.id)
+
+	// The handshake is sent on the first stream, so messages on the other
+	// streams are only read once the handshake has finished.
+	var wg sync.WaitGroup
+	for i := 1; i < len(p.streams); i++ {
+		wg.Add(1)
+		go func() {
+			defer func() {
+				p.StartClose()
+				wg.Done()
+			}()
+
+			select {
+			case <-p.onFinishHandshake:
+				p.readStream(i)
+			case <-p.onClosingCtx.Done():
+			}
+		}()
+	}
+
 	defer func() {
-		p.InboundMsgThrottler.RemoveNode(p.id)
 		p.StartClose()
+		// Wait for the readers of the other streams to exit to ensure that no
+		// reader can call Acquire after the node is removed from the throttler.
+		wg.Wait()
+		p.InboundMsgThrottler.RemoveNode(p.id)
 		p.close()
 	}()
 
+	p.readStream(0)
+}
+
+// readStream reads and handles messages from the stream at [index] until an
+// error occurs.
+func (p *Peer) readStream(index int) {
+	stream := p.streams[index]
 	// Continuously read and handle messages from this peer.
-	reader := bufio.NewReaderSize(p.conn, p.Config.ReadBufferSize)
+	reader := bufio.NewReaderSize(stream, p.Config.ReadBufferSize)
 	msgLenBytes := make([]byte, wrappers.IntLen)
 	for {
 		// Time out and close connection if we can't read the message length
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		if err := stream.SetReadDeadline(p.nextTimeout()); err != nil {
 			p.Log.Verbo(failedToSetDeadlineLog,
 				zap.Stringer("nodeID", p.id),
 				zap.String("direction", "read"),
@@ -389,16 +449,18 @@ func (p *Peer) readMessages() {
 		// throttler metrics to verify that there is no leak.
 		//
 		// Invariant: There must only be one call to Acquire at any given time
-		// with the same nodeID. In this package, only this goroutine ever
-		// performs Acquire. Additionally, we ensure that this goroutine has
-		// exited before calling [Network.Disconnected] to guarantee that there
-		// can't be multiple instances of this goroutine running over different
-		// peer instances.
+		// with the same nodeID. In this package, only the stream readers ever
+		// perform Acquire and they are serialized by [p.acquireLock].
+		// Additionally, we ensure that the readers have exited before calling
+		// [Network.Disconnected] to guarantee that there can't be multiple
+		// readers running over different peer instances.
+		p.acquireLock.Lock()
 		onFinishedHandling := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		p.acquireLock.Unlock()
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -407,7 +469,7 @@ func (p *Peer) readMessages() {
 		}
 
 		// Time out and close connection if we can't read message
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		if err := stream.SetReadDeadline(p.nextTimeout()); err != nil {
 			p.Log.Verbo(failedToSetDeadlineLog,
 				zap.Stringer("nodeID", p.id),
 				zap.String("direction", "read"),
@@ -451,6 +513,7 @@ func (p *Peer) readMessages() {
 			)
 
 			p.Metrics.NumFailedToParse.Inc()
+			p.Network.InvalidMessage(p.id)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -458,10 +521,29 @@ func (p *Peer) readMessages() {
 			continue
 		}
 
+		// Only the first stream may carry messages of any class, which
+		// prevents network messages from being handled concurrently.
+		if index != 0 && opClass(msg.Op) != index {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op),
+				zap.Int("stream", index),
+			)
+			p.Network.InvalidMessage(p.id)
+			msg.OnFinishedHandling()
+			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+			return
+		}
+
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
 
+		// Network messages are not sent for a chain, so a missing chainID is
+		// expected.
+		chainID, _ := message.GetChainID(msg.Message)
+		p.bandwidth.Received(chainID, msg.Op, int(msgLen))
+
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
 		p.handle(msg)
@@ -475,7 +557,10 @@ func (p *Peer) writeMessages() {
 		p.close()
 	}()
 
-	writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
+	writers := make([]*bufio.Writer, len(p.streams))
+	for i, stream := range p.streams {
+		writers[i] = bufio.NewWriterSize(stream, p.Config.WriteBufferSize)
+	}
 
 	// Make sure that the Handshake is the first message sent
 	mySignedIP, err := p.IPSigner.GetSignedIP()
@@ -511,12 +596,13 @@ func (p *Peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.TLSSignature,
 		mySignedIP.BLSSignatureBytes,
-		p.MySubnets.List(),
+		p.MySubnets.Get().List(),
 		p.SupportedACPs,
 		p.ObjectedACPs,
 		knownPeersFilter,
 		knownPeersSalt,
 		requestAllSubnetIPs,
+		p.MyQUICPort,
 	)
 	if err != nil {
 		p.Log.Error(failedToCreateMessageLog,
@@ -527,23 +613,25 @@ func (p *Peer) writeMessages() {
 		return
 	}
 
-	p.writeMessage(writer, msg)
+	p.writeMessage(writers, msg)
 
 	for {
 		msg, ok := p.messageQueue.PopNow()
 		if ok {
-			p.writeMessage(writer, msg)
+			p.writeMessage(writers, msg)
 			continue
 		}
 
 		// Make sure the peer was fully sent all prior messages before
 		// blocking.
-		if err := writer.Flush(); err != nil {
-			p.Log.Verbo("failed to flush writer",
-				zap.Stringer("nodeID", p.id),
-				zap.Error(err),
-			)
-			return
+		for _, writer := range writers {
+			if err := writer.Flush(); err != nil {
+				p.Log.Verbo("failed to flush writer",
+					zap.Stringer("nodeID", p.id),
+					zap.Error(err),
+				)
+				return
+			}
 		}
 
 		msg, ok = p.messageQueue.Pop()
@@ -552,11 +640,12 @@ func (p *Peer) writeMessages() {
 			return
 		}
 
-		p.writeMessage(writer, msg)
+		p.writeMessage(writers, msg)
 	}
 }
 
-func (p *Peer) writeMessage(writer io.Writer, msg *message.OutboundMessage) {
+// writeMessage writes [msg] to the writer of the stream it is sent on.
+func (p *Peer) writeMessage(writers []*bufio.Writer, msg *message.OutboundMessage) {
 	msgBytes := msg.Bytes
 	p.Log.Verbo("sending message",
 		zap.Stringer("op", msg.Op),
@@ -564,7 +653,9 @@ func (p *Peer) writeMessage(writer io.Writer, msg *message.OutboundMessage) {
 		zap.Binary("messageBytes", msgBytes),
 	)
 
-	if err := p.conn.SetWriteDeadline(p.nextTimeout()); err != nil {
+	index := streamIndex(msg.Op, len(p.streams))
+	writer := writers[index]
+	if err := p.streams[index].SetWriteDeadline(p.nextTimeout()); err != nil {
 		p.Log.Verbo(failedToSetDeadlineLog,
 			zap.Stringer("nodeID", p.id),
 			zap.String("direction", "write"),
@@ -600,6 +691,7 @@ func (p *Peer) writeMessage(writer io.Writer, msg *message.OutboundMessage) {
 	now := p.Clock.Time()
 	p.storeLastSent(now)
 	p.Metrics.Sent(msg)
+	p.bandwidth.Sent(msg.ChainID, msg.Op, len(msgBytes))
 }
 
 func (p *Peer) sendNetworkMessages() {
@@ -757,6 +849,7 @@ func (p *Peer) handlePing(msg *p2p.Ping) {
 			zap.Stringer("subnetID", constants.PrimaryNetworkID),
 			zap.Uint32("uptime", msg.Uptime),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -801,6 +894,7 @@ func (p *Peer) handlePong(*p2p.Pong) {
 			zap.Stringer("messageOp", message.PongOp),
 			zap.String("reason", "received unexpected pong"),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -818,6 +912,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.Stringer("messageOp", message.HandshakeOp),
 			zap.String("reason", "already received handshake"),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -830,6 +925,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.Uint32("peerNetworkID", msg.NetworkId),
 			zap.Uint32("ourNetworkID", p.NetworkID),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -886,6 +982,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.String("field", "trackedSubnets"),
 			zap.Int("numTrackedSubnets", numTrackedSubnets),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -900,6 +997,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 				zap.String("field", "trackedSubnets"),
 				zap.Error(err),
 			)
+			p.Network.InvalidMessage(p.id)
 			p.StartClose()
 			return
 		}
@@ -925,6 +1023,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.Reflect("supportedACPs", p.supportedACPs),
 			zap.Reflect("objectedACPs", p.objectedACPs),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -943,6 +1042,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 				zap.String("field", "knownPeers.filter"),
 				zap.Error(err),
 			)
+			p.Network.InvalidMessage(p.id)
 			p.StartClose()
 			return
 		}
@@ -955,6 +1055,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 				zap.String("field", "knownPeers.salt"),
 				zap.Int("saltLen", saltLen),
 			)
+			p.Network.InvalidMessage(p.id)
 			p.StartClose()
 			return
 		}
@@ -968,6 +1069,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.String("field", "ip"),
 			zap.Int("ipLen", len(msg.IpAddr)),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -980,9 +1082,23 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.String("field", "port"),
 			zap.Uint16("port", port),
 		)
+		p.Network.InvalidMessage(p.id)
+		p.StartClose()
+		return
+	}
+
+	if msg.QuicPort > math.MaxUint16 {
+		p.Log.Debug(malformedMessageLog,
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.HandshakeOp),
+			zap.String("field", "quicPort"),
+			zap.Uint32("quicPort", msg.QuicPort),
+		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
+	p.quicPort = uint16(msg.QuicPort)
 
 	p.ip = &SignedIP{
 		UnsignedIP: UnsignedIP{
@@ -1021,6 +1137,7 @@ func (p *Peer) handleHandshake(msg *p2p.Handshake) {
 			zap.String("field", "blsSignature"),
 			zap.Error(err),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -1072,6 +1189,7 @@ func (p *Peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.Stringer("messageOp", message.GetPeerListOp),
 			zap.String("reason", "not finished handshake"),
 		)
+		p.Network.InvalidMessage(p.id)
 		return
 	}
 
@@ -1084,6 +1202,7 @@ func (p *Peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.String("field", "knownPeers.filter"),
 			zap.Error(err),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -1096,6 +1215,7 @@ func (p *Peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.String("field", "knownPeers.salt"),
 			zap.Int("saltLen", saltLen),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 		return
 	}
@@ -1144,6 +1264,7 @@ func (p *Peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "cert"),
 				zap.Error(err),
 			)
+			p.Network.InvalidMessage(p.id)
 			p.StartClose()
 			return
 		}
@@ -1156,6 +1277,7 @@ func (p *Peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "ip"),
 				zap.Int("ipLen", len(claimedIPPort.IpAddr)),
 			)
+			p.Network.InvalidMessage(p.id)
 			p.StartClose()
 			return
 		}
@@ -1168,6 +1290,7 @@ func (p *Peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "port"),
 				zap.Uint16("port", port),
 			)
+			p.Network.InvalidMessage(p.id)
 			p.StartClose()
 			return
 		}
@@ -1190,6 +1313,7 @@ func (p *Peer) handlePeerList(msg *p2p.PeerList) {
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
+		p.Network.InvalidMessage(p.id)
 		p.StartClose()
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"crypto"
 	"net"
 	"net/netip"
+	"slices"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
 	"github.com/ava-labs/avalanchego/utils/resource"
@@ -83,7 +85,7 @@ func newConfig(t *testing.T) *Config {
 		Network:              TestNetwork,
 		Router:               nil,
 		VersionCompatibility: version.GetCompatibility(upgrade.InitiallyActiveTime),
-		MySubnets:            nil,
+		MySubnets:            utils.NewAtomic[set.Set[ids.ID]](nil),
 		Beacons:              validators.NewManager(),
 		Validators:           validators.NewManager(),
 		NetworkID:            constants.LocalID,
@@ -205,7 +207,8 @@ func TestSend(t *testing.T) {
 	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
 	awaitReady(t, peer0, peer1)
 
-	outboundGetMsg, err := config0.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	chainID := ids.GenerateTestID()
+	outboundGetMsg, err := config0.MessageCreator.Get(chainID, 1, time.Second, ids.Empty)
 	require.NoError(err)
 
 	require.True(peer0.Send(t.Context(), outboundGetMsg))
@@ -213,6 +216,23 @@ func TestSend(t *testing.T) {
 	inboundGetMsg := <-peer1.inboundMsgChan
 	require.Equal(message.GetOp, inboundGetMsg.Op)
 
+	// The message is accounted to the chain it was sent for.
+	numBytes := json.Uint64(len(outboundGetMsg.Bytes))
+	require.Contains(peer1.Info().Bandwidth.Usage, OpBandwidth{
+		ChainID:          chainID,
+		Op:               message.GetOp.String(),
+		MessagesReceived: 1,
+		ReceivedBytes:    numBytes,
+	})
+	require.Eventually(func() bool {
+		return slices.Contains(peer0.Info().Bandwidth.Usage, OpBandwidth{
+			ChainID:      chainID,
+			Op:           message.GetOp.String(),
+			MessagesSent: 1,
+			SentBytes:    numBytes,
+		})
+	}, time.Minute, time.Millisecond)
+
 	peer1.StartClose()
 	require.NoError(peer0.AwaitClosed(t.Context()))
 	require.NoError(peer1.AwaitClosed(t.Context()))
@@ -294,7 +314,7 @@ func TestTrackedSubnets(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			rawPeer0.config.MySubnets = set.Of(test.trackedSubnets...)
+			rawPeer0.config.MySubnets = utils.NewAtomic(set.Of(test.trackedSubnets...))
 			peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
 			if test.shouldDisconnect {
 				require.NoError(peer0.AwaitClosed(t.Context()))
@@ -0,0 +1,46 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"net"
+
+	"github.com/ava-labs/avalanchego/message"
+)
+
+// NumStreams is the number of streams of a [MultiStreamConn].
+const NumStreams = numClasses
+
+// MultiStreamConn is a connection that carries independent streams, such as a
+// QUIC connection. Each class of messages is sent on its own stream so that a
+// stalled stream of bootstrapping messages does not delay consensus messages.
+//
+// Closing the connection must close all of its streams.
+type MultiStreamConn interface {
+	net.Conn
+
+	// Streams returns the [NumStreams] streams of the connection. The first
+	// stream carries the network messages, including the handshake.
+	Streams() []net.Conn
+}
+
+// connStreams returns the streams that messages are read from and written to
+// over [conn].
+func connStreams(conn net.Conn) []net.Conn {
+	if conn, ok := conn.(MultiStreamConn); ok {
+		if streams := conn.Streams(); len(streams) == NumStreams {
+			return streams
+		}
+	}
+	return []net.Conn{conn}
+}
+
+// streamIndex returns the index of the stream that messages of [op] are sent
+// on when there are [numStreams] streams.
+func streamIndex(op message.Op, numStreams int) int {
+	if numStreams != NumStreams {
+		return 0
+	}
+	return opClass(op)
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var _ MultiStreamConn = (*testStreamsConn)(nil)
+
+type testStreamsConn struct {
+	net.Conn
+	streams []net.Conn
+}
+
+// newTestStreamsConns returns both ends of a connection with [NumStreams]
+// in-memory streams.
+func newTestStreamsConns() (*testStreamsConn, *testStreamsConn) {
+	var (
+		conn0 = &testStreamsConn{streams: make([]net.Conn, NumStreams)}
+		conn1 = &testStreamsConn{streams: make([]net.Conn, NumStreams)}
+	)
+	for i := range NumStreams {
+		conn0.streams[i], conn1.streams[i] = net.Pipe()
+	}
+	conn0.Conn = conn0.streams[0]
+	conn1.Conn = conn1.streams[0]
+	return conn0, conn1
+}
+
+func (c *testStreamsConn) Streams() []net.Conn {
+	return c.streams
+}
+
+func (c *testStreamsConn) Close() error {
+	var errs []error
+	for _, stream := range c.streams {
+		errs = append(errs, stream.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func TestStreamIndex(t *testing.T) {
+	tests := []struct {
+		op         message.Op
+		numStreams int
+		want       int
+	}{
+		{
+			op:         message.PingOp,
+			numStreams: NumStreams,
+			want:       consensusClass,
+		},
+		{
+			op:         message.HandshakeOp,
+			numStreams: NumStreams,
+			want:       consensusClass,
+		},
+		{
+			op:         message.GetAncestorsOp,
+			numStreams: NumStreams,
+			want:       bootstrapClass,
+		},
+		{
+			op:         message.AppGossipOp,
+			numStreams: NumStreams,
+			want:       appClass,
+		},
+		{
+			op:         message.AppGossipOp,
+			numStreams: 1,
+			want:       0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.op.String(), func(t *testing.T) {
+			require.Equal(t, test.want, streamIndex(test.op, test.numStreams))
+		})
+	}
+}
+
+func TestMultiStreamConn(t *testing.T) {
+	require := require.New(t)
+
+	config0 := newConfig(t)
+	config1 := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, config0)
+	rawPeer1 := newRawTestPeer(t, config1)
+
+	conn0, conn1 := newTestStreamsConns()
+	peer0 := startTestPeer(rawPeer0, rawPeer1, conn0)
+	peer1 := startTestPeer(rawPeer1, rawPeer0, conn1)
+	awaitReady(t, peer0, peer1)
+
+	chainID := ids.GenerateTestID()
+	getMsg, err := config0.MessageCreator.Get(chainID, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	getAncestorsMsg, err := config0.MessageCreator.GetAncestors(chainID, 2, time.Second, ids.Empty, p2p.EngineType_ENGINE_TYPE_UNSPECIFIED)
+	require.NoError(err)
+	appGossipMsg, err := config0.MessageCreator.AppGossip(chainID, []byte{1})
+	require.NoError(err)
+
+	// Messages of every class are delivered over their stream.
+	for _, msg := range []*message.OutboundMessage{getMsg, getAncestorsMsg, appGossipMsg} {
+		require.True(peer0.Send(t.Context(), msg))
+
+		inboundMsg := <-peer1.inboundMsgChan
+		require.Equal(msg.Op, inboundMsg.Op)
+	}
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(t.Context()))
+	require.NoError(peer1.AwaitClosed(t.Context()))
+}
+
+func TestMultiStreamConnDisconnectsOnMisroutedMessage(t *testing.T) {
+	require := require.New(t)
+
+	config0 := newConfig(t)
+	config1 := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, config0)
+	rawPeer1 := newRawTestPeer(t, config1)
+
+	conn0, conn1 := newTestStreamsConns()
+	peer0 := startTestPeer(rawPeer0, rawPeer1, conn0)
+	peer1 := startTestPeer(rawPeer1, rawPeer0, conn1)
+	awaitReady(t, peer0, peer1)
+
+	// Network messages may only be sent on the first stream.
+	pingMsg, err := config0.MessageCreator.Ping(0)
+	require.NoError(err)
+	msgLenBytes, err := writeMsgLen(uint32(len(pingMsg.Bytes)), constants.DefaultMaxMessageSize)
+	require.NoError(err)
+	_, err = conn0.streams[appClass].Write(append(msgLenBytes[:], pingMsg.Bytes...))
+	require.NoError(err)
+
+	require.NoError(peer1.AwaitClosed(t.Context()))
+	require.NoError(peer0.AwaitClosed(t.Context()))
+}
@@ -26,6 +26,8 @@ func (testNetwork) Track([]*ips.ClaimedIPPort) error {
 
 func (testNetwork) Disconnected(ids.NodeID) {}
 
+func (testNetwork) InvalidMessage(ids.NodeID) {}
+
 func (testNetwork) KnownPeers() ([]byte, []byte) {
 	return bloom.EmptyFilter.Marshal(), nil
 }
@@ -114,7 +114,7 @@ func StartTestPeer(
 			Network:              TestNetwork,
 			Router:               router,
 			VersionCompatibility: version.GetCompatibility(upgrade.InitiallyActiveTime),
-			MySubnets:            set.Set[ids.ID]{},
+			MySubnets:            utils.NewAtomic(set.Set[ids.ID]{}),
 			Beacons:              validators.NewManager(),
 			Validators:           validators.NewManager(),
 			NetworkID:            networkID,
@@ -0,0 +1,140 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+var (
+	errNotUpgraded = errors.New("connection has not been upgraded")
+
+	_ net.Conn             = (*conn)(nil)
+	_ peer.MultiStreamConn = (*streamsConn)(nil)
+	_ net.Conn             = (*stream)(nil)
+)
+
+// conn is a QUIC connection that has not been upgraded yet. It does not carry
+// any streams, so reading from and writing to it fails.
+type conn struct {
+	conn *quic.Conn
+
+	lock sync.Mutex
+	// deadline is the read deadline, which bounds the time the upgrade may
+	// take to open the streams.
+	deadline time.Time
+}
+
+func (*conn) Read([]byte) (int, error) {
+	return 0, errNotUpgraded
+}
+
+func (*conn) Write([]byte) (int, error) {
+	return 0, errNotUpgraded
+}
+
+func (c *conn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
+
+func (c *conn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.deadline = t
+	return nil
+}
+
+func (*conn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// context returns a context that is canceled at the read deadline.
+func (c *conn) context() (context.Context, context.CancelFunc) {
+	c.lock.Lock()
+	deadline := c.deadline
+	c.lock.Unlock()
+
+	if deadline.IsZero() {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithDeadline(context.Background(), deadline)
+}
+
+// streamsConn is an upgraded QUIC connection. Reads, writes, and deadlines of
+// the connection itself apply to its first stream.
+type streamsConn struct {
+	conn    *quic.Conn
+	streams []net.Conn
+}
+
+func (c *streamsConn) Streams() []net.Conn {
+	return c.streams
+}
+
+func (c *streamsConn) Read(b []byte) (int, error) {
+	return c.streams[0].Read(b)
+}
+
+func (c *streamsConn) Write(b []byte) (int, error) {
+	return c.streams[0].Write(b)
+}
+
+func (c *streamsConn) Close() error {
+	return c.conn.CloseWithError(0, "")
+}
+
+func (c *streamsConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *streamsConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *streamsConn) SetDeadline(t time.Time) error {
+	return c.streams[0].SetDeadline(t)
+}
+
+func (c *streamsConn) SetReadDeadline(t time.Time) error {
+	return c.streams[0].SetReadDeadline(t)
+}
+
+func (c *streamsConn) SetWriteDeadline(t time.Time) error {
+	return c.streams[0].SetWriteDeadline(t)
+}
+
+// stream is a bidirectional stream of a QUIC connection.
+type stream struct {
+	*quic.Stream
+	conn *quic.Conn
+}
+
+func (s *stream) LocalAddr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+func (s *stream) RemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// NextProto is the ALPN protocol negotiated by QUIC connections between peers.
+const NextProto = "avalanche-p2p"
+
+// keepAlivePeriod is shorter than the default idle timeout so that idle
+// connections are not closed between pings.
+const keepAlivePeriod = 10 * time.Second
+
+var (
+	_ net.Listener  = (*listener)(nil)
+	_ dialer.Dialer = (*quicDialer)(nil)
+
+	config = &quic.Config{
+		MaxIncomingStreams:    peer.NumStreams,
+		MaxIncomingUniStreams: -1,
+		KeepAlivePeriod:       keepAlivePeriod,
+	}
+)
+
+// Transport accepts and dials QUIC connections over a packet connection. The
+// connections are authenticated with the same TLS certificates as TCP
+// connections.
+type Transport struct {
+	transport *quic.Transport
+	tlsConfig *tls.Config
+}
+
+// NewTransport returns a new Transport over [conn]. Closing the transport does
+// not close [conn].
+func NewTransport(conn net.PacketConn, tlsConfig *tls.Config) *Transport {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{NextProto}
+	return &Transport{
+		transport: &quic.Transport{Conn: conn},
+		tlsConfig: tlsConfig,
+	}
+}
+
+// Listen returns a listener that accepts QUIC connections. The returned
+// connections must be upgraded with [NewServerUpgrader].
+func (t *Transport) Listen() (net.Listener, error) {
+	l, err := t.transport.Listen(t.tlsConfig, config)
+	if err != nil {
+		return nil, err
+	}
+	return &listener{listener: l}, nil
+}
+
+// NewDialer returns a dialer that dials QUIC connections. The returned
+// connections must be upgraded with [NewClientUpgrader].
+func (t *Transport) NewDialer(dialerConfig dialer.Config, log logging.Logger) dialer.Dialer {
+	var throttler throttling.DialThrottler
+	if dialerConfig.ThrottleRps <= 0 {
+		throttler = throttling.NewNoDialThrottler()
+	} else {
+		throttler = throttling.NewDialThrottler(int(dialerConfig.ThrottleRps))
+	}
+	return &quicDialer{
+		transport: t,
+		timeout:   dialerConfig.ConnectionTimeout,
+		log:       log,
+		throttler: throttler,
+	}
+}
+
+// Close closes the listener and all connections of the transport.
+func (t *Transport) Close() error {
+	return t.transport.Close()
+}
+
+type listener struct {
+	listener *quic.Listener
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.listener.Accept(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &conn{conn: c}, nil
+}
+
+func (l *listener) Close() error {
+	return l.listener.Close()
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+type quicDialer struct {
+	transport *Transport
+	timeout   time.Duration
+	log       logging.Logger
+	throttler throttling.DialThrottler
+}
+
+func (d *quicDialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
+	if err := d.throttler.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	d.log.Verbo("dialing",
+		zap.String("transport", "quic"),
+		zap.Stringer("ip", ip),
+	)
+
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+	c, err := d.transport.transport.Dial(
+		ctx,
+		net.UDPAddrFromAddrPort(ip),
+		d.transport.tlsConfig,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+	}
+	return &conn{conn: c}, nil
+}
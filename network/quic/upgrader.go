@@ -0,0 +1,150 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quic-go/quic-go"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+var (
+	errNotQUICConn   = errors.New("not a QUIC connection")
+	errNoCert        = errors.New("quic handshake finished with no peer certificate")
+	errInvalidStream = errors.New("invalid stream")
+
+	_ peer.Upgrader = (*serverUpgrader)(nil)
+	_ peer.Upgrader = (*clientUpgrader)(nil)
+)
+
+type serverUpgrader struct {
+	invalidCerts prometheus.Counter
+}
+
+// NewServerUpgrader returns an upgrader of the connections accepted by
+// [Transport.Listen]. The upgraded connections carry [peer.NumStreams]
+// streams opened by the dialer.
+func NewServerUpgrader(invalidCerts prometheus.Counter) peer.Upgrader {
+	return &serverUpgrader{
+		invalidCerts: invalidCerts,
+	}
+}
+
+func (u *serverUpgrader) Upgrade(netConn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	c, ok := netConn.(*conn)
+	if !ok {
+		return ids.EmptyNodeID, nil, nil, errNotQUICConn
+	}
+
+	nodeID, cert, err := peerCert(c.conn, u.invalidCerts)
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	streams := make([]net.Conn, peer.NumStreams)
+	for range streams {
+		s, err := c.conn.AcceptStream(ctx)
+		if err != nil {
+			return ids.EmptyNodeID, nil, nil, err
+		}
+
+		// The dialer identifies each stream by writing its index.
+		if err := s.SetReadDeadline(deadline); err != nil {
+			return ids.EmptyNodeID, nil, nil, err
+		}
+		var index [1]byte
+		if _, err := io.ReadFull(s, index[:]); err != nil {
+			return ids.EmptyNodeID, nil, nil, err
+		}
+		if err := s.SetReadDeadline(time.Time{}); err != nil {
+			return ids.EmptyNodeID, nil, nil, err
+		}
+
+		i := int(index[0])
+		if i >= len(streams) || streams[i] != nil {
+			return ids.EmptyNodeID, nil, nil, fmt.Errorf("%w: %d", errInvalidStream, i)
+		}
+		streams[i] = &stream{
+			Stream: s,
+			conn:   c.conn,
+		}
+	}
+	return nodeID, &streamsConn{conn: c.conn, streams: streams}, cert, nil
+}
+
+type clientUpgrader struct {
+	invalidCerts prometheus.Counter
+}
+
+// NewClientUpgrader returns an upgrader of the connections dialed by the
+// dialers of a [Transport]. The upgrader opens the [peer.NumStreams] streams of
+// the connection.
+func NewClientUpgrader(invalidCerts prometheus.Counter) peer.Upgrader {
+	return &clientUpgrader{
+		invalidCerts: invalidCerts,
+	}
+}
+
+func (u *clientUpgrader) Upgrade(netConn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	c, ok := netConn.(*conn)
+	if !ok {
+		return ids.EmptyNodeID, nil, nil, errNotQUICConn
+	}
+
+	nodeID, cert, err := peerCert(c.conn, u.invalidCerts)
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	streams := make([]net.Conn, peer.NumStreams)
+	for i := range streams {
+		s, err := c.conn.OpenStreamSync(ctx)
+		if err != nil {
+			return ids.EmptyNodeID, nil, nil, err
+		}
+
+		// A stream is only announced to the peer once data is sent on it.
+		if _, err := s.Write([]byte{byte(i)}); err != nil {
+			return ids.EmptyNodeID, nil, nil, err
+		}
+		streams[i] = &stream{
+			Stream: s,
+			conn:   c.conn,
+		}
+	}
+	return nodeID, &streamsConn{conn: c.conn, streams: streams}, cert, nil
+}
+
+// peerCert returns the certificate that the peer authenticated the QUIC
+// handshake with.
+func peerCert(c *quic.Conn, invalidCerts prometheus.Counter) (ids.NodeID, *staking.Certificate, error) {
+	state := c.ConnectionState().TLS
+	if len(state.PeerCertificates) == 0 {
+		return ids.EmptyNodeID, nil, errNoCert
+	}
+
+	tlsCert := state.PeerCertificates[0]
+	cert, err := staking.ParseCertificate(tlsCert.Raw)
+	if err != nil {
+		invalidCerts.Inc()
+		return ids.EmptyNodeID, nil, err
+	}
+	return ids.NodeIDFromCert(cert), cert, nil
+}
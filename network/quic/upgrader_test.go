@@ -0,0 +1,128 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"io"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quic-go/quic-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestTransport(t *testing.T) (*Transport, ids.NodeID) {
+	t.Helper()
+	require := require.New(t)
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(err)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+
+	transport := NewTransport(udpConn, peer.TLSConfig(*tlsCert, nil))
+	t.Cleanup(func() {
+		require.NoError(transport.Close())
+		require.NoError(udpConn.Close())
+	})
+	return transport, ids.NodeIDFromCert(cert)
+}
+
+type upgradeResult struct {
+	nodeID ids.NodeID
+	conn   net.Conn
+	err    error
+}
+
+func TestUpgrade(t *testing.T) {
+	require := require.New(t)
+
+	server, serverNodeID := newTestTransport(t)
+	client, clientNodeID := newTestTransport(t)
+
+	listener, err := server.Listen()
+	require.NoError(err)
+
+	serverResults := make(chan upgradeResult, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverResults <- upgradeResult{err: err}
+			return
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+			serverResults <- upgradeResult{err: err}
+			return
+		}
+
+		upgrader := NewServerUpgrader(prometheus.NewCounter(prometheus.CounterOpts{}))
+		nodeID, conn, _, err := upgrader.Upgrade(conn)
+		serverResults <- upgradeResult{
+			nodeID: nodeID,
+			conn:   conn,
+			err:    err,
+		}
+	}()
+
+	serverIP, err := ips.ParseAddrPort(listener.Addr().String())
+	require.NoError(err)
+
+	d := client.NewDialer(dialer.Config{ConnectionTimeout: 10 * time.Second}, logging.NoLog{})
+	clientConn, err := d.Dial(t.Context(), netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), serverIP.Port()))
+	require.NoError(err)
+
+	upgrader := NewClientUpgrader(prometheus.NewCounter(prometheus.CounterOpts{}))
+	nodeID, clientConn, cert, err := upgrader.Upgrade(clientConn)
+	require.NoError(err)
+	require.Equal(serverNodeID, nodeID)
+	require.Equal(serverNodeID, ids.NodeIDFromCert(cert))
+
+	serverResult := <-serverResults
+	require.NoError(serverResult.err)
+	require.Equal(clientNodeID, serverResult.nodeID)
+
+	clientStreams := clientConn.(peer.MultiStreamConn).Streams()
+	serverStreams := serverResult.conn.(peer.MultiStreamConn).Streams()
+	require.Len(clientStreams, peer.NumStreams)
+	require.Len(serverStreams, peer.NumStreams)
+
+	// Each stream must be delivered to the stream with the same index.
+	for i := range clientStreams {
+		_, err := clientStreams[i].Write([]byte{byte(i)})
+		require.NoError(err)
+	}
+	for i := range serverStreams {
+		var b [1]byte
+		_, err := io.ReadFull(serverStreams[i], b[:])
+		require.NoError(err)
+		require.Equal(byte(i), b[0])
+	}
+
+	require.NoError(clientConn.Close())
+	_, err = serverStreams[0].Read(make([]byte, 1))
+	var appErr *quic.ApplicationError
+	require.ErrorAs(err, &appErr)
+}
+
+func TestUpgradeNotQUICConn(t *testing.T) {
+	require := require.New(t)
+
+	conn, _ := net.Pipe()
+	upgrader := NewServerUpgrader(prometheus.NewCounter(prometheus.CounterOpts{}))
+	_, _, _, err := upgrader.Upgrade(conn)
+	require.ErrorIs(err, errNotQUICConn)
+}
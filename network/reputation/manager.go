@@ -0,0 +1,682 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/linked"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	responseReward        = .1
+	slowResponsePenalty   = .5
+	failurePenalty        = 1
+	invalidMessagePenalty = 10
+
+	// Scores that decayed to within minStoredScore of 0 are forgotten.
+	minStoredScore = .01
+
+	// At most maxScores scores are kept, so that peers can't exhaust the memory
+	// of the node by misbehaving from new node IDs. Once the limit is reached,
+	// the least recently updated score, which has decayed the most, is
+	// forgotten.
+	maxScores = 16384
+
+	scoreLen = 2 * wrappers.LongLen
+)
+
+var (
+	_ Manager = (*manager)(nil)
+
+	scorePrefix     = []byte("score")
+	nodeBanPrefix   = []byte("nodeBan")
+	nodeAllowPrefix = []byte("nodeAllow")
+	ipBanPrefix     = []byte("ipBan")
+	ipAllowPrefix   = []byte("ipAllow")
+)
+
+type score struct {
+	value   float64
+	updated time.Time
+}
+
+type manager struct {
+	log     logging.Logger
+	vdrs    validators.Manager
+	beacons validators.Manager
+	config  Config
+	clock   mockable.Clock
+
+	scoreDB     database.Database
+	nodeBanDB   database.Database
+	nodeAllowDB database.Database
+	ipBanDB     database.Database
+	ipAllowDB   database.Database
+
+	lock sync.RWMutex
+	// scores are ordered from the least to the most recently updated.
+	scores *linked.Hashmap[ids.NodeID, score]
+	// dirty contains the nodes whose scores changed since the last flush.
+	dirty set.Set[ids.NodeID]
+	// Bans map to the time that they expire, or to the zero time if they
+	// don't expire.
+	bannedNodes  map[ids.NodeID]time.Time
+	allowedNodes set.Set[ids.NodeID]
+	bannedIPs    map[netip.Prefix]time.Time
+	allowedIPs   set.Set[netip.Prefix]
+	onChange     []func()
+}
+
+// NewManager returns a manager that persists the reputation of peers in [db].
+// The primary network stake of [vdrs] bounds the automatic bans of validators,
+// and [beacons] are never banned automatically.
+func NewManager(
+	log logging.Logger,
+	db database.Database,
+	vdrs validators.Manager,
+	beacons validators.Manager,
+	config Config,
+) (Manager, error) {
+	m := &manager{
+		log:          log,
+		vdrs:         vdrs,
+		beacons:      beacons,
+		config:       config,
+		scoreDB:      prefixdb.New(scorePrefix, db),
+		nodeBanDB:    prefixdb.New(nodeBanPrefix, db),
+		nodeAllowDB:  prefixdb.New(nodeAllowPrefix, db),
+		ipBanDB:      prefixdb.New(ipBanPrefix, db),
+		ipAllowDB:    prefixdb.New(ipAllowPrefix, db),
+		scores:       linked.NewHashmap[ids.NodeID, score](),
+		bannedNodes:  make(map[ids.NodeID]time.Time),
+		allowedNodes: set.NewSet[ids.NodeID](0),
+		bannedIPs:    make(map[netip.Prefix]time.Time),
+		allowedIPs:   set.NewSet[netip.Prefix](0),
+	}
+	return m, m.load()
+}
+
+func (m *manager) load() error {
+	type nodeScore struct {
+		nodeID ids.NodeID
+		score  score
+	}
+	var scores []nodeScore
+	err := forEach(m.scoreDB, func(key, value []byte) error {
+		nodeID, err := ids.ToNodeID(key)
+		if err != nil {
+			return err
+		}
+		if len(value) != scoreLen {
+			return fmt.Errorf("expected %d bytes but got %d", scoreLen, len(value))
+		}
+		scores = append(scores, nodeScore{
+			nodeID: nodeID,
+			score: score{
+				value:   math.Float64frombits(binary.BigEndian.Uint64(value)),
+				updated: time.Unix(int64(binary.BigEndian.Uint64(value[wrappers.LongLen:])), 0),
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load scores: %w", err)
+	}
+	slices.SortFunc(scores, func(a, b nodeScore) int {
+		return a.score.updated.Compare(b.score.updated)
+	})
+	for _, s := range scores {
+		m.scores.Put(s.nodeID, s.score)
+	}
+	for m.scores.Len() > maxScores {
+		nodeID, _, _ := m.scores.Oldest()
+		m.scores.Delete(nodeID)
+		m.dirty.Add(nodeID)
+	}
+
+	err = forEach(m.nodeBanDB, func(key, value []byte) error {
+		nodeID, err := ids.ToNodeID(key)
+		if err != nil {
+			return err
+		}
+		expiry, err := parseExpiry(value)
+		m.bannedNodes[nodeID] = expiry
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load banned nodes: %w", err)
+	}
+
+	err = forEach(m.nodeAllowDB, func(key, _ []byte) error {
+		nodeID, err := ids.ToNodeID(key)
+		m.allowedNodes.Add(nodeID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load allowed nodes: %w", err)
+	}
+
+	err = forEach(m.ipBanDB, func(key, value []byte) error {
+		prefix, err := netip.ParsePrefix(string(key))
+		if err != nil {
+			return err
+		}
+		expiry, err := parseExpiry(value)
+		m.bannedIPs[prefix] = expiry
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load banned IPs: %w", err)
+	}
+
+	err = forEach(m.ipAllowDB, func(key, _ []byte) error {
+		prefix, err := netip.ParsePrefix(string(key))
+		m.allowedIPs.Add(prefix)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load allowed IPs: %w", err)
+	}
+	return nil
+}
+
+func (m *manager) RegisterResponse(nodeID ids.NodeID, latency time.Duration) {
+	if latency > m.config.SlowResponseLatency {
+		m.updateScore(nodeID, -slowResponsePenalty, "slow responses")
+		return
+	}
+	m.updateScore(nodeID, responseReward, "")
+}
+
+func (m *manager) RegisterFailure(nodeID ids.NodeID) {
+	m.updateScore(nodeID, -failurePenalty, "failed requests")
+}
+
+func (m *manager) RegisterInvalidMessage(nodeID ids.NodeID) {
+	m.updateScore(nodeID, -invalidMessagePenalty, "invalid messages")
+}
+
+// updateScore adds [change] to the score of [nodeID] and, if automatic bans are
+// enabled, bans [nodeID] if its score dropped to the ban score. [reason]
+// describes the cause of the change.
+func (m *manager) updateScore(nodeID ids.NodeID, change float64, reason string) {
+	m.lock.Lock()
+	now := m.clock.Time()
+	s, ok := m.scores.Get(nodeID)
+	if !ok && m.scores.Len() >= maxScores {
+		m.evictOldestScore()
+	}
+	value := m.decayedScore(s, now) + change
+	value = min(max(value, minScore), maxScore)
+	m.scores.Put(nodeID, score{
+		value:   value,
+		updated: now,
+	})
+	m.dirty.Add(nodeID)
+
+	if !m.config.AutoBanEnabled ||
+		value > m.config.BanScore ||
+		m.allowedNodes.Contains(nodeID) ||
+		m.isNodeBanned(nodeID, now) ||
+		!m.canAutoBan(nodeID, now) {
+		m.lock.Unlock()
+		return
+	}
+
+	expiry := now.Add(m.config.BanDuration)
+	err := m.banNode(nodeID, expiry)
+	onChange := m.onChange
+	m.lock.Unlock()
+
+	if err != nil {
+		m.log.Error("failed to persist node ban",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+	m.log.Info("banning node",
+		zap.Stringer("nodeID", nodeID),
+		zap.String("reason", reason),
+		zap.Float64("score", value),
+		zap.Time("until", expiry),
+	)
+	for _, f := range onChange {
+		f()
+	}
+}
+
+// evictOldestScore forgets the least recently updated score. The score is
+// removed from disk immediately so that the nodes pending a flush remain bounded
+// by maxScores. Assumes the lock is held.
+func (m *manager) evictOldestScore() {
+	nodeID, _, _ := m.scores.Oldest()
+	m.scores.Delete(nodeID)
+	m.dirty.Remove(nodeID)
+	if err := m.scoreDB.Delete(nodeID.Bytes()); err != nil {
+		m.log.Error("failed to delete evicted score",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// canAutoBan returns true if [nodeID] may be banned automatically at [now].
+// Beacons are never banned automatically, and validators are only banned if the
+// stake of the banned validators would remain within MaxBannedPortion of the
+// primary network stake. Assumes the lock is held.
+func (m *manager) canAutoBan(nodeID ids.NodeID, now time.Time) bool {
+	if _, ok := m.beacons.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+		m.log.Debug("not banning node",
+			zap.Stringer("nodeID", nodeID),
+			zap.String("reason", "node is a beacon"),
+		)
+		return false
+	}
+	if m.vdrs.GetWeight(constants.PrimaryNetworkID, nodeID) == 0 {
+		return true
+	}
+
+	bannedNodeIDs := set.NewSet[ids.NodeID](len(m.bannedNodes) + 1)
+	for bannedNodeID, expiry := range m.bannedNodes {
+		if !isExpired(expiry, now) && !m.allowedNodes.Contains(bannedNodeID) {
+			bannedNodeIDs.Add(bannedNodeID)
+		}
+	}
+	bannedNodeIDs.Add(nodeID)
+
+	bannedStake, err := m.vdrs.SubsetWeight(constants.PrimaryNetworkID, bannedNodeIDs)
+	if err != nil {
+		m.log.Error("error calculating banned stake",
+			zap.Error(err),
+		)
+		return false
+	}
+	totalStake, err := m.vdrs.TotalWeight(constants.PrimaryNetworkID)
+	if err != nil {
+		m.log.Error("error calculating total stake",
+			zap.Error(err),
+		)
+		return false
+	}
+	if float64(bannedStake) > float64(totalStake)*m.config.MaxBannedPortion {
+		m.log.Debug("not banning node",
+			zap.Stringer("nodeID", nodeID),
+			zap.String("reason", "banned stake would exceed the max portion"),
+			zap.Uint64("bannedStake", bannedStake),
+			zap.Uint64("totalStake", totalStake),
+		)
+		return false
+	}
+	return true
+}
+
+// decayedScore returns the value of [s] at [now].
+func (m *manager) decayedScore(s score, now time.Time) float64 {
+	elapsed := now.Sub(s.updated)
+	if elapsed <= 0 {
+		return s.value
+	}
+	return s.value * math.Exp2(-float64(elapsed)/float64(m.config.Halflife))
+}
+
+func (m *manager) IsNodeAllowed(nodeID ids.NodeID) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.allowedNodes.Contains(nodeID) || !m.isNodeBanned(nodeID, m.clock.Time())
+}
+
+func (m *manager) IsIPAllowed(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for prefix := range m.allowedIPs {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	now := m.clock.Time()
+	for prefix, expiry := range m.bannedIPs {
+		if prefix.Contains(ip) && !isExpired(expiry, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *manager) BanNode(nodeID ids.NodeID, duration time.Duration) error {
+	var expiry time.Time
+	if duration > 0 {
+		expiry = m.clock.Time().Add(duration)
+	}
+
+	m.lock.Lock()
+	if err := m.banNode(nodeID, expiry); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) UnbanNode(nodeID ids.NodeID) error {
+	m.lock.Lock()
+	if !m.isNodeBanned(nodeID, m.clock.Time()) {
+		m.lock.Unlock()
+		return ErrNotBanned
+	}
+
+	delete(m.bannedNodes, nodeID)
+	m.scores.Delete(nodeID)
+	m.dirty.Add(nodeID)
+	if err := m.nodeBanDB.Delete(nodeID.Bytes()); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) AllowNode(nodeID ids.NodeID) error {
+	m.lock.Lock()
+	m.allowedNodes.Add(nodeID)
+	if err := m.nodeAllowDB.Put(nodeID.Bytes(), nil); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) DisallowNode(nodeID ids.NodeID) error {
+	m.lock.Lock()
+	if !m.allowedNodes.Contains(nodeID) {
+		m.lock.Unlock()
+		return ErrNotAllowed
+	}
+
+	m.allowedNodes.Remove(nodeID)
+	if err := m.nodeAllowDB.Delete(nodeID.Bytes()); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) BanIPs(ips netip.Prefix, duration time.Duration) error {
+	ips = ips.Masked()
+	var expiry time.Time
+	if duration > 0 {
+		expiry = m.clock.Time().Add(duration)
+	}
+
+	m.lock.Lock()
+	m.bannedIPs[ips] = expiry
+	if err := m.ipBanDB.Put([]byte(ips.String()), packExpiry(expiry)); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) UnbanIPs(ips netip.Prefix) error {
+	ips = ips.Masked()
+
+	m.lock.Lock()
+	expiry, ok := m.bannedIPs[ips]
+	if !ok || isExpired(expiry, m.clock.Time()) {
+		m.lock.Unlock()
+		return ErrNotBanned
+	}
+
+	delete(m.bannedIPs, ips)
+	if err := m.ipBanDB.Delete([]byte(ips.String())); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) AllowIPs(ips netip.Prefix) error {
+	ips = ips.Masked()
+
+	m.lock.Lock()
+	m.allowedIPs.Add(ips)
+	if err := m.ipAllowDB.Put([]byte(ips.String()), nil); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) DisallowIPs(ips netip.Prefix) error {
+	ips = ips.Masked()
+
+	m.lock.Lock()
+	if !m.allowedIPs.Contains(ips) {
+		m.lock.Unlock()
+		return ErrNotAllowed
+	}
+
+	m.allowedIPs.Remove(ips)
+	if err := m.ipAllowDB.Delete([]byte(ips.String())); err != nil {
+		m.lock.Unlock()
+		return err
+	}
+	m.unlockAndNotify()
+	return nil
+}
+
+func (m *manager) Nodes(nodeIDs []ids.NodeID) []Node {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	now := m.clock.Time()
+	if len(nodeIDs) == 0 {
+		allNodeIDs := set.NewSet[ids.NodeID](m.scores.Len())
+		for it := m.scores.NewIterator(); it.Next(); {
+			allNodeIDs.Add(it.Key())
+		}
+		for nodeID, expiry := range m.bannedNodes {
+			if !isExpired(expiry, now) {
+				allNodeIDs.Add(nodeID)
+			}
+		}
+		allNodeIDs.Union(m.allowedNodes)
+		nodeIDs = allNodeIDs.List()
+	}
+
+	nodes := make([]Node, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		s, _ := m.scores.Get(nodeID)
+		nodes[i] = Node{
+			NodeID:  nodeID,
+			Score:   m.decayedScore(s, now),
+			Allowed: m.allowedNodes.Contains(nodeID),
+			Banned:  m.isNodeBanned(nodeID, now),
+		}
+		if nodes[i].Banned {
+			nodes[i].BannedUntil = m.bannedNodes[nodeID]
+		}
+	}
+	return nodes
+}
+
+func (m *manager) IPRanges() []IPRange {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var (
+		now      = m.clock.Time()
+		ipRanges = make(map[netip.Prefix]*IPRange)
+	)
+	for prefix, expiry := range m.bannedIPs {
+		if !isExpired(expiry, now) {
+			ipRanges[prefix] = &IPRange{
+				IPs:         prefix,
+				Banned:      true,
+				BannedUntil: expiry,
+			}
+		}
+	}
+	for prefix := range m.allowedIPs {
+		ipRange, ok := ipRanges[prefix]
+		if !ok {
+			ipRange = &IPRange{
+				IPs: prefix,
+			}
+			ipRanges[prefix] = ipRange
+		}
+		ipRange.Allowed = true
+	}
+
+	result := make([]IPRange, 0, len(ipRanges))
+	for _, ipRange := range ipRanges {
+		result = append(result, *ipRange)
+	}
+	return result
+}
+
+func (m *manager) OnChange(f func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.onChange = append(m.onChange, f)
+}
+
+func (m *manager) Flush() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.clock.Time()
+	for it := m.scores.NewIterator(); it.Next(); {
+		if math.Abs(m.decayedScore(it.Value(), now)) < minStoredScore {
+			nodeID := it.Key()
+			m.scores.Delete(nodeID)
+			m.dirty.Add(nodeID)
+		}
+	}
+
+	batch := m.scoreDB.NewBatch()
+	for nodeID := range m.dirty {
+		s, ok := m.scores.Get(nodeID)
+		if !ok {
+			if err := batch.Delete(nodeID.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value := make([]byte, scoreLen)
+		binary.BigEndian.PutUint64(value, math.Float64bits(s.value))
+		binary.BigEndian.PutUint64(value[wrappers.LongLen:], uint64(s.updated.Unix()))
+		if err := batch.Put(nodeID.Bytes(), value); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	m.dirty.Clear()
+
+	// Expired bans are removed lazily.
+	for nodeID, expiry := range m.bannedNodes {
+		if !isExpired(expiry, now) {
+			continue
+		}
+		delete(m.bannedNodes, nodeID)
+		if err := m.nodeBanDB.Delete(nodeID.Bytes()); err != nil {
+			return err
+		}
+	}
+	for prefix, expiry := range m.bannedIPs {
+		if !isExpired(expiry, now) {
+			continue
+		}
+		delete(m.bannedIPs, prefix)
+		if err := m.ipBanDB.Delete([]byte(prefix.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// banNode bans [nodeID] until [expiry]. Assumes the lock is held.
+func (m *manager) banNode(nodeID ids.NodeID, expiry time.Time) error {
+	m.bannedNodes[nodeID] = expiry
+	return m.nodeBanDB.Put(nodeID.Bytes(), packExpiry(expiry))
+}
+
+// isNodeBanned returns true if [nodeID] is banned at [now]. Assumes the lock is
+// held.
+func (m *manager) isNodeBanned(nodeID ids.NodeID, now time.Time) bool {
+	expiry, ok := m.bannedNodes[nodeID]
+	return ok && !isExpired(expiry, now)
+}
+
+// unlockAndNotify releases the lock and notifies the listeners of a change.
+func (m *manager) unlockAndNotify() {
+	onChange := m.onChange
+	m.lock.Unlock()
+
+	for _, f := range onChange {
+		f()
+	}
+}
+
+func isExpired(expiry, now time.Time) bool {
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+func packExpiry(expiry time.Time) []byte {
+	if expiry.IsZero() {
+		return database.PackUInt64(0)
+	}
+	return database.PackUInt64(uint64(expiry.Unix()))
+}
+
+func parseExpiry(b []byte) (time.Time, error) {
+	expiry, err := database.ParseUInt64(b)
+	if err != nil || expiry == 0 {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(expiry), 0), nil
+}
+
+func forEach(db database.Iteratee, f func(key, value []byte) error) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if err := f(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
@@ -0,0 +1,428 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var testConfig = Config{
+	Halflife:            time.Hour,
+	SlowResponseLatency: time.Second,
+	AutoBanEnabled:      true,
+	BanScore:            -25,
+	BanDuration:         time.Hour,
+	MaxBannedPortion:    .5,
+}
+
+func newTestManager(t *testing.T, db *memdb.Database, now time.Time) *manager {
+	t.Helper()
+
+	return newTestManagerWithConfig(t, db, now, testConfig)
+}
+
+func newTestManagerWithConfig(t *testing.T, db *memdb.Database, now time.Time, config Config) *manager {
+	t.Helper()
+
+	m, err := NewManager(logging.NoLog{}, db, validators.NewManager(), validators.NewManager(), config)
+	require.NoError(t, err)
+	m.(*manager).clock.Set(now)
+	return m.(*manager)
+}
+
+// banByScore registers invalid messages from [nodeID] until its score drops to
+// the ban score of [testConfig].
+func banByScore(m *manager, nodeID ids.NodeID) {
+	for range 3 {
+		m.RegisterInvalidMessage(nodeID)
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "invalid halflife",
+			modify: func(c *Config) {
+				c.Halflife = 0
+			},
+			expectedErr: errInvalidHalflife,
+		},
+		{
+			name: "invalid slow response latency",
+			modify: func(c *Config) {
+				c.SlowResponseLatency = 0
+			},
+			expectedErr: errInvalidSlowResponseLatency,
+		},
+		{
+			name: "positive ban score",
+			modify: func(c *Config) {
+				c.BanScore = 1
+			},
+			expectedErr: errInvalidBanScore,
+		},
+		{
+			name: "unreachable ban score",
+			modify: func(c *Config) {
+				c.BanScore = minScore
+			},
+			expectedErr: errInvalidBanScore,
+		},
+		{
+			name: "invalid ban duration",
+			modify: func(c *Config) {
+				c.BanDuration = 0
+			},
+			expectedErr: errInvalidBanDuration,
+		},
+		{
+			name: "negative max banned portion",
+			modify: func(c *Config) {
+				c.MaxBannedPortion = -.1
+			},
+			expectedErr: errInvalidMaxBannedPortion,
+		},
+		{
+			name: "max banned portion of all stake",
+			modify: func(c *Config) {
+				c.MaxBannedPortion = 1
+			},
+			expectedErr: errInvalidMaxBannedPortion,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestAutomaticBan(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	m := newTestManager(t, memdb.New(), now)
+
+	var numChanges int
+	m.OnChange(func() {
+		numChanges++
+	})
+
+	nodeID := ids.GenerateTestNodeID()
+	m.RegisterResponse(nodeID, time.Millisecond)
+	m.RegisterInvalidMessage(nodeID)
+	m.RegisterInvalidMessage(nodeID)
+	require.True(m.IsNodeAllowed(nodeID))
+	require.Zero(numChanges)
+
+	m.RegisterResponse(nodeID, 2*time.Second)
+	m.RegisterFailure(nodeID)
+	m.RegisterInvalidMessage(nodeID)
+	require.False(m.IsNodeAllowed(nodeID))
+	require.Equal(1, numChanges)
+	require.Equal(
+		[]Node{
+			{
+				NodeID:      nodeID,
+				Score:       .1 - 10 - 10 - .5 - 1 - 10,
+				Banned:      true,
+				BannedUntil: now.Add(time.Hour),
+			},
+		},
+		m.Nodes(nil),
+	)
+
+	// Further misbehavior doesn't extend the ban.
+	m.RegisterInvalidMessage(nodeID)
+	require.Equal(1, numChanges)
+
+	// The ban expires.
+	m.clock.Set(now.Add(time.Hour))
+	require.True(m.IsNodeAllowed(nodeID))
+
+	// The score decays.
+	nodes := m.Nodes([]ids.NodeID{nodeID})
+	require.Len(nodes, 1)
+	require.InDelta((.1-10-10-.5-1-10-10)/2, nodes[0].Score, .001)
+	require.False(nodes[0].Banned)
+}
+
+func TestAutomaticBanDisabled(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig
+	config.AutoBanEnabled = false
+	m := newTestManagerWithConfig(t, memdb.New(), time.Unix(1_000_000, 0), config)
+
+	nodeID := ids.GenerateTestNodeID()
+	banByScore(m, nodeID)
+	require.True(m.IsNodeAllowed(nodeID))
+
+	// Scores are still tracked, and nodes can still be banned manually.
+	nodes := m.Nodes([]ids.NodeID{nodeID})
+	require.Len(nodes, 1)
+	require.Equal(float64(-30), nodes[0].Score)
+	require.False(nodes[0].Banned)
+
+	require.NoError(m.BanNode(nodeID, 0))
+	require.False(m.IsNodeAllowed(nodeID))
+}
+
+func TestBeaconsAreNotBanned(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, memdb.New(), time.Unix(1_000_000, 0))
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(m.beacons.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1))
+	banByScore(m, nodeID)
+	require.True(m.IsNodeAllowed(nodeID))
+
+	// Beacons can still be banned manually.
+	require.NoError(m.BanNode(nodeID, 0))
+	require.False(m.IsNodeAllowed(nodeID))
+}
+
+func TestMaxBannedPortion(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, memdb.New(), time.Unix(1_000_000, 0))
+
+	var (
+		vdr0       = ids.GenerateTestNodeID()
+		vdr1       = ids.GenerateTestNodeID()
+		vdr2       = ids.GenerateTestNodeID()
+		nonVdrs    = []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+		allowedVdr = ids.GenerateTestNodeID()
+	)
+	require.NoError(m.vdrs.AddStaker(constants.PrimaryNetworkID, vdr0, nil, ids.Empty, 30))
+	require.NoError(m.vdrs.AddStaker(constants.PrimaryNetworkID, vdr1, nil, ids.Empty, 30))
+	require.NoError(m.vdrs.AddStaker(constants.PrimaryNetworkID, vdr2, nil, ids.Empty, 20))
+	require.NoError(m.vdrs.AddStaker(constants.PrimaryNetworkID, allowedVdr, nil, ids.Empty, 20))
+
+	// Bans of non-validators don't count towards the banned stake.
+	for _, nodeID := range nonVdrs {
+		banByScore(m, nodeID)
+		require.False(m.IsNodeAllowed(nodeID))
+	}
+
+	// Allowed validators that are banned don't count towards the banned stake.
+	require.NoError(m.BanNode(allowedVdr, 0))
+	require.NoError(m.AllowNode(allowedVdr))
+
+	banByScore(m, vdr0)
+	require.False(m.IsNodeAllowed(vdr0))
+
+	// Banning vdr1 would ban 60% of the stake.
+	banByScore(m, vdr1)
+	require.True(m.IsNodeAllowed(vdr1))
+
+	// Banning vdr2 bans exactly 50% of the stake.
+	banByScore(m, vdr2)
+	require.False(m.IsNodeAllowed(vdr2))
+
+	// Manual bans are not limited.
+	require.NoError(m.BanNode(vdr1, 0))
+	require.False(m.IsNodeAllowed(vdr1))
+}
+
+func TestAllowedNodesAreNotBanned(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t, memdb.New(), time.Unix(1_000_000, 0))
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(m.AllowNode(nodeID))
+	for range 10 {
+		m.RegisterInvalidMessage(nodeID)
+	}
+	require.True(m.IsNodeAllowed(nodeID))
+
+	// Allowing a node overrides manual bans.
+	require.NoError(m.BanNode(nodeID, 0))
+	require.True(m.IsNodeAllowed(nodeID))
+
+	require.NoError(m.DisallowNode(nodeID))
+	require.ErrorIs(m.DisallowNode(nodeID), ErrNotAllowed)
+	require.False(m.IsNodeAllowed(nodeID))
+
+	// Unbanning resets the score.
+	require.NoError(m.UnbanNode(nodeID))
+	require.ErrorIs(m.UnbanNode(nodeID), ErrNotBanned)
+	require.True(m.IsNodeAllowed(nodeID))
+	require.Equal([]Node{{NodeID: nodeID}}, m.Nodes([]ids.NodeID{nodeID}))
+}
+
+func TestIPRanges(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	m := newTestManager(t, memdb.New(), now)
+
+	bannedIPs, err := ParseIPRange("10.0.0.0/8")
+	require.NoError(err)
+	allowedIPs, err := ParseIPRange("10.1.2.3")
+	require.NoError(err)
+	require.Equal(netip.MustParsePrefix("10.1.2.3/32"), allowedIPs)
+
+	require.NoError(m.BanIPs(bannedIPs, time.Minute))
+	require.NoError(m.AllowIPs(allowedIPs))
+
+	require.False(m.IsIPAllowed(netip.MustParseAddr("10.1.1.1")))
+	require.False(m.IsIPAllowed(netip.MustParseAddr("::ffff:10.1.1.1")))
+	require.True(m.IsIPAllowed(netip.MustParseAddr("10.1.2.3")))
+	require.True(m.IsIPAllowed(netip.MustParseAddr("11.1.1.1")))
+	require.ElementsMatch(
+		[]IPRange{
+			{
+				IPs:         bannedIPs,
+				Banned:      true,
+				BannedUntil: now.Add(time.Minute),
+			},
+			{
+				IPs:     allowedIPs,
+				Allowed: true,
+			},
+		},
+		m.IPRanges(),
+	)
+
+	m.clock.Set(now.Add(time.Minute))
+	require.True(m.IsIPAllowed(netip.MustParseAddr("10.1.1.1")))
+	require.ErrorIs(m.UnbanIPs(bannedIPs), ErrNotBanned)
+
+	require.NoError(m.DisallowIPs(allowedIPs))
+	require.ErrorIs(m.DisallowIPs(allowedIPs), ErrNotAllowed)
+	require.Empty(m.IPRanges())
+}
+
+func TestPersistence(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db  = memdb.New()
+		now = time.Unix(1_000_000, 0)
+		m   = newTestManager(t, db, now)
+
+		scoredNodeID  = ids.GenerateTestNodeID()
+		bannedNodeID  = ids.GenerateTestNodeID()
+		allowedNodeID = ids.GenerateTestNodeID()
+		bannedIPs     = netip.MustParsePrefix("10.0.0.0/8")
+		allowedIPs    = netip.MustParsePrefix("192.168.0.0/16")
+	)
+	m.RegisterInvalidMessage(scoredNodeID)
+	require.NoError(m.BanNode(bannedNodeID, time.Hour))
+	require.NoError(m.AllowNode(allowedNodeID))
+	require.NoError(m.BanIPs(bannedIPs, 0))
+	require.NoError(m.AllowIPs(allowedIPs))
+	require.NoError(m.Flush())
+
+	m = newTestManager(t, db, now)
+	require.ElementsMatch(
+		[]Node{
+			{
+				NodeID: scoredNodeID,
+				Score:  -10,
+			},
+			{
+				NodeID:      bannedNodeID,
+				Banned:      true,
+				BannedUntil: now.Add(time.Hour),
+			},
+			{
+				NodeID:  allowedNodeID,
+				Allowed: true,
+			},
+		},
+		m.Nodes(nil),
+	)
+	require.ElementsMatch(
+		[]IPRange{
+			{
+				IPs:    bannedIPs,
+				Banned: true,
+			},
+			{
+				IPs:     allowedIPs,
+				Allowed: true,
+			},
+		},
+		m.IPRanges(),
+	)
+
+	// Expired bans and forgotten scores are removed from the database.
+	m.clock.Set(now.Add(20 * time.Hour))
+	require.NoError(m.Flush())
+
+	m = newTestManager(t, db, now)
+	require.Equal(
+		[]Node{
+			{
+				NodeID:  allowedNodeID,
+				Allowed: true,
+			},
+		},
+		m.Nodes(nil),
+	)
+}
+
+func TestScoresAreBounded(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db          = memdb.New()
+		now         = time.Unix(1_000_000, 0)
+		m           = newTestManager(t, db, now)
+		firstNodeID = ids.GenerateTestNodeID()
+		nodeIDs     = make([]ids.NodeID, 2*maxScores)
+	)
+	m.RegisterInvalidMessage(firstNodeID)
+	require.NoError(m.Flush())
+
+	for i := range nodeIDs {
+		now = now.Add(time.Second)
+		m.clock.Set(now)
+
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		m.RegisterInvalidMessage(nodeIDs[i])
+
+		// Scores that keep being updated aren't forgotten.
+		if i%1024 == 0 {
+			m.RegisterInvalidMessage(firstNodeID)
+		}
+	}
+	require.Equal(maxScores, m.scores.Len())
+	require.LessOrEqual(m.dirty.Len(), maxScores)
+
+	_, ok := m.scores.Get(firstNodeID)
+	require.True(ok)
+	_, ok = m.scores.Get(nodeIDs[0])
+	require.False(ok)
+	_, ok = m.scores.Get(nodeIDs[len(nodeIDs)-1])
+	require.True(ok)
+
+	require.NoError(m.Flush())
+	m = newTestManager(t, db, now)
+	require.Equal(maxScores, m.scores.Len())
+}
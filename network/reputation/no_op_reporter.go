@@ -0,0 +1,21 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// NoOpReporter is a Reporter that ignores the behavior of peers
+var NoOpReporter Reporter = noOpReporter{}
+
+type noOpReporter struct{}
+
+func (noOpReporter) RegisterResponse(ids.NodeID, time.Duration) {}
+
+func (noOpReporter) RegisterFailure(ids.NodeID) {}
+
+func (noOpReporter) RegisterInvalidMessage(ids.NodeID) {}
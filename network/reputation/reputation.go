@@ -0,0 +1,177 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	DefaultHalflife            = time.Hour
+	DefaultSlowResponseLatency = 5 * time.Second
+	DefaultBanScore            = -50
+	DefaultBanDuration         = time.Hour
+	DefaultMaxBannedPortion    = .05
+
+	// Scores are kept within [minScore, maxScore]. The maximum is kept low so
+	// that a peer can't build up enough reputation to misbehave for long
+	// without being banned.
+	minScore = -100
+	maxScore = 10
+)
+
+var (
+	ErrNotBanned  = errors.New("not banned")
+	ErrNotAllowed = errors.New("not allowed")
+
+	errInvalidHalflife            = errors.New("halflife must be positive")
+	errInvalidSlowResponseLatency = errors.New("slow response latency must be positive")
+	errInvalidBanScore            = fmt.Errorf("ban score must be in (%d, 0)", minScore)
+	errInvalidBanDuration         = errors.New("ban duration must be positive")
+	errInvalidMaxBannedPortion    = errors.New("invalid max portion of banned stake")
+)
+
+// Config defines how peers are scored and when they are banned.
+type Config struct {
+	// Halflife of the decay of scores towards 0.
+	Halflife time.Duration `json:"halflife"`
+	// Responses that take longer than SlowResponseLatency lower the score of
+	// the peer rather than raising it.
+	SlowResponseLatency time.Duration `json:"slowResponseLatency"`
+	// If AutoBanEnabled, peers whose score drops to BanScore are banned for
+	// BanDuration. Otherwise, peers are only banned manually.
+	AutoBanEnabled bool          `json:"autoBanEnabled"`
+	BanScore       float64       `json:"banScore"`
+	BanDuration    time.Duration `json:"banDuration"`
+	// Validators are only banned automatically if the stake of the banned
+	// validators remains within MaxBannedPortion of the primary network stake.
+	MaxBannedPortion float64 `json:"maxBannedPortion"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.Halflife <= 0:
+		return errInvalidHalflife
+	case c.SlowResponseLatency <= 0:
+		return errInvalidSlowResponseLatency
+	case c.BanScore <= minScore || c.BanScore >= 0:
+		return errInvalidBanScore
+	case c.BanDuration <= 0:
+		return errInvalidBanDuration
+	case c.MaxBannedPortion < 0 || c.MaxBannedPortion >= 1:
+		return fmt.Errorf("%w: must be in [0,1) but got %f", errInvalidMaxBannedPortion, c.MaxBannedPortion)
+	default:
+		return nil
+	}
+}
+
+// Reporter is notified about the behavior of peers.
+type Reporter interface {
+	// RegisterResponse registers that [nodeID] responded to a request after
+	// [latency].
+	RegisterResponse(nodeID ids.NodeID, latency time.Duration)
+	// RegisterFailure registers that a request sent to [nodeID] timed out.
+	RegisterFailure(nodeID ids.NodeID)
+	// RegisterInvalidMessage registers that [nodeID] sent a message that
+	// can't be parsed or that is malformed.
+	RegisterInvalidMessage(nodeID ids.NodeID)
+}
+
+// Manager scores peers based on their behavior and, if enabled, bans peers
+// whose score drops too low. Peers can also be banned and allowed manually,
+// either by NodeID or by IP range.
+//
+// Allowed peers and beacons are never banned automatically, and allowing a
+// NodeID or an IP range overrides bans of the same NodeID or of IP ranges
+// respectively.
+//
+// Scores, bans and allowed peers are persisted across restarts.
+type Manager interface {
+	Reporter
+
+	// IsNodeAllowed returns true if connections to [nodeID] are allowed.
+	IsNodeAllowed(nodeID ids.NodeID) bool
+	// IsIPAllowed returns true if connections to [ip] are allowed.
+	IsIPAllowed(ip netip.Addr) bool
+
+	// BanNode bans [nodeID] for [duration]. If [duration] is 0, [nodeID] is
+	// banned until it is unbanned.
+	BanNode(nodeID ids.NodeID, duration time.Duration) error
+	// UnbanNode removes the ban of [nodeID] and resets its score.
+	UnbanNode(nodeID ids.NodeID) error
+	// AllowNode allows [nodeID], even if it is banned.
+	AllowNode(nodeID ids.NodeID) error
+	// DisallowNode removes [nodeID] from the allowed nodes.
+	DisallowNode(nodeID ids.NodeID) error
+
+	// BanIPs bans the IPs in [ips] for [duration]. If [duration] is 0, the IPs
+	// are banned until they are unbanned.
+	BanIPs(ips netip.Prefix, duration time.Duration) error
+	// UnbanIPs removes the ban of [ips].
+	UnbanIPs(ips netip.Prefix) error
+	// AllowIPs allows the IPs in [ips], even if they are banned.
+	AllowIPs(ips netip.Prefix) error
+	// DisallowIPs removes [ips] from the allowed IP ranges.
+	DisallowIPs(ips netip.Prefix) error
+
+	// Nodes returns the reputation of [nodeIDs]. If [nodeIDs] is empty, the
+	// reputation of every node that has a score, is banned or is allowed is
+	// returned.
+	Nodes(nodeIDs []ids.NodeID) []Node
+	// IPRanges returns every IP range that is banned or allowed.
+	IPRanges() []IPRange
+
+	// OnChange registers [f] to be called whenever a node or an IP range is
+	// banned, unbanned, allowed or disallowed. [f] is called without holding
+	// any locks of the manager.
+	OnChange(f func())
+
+	// Flush persists the scores that changed since the last flush.
+	Flush() error
+}
+
+// Node is the reputation of a node.
+type Node struct {
+	NodeID  ids.NodeID
+	Score   float64
+	Allowed bool
+	Banned  bool
+	// BannedUntil is the time that the ban of the node expires. It is zero if
+	// the node is banned until it is unbanned.
+	BannedUntil time.Time
+}
+
+// IPRange is a banned or allowed range of IPs.
+type IPRange struct {
+	IPs     netip.Prefix
+	Allowed bool
+	Banned  bool
+	// BannedUntil is the time that the ban of the range expires. It is zero if
+	// the range is banned until it is unbanned.
+	BannedUntil time.Time
+}
+
+// ParseIPRange parses either an IP range in CIDR notation or a single IP.
+func ParseIPRange(s string) (netip.Prefix, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
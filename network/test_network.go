@@ -14,10 +14,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -101,6 +103,11 @@ func NewTestNetworkConfig(
 	if err != nil {
 		return nil, err
 	}
+
+	reputationManager, err := newTestReputation()
+	if err != nil {
+		return nil, err
+	}
 	return &Config{
 		HealthConfig: HealthConfig{
 			Enabled:                      true,
@@ -183,6 +190,7 @@ func NewTestNetworkConfig(
 		PeerReadBufferSize:           constants.DefaultNetworkPeerReadBufferSize,
 		PeerWriteBufferSize:          constants.DefaultNetworkPeerWriteBufferSize,
 		ResourceTracker:              resourceTracker,
+		Reputation:                   reputationManager,
 		CPUTargeter: tracker.NewTargeter(
 			logging.NoLog{},
 			&tracker.TargeterConfig{
@@ -206,6 +214,36 @@ func NewTestNetworkConfig(
 	}, nil
 }
 
+// EnableTestQUIC configures [cfg] to accept QUIC connections on a random UDP
+// port of [ip], and to dial peers over QUIC once they advertise a QUIC port. The
+// returned connection must be closed by the caller after the network is
+// closed.
+func EnableTestQUIC(cfg *Config, ip netip.Addr) (net.PacketConn, error) {
+	conn, err := net.ListenUDP("udp", net.UDPAddrFromAddrPort(netip.AddrPortFrom(ip, 0)))
+	if err != nil {
+		return nil, err
+	}
+	cfg.QUICConn = conn
+	return conn, nil
+}
+
+// newTestReputation returns a reputation manager that isn't persisted.
+func newTestReputation() (reputation.Manager, error) {
+	return reputation.NewManager(
+		logging.NoLog{},
+		memdb.New(),
+		validators.NewManager(),
+		validators.NewManager(),
+		reputation.Config{
+			Halflife:            reputation.DefaultHalflife,
+			SlowResponseLatency: reputation.DefaultSlowResponseLatency,
+			BanScore:            reputation.DefaultBanScore,
+			BanDuration:         reputation.DefaultBanDuration,
+			MaxBannedPortion:    reputation.DefaultMaxBannedPortion,
+		},
+	)
+}
+
 func NewTestNetwork(
 	log logging.Logger,
 	metrics prometheus.Registerer,
@@ -37,4 +37,76 @@ type commonMsgThrottler struct {
 	nodeToAtLargeBytesUsed map[ids.NodeID]uint64
 	// Max number of unprocessed bytes from validators
 	maxVdrBytes uint64
+	// Max number of unprocessed bytes in the at-large byte allocation
+	maxAtLargeBytes uint64
+	// Number of bytes in use beyond the validator byte allocation. Released
+	// bytes pay off the deficit before they are returned to the allocation.
+	//
+	// Only non-zero after the allocation was shrunk to less than the number of
+	// bytes in use.
+	vdrBytesDeficit uint64
+	// Number of bytes in use beyond the at-large byte allocation.
+	atLargeBytesDeficit uint64
+}
+
+// setConfig resizes the byte allocations to [config]. Bytes that are in use
+// when an allocation shrinks remain in use until they are released.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) setConfig(config MsgByteThrottlerConfig) {
+	t.remainingVdrBytes, t.vdrBytesDeficit = resize(
+		t.maxVdrBytes,
+		t.remainingVdrBytes,
+		t.vdrBytesDeficit,
+		config.VdrAllocSize,
+	)
+	t.remainingAtLargeBytes, t.atLargeBytesDeficit = resize(
+		t.maxAtLargeBytes,
+		t.remainingAtLargeBytes,
+		t.atLargeBytesDeficit,
+		config.AtLargeAllocSize,
+	)
+	t.maxVdrBytes = config.VdrAllocSize
+	t.maxAtLargeBytes = config.AtLargeAllocSize
+	t.nodeMaxAtLargeBytes = config.NodeMaxAtLargeBytes
+}
+
+// nodeAtLargeBytesAvailable returns the number of bytes that [nodeID] may
+// still take from the at-large allocation.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) nodeAtLargeBytesAvailable(nodeID ids.NodeID) uint64 {
+	used := t.nodeToAtLargeBytesUsed[nodeID]
+	if used >= t.nodeMaxAtLargeBytes {
+		return 0
+	}
+	return t.nodeMaxAtLargeBytes - used
+}
+
+// returnVdrBytes returns [bytes] to the validator allocation.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) returnVdrBytes(bytes uint64) {
+	paid := min(bytes, t.vdrBytesDeficit)
+	t.vdrBytesDeficit -= paid
+	t.remainingVdrBytes += bytes - paid
+}
+
+// returnAtLargeBytes returns [bytes] to the at-large allocation.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) returnAtLargeBytes(bytes uint64) {
+	paid := min(bytes, t.atLargeBytesDeficit)
+	t.atLargeBytesDeficit -= paid
+	t.remainingAtLargeBytes += bytes - paid
+}
+
+// resize returns the remaining bytes and the deficit of an allocation of
+// [oldMax] bytes once it is resized to [newMax] bytes.
+func resize(oldMax, remaining, deficit, newMax uint64) (uint64, uint64) {
+	used := oldMax - remaining + deficit
+	if used > newMax {
+		return 0, used - newMax
+	}
+	return newMax - used, 0
 }
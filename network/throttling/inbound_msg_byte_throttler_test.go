@@ -442,3 +442,71 @@ func TestMsgThrottlerNextMsg(t *testing.T) {
 	// next non validator message should finish
 	<-done
 }
+
+func TestInboundMsgByteThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        1024,
+		AtLargeAllocSize:    512,
+		NodeMaxAtLargeBytes: 512,
+	}
+	vdrs := validators.NewManager()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1))
+	nonVdrID := ids.GenerateTestNodeID()
+
+	throttler, err := newInboundMsgByteThrottler(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		vdrs,
+		config,
+	)
+	require.NoError(err)
+
+	// Use all of the at-large allocation.
+	release := throttler.Acquire(t.Context(), config.AtLargeAllocSize, vdrID)
+
+	// A non-validator waits for at-large bytes.
+	nonVdrDone := make(chan struct{})
+	go func() {
+		throttler.Acquire(t.Context(), config.AtLargeAllocSize, nonVdrID)
+		close(nonVdrDone)
+	}()
+	require.Eventually(func() bool {
+		throttler.lock.Lock()
+		defer throttler.lock.Unlock()
+		return throttler.waitingToAcquire.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	// Shrinking the at-large allocation keeps the bytes in use.
+	throttler.setConfig(MsgByteThrottlerConfig{
+		VdrAllocSize:        1024,
+		AtLargeAllocSize:    256,
+		NodeMaxAtLargeBytes: 256,
+	})
+	throttler.lock.Lock()
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Equal(uint64(256), throttler.atLargeBytesDeficit)
+	throttler.lock.Unlock()
+
+	// Released bytes pay off the deficit before they are given to the waiting
+	// message, which can take at most the new per-node limit.
+	release()
+	throttler.lock.Lock()
+	require.Zero(throttler.atLargeBytesDeficit)
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Equal(uint64(256), throttler.nodeToAtLargeBytesUsed[nonVdrID])
+	throttler.lock.Unlock()
+
+	// Growing the allocation gives the new bytes to the waiting message.
+	throttler.setConfig(config)
+	select {
+	case <-nonVdrDone:
+	case <-time.After(time.Second):
+		require.FailNow("message should have acquired the new bytes")
+	}
+	throttler.lock.Lock()
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Equal(config.AtLargeAllocSize, throttler.nodeToAtLargeBytesUsed[nonVdrID])
+	throttler.lock.Unlock()
+}
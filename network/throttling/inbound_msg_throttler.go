@@ -40,6 +40,10 @@ type InboundMsgThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// SetByteThrottlerConfig resizes the byte allocations that inbound
+	// messages are read from.
+	SetByteThrottlerConfig(config MsgByteThrottlerConfig)
 }
 
 type InboundMsgThrottlerConfig struct {
@@ -174,3 +178,7 @@ func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
 }
+
+func (t *inboundMsgThrottler) SetByteThrottlerConfig(config MsgByteThrottlerConfig) {
+	t.byteThrottler.setConfig(config)
+}
@@ -34,6 +34,9 @@ type OutboundMsgThrottler interface {
 	// sending the message. Must correspond to a previous call to
 	// Acquire([msg], [nodeID]) that returned true.
 	Release(msg *message.OutboundMessage, nodeID ids.NodeID)
+
+	// SetConfig resizes the byte allocations that messages are queued from.
+	SetConfig(config MsgByteThrottlerConfig)
 }
 
 type outboundMsgThrottler struct {
@@ -52,6 +55,7 @@ func NewSybilOutboundMsgThrottler(
 			log:                    log,
 			vdrs:                   vdrs,
 			maxVdrBytes:            config.VdrAllocSize,
+			maxAtLargeBytes:        config.AtLargeAllocSize,
 			remainingVdrBytes:      config.VdrAllocSize,
 			remainingAtLargeBytes:  config.AtLargeAllocSize,
 			nodeMaxAtLargeBytes:    config.NodeMaxAtLargeBytes,
@@ -77,7 +81,7 @@ func (t *outboundMsgThrottler) Acquire(msg *message.OutboundMessage, nodeID ids.
 		// only give as many bytes as needed
 		bytesNeeded,
 		// don't exceed per-node limit
-		t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[nodeID],
+		t.nodeAtLargeBytesAvailable(nodeID),
 		// don't give more bytes than are in the allocation
 		t.remainingAtLargeBytes,
 	)
@@ -155,19 +159,28 @@ func (t *outboundMsgThrottler) Release(msg *message.OutboundMessage, nodeID ids.
 	if t.nodeToVdrBytesUsed[nodeID] == 0 {
 		delete(t.nodeToVdrBytesUsed, nodeID)
 	}
-	t.remainingVdrBytes += vdrBytesToReturn
+	t.returnVdrBytes(vdrBytesToReturn)
 
 	// [atLargeBytesToReturn] is the number of bytes from [msgSize]
 	// that will be given to the at-large allocation.
 	atLargeBytesToReturn := msgSize - vdrBytesToReturn
 	// Mark that [nodeID] has released these bytes.
-	t.remainingAtLargeBytes += atLargeBytesToReturn
+	t.returnAtLargeBytes(atLargeBytesToReturn)
 	t.nodeToAtLargeBytesUsed[nodeID] -= atLargeBytesToReturn
 	if t.nodeToAtLargeBytesUsed[nodeID] == 0 {
 		delete(t.nodeToAtLargeBytesUsed, nodeID)
 	}
 }
 
+func (t *outboundMsgThrottler) SetConfig(config MsgByteThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.setConfig(config)
+	t.metrics.remainingAtLargeBytes.Set(float64(t.remainingAtLargeBytes))
+	t.metrics.remainingVdrBytes.Set(float64(t.remainingVdrBytes))
+}
+
 type outboundMsgThrottlerMetrics struct {
 	acquireSuccesses      prometheus.Counter
 	acquireFailures       prometheus.Counter
@@ -218,3 +231,5 @@ func (*noOutboundMsgThrottler) Acquire(*message.OutboundMessage, ids.NodeID) boo
 }
 
 func (*noOutboundMsgThrottler) Release(*message.OutboundMessage, ids.NodeID) {}
+
+func (*noOutboundMsgThrottler) SetConfig(MsgByteThrottlerConfig) {}
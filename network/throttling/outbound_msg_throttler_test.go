@@ -264,3 +264,54 @@ func testMsgWithSize(size uint64) *message.OutboundMessage {
 		Bytes:            make([]byte, size),
 	}
 }
+
+func TestSybilOutboundMsgThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        1024,
+		AtLargeAllocSize:    1024,
+		NodeMaxAtLargeBytes: 1024,
+	}
+	vdrs := validators.NewManager()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1))
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		vdrs,
+		config,
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+
+	// Use all of the at-large allocation and half of the validator allocation.
+	msg := testMsgWithSize(config.AtLargeAllocSize + config.VdrAllocSize/2)
+	require.True(throttlerIntf.Acquire(msg, vdrID))
+
+	// Shrink both allocations to less than the bytes in use.
+	throttlerIntf.SetConfig(MsgByteThrottlerConfig{
+		VdrAllocSize:        256,
+		AtLargeAllocSize:    512,
+		NodeMaxAtLargeBytes: 512,
+	})
+	require.Zero(throttler.remainingVdrBytes)
+	require.Equal(uint64(256), throttler.vdrBytesDeficit)
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Equal(uint64(512), throttler.atLargeBytesDeficit)
+	require.False(throttlerIntf.Acquire(testMsgWithSize(1), vdrID))
+
+	// Releasing the bytes pays off the deficits.
+	throttlerIntf.Release(msg, vdrID)
+	require.Equal(uint64(256), throttler.remainingVdrBytes)
+	require.Zero(throttler.vdrBytesDeficit)
+	require.Equal(uint64(512), throttler.remainingAtLargeBytes)
+	require.Zero(throttler.atLargeBytesDeficit)
+	require.Empty(throttler.nodeToVdrBytesUsed)
+	require.Empty(throttler.nodeToAtLargeBytesUsed)
+
+	// Growing the allocations makes the new bytes available.
+	throttlerIntf.SetConfig(config)
+	require.Equal(config.VdrAllocSize, throttler.remainingVdrBytes)
+	require.Equal(config.AtLargeAllocSize, throttler.remainingAtLargeBytes)
+	require.True(throttlerIntf.Acquire(testMsgWithSize(config.AtLargeAllocSize+1), vdrID))
+}
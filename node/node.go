@@ -49,6 +49,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -58,6 +59,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -116,7 +118,8 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix = []byte{0x00}
+	indexerDBPrefix    = []byte{0x00}
+	reputationDBPrefix = []byte("reputation")
 
 	errInvalidTLSKey        = errors.New("invalid TLS key")
 	errShuttingDown         = errors.New("server shutting down")
@@ -124,11 +127,19 @@ var (
 	errUpgradeNeeded        = errors.New("unknown network upgrade detected")
 	errUpgradeWithinTheDay  = errors.New("unknown network upgrade detected - update as soon as possible")
 	errUpgradeWithinTheHour = errors.New("imminent network upgrade detected - update immediately")
+	errNoConfigReloader     = errors.New("config reloading is not supported")
+	errSybilProtectionOff   = errors.New("subnets can't be tracked when sybil protection is disabled")
+	errTrackPrimaryNetwork  = errors.New("the primary network is always tracked")
+	errSubnetTracked        = errors.New("subnet is already tracked")
+	errSubnetNotTracked     = errors.New("subnet is not tracked")
+
+	_ node.Reloadable = (*Node)(nil)
 )
 
 // New returns an instance of Node
 func New(
 	config *node.Config,
+	configReloader node.Reloader,
 	logFactory logging.Factory,
 	logger logging.Logger,
 ) (*Node, error) {
@@ -145,6 +156,8 @@ func New(
 		StakingTLSCert:   stakingCert,
 		ID:               ids.NodeIDFromCert(stakingCert),
 		Config:           config,
+		configReloader:   configReloader,
+		trackedSubnets:   subnets.NewTracked(config.TrackedSubnets),
 	}
 
 	n.StakingSigner, err = newStakingSigner(config.StakingSignerConfig)
@@ -334,9 +347,15 @@ type Node struct {
 	// Manages creation of blockchains and routing messages to them
 	chainManager chains.Manager
 
+	// Subnets, other than the primary network, whose chains this node runs
+	trackedSubnets *subnets.Tracked
+
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Scores peers and manages banned and allowed peers
+	reputation reputation.Manager
+
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
@@ -352,6 +371,10 @@ type Node struct {
 	// beacon.
 	stakingAddress netip.AddrPort
 
+	// quicConn is the UDP socket QUIC connections are accepted on and dialed
+	// from. It is nil if QUIC is disabled.
+	quicConn net.PacketConn
+
 	// tlsKeyLogWriterCloser is a debug file handle that writes all the TLS
 	// session keys. This value should only be non-nil during debugging.
 	tlsKeyLogWriterCloser io.WriteCloser
@@ -370,6 +393,9 @@ type Node struct {
 	// This node's configuration
 	Config *node.Config
 
+	// Re-reads the node's configuration. May be nil.
+	configReloader node.Reloader
+
 	tracer trace.Tracer
 
 	// ensures that we only close the node once.
@@ -457,6 +483,16 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		publicAddr  netip.Addr
 		atomicIP    *utils.Atomic[netip.AddrPort]
 	)
+	if n.Config.QUICEnabled {
+		// Peers learn the QUIC port from the handshake, but using the staking
+		// port number keeps the firewall configuration simple.
+		quicAddress := net.JoinHostPort(n.Config.ListenHost, strconv.FormatUint(uint64(stakingPort), 10))
+		n.quicConn, err = net.ListenPacket("udp", quicAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen for QUIC connections: %w", err)
+		}
+		n.Config.NetworkConfig.QUICConn = n.quicConn
+	}
 	switch {
 	case n.Config.PublicIP != "":
 		// Use the specified public IP.
@@ -569,6 +605,18 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	}
 	n.benchlistManager = benchlist.NewManager(n.chainRouter, n.vdrs, benchlistReg, n.Config.BenchlistConfig)
 
+	// Configure peer reputation
+	n.reputation, err = reputation.NewManager(
+		n.Log,
+		prefixdb.New(reputationDBPrefix, n.DB),
+		n.vdrs,
+		n.bootstrappers,
+		n.Config.ReputationConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize peer reputation: %w", err)
+	}
+
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
 	var consensusRouter router.ExternalHandler = n.chainRouter
@@ -629,6 +677,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.Reputation = n.reputation
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -729,6 +778,14 @@ func (n *Node) Dispatch() error {
 	// and blocks until Shutdown returns.
 	n.Shutdown(1)
 
+	if n.quicConn != nil {
+		if err := n.quicConn.Close(); err != nil {
+			n.Log.Error("closing QUIC socket failed",
+				zap.Error(err),
+			)
+		}
+	}
+
 	if n.tlsKeyLogWriterCloser != nil {
 		err := n.tlsKeyLogWriterCloser.Close()
 		if err != nil {
@@ -884,6 +941,7 @@ func (n *Node) initIndexer() error {
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:      n.Config.IndexAPIEnabled,
 		AllowIncompleteIndex: n.Config.IndexAllowIncomplete,
+		Retention:            n.Config.IndexRetentionConfig,
 		DB:                   txIndexerDB,
 		Log:                  n.Log,
 		BlockAcceptorGroup:   n.BlockAcceptorGroup,
@@ -1024,6 +1082,17 @@ func (n *Node) initAPIServer() error {
 		return err
 	}
 
+	// Requests are rate limited before they are authenticated, so that
+	// unauthenticated clients can not consume the resources needed to verify
+	// their credentials without limit.
+	var middleware []server.Middleware
+	if n.Config.HTTPRateLimitConfig.Enabled() {
+		middleware = append(middleware, server.NewRateLimitMiddleware(n.Config.HTTPRateLimitConfig))
+	}
+	if n.Config.HTTPAuthConfig.Enabled() {
+		middleware = append(middleware, server.NewAuthMiddleware(n.Config.HTTPAuthConfig))
+	}
+
 	n.APIServer, err = server.New(
 		n.Log,
 		listener,
@@ -1035,6 +1104,7 @@ func (n *Node) initAPIServer() error {
 		apiRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		middleware,
 	)
 	return err
 }
@@ -1095,6 +1165,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	n.timeoutManager, err = timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
 		n.benchlistManager,
+		n.reputation,
 		requestsReg,
 		responseReg,
 	)
@@ -1205,7 +1276,7 @@ func (n *Node) initVMs() error {
 				UptimeLockedCalculator:    n.uptimeCalculator,
 				SybilProtectionEnabled:    n.Config.SybilProtectionEnabled,
 				PartialSyncPrimaryNetwork: n.Config.PartialSyncPrimaryNetwork,
-				TrackedSubnets:            n.Config.TrackedSubnets,
+				TrackedSubnets:            n.trackedSubnets,
 				DynamicFeeConfig:          n.Config.DynamicFeeConfig,
 				ValidatorFeeConfig:        n.Config.ValidatorFeeConfig,
 				UptimePercentage:          n.Config.UptimeRequirement,
@@ -1347,8 +1418,12 @@ func (n *Node) initAdminAPI() error {
 			ChainManager: n.chainManager,
 			HTTPServer:   n.APIServer,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
+			SnapshotDir:  n.Config.DatabaseConfig.SnapshotDir,
 			LogFactory:   n.LogFactory,
 			NodeConfig:   n.Config,
+			Reloader:     n,
+			Tracker:      n,
+			Reputation:   n.reputation,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
 		},
@@ -1891,6 +1966,13 @@ func (n *Node) shutdown() {
 	if n.Net != nil {
 		n.Net.StartClose()
 	}
+	if n.reputation != nil {
+		if err := n.reputation.Flush(); err != nil {
+			n.Log.Debug("error persisting peer reputations",
+				zap.Error(err),
+			)
+		}
+	}
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown",
 			zap.Error(err),
@@ -1939,3 +2021,116 @@ func (n *Node) shutdown() {
 func (n *Node) ExitCode() int {
 	return n.shuttingDownExitCode.Get()
 }
+
+// ReloadConfig re-reads the node's configuration and applies the changes that
+// do not require a restart.
+func (n *Node) ReloadConfig() (node.ReloadResult, error) {
+	if n.configReloader == nil {
+		return node.ReloadResult{}, errNoConfigReloader
+	}
+
+	result, err := n.configReloader.Reload(n)
+	if err != nil {
+		n.Log.Warn("failed to reload config",
+			zap.Error(err),
+		)
+		return node.ReloadResult{}, err
+	}
+
+	n.Log.Info("reloaded config",
+		zap.Strings("applied", result.Applied),
+		zap.Any("rejected", result.Rejected),
+	)
+	return result, nil
+}
+
+// TrackSubnet starts running the chains of [subnetID]. The chains are created
+// by the P-chain.
+func (n *Node) TrackSubnet(subnetID ids.ID) error {
+	switch {
+	case !n.Config.SybilProtectionEnabled:
+		return errSybilProtectionOff
+	case subnetID == constants.PrimaryNetworkID:
+		return errTrackPrimaryNetwork
+	case n.trackedSubnets.Contains(subnetID):
+		return fmt.Errorf("%w: %s", errSubnetTracked, subnetID)
+	}
+
+	// The network must be tracking the subnet before its chains are created so
+	// that the chains are notified of the connected subnet peers.
+	n.Net.TrackSubnet(subnetID)
+	if !n.trackedSubnets.Track(subnetID) {
+		return fmt.Errorf("%w: %s", errSubnetTracked, subnetID)
+	}
+	return nil
+}
+
+// UntrackSubnet stops the chains of [subnetID]. If [deleteData] is true, the
+// data of the chains is deleted.
+func (n *Node) UntrackSubnet(ctx context.Context, subnetID ids.ID, deleteData bool) error {
+	if subnetID == constants.PrimaryNetworkID {
+		return errTrackPrimaryNetwork
+	}
+	if !n.trackedSubnets.Untrack(subnetID) {
+		return fmt.Errorf("%w: %s", errSubnetNotTracked, subnetID)
+	}
+
+	n.Net.UntrackSubnet(subnetID)
+	if err := n.chainManager.StopSubnet(ctx, subnetID, deleteData); err != nil {
+		return fmt.Errorf("failed to stop subnet %s: %w", subnetID, err)
+	}
+
+	n.Log.Info("untracked subnet",
+		zap.Stringer("subnetID", subnetID),
+		zap.Bool("deletedData", deleteData),
+	)
+	return nil
+}
+
+func (n *Node) SetLogLevels(logLevel, displayLevel logging.Level) error {
+	for _, name := range n.LogFactory.GetLoggerNames() {
+		if err := n.LogFactory.SetLogLevel(name, logLevel); err != nil {
+			return err
+		}
+		if err := n.LogFactory.SetDisplayLevel(name, displayLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Node) SetNetworkHealthConfig(healthConfig network.HealthConfig) {
+	n.Net.SetHealthConfig(healthConfig)
+}
+
+func (n *Node) SetRouterHealthConfig(healthConfig router.HealthConfig) {
+	n.chainRouter.SetHealthConfig(healthConfig)
+}
+
+func (n *Node) SetMsgThrottlerConfig(inbound, outbound throttling.MsgByteThrottlerConfig) {
+	n.Net.SetMsgThrottlerConfig(inbound, outbound)
+}
+
+func (n *Node) SetBenchlistConfig(config benchlist.Config) error {
+	return n.benchlistManager.SetConfig(config)
+}
+
+// UpdateTrackedSubnets starts tracking the subnets in [track] and stops
+// tracking the subnets in [untrack]. The data of untracked subnets is kept.
+// Subnets that are already tracked, or already untracked, are skipped.
+func (n *Node) UpdateTrackedSubnets(track, untrack set.Set[ids.ID]) error {
+	var errs []error
+	for subnetID := range track {
+		if n.trackedSubnets.Contains(subnetID) {
+			continue
+		}
+		errs = append(errs, n.TrackSubnet(subnetID))
+	}
+	for subnetID := range untrack {
+		if !n.trackedSubnets.Contains(subnetID) {
+			continue
+		}
+		errs = append(errs, n.UntrackSubnet(context.Background(), subnetID, false))
+	}
+	return errors.Join(errs...)
+}
@@ -674,7 +674,10 @@ type Handshake struct {
 	IpBlsSig []byte `protobuf:"bytes,13,opt,name=ip_bls_sig,json=ipBlsSig,proto3" json:"ip_bls_sig,omitempty"`
 	// To avoid sending IPs that the client isn't interested in tracking, the
 	// server expects the client to confirm that it is tracking all subnets.
-	AllSubnets    bool `protobuf:"varint,14,opt,name=all_subnets,json=allSubnets,proto3" json:"all_subnets,omitempty"`
+	AllSubnets bool `protobuf:"varint,14,opt,name=all_subnets,json=allSubnets,proto3" json:"all_subnets,omitempty"`
+	// UDP port the peer accepts QUIC connections on, or 0 if the peer does not
+	// accept QUIC connections.
+	QuicPort      uint32 `protobuf:"varint,15,opt,name=quic_port,json=quicPort,proto3" json:"quic_port,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -807,6 +810,13 @@ func (x *Handshake) GetAllSubnets() bool {
 	return false
 }
 
+func (x *Handshake) GetQuicPort() uint32 {
+	if x != nil {
+		return x.QuicPort
+	}
+	return 0
+}
+
 // Metadata about a peer's P2P client used to determine compatibility
 type Client struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -3412,7 +3422,7 @@ const file_p2p_p2p_proto_rawDesc = "" +
 	"\amessageJ\x04\b\x01\x10\x02J\x04\b%\x10&\"$\n" +
 	"\x04Ping\x12\x16\n" +
 	"\x06uptime\x18\x01 \x01(\rR\x06uptimeJ\x04\b\x02\x10\x03\"\x12\n" +
-	"\x04PongJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\"\xf1\x03\n" +
+	"\x04PongJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\"\x8e\x04\n" +
 	"\tHandshake\x12\x1d\n" +
 	"\n" +
 	"network_id\x18\x01 \x01(\rR\tnetworkId\x12\x17\n" +
@@ -3432,7 +3442,8 @@ const file_p2p_p2p_proto_rawDesc = "" +
 	"\n" +
 	"ip_bls_sig\x18\r \x01(\fR\bipBlsSig\x12\x1f\n" +
 	"\vall_subnets\x18\x0e \x01(\bR\n" +
-	"allSubnets\"^\n" +
+	"allSubnets\x12\x1b\n" +
+	"\tquic_port\x18\x0f \x01(\rR\bquicPort\"^\n" +
 	"\x06Client\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05major\x18\x02 \x01(\rR\x05major\x12\x14\n" +
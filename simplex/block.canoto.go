@@ -30,10 +30,12 @@ const (
 	canoto__canotoSimplexBlock__Metadata   = 1
 	canoto__canotoSimplexBlock__InnerBlock = 2
 	canoto__canotoSimplexBlock__Blacklist  = 3
+	canoto__canotoSimplexBlock__EpochInfo  = 4
 
 	canoto__canotoSimplexBlock__Metadata__tag   = "\x0a" // canoto.Tag(canoto__canotoSimplexBlock__Metadata, canoto.Len)
 	canoto__canotoSimplexBlock__InnerBlock__tag = "\x12" // canoto.Tag(canoto__canotoSimplexBlock__InnerBlock, canoto.Len)
 	canoto__canotoSimplexBlock__Blacklist__tag  = "\x1a" // canoto.Tag(canoto__canotoSimplexBlock__Blacklist, canoto.Len)
+	canoto__canotoSimplexBlock__EpochInfo__tag  = "\x22" // canoto.Tag(canoto__canotoSimplexBlock__EpochInfo, canoto.Len)
 )
 
 type canotoData_canotoSimplexBlock struct {
@@ -63,6 +65,12 @@ func (*canotoSimplexBlock) CanotoSpec(...reflect.Type) *canoto.Spec {
 				OneOf:       "",
 				TypeBytes:   true,
 			},
+			{
+				FieldNumber: canoto__canotoSimplexBlock__EpochInfo,
+				Name:        "EpochInfo",
+				OneOf:       "",
+				TypeBytes:   true,
+			},
 		},
 	}
 	s.CalculateCanotoCache()
@@ -134,6 +142,17 @@ func (c *canotoSimplexBlock) UnmarshalCanotoFrom(r canoto.Reader) error {
 			if len(c.Blacklist) == 0 {
 				return canoto.ErrZeroValue
 			}
+		case canoto__canotoSimplexBlock__EpochInfo:
+			if wireType != canoto.Len {
+				return canoto.ErrUnexpectedWireType
+			}
+
+			if err := canoto.ReadBytes(&r, &c.EpochInfo); err != nil {
+				return err
+			}
+			if len(c.EpochInfo) == 0 {
+				return canoto.ErrZeroValue
+			}
 		default:
 			return canoto.ErrUnknownField
 		}
@@ -169,6 +188,9 @@ func (c *canotoSimplexBlock) CalculateCanotoCache() {
 	if len(c.Blacklist) != 0 {
 		size += uint64(len(canoto__canotoSimplexBlock__Blacklist__tag)) + canoto.SizeBytes(c.Blacklist)
 	}
+	if len(c.EpochInfo) != 0 {
+		size += uint64(len(canoto__canotoSimplexBlock__EpochInfo__tag)) + canoto.SizeBytes(c.EpochInfo)
+	}
 	atomic.StoreUint64(&c.canotoData.size, size)
 }
 
@@ -219,5 +241,9 @@ func (c *canotoSimplexBlock) MarshalCanotoInto(w canoto.Writer) canoto.Writer {
 		canoto.Append(&w, canoto__canotoSimplexBlock__Blacklist__tag)
 		canoto.AppendBytes(&w, c.Blacklist)
 	}
+	if len(c.EpochInfo) != 0 {
+		canoto.Append(&w, canoto__canotoSimplexBlock__EpochInfo__tag)
+		canoto.AppendBytes(&w, c.EpochInfo)
+	}
 	return w
 }
@@ -43,14 +43,20 @@ type Block struct {
 	blockTracker *blockTracker
 
 	blacklist simplex.Blacklist
+
+	// epoch contains the Simplex epoch information of the block
+	epoch epochInfo
 }
 
-func newBlock(metadata simplex.ProtocolMetadata, blacklist simplex.Blacklist, vmBlock snowman.Block, blockTracker *blockTracker) (*Block, error) {
+// newBlock returns a block that wraps [vmBlock]. Telocks are created with a nil
+// [vmBlock].
+func newBlock(metadata simplex.ProtocolMetadata, blacklist simplex.Blacklist, epoch epochInfo, vmBlock snowman.Block, blockTracker *blockTracker) (*Block, error) {
 	block := &Block{
 		metadata:     metadata,
 		vmBlock:      vmBlock,
 		blockTracker: blockTracker,
 		blacklist:    blacklist,
+		epoch:        epoch,
 	}
 	bytes, err := block.Bytes()
 	if err != nil {
@@ -65,6 +71,7 @@ type canotoSimplexBlock struct {
 	Metadata   []byte `canoto:"bytes,1"`
 	InnerBlock []byte `canoto:"bytes,2"`
 	Blacklist  []byte `canoto:"bytes,3"`
+	EpochInfo  []byte `canoto:"bytes,4"`
 
 	canotoData canotoData_canotoSimplexBlock
 }
@@ -80,9 +87,12 @@ func (b *Block) BlockHeader() simplex.BlockHeader {
 // Bytes returns the serialized bytes of the block.
 func (b *Block) Bytes() ([]byte, error) {
 	cBlock := &canotoSimplexBlock{
-		Metadata:   b.metadata.Bytes(),
-		InnerBlock: b.vmBlock.Bytes(),
-		Blacklist:  b.blacklist.Bytes(),
+		Metadata:  b.metadata.Bytes(),
+		Blacklist: b.blacklist.Bytes(),
+		EpochInfo: b.epoch.MarshalCanoto(),
+	}
+	if b.vmBlock != nil {
+		cBlock.InnerBlock = b.vmBlock.Bytes()
 	}
 
 	return cBlock.MarshalCanoto(), nil
@@ -99,7 +109,23 @@ func (b *Block) Verify(ctx context.Context) (simplex.VerifiedBlock, error) {
 		return nil, errGenesisVerification
 	}
 
-	if err := b.verifyParentMatchesPrevBlock(); err != nil {
+	prevBlock, ok := b.blockTracker.getBlockByDigest(b.metadata.Prev)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errDigestNotFound, b.metadata.Prev)
+	}
+
+	if err := b.blockTracker.verifyEpoch(ctx, prevBlock, b); err != nil {
+		return nil, fmt.Errorf("failed to verify epoch info: %w", err)
+	}
+
+	// Telocks only extend the chain until the sealing block of the epoch is
+	// finalized, so there is no VM block to verify.
+	if b.vmBlock == nil {
+		b.blockTracker.trackTelock(b)
+		return b, nil
+	}
+
+	if err := b.verifyParentMatchesPrevBlock(prevBlock); err != nil {
 		return nil, err
 	}
 
@@ -112,12 +138,7 @@ func (b *Block) Verify(ctx context.Context) (simplex.VerifiedBlock, error) {
 
 // verifyParentMatchesPrevBlock verifies that the previous block referenced in the current block's metadata
 // matches the parent of the current block's vmBlock.
-func (b *Block) verifyParentMatchesPrevBlock() error {
-	prevBlock, ok := b.blockTracker.getBlockByDigest(b.metadata.Prev)
-	if !ok {
-		return fmt.Errorf("%w: %s", errDigestNotFound, b.metadata.Prev)
-	}
-
+func (b *Block) verifyParentMatchesPrevBlock(prevBlock *Block) error {
 	if b.vmBlock.Parent() != prevBlock.vmBlock.ID() {
 		return fmt.Errorf("%w: parentID %s, prevID %s", errMismatchedPrevDigest, b.vmBlock.Parent(), prevBlock.vmBlock.ID())
 	}
@@ -146,18 +167,27 @@ func (d *blockDeserializer) DeserializeBlock(ctx context.Context, bytes []byte)
 		return nil, fmt.Errorf("%w: %w", errFailedToParseMetadata, err)
 	}
 
-	vmblock, err := d.parser.ParseBlock(ctx, canotoBlock.InnerBlock)
+	epoch, err := epochInfoFromBytes(canotoBlock.EpochInfo)
 	if err != nil {
 		return nil, err
 	}
 
+	// Telocks don't contain a VM block.
+	var vmblock snowman.Block
+	if len(canotoBlock.InnerBlock) != 0 {
+		vmblock, err = d.parser.ParseBlock(ctx, canotoBlock.InnerBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var blacklist simplex.Blacklist
 	err = blacklist.FromBytes(canotoBlock.Blacklist)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", errFailedToParseBlacklist, err)
 	}
 
-	return newBlock(*md, blacklist, vmblock, d.blockTracker)
+	return newBlock(*md, blacklist, epoch, vmblock, d.blockTracker)
 }
 
 // blockTracker is used to ensure that blocks are properly rejected, if competing blocks are accepted.
@@ -170,6 +200,9 @@ type blockTracker struct {
 	// handles block acceptance and rejection of inner blocks
 	tree tree.Tree
 
+	// epoch verifies the epoch information of blocks
+	epoch *epochTracker
+
 	vm block.ChainVM
 }
 
@@ -178,9 +211,45 @@ func newBlockTracker(vm block.ChainVM) *blockTracker {
 		tree:                  tree.New(),
 		simplexDigestsToBlock: make(map[simplex.Digest]*Block),
 		vm:                    vm,
+		epoch:                 &epochTracker{},
+	}
+}
+
+// setEpoch sets the epoch that blocks are verified against, and stops tracking
+// the telocks of the previous epoch.
+func (bt *blockTracker) setEpoch(epoch *epochTracker) {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+
+	bt.epoch = epoch
+	for digest, block := range bt.simplexDigestsToBlock {
+		if block.vmBlock == nil {
+			delete(bt.simplexDigestsToBlock, digest)
+		}
 	}
 }
 
+func (bt *blockTracker) currentEpoch() *epochTracker {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+
+	return bt.epoch
+}
+
+// verifyEpoch verifies the epoch information of [block], which is built on top
+// of [parent], against the current epoch.
+func (bt *blockTracker) verifyEpoch(ctx context.Context, parent *Block, block *Block) error {
+	return bt.currentEpoch().verify(ctx, parent, block)
+}
+
+// trackTelock tracks a verified telock.
+func (bt *blockTracker) trackTelock(block *Block) {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+
+	bt.simplexDigestsToBlock[block.digest] = block
+}
+
 // init sets the latest block in the tracker.
 // This should only be called once, with the genesis or latest block.
 func (bt *blockTracker) init(latestBlock *Block) {
@@ -29,8 +29,20 @@ const (
 )
 
 // BuildBlock continuously tries to build a block until the context is cancelled. If there are no blocks to be built, it will wait for an event from the VM.
+// Once the sealing block of the epoch is built, telocks are built without waiting for the VM.
 // It returns false if the context was cancelled, otherwise it returns the built block and true.
 func (b *BlockBuilder) BuildBlock(ctx context.Context, metadata simplex.ProtocolMetadata, blacklist simplex.Blacklist) (simplex.VerifiedBlock, bool) {
+	parent, ok := b.blockTracker.getBlockByDigest(metadata.Prev)
+	if !ok {
+		b.log.Error("Parent of block to build not found", zap.Stringer("prev", metadata.Prev))
+		return nil, false
+	}
+
+	epoch := b.blockTracker.currentEpoch()
+	if epoch.mustBuildTelock(parent) {
+		return b.buildTelock(ctx, metadata, blacklist, epoch.telockInfo(parent))
+	}
+
 	for curWait := initBackoff; ; curWait = backoff(ctx, curWait) {
 		if ctx.Err() != nil {
 			b.log.Debug("Context cancelled, stopping block building", zap.Error(ctx.Err()))
@@ -47,7 +59,14 @@ func (b *BlockBuilder) BuildBlock(ctx context.Context, metadata simplex.Protocol
 			b.log.Info("Error building block", zap.Error(err))
 			continue
 		}
-		simplexBlock, err := newBlock(metadata, blacklist, vmBlock, b.blockTracker)
+		// The epoch info is computed after the VM block is built so that the
+		// sealing block observes the latest P-chain height.
+		info, err := epoch.build(ctx, parent)
+		if err != nil {
+			b.log.Warn("Error computing epoch info", zap.Error(err))
+			continue
+		}
+		simplexBlock, err := newBlock(metadata, blacklist, info, vmBlock, b.blockTracker)
 		if err != nil {
 			b.log.Error("Error creating simplex block from built block", zap.Error(err))
 			return nil, false
@@ -63,6 +82,25 @@ func (b *BlockBuilder) BuildBlock(ctx context.Context, metadata simplex.Protocol
 	}
 }
 
+func (b *BlockBuilder) buildTelock(ctx context.Context, metadata simplex.ProtocolMetadata, blacklist simplex.Blacklist, info epochInfo) (simplex.VerifiedBlock, bool) {
+	telock, err := newBlock(metadata, blacklist, info, nil, b.blockTracker)
+	if err != nil {
+		b.log.Error("Error creating telock", zap.Error(err))
+		return nil, false
+	}
+	verifiedBlock, err := telock.Verify(ctx)
+	if err != nil {
+		b.log.Warn("Error verifying telock we built ourselves", zap.Error(err))
+		return nil, false
+	}
+
+	b.log.Debug("Built telock",
+		zap.Uint64("seq", metadata.Seq),
+		zap.Uint64("sealingBlockSeq", info.SealingBlockSeq),
+	)
+	return verifiedBlock, true
+}
+
 // WaitForPendingBlock blocks until a new block is ready to be built from the VM, or until the
 // context is cancelled.
 func (b *BlockBuilder) WaitForPendingBlock(ctx context.Context) {
@@ -12,6 +12,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	simplexparams "github.com/ava-labs/avalanchego/snow/consensus/simplex"
 )
 
 var (
@@ -117,14 +119,19 @@ func (v BLSVerifier) Verify(message []byte, signature []byte, signer simplex.Nod
 }
 
 func createVerifier(config *Config) (BLSVerifier, error) {
+	return newBLSVerifier(config.Ctx.NetworkID, config.Ctx.ChainID, config.Params.InitialValidators)
+}
+
+// newBLSVerifier returns a verifier of signatures by [validators].
+func newBLSVerifier(networkID uint32, chainID ids.ID, validators []simplexparams.ValidatorInfo) (BLSVerifier, error) {
 	verifier := BLSVerifier{
 		nodeID2PK: make(map[ids.NodeID]*bls.PublicKey),
-		networkID: config.Ctx.NetworkID,
-		chainID:   config.Ctx.ChainID,
+		networkID: networkID,
+		chainID:   chainID,
 	}
 
-	nodeIDs := make([]ids.NodeID, 0, len(config.Params.InitialValidators))
-	for _, node := range config.Params.InitialValidators {
+	nodeIDs := make([]ids.NodeID, 0, len(validators))
+	for _, node := range validators {
 		pk, err := bls.PublicKeyFromCompressedBytes(node.PublicKey)
 		if err != nil {
 			return BLSVerifier{}, fmt.Errorf("failed to parse public key for node %s: %w", node.NodeID, err)
@@ -17,6 +17,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/utils/set"
+
+	simplexparams "github.com/ava-labs/avalanchego/snow/consensus/simplex"
 )
 
 var (
@@ -32,6 +34,8 @@ type Comm struct {
 	broadcastNodes set.Set[ids.NodeID]
 	// allNodes are the IDs of all the nodes in the subnet
 	allNodes []simplex.NodeID
+	// isValidator is true if our node is one of allNodes
+	isValidator bool
 
 	// sender is used to send messages to other nodes
 	sender     sender.ExternalSender
@@ -39,12 +43,27 @@ type Comm struct {
 }
 
 func NewComm(config *Config) (*Comm, error) {
-	broadcastNodes := set.NewSet[ids.NodeID](len(config.Params.InitialValidators) - 1)
-	allNodes := make([]simplex.NodeID, 0, len(config.Params.InitialValidators))
+	comm := newComm(config, config.Params.InitialValidators)
+	if !comm.isValidator {
+		config.Log.Warn("Our node is not a validator for the subnet",
+			zap.Stringer("nodeID", config.Ctx.NodeID),
+			zap.Stringer("chainID", config.Ctx.ChainID),
+			zap.Stringer("subnetID", config.Ctx.SubnetID),
+		)
+		return nil, fmt.Errorf("our %w: %s", errNodeNotFound, config.Ctx.NodeID)
+	}
+	return comm, nil
+}
+
+// newComm returns a Comm that broadcasts messages to [validators]. Unlike
+// NewComm, our node is not required to be one of [validators].
+func newComm(config *Config, validators []simplexparams.ValidatorInfo) *Comm {
+	broadcastNodes := set.NewSet[ids.NodeID](len(validators))
+	allNodes := make([]simplex.NodeID, 0, len(validators))
 
 	includesOurNodeID := false
 	// grab all the nodes that are validators for the subnet
-	for _, vd := range config.Params.InitialValidators {
+	for _, vd := range validators {
 		allNodes = append(allNodes, vd.NodeID[:])
 		if vd.NodeID == config.Ctx.NodeID {
 			includesOurNodeID = true
@@ -54,24 +73,16 @@ func NewComm(config *Config) (*Comm, error) {
 		broadcastNodes.Add(vd.NodeID)
 	}
 
-	if !includesOurNodeID {
-		config.Log.Warn("Our node is not a validator for the subnet",
-			zap.Stringer("nodeID", config.Ctx.NodeID),
-			zap.Stringer("chainID", config.Ctx.ChainID),
-			zap.Stringer("subnetID", config.Ctx.SubnetID),
-		)
-		return nil, fmt.Errorf("our %w: %s", errNodeNotFound, config.Ctx.NodeID)
-	}
-
 	return &Comm{
 		subnetID:       config.Ctx.SubnetID,
 		broadcastNodes: broadcastNodes,
 		allNodes:       allNodes,
+		isValidator:    includesOurNodeID,
 		logger:         config.Log,
 		sender:         config.Sender,
 		msgBuilder:     config.OutboundMsgBuilder,
 		chainID:        config.Ctx.ChainID,
-	}, nil
+	}
 }
 
 func (c *Comm) Nodes() []simplex.NodeID {
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 
 	simplexparams "github.com/ava-labs/avalanchego/snow/consensus/simplex"
@@ -29,8 +30,14 @@ type Config struct {
 	DB database.KeyValueReaderWriter
 
 	// In the case of a crash, Simplex uses the WAL to recover its state and resume consensus.
+	// Records are tagged with the epoch they were written in.
 	WAL simplex.WriteAheadLog
 
+	// ValidatorState is used to change the validator set of the chain when the
+	// validator set of the subnet changes on the P-chain. If nil, the initial
+	// validators validate the chain forever.
+	ValidatorState validators.State
+
 	// SignBLS is the signing function used for this node to sign messages.
 	SignBLS SignFunc
 
@@ -47,10 +47,26 @@ type Engine struct {
 	validators.Connector
 	vm block.ChainVM
 
+	config       *Config
+	signer       BLSSigner
+	storage      *Storage
+	blockTracker *blockTracker
+
+	// epochLock protects epoch, quorumDeserializer, epochErr and stopped.
+	// epoch and quorumDeserializer are replaced when the sealing block of the
+	// epoch is indexed.
+	epochLock          sync.RWMutex
 	epoch              *simplex.Epoch
 	blockDeserializer  *blockDeserializer
 	quorumDeserializer *QCDeserializer
-	logger             logging.Logger
+	// epochErr is the error that prevented the next epoch from starting, if
+	// any. The engine reports itself as unhealthy while it is set.
+	epochErr error
+	// stopped is set once the engine is shut down, after which no epoch may
+	// be started.
+	stopped bool
+
+	logger logging.Logger
 
 	tickInterval time.Duration
 	shutdown     chan struct{}
@@ -72,6 +88,8 @@ func NewEngine(ctx context.Context, config *Config) (*Engine, error) {
 	return newEngineWithSignerVerifier(ctx, config, signer, verifier)
 }
 
+// newEngineWithSignerVerifier creates a new simplex engine. The verifier is
+// used to verify the signatures of the initial validators.
 func newEngineWithSignerVerifier(ctx context.Context, config *Config, signer BLSSigner, verifier BLSVerifier) (*Engine, error) {
 	if config.Params == nil {
 		return nil, errNilSimplexParameters
@@ -81,14 +99,6 @@ func newEngineWithSignerVerifier(ctx context.Context, config *Config, signer BLS
 		verifier: &verifier,
 	}
 
-	signatureAggregator := &SignatureAggregator{
-		verifier: &verifier,
-	}
-	comm, err := NewComm(config)
-	if err != nil {
-		return nil, err
-	}
-
 	bt := newBlockTracker(config.VM)
 
 	storage, err := newStorage(ctx, config, qcDeserializer, bt)
@@ -113,42 +123,12 @@ func newEngineWithSignerVerifier(ctx context.Context, config *Config, signer BLS
 	// Initialize the blockTracker with the last block fetched from Storage.
 	bt.init(simplexBlock)
 
-	blockBuilder := &BlockBuilder{
-		vm:           config.VM,
-		blockTracker: bt,
-		log:          config.Log,
-	}
-
-	blockDeserializer := &blockDeserializer{
-		parser:       config.VM,
-		blockTracker: bt,
-	}
-
-	epochConfig := simplex.EpochConfig{
-		MaxProposalWait:     config.Params.MaxNetworkDelay,
-		MaxRebroadcastWait:  config.Params.MaxRebroadcastWait,
-		QCDeserializer:      qcDeserializer,
-		Logger:              config.Log,
-		ID:                  config.Ctx.NodeID[:],
-		Signer:              &signer,
-		Verifier:            &verifier,
-		BlockDeserializer:   blockDeserializer,
-		SignatureAggregator: signatureAggregator,
-		Comm:                comm,
-		Storage:             storage,
-		WAL:                 config.WAL,
-		BlockBuilder:        blockBuilder,
-		Epoch:               simplexBlock.metadata.Epoch,
-		StartTime:           time.Now(),
-		ReplicationEnabled:  true,
-	}
-
-	epoch, err := simplex.NewEpoch(epochConfig)
+	state, err := currentEpoch(storage, config.Params.InitialValidators, simplexBlock)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("couldn't find the epoch of the last block: %w", err)
 	}
 
-	return &Engine{
+	e := &Engine{
 		AllGetsServer:               common.NewNoOpAllGetsServer(config.Log),
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Log),
 		AcceptedStateSummaryHandler: common.NewNoOpAcceptedStateSummaryHandler(config.Log),
@@ -162,14 +142,115 @@ func newEngineWithSignerVerifier(ctx context.Context, config *Config, signer BLS
 		Connector:                   config.VM,
 		vm:                          config.VM,
 
-		epoch:              epoch,
-		blockDeserializer:  blockDeserializer,
-		quorumDeserializer: qcDeserializer,
-		logger:             config.Log,
+		config:       config,
+		signer:       signer,
+		storage:      storage,
+		blockTracker: bt,
+		blockDeserializer: &blockDeserializer{
+			parser:       config.VM,
+			blockTracker: bt,
+		},
+		logger: config.Log,
 
 		tickInterval: getTickInterval(config.Params),
 		shutdown:     make(chan struct{}, 1),
-	}, nil
+	}
+
+	e.epoch, e.quorumDeserializer, err = e.newEpoch(state)
+	if err != nil {
+		return nil, err
+	}
+	e.setEpoch(state)
+	return e, nil
+}
+
+// currentEpoch returns the epoch that [lastBlock] belongs to, or the epoch that
+// follows it if [lastBlock] is a sealing block.
+func currentEpoch(storage *Storage, initialValidators []simplexparams.ValidatorInfo, lastBlock *Block) (epochState, error) {
+	switch {
+	case lastBlock.epoch.isSealingBlock():
+		return nextEpoch(lastBlock), nil
+	case lastBlock.epoch.EpochNumber == 0:
+		return firstEpoch(initialValidators), nil
+	}
+
+	sealingBlock, _, err := storage.Retrieve(lastBlock.epoch.EpochNumber)
+	if err != nil {
+		return epochState{}, fmt.Errorf("couldn't find sealing block at height %d: %w", lastBlock.epoch.EpochNumber, err)
+	}
+	simplexBlock, ok := sealingBlock.(*Block)
+	if !ok || !simplexBlock.epoch.isSealingBlock() {
+		return epochState{}, fmt.Errorf("%w: block %d is not a sealing block", errInvalidEpochInfo, lastBlock.epoch.EpochNumber)
+	}
+	return nextEpoch(simplexBlock), nil
+}
+
+// newEpoch creates a Simplex epoch that is validated by the validators of
+// [state].
+func (e *Engine) newEpoch(state epochState) (*simplex.Epoch, *QCDeserializer, error) {
+	qcDeserializer, err := e.storage.qcDeserializer(state.number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comm := newComm(e.config, state.validators)
+	if !comm.isValidator {
+		// Without a validator state, the validator set never changes, so a
+		// node that isn't a validator would never participate.
+		if e.config.ValidatorState == nil {
+			return nil, nil, fmt.Errorf("our %w: %s", errNodeNotFound, e.config.Ctx.NodeID)
+		}
+		e.logger.Info("Following epoch as a non-validator",
+			zap.Uint64("epoch", state.number),
+			zap.Stringer("nodeID", e.config.Ctx.NodeID),
+		)
+	}
+
+	epochConfig := simplex.EpochConfig{
+		MaxProposalWait:    e.config.Params.MaxNetworkDelay,
+		MaxRebroadcastWait: e.config.Params.MaxRebroadcastWait,
+		QCDeserializer:     qcDeserializer,
+		Logger:             e.config.Log,
+		ID:                 e.config.Ctx.NodeID[:],
+		Signer:             &e.signer,
+		Verifier:           qcDeserializer.verifier,
+		BlockDeserializer:  e.blockDeserializer,
+		SignatureAggregator: &SignatureAggregator{
+			verifier: qcDeserializer.verifier,
+		},
+		Comm:    comm,
+		Storage: e.storage,
+		WAL: &epochWAL{
+			WriteAheadLog: e.config.WAL,
+			epoch:         state.number,
+		},
+		BlockBuilder: &BlockBuilder{
+			vm:           e.config.VM,
+			blockTracker: e.blockTracker,
+			log:          e.config.Log,
+		},
+		Epoch:              state.number,
+		StartTime:          time.Now(),
+		ReplicationEnabled: true,
+	}
+
+	epoch, err := simplex.NewEpoch(epochConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	// NewEpoch assumes that the last indexed block belongs to the epoch, which
+	// isn't the case when the last indexed block is a sealing block.
+	epoch.Epoch = state.number
+	return epoch, qcDeserializer, nil
+}
+
+// setEpoch verifies new blocks against the validators of [state].
+func (e *Engine) setEpoch(state epochState) {
+	e.blockTracker.setEpoch(&epochTracker{
+		epochState: state,
+		subnetID:   e.config.Ctx.SubnetID,
+		state:      e.config.ValidatorState,
+	})
 }
 
 func (e *Engine) Start(_ context.Context, _ uint32) error {
@@ -184,6 +265,7 @@ func (e *Engine) Start(_ context.Context, _ uint32) error {
 	}
 
 	go e.tick()
+	go e.reconfigure()
 	return nil
 }
 
@@ -201,14 +283,76 @@ func (e *Engine) tick() {
 	for {
 		select {
 		case tick := <-ticker.C:
+			e.epochLock.RLock()
 			e.epoch.AdvanceTime(tick)
+			e.epochLock.RUnlock()
+		case <-e.shutdown:
+			return
+		}
+	}
+}
+
+// reconfigure starts the next epoch once the sealing block of the current
+// epoch is indexed.
+func (e *Engine) reconfigure() {
+	for {
+		select {
+		case sealingBlock := <-e.storage.sealingBlocks:
+			if err := e.startNextEpoch(sealingBlock); err != nil {
+				e.logger.Error("Failed to start next epoch",
+					zap.Uint64("sealingBlockSeq", sealingBlock.metadata.Seq),
+					zap.Error(err),
+				)
+			}
 		case <-e.shutdown:
 			return
 		}
 	}
 }
 
+// startNextEpoch stops the current epoch and starts the epoch that follows
+// [sealingBlock].
+//
+// The next epoch is created before the current epoch is stopped, so that the
+// current epoch keeps running if the next epoch can't be created. If the next
+// epoch fails to be created or started, the engine reports itself as unhealthy.
+func (e *Engine) startNextEpoch(sealingBlock *Block) error {
+	e.epochLock.Lock()
+	defer e.epochLock.Unlock()
+
+	if e.stopped {
+		return nil
+	}
+
+	state := nextEpoch(sealingBlock)
+	epoch, qcDeserializer, err := e.newEpoch(state)
+	if err != nil {
+		e.epochErr = fmt.Errorf("failed to create epoch %d: %w", state.number, err)
+		return e.epochErr
+	}
+
+	e.epoch.Stop()
+	e.setEpoch(state)
+	e.epoch = epoch
+	e.quorumDeserializer = qcDeserializer
+
+	e.logger.Info("Starting next simplex epoch",
+		zap.Uint64("epoch", state.number),
+		zap.Uint64("pChainReferenceHeight", state.pChainReferenceHeight),
+		zap.Int("numValidators", len(state.validators)),
+	)
+	if err := e.epoch.Start(); err != nil {
+		e.epochErr = fmt.Errorf("failed to start epoch %d: %w", state.number, err)
+		return e.epochErr
+	}
+	e.epochErr = nil
+	return nil
+}
+
 func (e *Engine) Simplex(ctx context.Context, nodeID ids.NodeID, msg *p2p.Simplex) error {
+	e.epochLock.RLock()
+	defer e.epochLock.RUnlock()
+
 	simplexMsg, err := e.p2pToSimplexMessage(ctx, msg)
 	if err != nil {
 		e.logger.Debug("failed to convert p2p message to simplex message", zap.Error(err))
@@ -218,6 +362,7 @@ func (e *Engine) Simplex(ctx context.Context, nodeID ids.NodeID, msg *p2p.Simple
 	return e.epoch.HandleMessage(simplexMsg, nodeID[:])
 }
 
+// p2pToSimplexMessage must be called while holding the epochLock.
 func (e *Engine) p2pToSimplexMessage(ctx context.Context, msg *p2p.Simplex) (*simplex.Message, error) {
 	if msg == nil {
 		return nil, errNilField
@@ -261,18 +406,27 @@ func (*Engine) Notify(_ context.Context, _ common.Message) error {
 }
 
 func (e *Engine) HealthCheck(ctx context.Context) (interface{}, error) {
+	e.epochLock.RLock()
+	epochErr := e.epochErr
+	e.epochLock.RUnlock()
+
 	vmIntf, vmErr := e.vm.HealthCheck(ctx)
 	intf := map[string]interface{}{
 		"consensus": struct{}{},
 		"vm":        vmIntf,
 	}
 
-	return intf, vmErr
+	return intf, errors.Join(epochErr, vmErr)
 }
 
 func (e *Engine) Shutdown(_ context.Context) error {
 	e.shutdownOnce.Do(func() {
+		// The write lock ensures that reconfigure can't start an epoch after
+		// the current epoch is stopped.
+		e.epochLock.Lock()
+		e.stopped = true
 		e.epoch.Stop()
+		e.epochLock.Unlock()
 		e.logger.Info("Stopped simplex engine")
 		close(e.shutdown)
 	})
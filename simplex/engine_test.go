@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/sender/sendermock"
 	"github.com/ava-labs/avalanchego/utils"
@@ -859,6 +860,117 @@ func FuzzSimplexEmptyNotarizations(f *testing.F) {
 	})
 }
 
+func TestEngineStartsNextEpoch(t *testing.T) {
+	require := require.New(t)
+
+	configs := createSimplexEngineConfig(t, noKeyReuse)
+	config := configs[0]
+
+	// Replace a validator other than this node.
+	nextValidators := append(
+		validatorInfos(generateTestNodes(t, 1)),
+		config.Params.InitialValidators[:3]...,
+	)
+	config.ValidatorState = newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+		return nextValidators
+	})
+
+	engine, err := NewEngine(t.Context(), config)
+	require.NoError(err)
+	t.Cleanup(func() {
+		require.NoError(engine.Shutdown(t.Context()))
+	})
+	require.NoError(engine.Start(t.Context(), 1))
+
+	genesis, _, err := engine.storage.Retrieve(0)
+	require.NoError(err)
+	sealingBlock := withEpochInfo(t, &Block{
+		metadata: simplex.ProtocolMetadata{
+			Version: 1,
+			Round:   1,
+			Seq:     1,
+			Prev:    genesis.BlockHeader().Digest,
+		},
+		vmBlock: &wrappedBlock{
+			Block: snowmantest.BuildChild(snowmantest.Genesis),
+			vm:    config.VM.(*wrappedVM),
+		},
+		blockTracker: engine.blockTracker,
+	}, epochInfo{
+		NextPChainReferenceHeight: 10,
+		BlockValidationDescriptor: newValidationDescriptor(sortedValidators(nextValidators)),
+	})
+	_, err = sealingBlock.Verify(t.Context())
+	require.NoError(err)
+
+	require.NoError(engine.storage.Index(t.Context(), sealingBlock, newTestFinalization(t, configs, sealingBlock.BlockHeader())))
+
+	require.Eventually(func() bool {
+		engine.epochLock.RLock()
+		defer engine.epochLock.RUnlock()
+
+		return engine.epoch.Epoch == sealingBlock.metadata.Seq
+	}, 5*time.Second, 10*time.Millisecond)
+
+	expectedNodeIDs := make([]ids.NodeID, 0, len(nextValidators))
+	for _, vdr := range sortedValidators(nextValidators) {
+		expectedNodeIDs = append(expectedNodeIDs, vdr.NodeID)
+	}
+
+	engine.epochLock.RLock()
+	md := engine.epoch.Metadata()
+	canonicalNodeIDs := engine.quorumDeserializer.verifier.canonicalNodeIDs
+	engine.epochLock.RUnlock()
+	require.Equal(uint64(2), md.Seq)
+	require.Equal(sealingBlock.digest, md.Prev)
+	require.Equal(expectedNodeIDs, canonicalNodeIDs)
+
+	// A restarted engine resumes in the new epoch.
+	restarted, err := NewEngine(t.Context(), config)
+	require.NoError(err)
+	require.Equal(sealingBlock.metadata.Seq, restarted.epoch.Epoch)
+	require.Equal(expectedNodeIDs, restarted.quorumDeserializer.verifier.canonicalNodeIDs)
+}
+
+func TestEngineKeepsEpochIfNextEpochFails(t *testing.T) {
+	require := require.New(t)
+
+	engine, configs := setupEngine(t)
+
+	// The sealing block isn't indexed, so the next epoch can't be created.
+	genesis, _, err := engine.storage.Retrieve(0)
+	require.NoError(err)
+	sealingBlock := withEpochInfo(t, &Block{
+		metadata: simplex.ProtocolMetadata{
+			Version: 1,
+			Round:   1,
+			Seq:     1,
+			Prev:    genesis.BlockHeader().Digest,
+		},
+		vmBlock: &wrappedBlock{
+			Block: snowmantest.BuildChild(snowmantest.Genesis),
+			vm:    configs[0].VM.(*wrappedVM),
+		},
+		blockTracker: engine.blockTracker,
+	}, epochInfo{
+		NextPChainReferenceHeight: 10,
+		BlockValidationDescriptor: newValidationDescriptor(sortedValidators(configs[0].Params.InitialValidators)),
+	})
+
+	epoch := engine.epoch
+	err = engine.startNextEpoch(sealingBlock)
+	require.ErrorIs(err, errInvalidEpochInfo)
+	require.Equal(epoch, engine.epoch)
+
+	_, err = engine.HealthCheck(t.Context())
+	require.ErrorIs(err, errInvalidEpochInfo)
+
+	// Once shut down, the engine never starts another epoch.
+	require.NoError(engine.Shutdown(t.Context()))
+	require.NoError(engine.startNextEpoch(sealingBlock))
+	require.Equal(epoch, engine.epoch)
+}
+
 func setupEngineForFuzz(t *testing.T) (*Engine, []*Config) {
 	configs := createSimplexEngineConfig(t, reuseKeys)
 
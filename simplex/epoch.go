@@ -0,0 +1,338 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simplex
+
+//go:generate go tool canoto $GOFILE
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ava-labs/simplex"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	simplexparams "github.com/ava-labs/avalanchego/snow/consensus/simplex"
+)
+
+var (
+	errInvalidEpochInfo        = errors.New("invalid epoch info")
+	errFailedToParseEpochInfo  = errors.New("failed to parse epoch info")
+	errReconfigurationDisabled = errors.New("validator set reconfiguration is disabled")
+	errExpectedTelock          = errors.New("expected telock")
+	errUnexpectedTelock        = errors.New("unexpected telock")
+	errUnknownPChainHeight     = errors.New("unknown P-chain height")
+	errNoValidators            = errors.New("no validators")
+	errMismatchedValidatorSet  = errors.New("mismatched validator set")
+	errUnchangedValidatorSet   = errors.New("unchanged validator set")
+)
+
+// epochInfo is the Simplex epoch information encoded in every block, as
+// described in docs/reconfiguration.md. The genesis block and the blocks of
+// the first epoch have an empty epochInfo.
+//
+// The previous VM block sequence (field 5) and the next epoch approvals
+// (field 7) are not encoded. Without approvals, the first block that observes
+// a change of the validator set on the P-chain is the sealing block.
+type epochInfo struct {
+	// PChainReferenceHeight is the P-chain height the validator set of the
+	// epoch is derived from. It is 0 in the first epoch, whose validators are
+	// the initial validators of the chain.
+	PChainReferenceHeight uint64 `canoto:"uint,1"`
+	// EpochNumber is the sequence number of the sealing block of the previous
+	// epoch, or 0 in the first epoch.
+	EpochNumber uint64 `canoto:"uint,2"`
+	// PrevSealingBlockHash is the digest of the sealing block of the previous
+	// epoch, or empty in the first epoch.
+	PrevSealingBlockHash [32]byte `canoto:"fixed bytes,3"`
+	// NextPChainReferenceHeight is the P-chain height the validator set of the
+	// next epoch is derived from. It is only set in sealing blocks.
+	NextPChainReferenceHeight uint64 `canoto:"uint,4"`
+	// BlockValidationDescriptor is the validator set of the next epoch. Its
+	// presence identifies the sealing block of the epoch.
+	BlockValidationDescriptor *validationDescriptor `canoto:"pointer,6"`
+	// SealingBlockSeq is the sequence number of the sealing block of the epoch
+	// in telocks, and 0 in all other blocks.
+	SealingBlockSeq uint64 `canoto:"uint,8"`
+
+	canotoData canotoData_epochInfo
+}
+
+// validationDescriptor describes how to verify the quorum certificates of the
+// blocks of an epoch.
+type validationDescriptor struct {
+	// Validators are sorted by node ID.
+	Validators []descriptorValidator `canoto:"repeated value,1"`
+
+	canotoData canotoData_validationDescriptor
+}
+
+type descriptorValidator struct {
+	NodeID [ids.NodeIDLen]byte `canoto:"fixed bytes,1"`
+	// PublicKey is the compressed BLS public key of the validator.
+	PublicKey []byte `canoto:"bytes,2"`
+
+	canotoData canotoData_descriptorValidator
+}
+
+func newValidationDescriptor(vdrs []simplexparams.ValidatorInfo) *validationDescriptor {
+	d := &validationDescriptor{
+		Validators: make([]descriptorValidator, len(vdrs)),
+	}
+	for i, vdr := range vdrs {
+		d.Validators[i] = descriptorValidator{
+			NodeID:    vdr.NodeID,
+			PublicKey: vdr.PublicKey,
+		}
+	}
+	return d
+}
+
+func (d *validationDescriptor) validators() []simplexparams.ValidatorInfo {
+	vdrs := make([]simplexparams.ValidatorInfo, len(d.Validators))
+	for i, vdr := range d.Validators {
+		vdrs[i] = simplexparams.ValidatorInfo{
+			NodeID:    vdr.NodeID,
+			PublicKey: vdr.PublicKey,
+		}
+	}
+	return vdrs
+}
+
+func epochInfoFromBytes(b []byte) (epochInfo, error) {
+	var info epochInfo
+	if err := info.UnmarshalCanoto(b); err != nil {
+		return epochInfo{}, fmt.Errorf("%w: %w", errFailedToParseEpochInfo, err)
+	}
+	return info, nil
+}
+
+// isSealingBlock returns true if the block is the last block of its epoch.
+func (e *epochInfo) isSealingBlock() bool {
+	return e.BlockValidationDescriptor != nil
+}
+
+// isTelock returns true if the block was built on top of the sealing block of
+// its epoch. Telocks carry no VM block and are never indexed.
+func (e *epochInfo) isTelock() bool {
+	return e.SealingBlockSeq != 0
+}
+
+// epochState is the state of an epoch, which is derived from the sealing block
+// of the previous epoch.
+type epochState struct {
+	number                uint64
+	pChainReferenceHeight uint64
+	prevSealingBlockHash  simplex.Digest
+	// validators of the epoch, sorted by node ID.
+	validators []simplexparams.ValidatorInfo
+}
+
+// firstEpoch returns the state of the epoch that starts at genesis.
+func firstEpoch(initialValidators []simplexparams.ValidatorInfo) epochState {
+	vdrs := slices.Clone(initialValidators)
+	sortValidators(vdrs)
+	return epochState{
+		validators: vdrs,
+	}
+}
+
+// nextEpoch returns the state of the epoch that follows [sealingBlock].
+func nextEpoch(sealingBlock *Block) epochState {
+	return epochState{
+		number:                sealingBlock.metadata.Seq,
+		pChainReferenceHeight: sealingBlock.epoch.NextPChainReferenceHeight,
+		prevSealingBlockHash:  sealingBlock.digest,
+		validators:            sealingBlock.epoch.BlockValidationDescriptor.validators(),
+	}
+}
+
+// epochTracker computes and verifies the epoch information of the blocks built
+// in an epoch.
+type epochTracker struct {
+	epochState
+
+	subnetID ids.ID
+	// state is used to detect changes of the validator set of the subnet. If
+	// nil, the validator set of the epoch is never changed.
+	state validators.State
+}
+
+// build returns the epoch information of a block built on top of [parent].
+func (t *epochTracker) build(ctx context.Context, parent *Block) (epochInfo, error) {
+	if t.mustBuildTelock(parent) {
+		return t.telockInfo(parent), nil
+	}
+
+	info := t.info()
+	if t.state == nil {
+		return info, nil
+	}
+
+	height, err := t.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return epochInfo{}, fmt.Errorf("failed to get current P-chain height: %w", err)
+	}
+	if height <= t.pChainReferenceHeight {
+		return info, nil
+	}
+
+	vdrs, err := t.validatorsAt(ctx, height)
+	if err != nil {
+		return epochInfo{}, err
+	}
+	// An epoch without validators could never be sealed.
+	if len(vdrs) == 0 || equalValidators(vdrs, t.validators) {
+		return info, nil
+	}
+
+	info.NextPChainReferenceHeight = height
+	info.BlockValidationDescriptor = newValidationDescriptor(vdrs)
+	return info, nil
+}
+
+// verify verifies the epoch information of [b], which is built on top of
+// [parent].
+func (t *epochTracker) verify(ctx context.Context, parent *Block, b *Block) error {
+	info := &b.epoch
+	if info.EpochNumber != t.number ||
+		info.PChainReferenceHeight != t.pChainReferenceHeight ||
+		simplex.Digest(info.PrevSealingBlockHash) != t.prevSealingBlockHash {
+		return fmt.Errorf("%w: block does not belong to epoch %d", errInvalidEpochInfo, t.number)
+	}
+
+	if t.mustBuildTelock(parent) {
+		if b.vmBlock != nil {
+			return errExpectedTelock
+		}
+		if info.SealingBlockSeq != sealingBlockSeq(parent) ||
+			info.NextPChainReferenceHeight != 0 ||
+			info.BlockValidationDescriptor != nil {
+			return fmt.Errorf("%w: telock does not follow the sealing block of epoch %d", errInvalidEpochInfo, t.number)
+		}
+		return nil
+	}
+
+	if b.vmBlock == nil {
+		return errUnexpectedTelock
+	}
+	if info.SealingBlockSeq != 0 {
+		return fmt.Errorf("%w: unexpected sealing block sequence %d", errInvalidEpochInfo, info.SealingBlockSeq)
+	}
+	if parent.epoch.EpochNumber != t.number && parent.digest != t.prevSealingBlockHash {
+		return fmt.Errorf("%w: parent %s is not the sealing block of the previous epoch", errInvalidEpochInfo, parent.digest)
+	}
+	if info.NextPChainReferenceHeight == 0 && info.BlockValidationDescriptor == nil {
+		return nil
+	}
+	return t.verifySealingBlock(ctx, info)
+}
+
+// verifySealingBlock verifies that the validator set of the next epoch is the
+// validator set at a P-chain height that we have observed, and that it differs
+// from the validator set of the epoch.
+func (t *epochTracker) verifySealingBlock(ctx context.Context, info *epochInfo) error {
+	if t.state == nil {
+		return errReconfigurationDisabled
+	}
+	if info.BlockValidationDescriptor == nil || info.NextPChainReferenceHeight <= t.pChainReferenceHeight {
+		return fmt.Errorf("%w: invalid sealing block", errInvalidEpochInfo)
+	}
+
+	height, err := t.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current P-chain height: %w", err)
+	}
+	if info.NextPChainReferenceHeight > height {
+		return fmt.Errorf("%w: %d > %d", errUnknownPChainHeight, info.NextPChainReferenceHeight, height)
+	}
+
+	vdrs, err := t.validatorsAt(ctx, info.NextPChainReferenceHeight)
+	if err != nil {
+		return err
+	}
+	if len(vdrs) == 0 {
+		return fmt.Errorf("%w at P-chain height %d", errNoValidators, info.NextPChainReferenceHeight)
+	}
+	if !equalValidators(vdrs, info.BlockValidationDescriptor.validators()) {
+		return fmt.Errorf("%w at P-chain height %d", errMismatchedValidatorSet, info.NextPChainReferenceHeight)
+	}
+	if equalValidators(vdrs, t.validators) {
+		return fmt.Errorf("%w at P-chain height %d", errUnchangedValidatorSet, info.NextPChainReferenceHeight)
+	}
+	return nil
+}
+
+// mustBuildTelock returns true if [parent] is the sealing block of the epoch or
+// a telock.
+func (t *epochTracker) mustBuildTelock(parent *Block) bool {
+	return parent.epoch.EpochNumber == t.number &&
+		(parent.epoch.isSealingBlock() || parent.epoch.isTelock())
+}
+
+// info returns the epoch information of the blocks of the epoch.
+func (t *epochTracker) info() epochInfo {
+	return epochInfo{
+		PChainReferenceHeight: t.pChainReferenceHeight,
+		EpochNumber:           t.number,
+		PrevSealingBlockHash:  t.prevSealingBlockHash,
+	}
+}
+
+func (t *epochTracker) telockInfo(parent *Block) epochInfo {
+	info := t.info()
+	info.SealingBlockSeq = sealingBlockSeq(parent)
+	return info
+}
+
+// sealingBlockSeq returns the sequence number of the sealing block that
+// [parent], a sealing block or a telock, follows or is.
+func sealingBlockSeq(parent *Block) uint64 {
+	if parent.epoch.isSealingBlock() {
+		return parent.metadata.Seq
+	}
+	return parent.epoch.SealingBlockSeq
+}
+
+// validatorsAt returns the validators of the subnet with a BLS public key at
+// P-chain [height], sorted by node ID.
+func (t *epochTracker) validatorsAt(ctx context.Context, height uint64) ([]simplexparams.ValidatorInfo, error) {
+	vdrSet, err := t.state.GetValidatorSet(ctx, height, t.subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set at P-chain height %d: %w", height, err)
+	}
+
+	vdrs := make([]simplexparams.ValidatorInfo, 0, len(vdrSet))
+	for nodeID, vdr := range vdrSet {
+		// Validators without a BLS public key can't sign.
+		if vdr.PublicKey == nil {
+			continue
+		}
+		vdrs = append(vdrs, simplexparams.ValidatorInfo{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicKeyToCompressedBytes(vdr.PublicKey),
+		})
+	}
+	sortValidators(vdrs)
+	return vdrs, nil
+}
+
+func sortValidators(vdrs []simplexparams.ValidatorInfo) {
+	slices.SortFunc(vdrs, func(a, b simplexparams.ValidatorInfo) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+}
+
+// equalValidators returns true if [a] and [b], which are sorted by node ID,
+// contain the same validators.
+func equalValidators(a, b []simplexparams.ValidatorInfo) bool {
+	return slices.EqualFunc(a, b, func(a, b simplexparams.ValidatorInfo) bool {
+		return a.NodeID == b.NodeID && bytes.Equal(a.PublicKey, b.PublicKey)
+	})
+}
@@ -0,0 +1,394 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simplex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/simplex"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
+	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/blocktest"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	simplexparams "github.com/ava-labs/avalanchego/snow/consensus/simplex"
+)
+
+func TestEpochTrackerBuild(t *testing.T) {
+	var (
+		initialValidators = validatorInfos(generateTestNodes(t, 4))
+		nextValidators    = append(validatorInfos(generateTestNodes(t, 1)), initialValidators[1:]...)
+		sealingInfo       = epochInfo{
+			NextPChainReferenceHeight: 10,
+			BlockValidationDescriptor: newValidationDescriptor(sortedValidators(nextValidators)),
+		}
+		genesis      = &Block{}
+		sealingBlock = &Block{
+			metadata: simplex.ProtocolMetadata{Seq: 5},
+			epoch:    sealingInfo,
+		}
+		telock = &Block{
+			metadata: simplex.ProtocolMetadata{Seq: 6},
+			epoch:    epochInfo{SealingBlockSeq: 5},
+		}
+	)
+
+	tests := []struct {
+		name         string
+		state        validators.State
+		parent       *Block
+		expectedInfo epochInfo
+	}{
+		{
+			name:   "static validator set",
+			parent: genesis,
+		},
+		{
+			name:   "P-chain height not increased",
+			state:  newTestValidatorState(t, 0, nil),
+			parent: genesis,
+		},
+		{
+			name: "unchanged validator set",
+			state: newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+				return initialValidators
+			}),
+			parent: genesis,
+		},
+		{
+			name: "no validators",
+			state: newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+				return nil
+			}),
+			parent: genesis,
+		},
+		{
+			name: "changed validator set",
+			state: newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+				return nextValidators
+			}),
+			parent:       genesis,
+			expectedInfo: sealingInfo,
+		},
+		{
+			name:         "parent is sealing block",
+			parent:       sealingBlock,
+			expectedInfo: epochInfo{SealingBlockSeq: 5},
+		},
+		{
+			name:         "parent is telock",
+			parent:       telock,
+			expectedInfo: epochInfo{SealingBlockSeq: 5},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tracker := &epochTracker{
+				epochState: firstEpoch(initialValidators),
+				state:      test.state,
+			}
+			info, err := tracker.build(t.Context(), test.parent)
+			require.NoError(err)
+			require.Equal(test.expectedInfo.MarshalCanoto(), info.MarshalCanoto())
+		})
+	}
+}
+
+func TestEpochTrackerVerify(t *testing.T) {
+	var (
+		initialValidators = validatorInfos(generateTestNodes(t, 4))
+		nextValidators    = append(validatorInfos(generateTestNodes(t, 1)), initialValidators[1:]...)
+		otherValidators   = validatorInfos(generateTestNodes(t, 4))
+		nextValidatorsAt  = func(height uint64) []simplexparams.ValidatorInfo {
+			if height < 10 {
+				return initialValidators
+			}
+			return nextValidators
+		}
+		vmBlock = snowmantest.BuildChild(snowmantest.Genesis)
+
+		genesis      = &Block{}
+		sealingBlock = &Block{
+			digest:   simplex.Digest{1},
+			metadata: simplex.ProtocolMetadata{Seq: 5},
+			epoch: epochInfo{
+				NextPChainReferenceHeight: 10,
+				BlockValidationDescriptor: newValidationDescriptor(sortedValidators(nextValidators)),
+			},
+		}
+		telock = &Block{
+			metadata: simplex.ProtocolMetadata{Seq: 6},
+			epoch:    epochInfo{SealingBlockSeq: 5},
+		}
+		nextEpochInfo = epochInfo{
+			PChainReferenceHeight: 10,
+			EpochNumber:           5,
+			PrevSealingBlockHash:  sealingBlock.digest,
+		}
+	)
+
+	tests := []struct {
+		name        string
+		epoch       epochState
+		state       validators.State
+		parent      *Block
+		block       *Block
+		expectedErr error
+	}{
+		{
+			name:   "valid block",
+			epoch:  firstEpoch(initialValidators),
+			parent: genesis,
+			block:  &Block{vmBlock: vmBlock},
+		},
+		{
+			name:        "wrong epoch number",
+			epoch:       firstEpoch(initialValidators),
+			parent:      genesis,
+			block:       &Block{vmBlock: vmBlock, epoch: epochInfo{EpochNumber: 5}},
+			expectedErr: errInvalidEpochInfo,
+		},
+		{
+			name:        "unexpected telock",
+			epoch:       firstEpoch(initialValidators),
+			parent:      genesis,
+			block:       &Block{},
+			expectedErr: errUnexpectedTelock,
+		},
+		{
+			name:        "unexpected sealing block sequence",
+			epoch:       firstEpoch(initialValidators),
+			parent:      genesis,
+			block:       &Block{vmBlock: vmBlock, epoch: epochInfo{SealingBlockSeq: 5}},
+			expectedErr: errInvalidEpochInfo,
+		},
+		{
+			name:   "valid telock",
+			epoch:  firstEpoch(initialValidators),
+			parent: sealingBlock,
+			block:  &Block{epoch: epochInfo{SealingBlockSeq: 5}},
+		},
+		{
+			name:   "valid telock following telock",
+			epoch:  firstEpoch(initialValidators),
+			parent: telock,
+			block:  &Block{epoch: epochInfo{SealingBlockSeq: 5}},
+		},
+		{
+			name:        "expected telock",
+			epoch:       firstEpoch(initialValidators),
+			parent:      sealingBlock,
+			block:       &Block{vmBlock: vmBlock, epoch: epochInfo{SealingBlockSeq: 5}},
+			expectedErr: errExpectedTelock,
+		},
+		{
+			name:        "telock following wrong sealing block",
+			epoch:       firstEpoch(initialValidators),
+			parent:      telock,
+			block:       &Block{epoch: epochInfo{SealingBlockSeq: 6}},
+			expectedErr: errInvalidEpochInfo,
+		},
+		{
+			name:        "reconfiguration disabled",
+			epoch:       firstEpoch(initialValidators),
+			parent:      genesis,
+			block:       &Block{vmBlock: vmBlock, epoch: sealingBlock.epoch},
+			expectedErr: errReconfigurationDisabled,
+		},
+		{
+			name:   "valid sealing block",
+			epoch:  firstEpoch(initialValidators),
+			state:  newTestValidatorState(t, 10, nextValidatorsAt),
+			parent: genesis,
+			block:  &Block{vmBlock: vmBlock, epoch: sealingBlock.epoch},
+		},
+		{
+			name:   "sealing block without validation descriptor",
+			epoch:  firstEpoch(initialValidators),
+			state:  newTestValidatorState(t, 10, nextValidatorsAt),
+			parent: genesis,
+			block: &Block{vmBlock: vmBlock, epoch: epochInfo{
+				NextPChainReferenceHeight: 10,
+			}},
+			expectedErr: errInvalidEpochInfo,
+		},
+		{
+			name:        "unknown P-chain height",
+			epoch:       firstEpoch(initialValidators),
+			state:       newTestValidatorState(t, 9, nextValidatorsAt),
+			parent:      genesis,
+			block:       &Block{vmBlock: vmBlock, epoch: sealingBlock.epoch},
+			expectedErr: errUnknownPChainHeight,
+		},
+		{
+			name:  "no validators",
+			epoch: firstEpoch(initialValidators),
+			state: newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+				return nil
+			}),
+			parent: genesis,
+			block: &Block{vmBlock: vmBlock, epoch: epochInfo{
+				NextPChainReferenceHeight: 10,
+				BlockValidationDescriptor: &validationDescriptor{},
+			}},
+			expectedErr: errNoValidators,
+		},
+		{
+			name:   "mismatched validator set",
+			epoch:  firstEpoch(initialValidators),
+			state:  newTestValidatorState(t, 10, nextValidatorsAt),
+			parent: genesis,
+			block: &Block{vmBlock: vmBlock, epoch: epochInfo{
+				NextPChainReferenceHeight: 10,
+				BlockValidationDescriptor: newValidationDescriptor(sortedValidators(otherValidators)),
+			}},
+			expectedErr: errMismatchedValidatorSet,
+		},
+		{
+			name:  "unchanged validator set",
+			epoch: firstEpoch(initialValidators),
+			state: newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+				return initialValidators
+			}),
+			parent: genesis,
+			block: &Block{vmBlock: vmBlock, epoch: epochInfo{
+				NextPChainReferenceHeight: 10,
+				BlockValidationDescriptor: newValidationDescriptor(sortedValidators(initialValidators)),
+			}},
+			expectedErr: errUnchangedValidatorSet,
+		},
+		{
+			name:   "first block of next epoch",
+			epoch:  nextEpoch(sealingBlock),
+			parent: sealingBlock,
+			block:  &Block{vmBlock: vmBlock, epoch: nextEpochInfo},
+		},
+		{
+			name:   "parent is not the sealing block of the previous epoch",
+			epoch:  nextEpoch(sealingBlock),
+			parent: genesis,
+			block:  &Block{vmBlock: vmBlock, epoch: nextEpochInfo},
+
+			expectedErr: errInvalidEpochInfo,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := &epochTracker{
+				epochState: test.epoch,
+				state:      test.state,
+			}
+			err := tracker.verify(t.Context(), test.parent, test.block)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestTelockSerialization(t *testing.T) {
+	require := require.New(t)
+	ctx := t.Context()
+
+	nextValidators := validatorInfos(generateTestNodes(t, 4))
+	genesis := newTestBlock(t, newBlockConfig{})
+	sealingBlock := withEpochInfo(t, newTestBlock(t, newBlockConfig{prev: genesis}), epochInfo{
+		NextPChainReferenceHeight: 10,
+		BlockValidationDescriptor: newValidationDescriptor(sortedValidators(nextValidators)),
+	})
+	telock := withEpochInfo(t, &Block{
+		metadata: simplex.ProtocolMetadata{
+			Round: sealingBlock.metadata.Round + 1,
+			Seq:   sealingBlock.metadata.Seq + 1,
+			Prev:  sealingBlock.digest,
+		},
+	}, epochInfo{SealingBlockSeq: sealingBlock.metadata.Seq})
+
+	testVM := &blocktest.VM{
+		VM: enginetest.VM{
+			T: t,
+		},
+	}
+	testVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		vmBlock := sealingBlock.vmBlock.(*wrappedBlock).Block
+		require.Equal(vmBlock.Bytes(), b)
+		return vmBlock, nil
+	}
+	deserializer := &blockDeserializer{
+		parser:       testVM,
+		blockTracker: genesis.blockTracker,
+	}
+
+	for _, expectedBlock := range []*Block{sealingBlock, telock} {
+		expectedBytes, err := expectedBlock.Bytes()
+		require.NoError(err)
+
+		parsedBlock, err := deserializer.DeserializeBlock(ctx, expectedBytes)
+		require.NoError(err)
+		require.IsType(&Block{}, parsedBlock)
+
+		parsedBytes, err := parsedBlock.(*Block).Bytes()
+		require.NoError(err)
+		require.Equal(expectedBytes, parsedBytes)
+		require.Equal(expectedBlock.digest, parsedBlock.BlockHeader().Digest)
+		require.Equal(expectedBlock.epoch.MarshalCanoto(), parsedBlock.(*Block).epoch.MarshalCanoto())
+	}
+}
+
+// newTestValidatorState returns a validator state at P-chain [currentHeight]
+// in which the subnet is validated by vdrsAt(height).
+func newTestValidatorState(
+	t *testing.T,
+	currentHeight uint64,
+	vdrsAt func(height uint64) []simplexparams.ValidatorInfo,
+) *validatorstest.State {
+	return &validatorstest.State{
+		T: t,
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return currentHeight, nil
+		},
+		GetValidatorSetF: func(_ context.Context, height uint64, _ ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			vdrs := vdrsAt(height)
+			vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrs))
+			for _, vdr := range vdrs {
+				pk, err := bls.PublicKeyFromCompressedBytes(vdr.PublicKey)
+				require.NoError(t, err)
+				vdrSet[vdr.NodeID] = &validators.GetValidatorOutput{
+					NodeID:    vdr.NodeID,
+					PublicKey: pk,
+					Weight:    1,
+				}
+			}
+			return vdrSet, nil
+		},
+	}
+}
+
+func validatorInfos(nodes []*testNode) []simplexparams.ValidatorInfo {
+	vdrs := make([]simplexparams.ValidatorInfo, len(nodes))
+	for i, node := range nodes {
+		vdrs[i] = node.ValidatorInfo
+	}
+	return vdrs
+}
+
+func sortedValidators(vdrs []simplexparams.ValidatorInfo) []simplexparams.ValidatorInfo {
+	return firstEpoch(vdrs).validators
+}
+
+// withEpochInfo sets the epoch info of [block] and recomputes its digest.
+func withEpochInfo(t *testing.T, block *Block, info epochInfo) *Block {
+	block.epoch = info
+	blockBytes, err := block.Bytes()
+	require.NoError(t, err)
+	block.digest = computeDigest(blockBytes)
+	return block
+}
@@ -10,12 +10,14 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 
 	"github.com/ava-labs/simplex"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -33,9 +35,11 @@ var (
 	errUnexpectedSeq    = errors.New("unexpected sequence number")
 	errInvalidQC        = errors.New("invalid quorum certificate")
 	errMismatchedDigest = errors.New("mismatched digest in finalization")
+	errUnexpectedBlock  = errors.New("unexpected block type")
 
 	finalizationPrefix = []byte("f")
 	blacklistPrefix    = []byte("b")
+	epochPrefix        = []byte("e")
 )
 
 type Storage struct {
@@ -51,8 +55,18 @@ type Storage struct {
 	// lastIndexed is the last indexed block digest.
 	lastIndexedDigest simplex.Digest
 
-	// deserializer is used to deserialize quorum certificates from bytes.
-	deserializer *QCDeserializer
+	networkID uint32
+	chainID   ids.ID
+
+	// deserializersLock protects deserializers
+	deserializersLock sync.Mutex
+	// deserializers are used to deserialize the quorum certificates of the
+	// blocks of each epoch.
+	deserializers map[uint64]*QCDeserializer
+
+	// sealingBlocks receives the sealing blocks of epochs once they are
+	// indexed.
+	sealingBlocks chan *Block
 
 	// blockTracker is used to manage blocks that have been indexed.
 	blockTracker *blockTracker
@@ -75,9 +89,14 @@ func newStorage(ctx context.Context, config *Config, qcDeserializer *QCDeseriali
 		db:           config.DB,
 		genesisBlock: genesisBlock,
 		vm:           config.VM,
-		deserializer: qcDeserializer,
-		blockTracker: blockTracker,
-		log:          config.Log,
+		networkID:    config.Ctx.NetworkID,
+		chainID:      config.Ctx.ChainID,
+		deserializers: map[uint64]*QCDeserializer{
+			0: qcDeserializer,
+		},
+		sealingBlocks: make(chan *Block, 1),
+		blockTracker:  blockTracker,
+		log:           config.Log,
 	}
 
 	lastAccepted, err := config.VM.LastAccepted(ctx)
@@ -121,7 +140,12 @@ func (s *Storage) Retrieve(seq uint64) (simplex.VerifiedBlock, simplex.Finalizat
 		return nil, simplex.Finalization{}, err
 	}
 
-	finalization, err := s.retrieveFinalization(seq)
+	epoch, err := s.retrieveEpochInfo(seq)
+	if err != nil {
+		return nil, simplex.Finalization{}, err
+	}
+
+	finalization, err := s.retrieveFinalization(seq, epoch.EpochNumber)
 	if err != nil {
 		return nil, simplex.Finalization{}, err
 	}
@@ -131,7 +155,7 @@ func (s *Storage) Retrieve(seq uint64) (simplex.VerifiedBlock, simplex.Finalizat
 		return nil, simplex.Finalization{}, err
 	}
 
-	vb, err := newBlock(finalization.Finalization.ProtocolMetadata, blacklist, block, s.blockTracker)
+	vb, err := newBlock(finalization.Finalization.ProtocolMetadata, blacklist, epoch, block, s.blockTracker)
 	if err != nil {
 		s.log.Error("failed to create simplex block", zap.Uint64("seq", seq), zap.Error(err))
 		return nil, simplex.Finalization{}, err
@@ -142,8 +166,22 @@ func (s *Storage) Retrieve(seq uint64) (simplex.VerifiedBlock, simplex.Finalizat
 
 // Index indexes the finalization in the storage.
 // It stores the finalization bytes and increments numBlocks.
+// Telocks are not indexed, and indexing a sealing block ends its epoch.
 func (s *Storage) Index(ctx context.Context, block simplex.VerifiedBlock, finalization simplex.Finalization) error {
+	simplexBlock, ok := block.(*Block)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnexpectedBlock, block)
+	}
+
 	bh := block.BlockHeader()
+	if simplexBlock.epoch.isTelock() {
+		s.log.Debug("Skipping indexing of telock",
+			zap.Uint64("seq", bh.Seq),
+			zap.Uint64("sealingBlockSeq", simplexBlock.epoch.SealingBlockSeq),
+		)
+		return nil
+	}
+
 	numBlocks := s.numBlocks.Load()
 	if numBlocks != bh.Seq {
 		s.log.Error("Attempted to index block with mismatched sequence number",
@@ -172,6 +210,10 @@ func (s *Storage) Index(ctx context.Context, block simplex.VerifiedBlock, finali
 		return errInvalidQC
 	}
 
+	if err := s.db.Put(epochKey(bh.Seq), simplexBlock.epoch.MarshalCanoto()); err != nil {
+		return fmt.Errorf("failed to store epoch info: %w", err)
+	}
+
 	finalizationBytes := finalizationToBytes(finalization)
 	if err := s.db.Put(finalizationKey(bh.Seq), finalizationBytes); err != nil {
 		return fmt.Errorf("failed to store finalization: %w", err)
@@ -189,6 +231,19 @@ func (s *Storage) Index(ctx context.Context, block simplex.VerifiedBlock, finali
 
 	s.numBlocks.Add(1) // only increment numBlocks after successful indexing
 	s.lastIndexedDigest = bh.Digest
+
+	if simplexBlock.epoch.isSealingBlock() {
+		s.log.Info("Indexed sealing block",
+			zap.Uint64("seq", bh.Seq),
+			zap.Uint64("epoch", simplexBlock.epoch.EpochNumber),
+			zap.Uint64("nextPChainReferenceHeight", simplexBlock.epoch.NextPChainReferenceHeight),
+		)
+		select {
+		case s.sealingBlocks <- simplexBlock:
+		default:
+			s.log.Error("Dropped sealing block", zap.Uint64("seq", bh.Seq))
+		}
+	}
 	return nil
 }
 
@@ -206,6 +261,13 @@ func blacklistKey(seq uint64) []byte {
 	return seqBuff
 }
 
+func epochKey(seq uint64) []byte {
+	seqBuff := make([]byte, len(epochPrefix)+8)
+	copy(seqBuff, epochPrefix)
+	binary.BigEndian.PutUint64(seqBuff[len(epochPrefix):], seq)
+	return seqBuff
+}
+
 // getGenesisBlock returns the genesis block wrapped as a Block instance.
 func getGenesisBlock(ctx context.Context, config *Config, blockTracker *blockTracker) (*Block, error) {
 	snowmanGenesis, err := getBlock(ctx, config.VM, 0)
@@ -230,9 +292,10 @@ func getGenesisBlock(ctx context.Context, config *Config, blockTracker *blockTra
 	return genesis, nil
 }
 
-// retrieveFinalization retrieves the finalization at [seq].
+// retrieveFinalization retrieves the finalization at [seq], which belongs to
+// [epoch].
 // If the finalization is not found, it returns false.
-func (s *Storage) retrieveFinalization(seq uint64) (simplex.Finalization, error) {
+func (s *Storage) retrieveFinalization(seq uint64, epoch uint64) (simplex.Finalization, error) {
 	finalizationBytes, err := s.db.Get(finalizationKey(seq))
 	if err != nil {
 		if err == database.ErrNotFound {
@@ -247,7 +310,54 @@ func (s *Storage) retrieveFinalization(seq uint64) (simplex.Finalization, error)
 		return simplex.Finalization{}, err
 	}
 
-	return canotoFinalization.toFinalization(s.deserializer)
+	deserializer, err := s.qcDeserializer(epoch)
+	if err != nil {
+		return simplex.Finalization{}, err
+	}
+	return canotoFinalization.toFinalization(deserializer)
+}
+
+// retrieveEpochInfo retrieves the epoch info of the block at [seq].
+func (s *Storage) retrieveEpochInfo(seq uint64) (epochInfo, error) {
+	epochBytes, err := s.db.Get(epochKey(seq))
+	if err != nil {
+		if err == database.ErrNotFound {
+			return epochInfo{}, nil
+		}
+		s.log.Debug("Failed to retrieve epoch info", zap.Uint64("seq", seq), zap.Error(err))
+		return epochInfo{}, err
+	}
+	return epochInfoFromBytes(epochBytes)
+}
+
+// qcDeserializer returns the deserializer of the quorum certificates of the
+// blocks of [epoch]. The validators of an epoch, other than the first, are
+// read from the sealing block of the previous epoch.
+func (s *Storage) qcDeserializer(epoch uint64) (*QCDeserializer, error) {
+	s.deserializersLock.Lock()
+	defer s.deserializersLock.Unlock()
+
+	if deserializer, ok := s.deserializers[epoch]; ok {
+		return deserializer, nil
+	}
+
+	sealingEpoch, err := s.retrieveEpochInfo(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if !sealingEpoch.isSealingBlock() {
+		return nil, fmt.Errorf("%w: block %d is not a sealing block", errInvalidEpochInfo, epoch)
+	}
+
+	verifier, err := newBLSVerifier(s.networkID, s.chainID, sealingEpoch.BlockValidationDescriptor.validators())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create verifier of epoch %d: %w", epoch, err)
+	}
+	deserializer := &QCDeserializer{
+		verifier: &verifier,
+	}
+	s.deserializers[epoch] = deserializer
+	return deserializer, nil
 }
 
 func (s *Storage) retrieveBlacklist(seq uint64) (simplex.Blacklist, error) {
@@ -14,6 +14,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
+
+	simplexparams "github.com/ava-labs/avalanchego/snow/consensus/simplex"
 )
 
 func TestStorageNew(t *testing.T) {
@@ -311,3 +313,90 @@ func TestStorageIndexSuccess(t *testing.T) {
 
 	require.Equal(t, uint64(numBlocks+1), s.NumBlocks())
 }
+
+func TestStorageIndexSealingBlock(t *testing.T) {
+	require := require.New(t)
+	ctx := t.Context()
+
+	genesis := newTestBlock(t, newBlockConfig{numNodes: 4})
+	configs := newNetworkConfigs(t, 4)
+	nextConfigs := newNetworkConfigs(t, 4)
+	for _, config := range nextConfigs {
+		config.Ctx.ChainID = configs[0].Ctx.ChainID
+	}
+	nextValidators := nextConfigs[0].Params.InitialValidators
+
+	_, verifier, err := NewBLSAuth(configs[0])
+	require.NoError(err)
+	qc := QCDeserializer{verifier: &verifier}
+	configs[0].VM = genesis.vmBlock.(*wrappedBlock).vm
+
+	s, err := newStorage(ctx, configs[0], &qc, genesis.blockTracker)
+	require.NoError(err)
+
+	genesis.blockTracker.setEpoch(&epochTracker{
+		epochState: firstEpoch(configs[0].Params.InitialValidators),
+		state: newTestValidatorState(t, 10, func(uint64) []simplexparams.ValidatorInfo {
+			return nextValidators
+		}),
+	})
+
+	sealingBlock := withEpochInfo(t, newTestBlock(t, newBlockConfig{prev: genesis}), epochInfo{
+		NextPChainReferenceHeight: 10,
+		BlockValidationDescriptor: newValidationDescriptor(sortedValidators(nextValidators)),
+	})
+	_, err = sealingBlock.Verify(ctx)
+	require.NoError(err)
+	sealingFin := newTestFinalization(t, configs, sealingBlock.BlockHeader())
+	require.NoError(s.Index(ctx, sealingBlock, sealingFin))
+	require.Equal(sealingBlock, <-s.sealingBlocks)
+
+	// Telocks are finalized by the epoch being sealed, but are not indexed.
+	telock := withEpochInfo(t, &Block{
+		metadata: simplex.ProtocolMetadata{
+			Version: 1,
+			Epoch:   1,
+			Round:   sealingBlock.metadata.Round + 1,
+			Seq:     sealingBlock.metadata.Seq + 1,
+			Prev:    sealingBlock.digest,
+		},
+		blockTracker: genesis.blockTracker,
+	}, epochInfo{SealingBlockSeq: sealingBlock.metadata.Seq})
+	_, err = telock.Verify(ctx)
+	require.NoError(err)
+	require.NoError(s.Index(ctx, telock, newTestFinalization(t, configs, telock.BlockHeader())))
+	require.Equal(uint64(2), s.NumBlocks())
+
+	// The next epoch is finalized by the new validator set.
+	genesis.blockTracker.setEpoch(&epochTracker{
+		epochState: nextEpoch(sealingBlock),
+	})
+	child := withEpochInfo(t, newTestBlock(t, newBlockConfig{
+		prev:  sealingBlock,
+		round: telock.metadata.Round + 1,
+	}), epochInfo{
+		PChainReferenceHeight: 10,
+		EpochNumber:           sealingBlock.metadata.Seq,
+		PrevSealingBlockHash:  sealingBlock.digest,
+	})
+	_, err = child.Verify(ctx)
+	require.NoError(err)
+	childFin := newTestFinalization(t, nextConfigs, child.BlockHeader())
+	require.NoError(s.Index(ctx, child, childFin))
+	require.Empty(s.sealingBlocks)
+	require.Equal(uint64(3), s.NumBlocks())
+
+	for seq, expectedBlock := range []*Block{sealingBlock, child} {
+		gotBlock, gotFin, err := s.Retrieve(uint64(seq + 1))
+		require.NoError(err)
+
+		expectedBytes, err := expectedBlock.Bytes()
+		require.NoError(err)
+		gotBytes, err := gotBlock.Bytes()
+		require.NoError(err)
+		require.Equal(expectedBytes, gotBytes)
+
+		// The finalization must be verified by the validators of its epoch.
+		require.NoError(gotFin.Verify())
+	}
+}
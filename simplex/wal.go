@@ -0,0 +1,68 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simplex
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/ava-labs/simplex"
+)
+
+const (
+	// epochRecordType prefixes records that are tagged with an epoch. Simplex
+	// records start with their uint16 record type, which is never this value,
+	// so records written before they were tagged can be told apart.
+	epochRecordType uint16 = math.MaxUint16
+
+	epochRecordTypeLen = 2
+	epochLen           = 8
+	epochTagLen        = epochRecordTypeLen + epochLen
+)
+
+var _ simplex.WriteAheadLog = (*epochWAL)(nil)
+
+// epochWAL tags the records of a write ahead log with the epoch they were
+// written in, so that an epoch is only restored from its own records.
+//
+// Untagged records were written before epochs were introduced, and are treated
+// as records of the first epoch.
+type epochWAL struct {
+	simplex.WriteAheadLog
+	epoch uint64
+}
+
+func (w *epochWAL) Append(record []byte) error {
+	taggedRecord := make([]byte, epochTagLen, epochTagLen+len(record))
+	binary.BigEndian.PutUint16(taggedRecord, epochRecordType)
+	binary.BigEndian.PutUint64(taggedRecord[epochRecordTypeLen:], w.epoch)
+	return w.WriteAheadLog.Append(append(taggedRecord, record...))
+}
+
+// ReadAll returns the records written in the epoch, without their tags.
+func (w *epochWAL) ReadAll() ([][]byte, error) {
+	taggedRecords, err := w.WriteAheadLog.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([][]byte, 0, len(taggedRecords))
+	for _, taggedRecord := range taggedRecords {
+		epoch, record := parseEpochRecord(taggedRecord)
+		if epoch != w.epoch {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseEpochRecord returns the epoch that [taggedRecord] was written in and the
+// record without its tag.
+func parseEpochRecord(taggedRecord []byte) (uint64, []byte) {
+	if len(taggedRecord) < epochTagLen || binary.BigEndian.Uint16(taggedRecord) != epochRecordType {
+		return 0, taggedRecord
+	}
+	return binary.BigEndian.Uint64(taggedRecord[epochRecordTypeLen:]), taggedRecord[epochTagLen:]
+}
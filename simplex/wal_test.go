@@ -0,0 +1,55 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simplex
+
+import (
+	"testing"
+
+	"github.com/ava-labs/simplex/wal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpochWAL(t *testing.T) {
+	require := require.New(t)
+
+	memWAL := wal.NewMemWAL(t)
+	epoch0 := &epochWAL{WriteAheadLog: memWAL}
+	epoch1 := &epochWAL{WriteAheadLog: memWAL, epoch: 1}
+
+	require.NoError(epoch0.Append([]byte{0}))
+	require.NoError(epoch1.Append([]byte{1}))
+	require.NoError(epoch1.Append(nil))
+
+	records, err := epoch0.ReadAll()
+	require.NoError(err)
+	require.Equal([][]byte{{0}}, records)
+
+	records, err = epoch1.ReadAll()
+	require.NoError(err)
+	require.Equal([][]byte{{1}, {}}, records)
+
+	records, err = (&epochWAL{WriteAheadLog: memWAL, epoch: 2}).ReadAll()
+	require.NoError(err)
+	require.Empty(records)
+}
+
+func TestEpochWALUntaggedRecords(t *testing.T) {
+	require := require.New(t)
+
+	memWAL := wal.NewMemWAL(t)
+	require.NoError(memWAL.Append([]byte{0}))
+	require.NoError(memWAL.Append([]byte{0, 1, 2}))
+
+	epoch0 := &epochWAL{WriteAheadLog: memWAL}
+	require.NoError(epoch0.Append([]byte{3}))
+	require.NoError((&epochWAL{WriteAheadLog: memWAL, epoch: 1}).Append([]byte{4}))
+
+	records, err := epoch0.ReadAll()
+	require.NoError(err)
+	require.Equal([][]byte{{0}, {0, 1, 2}, {3}}, records)
+
+	records, err = (&epochWAL{WriteAheadLog: memWAL, epoch: 1}).ReadAll()
+	require.NoError(err)
+	require.Equal([][]byte{{4}}, records)
+}
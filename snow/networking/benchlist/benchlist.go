@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/heap"
 	"github.com/ava-labs/avalanchego/utils/math"
@@ -36,6 +37,8 @@ const (
 	eventQueueInitSize = 16
 )
 
+var errInvalidMaxPortion = errors.New("invalid max portion of benched stake")
+
 // Config defines the configuration for a benchlist
 type Config struct {
 	Halflife           time.Duration `json:"halflife"`
@@ -45,6 +48,13 @@ type Config struct {
 	MaxPortion         float64       `json:"maxPortion"`
 }
 
+func (c *Config) Verify() error {
+	if c.MaxPortion < 0 || c.MaxPortion >= 1 {
+		return fmt.Errorf("%w: must be in [0,1) but got %f", errInvalidMaxPortion, c.MaxPortion)
+	}
+	return nil
+}
+
 // event is a raw success/failure observation sent from any goroutine to the
 // single consumer goroutine that owns all mutable node state. The observation
 // time is captured at enqueue so the EWMA sees accurate timestamps even if the
@@ -87,11 +97,9 @@ type benchlist struct {
 	numBenched    prometheus.Gauge
 	weightBenched prometheus.Gauge
 
-	halflife           time.Duration
-	unbenchProbability float64
-	benchProbability   float64
-	benchDuration      time.Duration
-	maxPortion         float64
+	// config may be replaced while the benchlist is running. Changes apply
+	// to the observations processed after the change.
+	config utils.Atomic[Config]
 
 	// Event queue: producers push observations; the single consumer goroutine
 	// pops and processes them. The queue is unbounded so producers never block.
@@ -123,8 +131,8 @@ func newBenchlist(
 	config Config,
 	reg prometheus.Registerer,
 ) (*benchlist, error) {
-	if config.MaxPortion < 0 || config.MaxPortion >= 1 {
-		return nil, fmt.Errorf("max portion of benched stake must be in [0,1) but got %f", config.MaxPortion)
+	if err := config.Verify(); err != nil {
+		return nil, err
 	}
 
 	b := &benchlist{
@@ -141,18 +149,14 @@ func newBenchlist(
 			Help: "Weight of currently benched validators",
 		}),
 
-		halflife:           config.Halflife,
-		unbenchProbability: config.UnbenchProbability,
-		benchProbability:   config.BenchProbability,
-		benchDuration:      config.BenchDuration,
-		maxPortion:         config.MaxPortion,
-		events:             buffer.NewUnboundedDeque[event](eventQueueInitSize),
-		eventReady:         make(chan struct{}, 1),
-		nodes:              make(map[ids.NodeID]*node),
-		timeoutHeap:        heap.NewMap[ids.NodeID, time.Time](time.Time.Before),
-		shutdownChan:       make(chan struct{}),
-		shutdownDone:       make(chan struct{}),
+		events:       buffer.NewUnboundedDeque[event](eventQueueInitSize),
+		eventReady:   make(chan struct{}, 1),
+		nodes:        make(map[ids.NodeID]*node),
+		timeoutHeap:  heap.NewMap[ids.NodeID, time.Time](time.Time.Before),
+		shutdownChan: make(chan struct{}),
+		shutdownDone: make(chan struct{}),
 	}
+	b.config.Set(config)
 
 	err := errors.Join(
 		reg.Register(b.numBenched),
@@ -246,7 +250,10 @@ func (b *benchlist) processEvents() {
 // processObservation updates a node's EWMA and transitions bench state if the
 // failure probability crosses a threshold.
 func (b *benchlist) processObservation(ev event) {
-	nodeID := ev.nodeID
+	var (
+		nodeID = ev.nodeID
+		config = b.config.Get()
+	)
 
 	n, ok := b.nodes[nodeID]
 	if b.vdrs.GetWeight(b.ctx.SubnetID, nodeID) == 0 {
@@ -269,13 +276,13 @@ func (b *benchlist) processObservation(ev event) {
 	p := n.failureProbability.Read()
 
 	switch {
-	case !n.isBenched && p > b.benchProbability:
+	case !n.isBenched && p > config.BenchProbability:
 		if !b.tryMakeRoom(nodeID, p) {
 			return
 		}
 
 		n.isBenched = true
-		b.timeoutHeap.Push(nodeID, time.Now().Add(b.benchDuration))
+		b.timeoutHeap.Push(nodeID, time.Now().Add(config.BenchDuration))
 
 		b.lock.Lock()
 		b.benched.Add(nodeID)
@@ -286,7 +293,7 @@ func (b *benchlist) processObservation(ev event) {
 			zap.Float64("failureProbability", p),
 		)
 		b.benchable.Benched(b.ctx.ChainID, nodeID)
-	case n.isBenched && p < b.unbenchProbability:
+	case n.isBenched && p < config.UnbenchProbability:
 		n.isBenched = false
 		b.timeoutHeap.Remove(nodeID)
 
@@ -338,7 +345,7 @@ func (b *benchlist) processTimeouts() {
 // newFailureProbabilityAverager creates a failure probability averager with an
 // optimistic prior to slightly favor newly tracked nodes.
 func (b *benchlist) newFailureProbabilityAverager(now time.Time) math.Averager {
-	return math.NewAverager(success, b.halflife, now)
+	return math.NewAverager(success, b.config.Get().Halflife, now)
 }
 
 // tryMakeRoom checks whether benching nodeID fits within maxPortion.
@@ -366,7 +373,7 @@ func (b *benchlist) tryMakeRoom(nodeID ids.NodeID, incomingFailureProbability fl
 		return false
 	}
 
-	maxBenchedStake := float64(totalStake) * b.maxPortion
+	maxBenchedStake := float64(totalStake) * b.config.Get().MaxPortion
 
 	// Fast path: benching fits directly without eviction.
 	newBenchedStake, err := math.Add(benchedStake, incomingStake)
@@ -36,6 +36,9 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// SetConfig replaces the config of the benchlists of all chains. Nodes
+	// that are already benched stay benched for their original duration.
+	SetConfig(config Config) error
 	// Shutdown stops all chain benchlists.
 	Shutdown()
 }
@@ -123,6 +126,21 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	return nil
 }
 
+func (m *manager) SetConfig(config Config) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.config = config
+	for _, benchlist := range m.chains {
+		benchlist.config.Set(config)
+	}
+	return nil
+}
+
 func (m *manager) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
 	m.lock.RLock()
 	benchlist, ok := m.chains[chainID]
@@ -181,4 +199,8 @@ func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
 
+func (noBenchlist) SetConfig(Config) error {
+	return nil
+}
+
 func (noBenchlist) Shutdown() {}
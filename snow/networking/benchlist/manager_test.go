@@ -5,6 +5,7 @@ package benchlist
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -65,3 +66,45 @@ func TestManagerShutdownStopsChainBenchlists(t *testing.T) {
 	require.Empty(m.chains)
 	m.lock.RUnlock()
 }
+
+func TestManagerSetConfig(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, ids.GenerateTestID())
+	ctx := snowtest.ConsensusContext(snowCtx)
+	ctx.PrimaryAlias = "chain"
+
+	config := Config{
+		Halflife:           DefaultHalflife,
+		UnbenchProbability: DefaultUnbenchProbability,
+		BenchProbability:   DefaultBenchProbability,
+		BenchDuration:      DefaultBenchDuration,
+		MaxPortion:         0.5,
+	}
+	m := NewManager(
+		noOpBenchable{},
+		validators.NewManager(),
+		metrics.NewPrefixGatherer(),
+		config,
+	).(*manager)
+	defer m.Shutdown()
+	require.NoError(m.RegisterChain(ctx))
+
+	newConfig := config
+	newConfig.BenchProbability = 0.9
+	newConfig.BenchDuration = time.Minute
+	require.NoError(m.SetConfig(newConfig))
+	require.Equal(newConfig, m.chains[ctx.ChainID].config.Get())
+
+	// Chains registered later use the new config.
+	snowCtx = snowtest.Context(t, ids.GenerateTestID())
+	ctx = snowtest.ConsensusContext(snowCtx)
+	ctx.PrimaryAlias = "otherChain"
+	require.NoError(m.RegisterChain(ctx))
+	require.Equal(newConfig, m.chains[ctx.ChainID].config.Get())
+
+	invalidConfig := newConfig
+	invalidConfig.MaxPortion = 1
+	require.ErrorIs(m.SetConfig(invalidConfig), errInvalidMaxPortion)
+	require.Equal(newConfig, m.chains[ctx.ChainID].config.Get())
+}
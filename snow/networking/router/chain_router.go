@@ -467,7 +467,7 @@ func (cr *ChainRouter) AddChain(ctx context.Context, chain handler.Handler) {
 		zap.Stringer("chainID", chainID),
 	)
 	chain.SetOnStopped(func() {
-		cr.removeChain(ctx, chainID)
+		cr.removeChain(ctx, chain)
 	})
 	cr.chainHandlers[chainID] = chain
 
@@ -668,6 +668,13 @@ func (cr *ChainRouter) Unbenched(chainID ids.ID, nodeID ids.NodeID) {
 	}
 }
 
+func (cr *ChainRouter) SetHealthConfig(healthConfig HealthConfig) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	cr.healthConfig = healthConfig
+}
+
 // HealthCheck returns results of router health checks. Returns:
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
@@ -710,10 +717,13 @@ func (cr *ChainRouter) HealthCheck(context.Context) (interface{}, error) {
 
 // RemoveChain removes the specified chain so that incoming
 // messages can't be routed to it
-func (cr *ChainRouter) removeChain(ctx context.Context, chainID ids.ID) {
+func (cr *ChainRouter) removeChain(ctx context.Context, chain handler.Handler) {
+	chainID := chain.Context().ChainID
+
 	cr.lock.Lock()
-	chain, exists := cr.chainHandlers[chainID]
-	if !exists {
+	// If the chain was stopped and then added again, the new handler must not
+	// be removed.
+	if cr.chainHandlers[chainID] != chain {
 		cr.log.Debug("can't remove unknown chain",
 			zap.Stringer("chainID", chainID),
 		)
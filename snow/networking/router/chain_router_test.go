@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
@@ -68,6 +69,7 @@ func TestShutdown(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -330,6 +332,7 @@ func TestShutdownTimesOut(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -480,6 +483,7 @@ func TestRouterTimeout(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -813,6 +817,7 @@ func TestRouterHonorsRequestedEngine(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -1022,6 +1027,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -1185,6 +1191,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -1664,6 +1671,7 @@ func newChainRouterTest(t *testing.T) (*ChainRouter, *enginetest.Engine) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
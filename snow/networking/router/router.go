@@ -40,6 +40,8 @@ type Router interface {
 	) error
 	Shutdown(context.Context)
 	AddChain(ctx context.Context, chain handler.Handler)
+	// SetHealthConfig replaces the thresholds used by the health check.
+	SetHealthConfig(healthConfig HealthConfig)
 	health.Checker
 }
 
@@ -153,6 +153,10 @@ func (r *tracedRouter) Unbenched(chainID ids.ID, nodeID ids.NodeID) {
 	r.router.Unbenched(chainID, nodeID)
 }
 
+func (r *tracedRouter) SetHealthConfig(healthConfig HealthConfig) {
+	r.router.SetHealthConfig(healthConfig)
+}
+
 func (r *tracedRouter) HealthCheck(ctx context.Context) (interface{}, error) {
 	ctx, span := r.tracer.Start(ctx, "tracedRouter.HealthCheck")
 	defer span.End()
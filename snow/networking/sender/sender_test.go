@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/message/messagemock"
 	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
@@ -66,6 +67,7 @@ func TestTimeout(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -324,6 +326,7 @@ func TestReliableMessages(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
@@ -483,6 +486,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 					TimeoutCoefficient: 1.25,
 				},
 				benchlist,
+				reputation.NoOpReporter,
 				prometheus.NewRegistry(),
 				prometheus.NewRegistry(),
 			)
@@ -1353,6 +1357,7 @@ func newTimeoutManager(t testing.TB, benchlistMgr benchlist.Manager, initialTime
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlistMgr,
+		reputation.NoOpReporter,
 		prometheus.NewRegistry(),
 		prometheus.NewRegistry(),
 	)
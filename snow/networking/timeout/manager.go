@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/reputation"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/timer"
@@ -20,6 +21,7 @@ import (
 func NewManager(
 	timeoutConfig *timer.AdaptiveTimeoutConfig,
 	benchlistMgr benchlist.Manager,
+	reputationReporter reputation.Reporter,
 	requestReg prometheus.Registerer,
 	responseReg prometheus.Registerer,
 ) (*Manager, error) {
@@ -37,18 +39,20 @@ func NewManager(
 	}
 
 	return &Manager{
-		tm:           tm,
-		benchlistMgr: benchlistMgr,
-		metrics:      m,
+		tm:                 tm,
+		benchlistMgr:       benchlistMgr,
+		reputationReporter: reputationReporter,
+		metrics:            m,
 	}, nil
 }
 
 // Manager manages timeouts for requests sent to peers.
 type Manager struct {
-	tm           timer.AdaptiveTimeoutManager
-	benchlistMgr benchlist.Manager
-	metrics      *timeoutMetrics
-	stopOnce     sync.Once
+	tm                 timer.AdaptiveTimeoutManager
+	benchlistMgr       benchlist.Manager
+	reputationReporter reputation.Reporter
+	metrics            *timeoutMetrics
+	stopOnce           sync.Once
 }
 
 // Dispatch starts the manager. Must be called before any other method.
@@ -94,8 +98,9 @@ func (m *Manager) RegisterRequest(
 	newTimeoutHandler := func() {
 		if requestID.Op != byte(message.AppResponseOp) {
 			// If the request timed out and wasn't an AppRequest, tell the
-			// benchlist manager.
+			// benchlist manager and lower the reputation of the peer.
 			m.benchlistMgr.RegisterFailure(chainID, nodeID)
+			m.reputationReporter.RegisterFailure(nodeID)
 		}
 		timeoutHandler()
 	}
@@ -115,6 +120,7 @@ func (m *Manager) RegisterResponse(
 ) {
 	m.metrics.Observe(chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
+	m.reputationReporter.RegisterResponse(nodeID, latency)
 	m.tm.Remove(requestID)
 }
 
@@ -31,6 +31,11 @@ type Tracker interface {
 
 	Connect(nodeID ids.NodeID) error
 	Disconnect(nodeID ids.NodeID) error
+
+	// ConnectedSince returns the time since which [nodeID] has been observed
+	// to be connected. Returns false if [nodeID] isn't connected or if the
+	// uptimes aren't being tracked.
+	ConnectedSince(nodeID ids.NodeID) (time.Time, bool)
 }
 
 type Calculator interface {
@@ -50,6 +55,8 @@ type manager struct {
 	// Whether we have started tracking the uptime of the nodes
 	// This is used to avoid setting the uptime before we have started tracking
 	startedTracking bool
+	// The time at which we last started tracking the uptime of the nodes
+	startedTrackingAt time.Time
 }
 
 func NewManager(state State, clk *mockable.Clock) Manager {
@@ -72,6 +79,7 @@ func (m *manager) StartTracking(nodeIDs []ids.NodeID) error {
 	}
 
 	m.startedTracking = true
+	m.startedTrackingAt = m.clock.UnixTime()
 	return nil
 }
 
@@ -80,10 +88,14 @@ func (m *manager) StopTracking(nodeIDs []ids.NodeID) error {
 		return errNotStartedTracking
 	}
 
+	now := m.clock.UnixTime()
 	for _, nodeID := range nodeIDs {
 		if err := m.updateUptime(nodeID); err != nil {
 			return err
 		}
+		if err := m.recordInterval(nodeID, now); err != nil {
+			return err
+		}
 	}
 
 	m.startedTracking = false
@@ -111,7 +123,24 @@ func (m *manager) Disconnect(nodeID ids.NodeID) error {
 		return nil
 	}
 
-	return m.updateUptime(nodeID)
+	if err := m.updateUptime(nodeID); err != nil {
+		return err
+	}
+	return m.recordInterval(nodeID, m.clock.UnixTime())
+}
+
+func (m *manager) ConnectedSince(nodeID ids.NodeID) (time.Time, bool) {
+	timeConnected, isConnected := m.connections[nodeID]
+	if !m.startedTracking || !isConnected {
+		return time.Time{}, false
+	}
+
+	// Only the time that the peer was connected while we were tracking has
+	// been observed.
+	if timeConnected.Before(m.startedTrackingAt) {
+		timeConnected = m.startedTrackingAt
+	}
+	return timeConnected, true
 }
 
 func (m *manager) CalculateUptime(nodeID ids.NodeID) (time.Duration, time.Time, error) {
@@ -200,3 +229,26 @@ func (m *manager) updateUptime(nodeID ids.NodeID) error {
 
 	return m.state.SetUptime(nodeID, newDuration, newLastUpdated)
 }
+
+// recordInterval records the interval ending at [end] during which the node
+// has been observed to be connected.
+func (m *manager) recordInterval(nodeID ids.NodeID, end time.Time) error {
+	start, isConnected := m.ConnectedSince(nodeID)
+	if !isConnected || !end.After(start) {
+		return nil
+	}
+
+	_, err := m.state.GetStartTime(nodeID)
+	if err == database.ErrNotFound {
+		// We don't record the history of non-validators.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return m.state.AddUptimeInterval(nodeID, Interval{
+		Start: start,
+		End:   end,
+	})
+}
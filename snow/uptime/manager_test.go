@@ -545,3 +545,63 @@ func TestStopTrackingUnixTimeRegression(t *testing.T) {
 	require.NoError(err)
 	require.GreaterOrEqual(float64(1), perc)
 }
+
+func TestUptimeIntervals(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID0   = ids.GenerateTestNodeID()
+		nodeID1   = ids.GenerateTestNodeID()
+		startTime = time.Unix(1_000_000, 0)
+	)
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	clk := mockable.Clock{}
+	clk.Set(startTime)
+	up := NewManager(s, &clk)
+
+	// Connections before tracking started are only observed from the time
+	// that tracking started.
+	require.NoError(up.Connect(nodeID0))
+	require.NoError(up.Connect(nodeID1))
+	_, connected := up.ConnectedSince(nodeID0)
+	require.False(connected)
+
+	clk.Set(startTime.Add(time.Second))
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0}))
+	connectedSince, connected := up.ConnectedSince(nodeID0)
+	require.True(connected)
+	require.Equal(startTime.Add(time.Second), connectedSince)
+
+	clk.Set(startTime.Add(3 * time.Second))
+	require.NoError(up.Disconnect(nodeID0))
+	require.NoError(up.Disconnect(nodeID1))
+	_, connected = up.ConnectedSince(nodeID0)
+	require.False(connected)
+
+	clk.Set(startTime.Add(5 * time.Second))
+	require.NoError(up.Connect(nodeID0))
+
+	// The interval of a node that is still connected is recorded when
+	// tracking stops.
+	clk.Set(startTime.Add(8 * time.Second))
+	require.NoError(up.StopTracking([]ids.NodeID{nodeID0}))
+
+	require.Equal(
+		[]Interval{
+			{
+				Start: startTime.Add(time.Second),
+				End:   startTime.Add(3 * time.Second),
+			},
+			{
+				Start: startTime.Add(5 * time.Second),
+				End:   startTime.Add(8 * time.Second),
+			},
+		},
+		s.intervals[nodeID0],
+	)
+	// The history of non-validators isn't recorded.
+	require.Empty(s.intervals[nodeID1])
+}
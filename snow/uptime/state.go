@@ -9,6 +9,13 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 )
 
+// Interval is a period of time during which a node was observed to be
+// connected.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
 type State interface {
 	// GetUptime returns [upDuration] and [lastUpdated] of [nodeID]
 	// Returns [database.ErrNotFound] if [nodeID] isn't currently a validator.
@@ -31,4 +38,13 @@ type State interface {
 	GetStartTime(
 		nodeID ids.NodeID,
 	) (startTime time.Time, err error)
+
+	// AddUptimeInterval records that [nodeID] was observed to be connected
+	// during [interval].
+	// Invariant: expects the bounds of [interval] to be truncated (floored) to
+	//            the nearest second.
+	AddUptimeInterval(
+		nodeID ids.NodeID,
+		interval Interval,
+	) error
 }
@@ -22,11 +22,13 @@ type TestState struct {
 	dbReadError  error
 	dbWriteError error
 	nodes        map[ids.NodeID]*uptime
+	intervals    map[ids.NodeID][]Interval
 }
 
 func NewTestState() *TestState {
 	return &TestState{
-		nodes: make(map[ids.NodeID]*uptime),
+		nodes:     make(map[ids.NodeID]*uptime),
+		intervals: make(map[ids.NodeID][]Interval),
 	}
 }
 
@@ -63,3 +65,14 @@ func (s *TestState) GetStartTime(nodeID ids.NodeID) (time.Time, error) {
 	}
 	return up.startTime, s.dbReadError
 }
+
+func (s *TestState) AddUptimeInterval(nodeID ids.NodeID, interval Interval) error {
+	if _, exists := s.nodes[nodeID]; !exists {
+		return database.ErrNotFound
+	}
+	s.intervals[nodeID] = append(s.intervals[nodeID], Interval{
+		Start: time.Unix(interval.Start.Unix(), 0),
+		End:   time.Unix(interval.End.Unix(), 0),
+	})
+	return s.dbWriteError
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// Tracked holds the subnets, other than the primary network, whose
+// chains are run by this node. Subnets can be tracked and untracked while the
+// node is running.
+//
+// A nil Tracked doesn't contain any subnets.
+type Tracked struct {
+	lock    sync.RWMutex
+	subnets set.Set[ids.ID]
+	// onTracked is called with every subnet that starts being tracked.
+	onTracked []func(subnetID ids.ID)
+}
+
+// NewTracked returns the tracked subnets, initialized to [subnetIDs].
+func NewTracked(subnetIDs set.Set[ids.ID]) *Tracked {
+	return &Tracked{
+		subnets: set.Of(subnetIDs.List()...),
+	}
+}
+
+// Contains returns true if [subnetID] is tracked.
+func (t *Tracked) Contains(subnetID ids.ID) bool {
+	if t == nil {
+		return false
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.subnets.Contains(subnetID)
+}
+
+// List returns the tracked subnets.
+func (t *Tracked) List() []ids.ID {
+	if t == nil {
+		return nil
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.subnets.List()
+}
+
+// OnTracked registers [f] to be called with every subnet that starts being
+// tracked. [f] is called without holding any locks of [t].
+func (t *Tracked) OnTracked(f func(subnetID ids.ID)) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.onTracked = append(t.onTracked, f)
+}
+
+// Track starts tracking [subnetID]. Returns false if [subnetID] was already
+// tracked.
+func (t *Tracked) Track(subnetID ids.ID) bool {
+	t.lock.Lock()
+	if t.subnets.Contains(subnetID) {
+		t.lock.Unlock()
+		return false
+	}
+	t.subnets.Add(subnetID)
+	onTracked := t.onTracked
+	t.lock.Unlock()
+
+	for _, f := range onTracked {
+		f(subnetID)
+	}
+	return true
+}
+
+// Untrack stops tracking [subnetID]. Returns false if [subnetID] wasn't
+// tracked.
+func (t *Tracked) Untrack(subnetID ids.ID) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if !t.subnets.Contains(subnetID) {
+		return false
+	}
+	t.subnets.Remove(subnetID)
+	return true
+}
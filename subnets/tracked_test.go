@@ -0,0 +1,48 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subnets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestTracked(t *testing.T) {
+	require := require.New(t)
+
+	initialSubnetID := ids.GenerateTestID()
+	initial := set.Of(initialSubnetID)
+	trackedSubnets := NewTracked(initial)
+
+	var tracked []ids.ID
+	trackedSubnets.OnTracked(func(subnetID ids.ID) {
+		// The listener must be able to read the tracked subnets.
+		require.True(trackedSubnets.Contains(subnetID))
+		tracked = append(tracked, subnetID)
+	})
+
+	subnetID := ids.GenerateTestID()
+	require.False(trackedSubnets.Contains(subnetID))
+	require.False(trackedSubnets.Track(initialSubnetID))
+	require.True(trackedSubnets.Track(subnetID))
+	require.False(trackedSubnets.Track(subnetID))
+	require.Equal([]ids.ID{subnetID}, tracked)
+	require.ElementsMatch([]ids.ID{initialSubnetID, subnetID}, trackedSubnets.List())
+
+	// The initial set isn't modified.
+	require.Equal(set.Of(initialSubnetID), initial)
+
+	require.True(trackedSubnets.Untrack(subnetID))
+	require.False(trackedSubnets.Untrack(subnetID))
+	require.False(trackedSubnets.Contains(subnetID))
+	require.Equal([]ids.ID{initialSubnetID}, trackedSubnets.List())
+
+	// Tracking the subnet again notifies the listener again.
+	require.True(trackedSubnets.Track(subnetID))
+	require.Equal([]ids.ID{subnetID, subnetID}, tracked)
+}
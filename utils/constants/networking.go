@@ -54,6 +54,7 @@ const (
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
 
 	DefaultNetworkTCPProxyEnabled = false
+	DefaultNetworkQUICEnabled     = false
 
 	// The PROXY protocol specification recommends setting this value to be at
 	// least 3 seconds to cover a TCP retransmit.
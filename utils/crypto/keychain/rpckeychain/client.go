@@ -0,0 +1,220 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpckeychain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/keychain"
+)
+
+// DefaultSignTimeout is the default duration that the signing service is given
+// to sign a message.
+const DefaultSignTimeout = time.Minute
+
+var (
+	_ keychain.Keychain = (*Client)(nil)
+	_ keychain.Signer   = (*remoteSigner)(nil)
+
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Client is a keychain whose keys are held by a remote signing service. Key
+// material is never loaded by the client.
+//
+// In addition to keychain.Keychain, Client implements the EthKeychain
+// interface used by the C-chain wallet.
+type Client struct {
+	client pb.KeychainClient
+	// grpc.ClientConn handles transient connection errors.
+	connection *grpc.ClientConn
+	// signTimeout is the deadline applied to each signing request, as
+	// keychain.Signer does not provide a context.
+	signTimeout time.Duration
+
+	lock      sync.RWMutex
+	avaxAddrs map[ids.ShortID]*secp256k1.PublicKey
+	ethAddrs  map[common.Address]*secp256k1.PublicKey
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithSignTimeout sets the duration that the signing service is given to sign
+// a message. If [timeout] is not positive, signing requests never time out.
+// Defaults to DefaultSignTimeout.
+func WithSignTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.signTimeout = timeout
+	}
+}
+
+// NewClient connects to the signing service at [url] and discovers the
+// addresses it can sign for.
+func NewClient(ctx context.Context, url string, options ...ClientOption) (*Client, error) {
+	opts := grpc.WithConnectParams(grpc.ConnectParams{
+		Backoff: backoff.DefaultConfig,
+		// same as grpc default
+		MinConnectTimeout: 20 * time.Second,
+	})
+
+	// the rpc-keychain client should call a proxy server (on the same machine)
+	// that forwards the request to the actual signing service instead of
+	// relying on tls-credentials
+	conn, err := grpc.NewClient(url, opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc keychain client: %w", err)
+	}
+
+	c := &Client{
+		client:      pb.NewKeychainClient(conn),
+		connection:  conn,
+		signTimeout: DefaultSignTimeout,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if err := c.Refresh(ctx); err != nil {
+		return nil, errors.Join(err, conn.Close())
+	}
+	return c, nil
+}
+
+// Refresh re-discovers the addresses that the signing service can sign for.
+func (c *Client) Refresh(ctx context.Context) error {
+	resp, err := c.client.PublicKeys(ctx, &pb.PublicKeysRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get public keys: %w", err)
+	}
+
+	var (
+		pkBytes   = resp.GetPublicKeys()
+		avaxAddrs = make(map[ids.ShortID]*secp256k1.PublicKey, len(pkBytes))
+		ethAddrs  = make(map[common.Address]*secp256k1.PublicKey, len(pkBytes))
+	)
+	for _, b := range pkBytes {
+		pk, err := secp256k1.ToPublicKey(b)
+		if err != nil {
+			return fmt.Errorf("failed to parse public key: %w", err)
+		}
+		avaxAddrs[pk.Address()] = pk
+		ethAddrs[pk.EthAddress()] = pk
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.avaxAddrs = avaxAddrs
+	c.ethAddrs = ethAddrs
+	return nil
+}
+
+func (c *Client) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	pk, ok := c.avaxAddrs[addr]
+	if !ok {
+		return nil, false
+	}
+	return c.newSigner(pk), true
+}
+
+func (c *Client) Addresses() set.Set[ids.ShortID] {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	addrs := set.NewSet[ids.ShortID](len(c.avaxAddrs))
+	for addr := range c.avaxAddrs {
+		addrs.Add(addr)
+	}
+	return addrs
+}
+
+func (c *Client) GetEth(addr common.Address) (keychain.Signer, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	pk, ok := c.ethAddrs[addr]
+	if !ok {
+		return nil, false
+	}
+	return c.newSigner(pk), true
+}
+
+func (c *Client) EthAddresses() set.Set[common.Address] {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	addrs := set.NewSet[common.Address](len(c.ethAddrs))
+	for addr := range c.ethAddrs {
+		addrs.Add(addr)
+	}
+	return addrs
+}
+
+func (c *Client) newSigner(pk *secp256k1.PublicKey) *remoteSigner {
+	return &remoteSigner{
+		client:  c.client,
+		pk:      pk,
+		timeout: c.signTimeout,
+	}
+}
+
+func (c *Client) Shutdown() error {
+	if err := c.connection.Close(); err != nil {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+
+	return nil
+}
+
+type remoteSigner struct {
+	client  pb.KeychainClient
+	pk      *secp256k1.PublicKey
+	timeout time.Duration
+}
+
+func (s *remoteSigner) Sign(message []byte) ([]byte, error) {
+	ctx := context.Background()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	resp, err := s.client.Sign(ctx, &pb.SignRequest{
+		PublicKey: s.pk.Bytes(),
+		Message:   message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	// The signature is verified so that a misbehaving signing service can not
+	// cause an invalid transaction to be issued.
+	sig := resp.GetSignature()
+	if !s.pk.Verify(message, sig) {
+		return nil, fmt.Errorf("%w for %s", ErrInvalidSignature, s.pk.Address())
+	}
+	return sig, nil
+}
+
+func (s *remoteSigner) Address() ids.ShortID {
+	return s.pk.Address()
+}
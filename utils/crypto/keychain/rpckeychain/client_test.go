@@ -0,0 +1,214 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpckeychain
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/libevm/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/keychain"
+)
+
+var errPolicy = errors.New("rejected by policy")
+
+func newClient(t *testing.T, server pb.KeychainServer, options ...ClientOption) *Client {
+	require := require.New(t)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterKeychainServer(grpcServer, server)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(err)
+
+	c := &Client{
+		client:      pb.NewKeychainClient(conn),
+		connection:  conn,
+		signTimeout: DefaultSignTimeout,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	require.NoError(c.Refresh(t.Context()))
+	t.Cleanup(func() {
+		require.NoError(c.Shutdown())
+	})
+	return c
+}
+
+func TestClientAddresses(t *testing.T) {
+	require := require.New(t)
+
+	keys := secp256k1.TestKeys()
+	c := newClient(t, NewServer(nil, keys...))
+
+	var (
+		expectedAddrs    set.Set[ids.ShortID]
+		expectedEthAddrs set.Set[common.Address]
+	)
+	for _, key := range keys {
+		expectedAddrs.Add(key.Address())
+		expectedEthAddrs.Add(key.EthAddress())
+	}
+	require.Equal(expectedAddrs, c.Addresses())
+	require.Equal(expectedEthAddrs, c.EthAddresses())
+
+	_, ok := c.Get(ids.GenerateTestShortID())
+	require.False(ok)
+	_, ok = c.GetEth(common.Address{})
+	require.False(ok)
+}
+
+func TestClientSign(t *testing.T) {
+	require := require.New(t)
+
+	key := secp256k1.TestKeys()[0]
+	c := newClient(t, NewServer(nil, key))
+
+	msg := []byte("message")
+	expectedSig, err := key.Sign(msg)
+	require.NoError(err)
+
+	signer, ok := c.Get(key.Address())
+	require.True(ok)
+	require.Equal(key.Address(), signer.Address())
+
+	sig, err := signer.Sign(msg)
+	require.NoError(err)
+	require.Equal(expectedSig, sig)
+
+	signer, ok = c.GetEth(key.EthAddress())
+	require.True(ok)
+	require.Equal(key.Address(), signer.Address())
+
+	sig, err = signer.Sign(msg)
+	require.NoError(err)
+	require.Equal(expectedSig, sig)
+}
+
+func TestClientSignPolicy(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key     = secp256k1.TestKeys()[0]
+		allowed = []byte("allowed")
+	)
+	policy := func(_ context.Context, pk *secp256k1.PublicKey, msg []byte) error {
+		require.Equal(key.Address(), pk.Address())
+		if string(msg) != string(allowed) {
+			return errPolicy
+		}
+		return nil
+	}
+	c := newClient(t, NewServer(policy, key))
+
+	signer, ok := c.Get(key.Address())
+	require.True(ok)
+
+	_, err := signer.Sign(allowed)
+	require.NoError(err)
+
+	_, err = signer.Sign([]byte("denied"))
+	require.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func TestServerSignUnknownKey(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key     = secp256k1.TestKeys()[0]
+		unknown = secp256k1.TestKeys()[1]
+	)
+	s := NewServer(nil, key)
+
+	_, err := s.Sign(t.Context(), &pb.SignRequest{
+		PublicKey: unknown.PublicKey().Bytes(),
+		Message:   []byte("message"),
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	_, err = s.Sign(t.Context(), &pb.SignRequest{
+		PublicKey: []byte("invalid"),
+		Message:   []byte("message"),
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+type invalidSignatureServer struct {
+	*Server
+}
+
+func (*invalidSignatureServer) Sign(context.Context, *pb.SignRequest) (*pb.SignResponse, error) {
+	return &pb.SignResponse{
+		Signature: make([]byte, secp256k1.SignatureLen),
+	}, nil
+}
+
+func TestClientSignInvalidSignature(t *testing.T) {
+	require := require.New(t)
+
+	key := secp256k1.TestKeys()[0]
+	c := newClient(t, &invalidSignatureServer{
+		Server: NewServer(nil, key),
+	})
+
+	signer, ok := c.Get(key.Address())
+	require.True(ok)
+
+	_, err := signer.Sign([]byte("message"))
+	require.ErrorIs(err, ErrInvalidSignature)
+}
+
+type blockingServer struct {
+	*Server
+}
+
+func (*blockingServer) Sign(ctx context.Context, _ *pb.SignRequest) (*pb.SignResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestClientSignTimeout(t *testing.T) {
+	require := require.New(t)
+
+	key := secp256k1.TestKeys()[0]
+	c := newClient(
+		t,
+		&blockingServer{
+			Server: NewServer(nil, key),
+		},
+		WithSignTimeout(time.Millisecond),
+	)
+
+	signer, ok := c.Get(key.Address())
+	require.True(ok)
+
+	_, err := signer.Sign([]byte("message"))
+	require.Equal(codes.DeadlineExceeded, status.Code(err))
+}
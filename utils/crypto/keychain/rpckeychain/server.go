@@ -0,0 +1,84 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpckeychain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/keychain"
+)
+
+var (
+	_ pb.KeychainServer = (*Server)(nil)
+
+	ErrUnknownKey = errors.New("unknown key")
+)
+
+// Policy is called before every signing request is served. If an error is
+// returned, the request is rejected with the PermissionDenied status code.
+// Requests for keys that are not held by the server are rejected with the
+// NotFound status code.
+type Policy func(ctx context.Context, pk *secp256k1.PublicKey, message []byte) error
+
+// Server serves signing requests with keys held in memory. It can be used as a
+// local stand-in for a remote signing service.
+type Server struct {
+	pb.UnsafeKeychainServer
+	policy Policy
+	keys   map[ids.ShortID]*secp256k1.PrivateKey
+}
+
+// NewServer returns a signing service that signs with [keys]. If [policy] is
+// nil, every request is allowed.
+func NewServer(policy Policy, keys ...*secp256k1.PrivateKey) *Server {
+	s := &Server{
+		policy: policy,
+		keys:   make(map[ids.ShortID]*secp256k1.PrivateKey, len(keys)),
+	}
+	for _, key := range keys {
+		s.keys[key.Address()] = key
+	}
+	return s
+}
+
+func (s *Server) PublicKeys(context.Context, *pb.PublicKeysRequest) (*pb.PublicKeysResponse, error) {
+	pks := make([][]byte, 0, len(s.keys))
+	for _, key := range s.keys {
+		pks = append(pks, key.PublicKey().Bytes())
+	}
+	return &pb.PublicKeysResponse{
+		PublicKeys: pks,
+	}, nil
+}
+
+func (s *Server) Sign(ctx context.Context, req *pb.SignRequest) (*pb.SignResponse, error) {
+	pk, err := secp256k1.ToPublicKey(req.PublicKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	key, ok := s.keys[pk.Address()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("%s: %s", ErrUnknownKey, pk.Address()))
+	}
+
+	if s.policy != nil {
+		if err := s.policy(ctx, pk, req.Message); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+
+	sig, err := key.Sign(req.Message)
+	return &pb.SignResponse{
+		Signature: sig,
+	}, err
+}
@@ -0,0 +1,304 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package iblt implements invertible bloom lookup tables of IDs.
+//
+// Two tables created with the same parameters can be subtracted from each
+// other, after which the IDs that are only in one of the two tables can be
+// decoded with high probability, as long as the number of such IDs is small
+// compared to the number of cells. The size of a table only depends on the
+// size of the difference to decode, not on the number of IDs in the tables.
+package iblt
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	minHashes = 1
+	// Each hash consumes 4 of the 24 bytes of the ID hash that aren't used as
+	// the checksum.
+	maxHashes = 6
+	// MaxCells bounds the size of tables, and therefore of parsed tables.
+	MaxCells = 1 << 16
+
+	countLen    = 4
+	checksumLen = 8
+	hashLen     = 4
+	cellLen     = countLen + ids.IDLen + checksumLen
+)
+
+var (
+	errTooFewHashes         = errors.New("too few hashes")
+	errTooManyHashes        = errors.New("too many hashes")
+	errTooFewCells          = errors.New("too few cells")
+	errTooManyCells         = errors.New("too many cells")
+	errInvalidLength        = errors.New("invalid length")
+	errMismatchedParameters = errors.New("mismatched parameters")
+)
+
+type cell struct {
+	// count is the number of IDs added to the cell minus the number of IDs
+	// removed from the cell.
+	count    int32
+	idSum    ids.ID
+	checksum uint64
+}
+
+func (c *cell) update(id ids.ID, checksum uint64, delta int32) {
+	c.count += delta
+	for i := range c.idSum {
+		c.idSum[i] ^= id[i]
+	}
+	c.checksum ^= checksum
+}
+
+func (c *cell) isEmpty() bool {
+	return c.count == 0 && c.idSum == ids.Empty && c.checksum == 0
+}
+
+// Table is an invertible bloom lookup table of IDs.
+//
+// Table is not safe for concurrent use.
+type Table struct {
+	salt      ids.ID
+	numHashes int
+	cells     []cell
+}
+
+// New returns an empty table with [numHashes] cells per ID and at least
+// [numCells] cells. [numCells] is rounded up to a multiple of [numHashes].
+//
+// [salt] randomizes which cells each ID is mapped to. Only tables with the same
+// salt can be subtracted from each other.
+func New(numHashes, numCells int, salt ids.ID) (*Table, error) {
+	if err := verifyHashes(numHashes); err != nil {
+		return nil, err
+	}
+	if numCells < numHashes {
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewCells, numCells, numHashes)
+	}
+
+	// Round up to a multiple of [numHashes] so that every hash maps to the
+	// same number of cells.
+	numCells = (numCells + numHashes - 1) / numHashes * numHashes
+	if numCells > MaxCells {
+		return nil, fmt.Errorf("%w: %d > %d", errTooManyCells, numCells, MaxCells)
+	}
+	return &Table{
+		salt:      salt,
+		numHashes: numHashes,
+		cells:     make([]cell, numCells),
+	}, nil
+}
+
+func verifyHashes(numHashes int) error {
+	switch {
+	case numHashes < minHashes:
+		return fmt.Errorf("%w: %d < %d", errTooFewHashes, numHashes, minHashes)
+	case numHashes > maxHashes:
+		return fmt.Errorf("%w: %d > %d", errTooManyHashes, numHashes, maxHashes)
+	default:
+		return nil
+	}
+}
+
+// NumHashes returns the number of cells each ID is added to.
+func (t *Table) NumHashes() int {
+	return t.numHashes
+}
+
+// NumCells returns the number of cells in the table.
+func (t *Table) NumCells() int {
+	return len(t.cells)
+}
+
+// Add adds [id] to the table.
+func (t *Table) Add(id ids.ID) {
+	t.update(id, 1)
+}
+
+// Remove removes [id] from the table. [id] does not need to have been added.
+func (t *Table) Remove(id ids.ID) {
+	t.update(id, -1)
+}
+
+func (t *Table) update(id ids.ID, delta int32) {
+	checksum, indices := t.locate(id)
+	for _, index := range indices[:t.numHashes] {
+		t.cells[index].update(id, checksum, delta)
+	}
+}
+
+// locate returns the checksum of [id] and the indices of the cells that [id]
+// is mapped to. Only the first [t.numHashes] indices are populated.
+func (t *Table) locate(id ids.ID) (uint64, [maxHashes]int) {
+	var bytes [2 * ids.IDLen]byte
+	copy(bytes[:], t.salt[:])
+	copy(bytes[ids.IDLen:], id[:])
+	hash := sha256.Sum256(bytes[:])
+
+	var (
+		indices      [maxHashes]int
+		cellsPerHash = uint32(len(t.cells) / t.numHashes)
+	)
+	for i := 0; i < t.numHashes; i++ {
+		offset := checksumLen + i*hashLen
+		indices[i] = int(uint32(i)*cellsPerHash + binary.BigEndian.Uint32(hash[offset:])%cellsPerHash)
+	}
+	return binary.BigEndian.Uint64(hash[:checksumLen]), indices
+}
+
+// Subtract removes all of the IDs in [other] from the table.
+//
+// [other] must have been created with the same parameters as the table.
+func (t *Table) Subtract(other *Table) error {
+	if t.salt != other.salt || t.numHashes != other.numHashes || len(t.cells) != len(other.cells) {
+		return errMismatchedParameters
+	}
+
+	for i := range t.cells {
+		c := &t.cells[i]
+		o := &other.cells[i]
+		c.count -= o.count
+		for j := range c.idSum {
+			c.idSum[j] ^= o.idSum[j]
+		}
+		c.checksum ^= o.checksum
+	}
+	return nil
+}
+
+// Decode returns the IDs that were added to, and the IDs that were removed
+// from, the table.
+//
+// If [complete] is false, the table contained too many IDs to be fully
+// decoded and the returned IDs are a subset of the IDs in the table.
+//
+// The table is not modified.
+func (t *Table) Decode() (added []ids.ID, removed []ids.ID, complete bool) {
+	cells := make([]cell, len(t.cells))
+	copy(cells, t.cells)
+	peeling := &Table{
+		salt:      t.salt,
+		numHashes: t.numHashes,
+		cells:     cells,
+	}
+
+	var pure []int
+	for i := range cells {
+		if peeling.isPure(i) {
+			pure = append(pure, i)
+		}
+	}
+
+	// Each cell can only be peeled once when decoding a valid table, so
+	// decoding is bounded by the number of cells to handle malicious tables.
+	for len(pure) > 0 && len(added)+len(removed) < len(cells) {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+
+		// Removing a previous ID may have modified this cell.
+		if !peeling.isPure(i) {
+			continue
+		}
+
+		var (
+			id    = cells[i].idSum
+			count = cells[i].count
+		)
+		if count > 0 {
+			added = append(added, id)
+		} else {
+			removed = append(removed, id)
+		}
+
+		checksum, indices := peeling.locate(id)
+		for _, index := range indices[:peeling.numHashes] {
+			cells[index].update(id, checksum, -count)
+			if peeling.isPure(index) {
+				pure = append(pure, index)
+			}
+		}
+	}
+
+	for i := range cells {
+		if !cells[i].isEmpty() {
+			return added, removed, false
+		}
+	}
+	return added, removed, true
+}
+
+// isPure returns true if the cell at [index] contains exactly one ID.
+func (t *Table) isPure(index int) bool {
+	c := &t.cells[index]
+	if c.count != 1 && c.count != -1 {
+		return false
+	}
+	checksum, _ := t.locate(c.idSum)
+	return checksum == c.checksum
+}
+
+// Marshal returns the byte representation of the table. The salt is not
+// included.
+func (t *Table) Marshal() []byte {
+	bytes := make([]byte, 1+len(t.cells)*cellLen)
+	bytes[0] = byte(t.numHashes)
+	offset := 1
+	for _, c := range t.cells {
+		binary.BigEndian.PutUint32(bytes[offset:], uint32(c.count))
+		offset += countLen
+		copy(bytes[offset:], c.idSum[:])
+		offset += ids.IDLen
+		binary.BigEndian.PutUint64(bytes[offset:], c.checksum)
+		offset += checksumLen
+	}
+	return bytes
+}
+
+// Parse [bytes] into a table that was created with [salt].
+func Parse(bytes []byte, salt ids.ID) (*Table, error) {
+	if len(bytes) == 0 {
+		return nil, errInvalidLength
+	}
+	numHashes := int(bytes[0])
+	if err := verifyHashes(numHashes); err != nil {
+		return nil, err
+	}
+
+	cellBytes := len(bytes) - 1
+	numCells := cellBytes / cellLen
+	switch {
+	case cellBytes%cellLen != 0:
+		return nil, fmt.Errorf("%w: %d bytes of cells", errInvalidLength, cellBytes)
+	case numCells < numHashes:
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewCells, numCells, numHashes)
+	case numCells > MaxCells:
+		return nil, fmt.Errorf("%w: %d > %d", errTooManyCells, numCells, MaxCells)
+	case numCells%numHashes != 0:
+		return nil, fmt.Errorf("%w: %d cells is not a multiple of %d hashes", errInvalidLength, numCells, numHashes)
+	}
+
+	t := &Table{
+		salt:      salt,
+		numHashes: numHashes,
+		cells:     make([]cell, numCells),
+	}
+	offset := 1
+	for i := range t.cells {
+		c := &t.cells[i]
+		c.count = int32(binary.BigEndian.Uint32(bytes[offset:]))
+		offset += countLen
+		copy(c.idSum[:], bytes[offset:])
+		offset += ids.IDLen
+		c.checksum = binary.BigEndian.Uint64(bytes[offset:])
+		offset += checksumLen
+	}
+	return t, nil
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package iblt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		numHashes   int
+		numCells    int
+		expectedErr error
+	}{
+		{
+			numHashes:   0,
+			numCells:    1,
+			expectedErr: errTooFewHashes,
+		},
+		{
+			numHashes:   maxHashes + 1,
+			numCells:    MaxCells,
+			expectedErr: errTooManyHashes,
+		},
+		{
+			numHashes:   3,
+			numCells:    2,
+			expectedErr: errTooFewCells,
+		},
+		{
+			numHashes:   3,
+			numCells:    MaxCells,
+			expectedErr: errTooManyCells,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expectedErr.Error(), func(t *testing.T) {
+			_, err := New(test.numHashes, test.numCells, ids.Empty)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewRoundsUpCells(t *testing.T) {
+	table, err := New(3, 10, ids.Empty)
+	require.NoError(t, err)
+	require.Equal(t, 12, table.NumCells())
+}
+
+func TestDecode(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		salt   = ids.GenerateTestID()
+		shared = make([]ids.ID, 1000)
+		onlyA  = []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+		onlyB  = []ids.ID{ids.GenerateTestID()}
+	)
+	for i := range shared {
+		shared[i] = ids.GenerateTestID()
+	}
+
+	a, err := New(3, 30, salt)
+	require.NoError(err)
+	b, err := New(3, 30, salt)
+	require.NoError(err)
+	for _, id := range shared {
+		a.Add(id)
+		b.Add(id)
+	}
+	for _, id := range onlyA {
+		a.Add(id)
+	}
+	for _, id := range onlyB {
+		b.Add(id)
+	}
+
+	require.NoError(a.Subtract(b))
+	added, removed, complete := a.Decode()
+	require.True(complete)
+	require.ElementsMatch(onlyA, added)
+	require.ElementsMatch(onlyB, removed)
+
+	// Decoding does not modify the table
+	added2, removed2, complete2 := a.Decode()
+	require.True(complete2)
+	require.Equal(added, added2)
+	require.Equal(removed, removed2)
+}
+
+func TestDecodeIncomplete(t *testing.T) {
+	require := require.New(t)
+
+	table, err := New(3, 12, ids.Empty)
+	require.NoError(err)
+
+	added := make([]ids.ID, 100)
+	for i := range added {
+		added[i] = ids.GenerateTestID()
+		table.Add(added[i])
+	}
+
+	decoded, removed, complete := table.Decode()
+	require.False(complete)
+	require.Subset(added, decoded)
+	require.Empty(removed)
+}
+
+func TestRemove(t *testing.T) {
+	require := require.New(t)
+
+	table, err := New(3, 12, ids.Empty)
+	require.NoError(err)
+
+	id := ids.GenerateTestID()
+	table.Add(id)
+	table.Remove(id)
+
+	added, removed, complete := table.Decode()
+	require.True(complete)
+	require.Empty(added)
+	require.Empty(removed)
+}
+
+func TestSubtractMismatchedParameters(t *testing.T) {
+	require := require.New(t)
+
+	a, err := New(3, 12, ids.Empty)
+	require.NoError(err)
+	b, err := New(3, 24, ids.Empty)
+	require.NoError(err)
+	c, err := New(3, 12, ids.GenerateTestID())
+	require.NoError(err)
+
+	require.ErrorIs(a.Subtract(b), errMismatchedParameters)
+	require.ErrorIs(a.Subtract(c), errMismatchedParameters)
+}
+
+func TestMarshalThenParse(t *testing.T) {
+	require := require.New(t)
+
+	salt := ids.GenerateTestID()
+	table, err := New(4, 40, salt)
+	require.NoError(err)
+
+	id := ids.GenerateTestID()
+	table.Add(id)
+	table.Remove(ids.GenerateTestID())
+
+	parsed, err := Parse(table.Marshal(), salt)
+	require.NoError(err)
+	require.Equal(table, parsed)
+}
+
+func TestParseErrors(t *testing.T) {
+	validCells := make([]byte, 3*cellLen)
+	tests := []struct {
+		name        string
+		bytes       []byte
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			bytes:       nil,
+			expectedErr: errInvalidLength,
+		},
+		{
+			name:        "too few hashes",
+			bytes:       append([]byte{0}, validCells...),
+			expectedErr: errTooFewHashes,
+		},
+		{
+			name:        "too many hashes",
+			bytes:       append([]byte{maxHashes + 1}, validCells...),
+			expectedErr: errTooManyHashes,
+		},
+		{
+			name:        "partial cell",
+			bytes:       append([]byte{3}, validCells[1:]...),
+			expectedErr: errInvalidLength,
+		},
+		{
+			name:        "too few cells",
+			bytes:       append([]byte{4}, validCells...),
+			expectedErr: errTooFewCells,
+		},
+		{
+			name:        "cells not a multiple of hashes",
+			bytes:       append([]byte{2}, validCells...),
+			expectedErr: errInvalidLength,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.bytes, ids.Empty)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func FuzzParseThenDecode(f *testing.F) {
+	f.Fuzz(func(t *testing.T, bytes []byte) {
+		table, err := Parse(bytes, ids.Empty)
+		if err != nil {
+			return
+		}
+
+		// Decoding malicious tables must terminate.
+		added, removed, _ := table.Decode()
+		require.LessOrEqual(t, len(added)+len(removed), table.NumCells())
+		require.Equal(t, bytes, table.Marshal())
+	})
+}
+
+func BenchmarkAdd(b *testing.B) {
+	table, err := New(3, 1024, ids.Empty)
+	require.NoError(b, err)
+
+	id := ids.GenerateTestID()
+	for b.Loop() {
+		table.Add(id)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	table, err := New(3, 1024, ids.Empty)
+	require.NoError(b, err)
+	for range 512 {
+		table.Add(ids.GenerateTestID())
+	}
+
+	for b.Loop() {
+		table.Decode()
+	}
+}
@@ -40,6 +40,10 @@ type Factory interface {
 	// GetLoggerNames returns the names of all logs created by this factory
 	GetLoggerNames() []string
 
+	// Remove stops the logger with name [name] and removes it from the
+	// factory, so that a logger with the same name can be created again.
+	Remove(name string)
+
 	// Close stops and clears all of a Factory's instantiated loggers
 	Close()
 }
@@ -170,6 +174,18 @@ func (f *factory) GetLoggerNames() []string {
 	return maps.Keys(f.loggers)
 }
 
+func (f *factory) Remove(name string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	lw, ok := f.loggers[name]
+	if !ok {
+		return
+	}
+	lw.logger.Stop()
+	delete(f.loggers, name)
+}
+
 func (f *factory) Close() {
 	f.lock.Lock()
 	defer f.lock.Unlock()
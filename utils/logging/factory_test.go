@@ -0,0 +1,34 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactoryRemove(t *testing.T) {
+	require := require.New(t)
+
+	f := NewFactory(Config{
+		RotatingWriterConfig: RotatingWriterConfig{
+			Directory: t.TempDir(),
+		},
+	})
+	defer f.Close()
+
+	_, err := f.MakeChain("C")
+	require.NoError(err)
+
+	f.Remove("C")
+	require.Empty(f.GetLoggerNames())
+
+	// Removing an unknown logger is a no-op.
+	f.Remove("C")
+
+	_, err = f.MakeChain("C")
+	require.NoError(err)
+	require.Equal([]string{"C"}, f.GetLoggerNames())
+}
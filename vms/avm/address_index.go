@@ -0,0 +1,112 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/state"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var _ addressindex.Chain = (*addressIndexChain)(nil)
+
+// addressIndexChain provides the accepted blocks of the X-chain to the address
+// index.
+//
+// Transactions accepted before the X-chain was linearized aren't in any block,
+// so they aren't indexed.
+type addressIndexChain struct {
+	state state.State
+}
+
+func (c *addressIndexChain) LastAcceptedHeight() (uint64, error) {
+	blk, err := c.state.GetBlock(c.state.GetLastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+func (c *addressIndexChain) Txs(height uint64) ([]addressindex.Tx, error) {
+	blkID, err := c.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := c.state.GetBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	blkTxs := blk.Txs()
+	indexedTxs := make([]addressindex.Tx, 0, len(blkTxs))
+	for _, tx := range blkTxs {
+		txID := tx.ID()
+		addrs, err := c.addresses(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get addresses of tx %s: %w", txID, err)
+		}
+		if addrs.Len() == 0 {
+			continue
+		}
+		indexedTxs = append(indexedTxs, addressindex.Tx{
+			ID:        txID,
+			Addresses: addrs,
+		})
+	}
+	return indexedTxs, nil
+}
+
+// addresses returns the addresses that own the UTXOs consumed or produced by
+// [tx].
+func (c *addressIndexChain) addresses(tx *txs.Tx) (set.Set[ids.ShortID], error) {
+	var addrs set.Set[ids.ShortID]
+	for _, utxo := range tx.UTXOs() {
+		addressindex.AddAddresses(&addrs, utxo.Out)
+	}
+	if exportTx, ok := tx.Unsigned.(*txs.ExportTx); ok {
+		for _, out := range exportTx.ExportedOuts {
+			addressindex.AddAddresses(&addrs, out.Out)
+		}
+	}
+
+	for _, utxoID := range tx.Unsigned.InputUTXOs() {
+		// Imported UTXOs were produced on another chain.
+		if utxoID.Symbol {
+			continue
+		}
+
+		out, err := c.producedOutput(utxoID)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", utxoID, err)
+		}
+		addressindex.AddAddresses(&addrs, out)
+	}
+	return addrs, nil
+}
+
+// producedOutput returns the output of the UTXO [utxoID], even if the UTXO
+// has already been consumed. If the output can't be found,
+// [database.ErrNotFound] is returned.
+func (c *addressIndexChain) producedOutput(utxoID *avax.UTXOID) (any, error) {
+	tx, err := c.state.GetTx(utxoID.TxID)
+	if err != nil {
+		return nil, err
+	}
+	for _, utxo := range tx.UTXOs() {
+		if utxo.OutputIndex == utxoID.OutputIndex {
+			return utxo.Out, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
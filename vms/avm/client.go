@@ -163,6 +163,29 @@ func (c *Client) GetAtomicUTXOs(
 	return utxos, endAddr, endUTXOID, err
 }
 
+// GetAddressTxs returns the accepted txs that consumed or produced UTXOs owned
+// by addr. Only txs after the tx startTxID, accepted at startHeight, are
+// returned.
+func (c *Client) GetAddressTxs(
+	ctx context.Context,
+	addr ids.ShortID,
+	limit uint32,
+	startHeight uint64,
+	startTxID ids.ID,
+	options ...rpc.Option,
+) (*api.GetAddressTxsReply, error) {
+	res := &api.GetAddressTxsReply{}
+	err := c.Requester.SendRequest(ctx, "avm.getAddressTxs", &api.GetAddressTxsArgs{
+		Address: addr.String(),
+		Limit:   json.Uint32(limit),
+		StartIndex: api.AddressTx{
+			Height: json.Uint64(startHeight),
+			TxID:   startTxID,
+		},
+	}, res, options...)
+	return res, err
+}
+
 // GetAssetDescription returns a description of assetID.
 func (c *Client) GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error) {
 	res := &GetAssetDescriptionReply{}
@@ -10,13 +10,17 @@ import (
 )
 
 var DefaultConfig = Config{
-	Network:          network.DefaultConfig,
-	ChecksumsEnabled: false,
+	Network:                network.DefaultConfig,
+	ChecksumsEnabled:       false,
+	IndexAddressTxs:        false,
+	MempoolPriorityEnabled: false,
 }
 
 type Config struct {
-	Network          network.Config `json:"network"`
-	ChecksumsEnabled bool           `json:"checksums-enabled"`
+	Network                network.Config `json:"network"`
+	ChecksumsEnabled       bool           `json:"checksums-enabled"`
+	IndexAddressTxs        bool           `json:"index-address-txs"`
+	MempoolPriorityEnabled bool           `json:"mempool-priority-enabled"`
 }
 
 func ParseConfig(configBytes []byte) (Config, error) {
@@ -56,6 +56,22 @@ func TestParseConfig(t *testing.T) {
 				ChecksumsEnabled: DefaultConfig.ChecksumsEnabled,
 			},
 		},
+		{
+			name:        "manually specified index address txs",
+			configBytes: []byte(`{"index-address-txs":true}`),
+			expectedConfig: Config{
+				Network:         network.DefaultConfig,
+				IndexAddressTxs: true,
+			},
+		},
+		{
+			name:        "manually specified mempool priority",
+			configBytes: []byte(`{"mempool-priority-enabled":true}`),
+			expectedConfig: Config{
+				Network:                network.DefaultConfig,
+				MempoolPriorityEnabled: true,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -128,8 +128,8 @@ func setup(tb testing.TB, c *envConfig) *environment {
 		ctx,
 		prefixdb.New([]byte{1}, baseDB),
 		genesisBytes,
-		configBytes,
 		nil,
+		configBytes,
 		append(
 			[]*common.Fx{
 				{
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -40,6 +41,8 @@ var (
 	errNilTxID          = errors.New("nil transaction ID")
 	errNoAddresses      = errors.New("no addresses provided")
 	errNotLinearized    = errors.New("chain is not linearized")
+
+	errAddressIndexDisabled = errors.New("address index is disabled")
 )
 
 // FormattedAssetID defines a JSON formatted struct containing an assetID as a string
@@ -386,6 +389,64 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, reply *api.G
 	return nil
 }
 
+// GetAddressTxs returns the IDs of the accepted transactions that consumed or
+// produced UTXOs owned by the given address, ordered by height.
+func (s *Service) GetAddressTxs(_ *http.Request, args *api.GetAddressTxsArgs, reply *api.GetAddressTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getAddressTxs"),
+		logging.UserString("address", args.Address),
+	)
+
+	if !s.vm.indexAddressTxs {
+		return errAddressIndexDisabled
+	}
+
+	addr, err := avax.ParseServiceAddress(s.vm, args.Address)
+	if err != nil {
+		return err
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || int(maxPageSize) < limit {
+		limit = int(maxPageSize)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.addressIndex == nil {
+		return errNotLinearized
+	}
+
+	entries, err := s.vm.addressIndex.GetTxs(
+		addr,
+		addressindex.Entry{
+			Height: uint64(args.StartIndex.Height),
+			TxID:   args.StartIndex.TxID,
+		},
+		limit,
+	)
+	if err != nil {
+		return fmt.Errorf("problem retrieving txs: %w", err)
+	}
+
+	reply.Txs = make([]api.AddressTx, len(entries))
+	for i, entry := range entries {
+		reply.Txs[i] = api.AddressTx{
+			Height: avajson.Uint64(entry.Height),
+			TxID:   entry.TxID,
+		}
+	}
+
+	reply.EndIndex = args.StartIndex
+	if len(reply.Txs) > 0 {
+		reply.EndIndex = reply.Txs[len(reply.Txs)-1]
+	}
+	reply.NumFetched = avajson.Uint64(len(reply.Txs))
+	return nil
+}
+
 // GetAssetDescriptionArgs are arguments for passing into GetAssetDescription requests
 type GetAssetDescriptionArgs struct {
 	AssetID string `json:"assetID"`
@@ -2755,3 +2755,68 @@ func TestServiceGetHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		fork: upgradetest.Latest,
+		vmDynamicConfig: &Config{
+			Network:         DefaultConfig.Network,
+			IndexAddressTxs: true,
+		},
+	})
+	service := &Service{vm: env.vm}
+	env.vm.ctx.Lock.Unlock()
+
+	var (
+		spenderAddr = keys[0].PublicKey().Address()
+		changeAddr  = keys[1].PublicKey().Address()
+	)
+
+	// The index is built in the background.
+	require.Eventually(func() bool {
+		err := service.GetAddressTxs(nil, &api.GetAddressTxsArgs{
+			Address: spenderAddr.String(),
+		}, &api.GetAddressTxsReply{})
+		return err == nil
+	}, time.Minute, 10*time.Millisecond)
+
+	tx := newAvaxBaseTxWithOutputs(t, env)
+	issueAndAccept(require, env.vm, tx)
+
+	expectedTxs := []api.AddressTx{{
+		Height: 1,
+		TxID:   tx.ID(),
+	}}
+	for _, addr := range []ids.ShortID{spenderAddr, changeAddr} {
+		reply := api.GetAddressTxsReply{}
+		require.NoError(service.GetAddressTxs(nil, &api.GetAddressTxsArgs{
+			Address: addr.String(),
+		}, &reply))
+		require.Equal(expectedTxs, reply.Txs)
+		require.Equal(expectedTxs[0], reply.EndIndex)
+		require.Equal(avajson.Uint64(1), reply.NumFetched)
+
+		// Paginating past the last tx returns no txs.
+		require.NoError(service.GetAddressTxs(nil, &api.GetAddressTxsArgs{
+			Address:    addr.String(),
+			StartIndex: reply.EndIndex,
+		}, &reply))
+		require.Empty(reply.Txs)
+		require.Equal(expectedTxs[0], reply.EndIndex)
+	}
+}
+
+func TestServiceGetAddressTxsDisabled(t *testing.T) {
+	env := setup(t, &envConfig{
+		fork: upgradetest.Latest,
+	})
+	service := &Service{vm: env.vm}
+	env.vm.ctx.Lock.Unlock()
+
+	err := service.GetAddressTxs(nil, &api.GetAddressTxsArgs{
+		Address: ids.GenerateTestShortID().String(),
+	}, &api.GetAddressTxsReply{})
+	require.ErrorIs(t, err, errAddressIndexDisabled)
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	intrinsicInputDBRead   = 1
+	intrinsicInputDBWrite  = 1
+	intrinsicOutputDBWrite = 1
+
+	// Matches the cost of a secp256k1 signature verification on the P-chain.
+	intrinsicSignatureCompute = 200
+)
+
+var (
+	_ txs.Visitor = (*complexityCalculator)(nil)
+
+	// Weights converts the complexity of an X-chain tx into gas. They match
+	// the weights used by the P-chain on mainnet so that the fee per unit of
+	// gas is comparable across both chains.
+	Weights = gas.Dimensions{
+		gas.Bandwidth: 1,
+		gas.DBRead:    1_000,
+		gas.DBWrite:   1_000,
+		gas.Compute:   4,
+	}
+)
+
+// Complexity returns the amount of gas that [tx] consumes, as weighted by
+// [weights].
+//
+// The X-chain does not charge fees based on complexity, so this is only used to
+// prioritize txs in the mempool.
+func Complexity(tx *txs.Tx, weights gas.Dimensions) (gas.Gas, error) {
+	c := &complexityCalculator{}
+	if err := tx.Unsigned.Visit(c); err != nil {
+		return 0, err
+	}
+
+	c.complexity[gas.Bandwidth] = uint64(tx.Size())
+	for _, cred := range tx.Creds {
+		c.complexity[gas.Compute] += uint64(numSignatures(cred.Credential)) * intrinsicSignatureCompute
+	}
+	return c.complexity.ToGas(weights)
+}
+
+func numSignatures(cred verify.Verifiable) int {
+	switch cred := cred.(type) {
+	case *secp256k1fx.Credential:
+		return len(cred.Sigs)
+	case *nftfx.Credential:
+		return len(cred.Sigs)
+	case *propertyfx.Credential:
+		return len(cred.Sigs)
+	default:
+		return 1
+	}
+}
+
+type complexityCalculator struct {
+	complexity gas.Dimensions
+}
+
+func (c *complexityCalculator) BaseTx(tx *txs.BaseTx) error {
+	c.consume(len(tx.Ins))
+	c.produce(len(tx.Outs))
+	return nil
+}
+
+func (c *complexityCalculator) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	for _, state := range tx.States {
+		c.produce(len(state.Outs))
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *complexityCalculator) OperationTx(tx *txs.OperationTx) error {
+	for _, op := range tx.Ops {
+		c.consume(len(op.UTXOIDs))
+		c.produce(len(op.Op.Outs()))
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *complexityCalculator) ImportTx(tx *txs.ImportTx) error {
+	c.consume(len(tx.ImportedIns))
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *complexityCalculator) ExportTx(tx *txs.ExportTx) error {
+	c.produce(len(tx.ExportedOuts))
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *complexityCalculator) consume(numInputs int) {
+	c.complexity[gas.DBRead] += uint64(numInputs) * intrinsicInputDBRead
+	c.complexity[gas.DBWrite] += uint64(numInputs) * intrinsicInputDBWrite
+}
+
+func (c *complexityCalculator) produce(numOutputs int) {
+	c.complexity[gas.DBWrite] += uint64(numOutputs) * intrinsicOutputDBWrite
+}
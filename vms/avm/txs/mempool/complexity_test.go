@@ -0,0 +1,95 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestComplexity(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       txs.UnsignedTx
+		numSigs  int
+		expected gas.Dimensions
+	}{
+		{
+			name: "base tx",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins:  make([]*avax.TransferableInput, 2),
+				Outs: make([]*avax.TransferableOutput, 3),
+			}},
+			numSigs: 2,
+			expected: gas.Dimensions{
+				gas.DBRead:  2,
+				gas.DBWrite: 5,
+				gas.Compute: 2 * intrinsicSignatureCompute,
+			},
+		},
+		{
+			name: "import tx",
+			tx: &txs.ImportTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					Outs: make([]*avax.TransferableOutput, 1),
+				}},
+				ImportedIns: make([]*avax.TransferableInput, 2),
+			},
+			numSigs: 1,
+			expected: gas.Dimensions{
+				gas.DBRead:  2,
+				gas.DBWrite: 3,
+				gas.Compute: intrinsicSignatureCompute,
+			},
+		},
+		{
+			name: "export tx",
+			tx: &txs.ExportTx{
+				BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+					Ins: make([]*avax.TransferableInput, 1),
+				}},
+				ExportedOuts: make([]*avax.TransferableOutput, 2),
+			},
+			numSigs: 1,
+			expected: gas.Dimensions{
+				gas.DBRead:  1,
+				gas.DBWrite: 3,
+				gas.Compute: intrinsicSignatureCompute,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx := &txs.Tx{
+				Unsigned: test.tx,
+				Creds: []*fxs.FxCredential{{
+					Credential: &secp256k1fx.Credential{
+						Sigs: make([][secp256k1.SignatureLen]byte, test.numSigs),
+					},
+				}},
+			}
+			tx.SetBytes(nil, make([]byte, 100))
+
+			expected := test.expected
+			expected[gas.Bandwidth] = 100
+
+			complexity, err := Complexity(tx, Weights)
+			require.NoError(err)
+
+			expectedGas, err := expected.ToGas(Weights)
+			require.NoError(err)
+			require.Equal(expectedGas, complexity)
+		})
+	}
+}
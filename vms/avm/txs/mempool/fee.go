@@ -0,0 +1,100 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ txs.Visitor = (*burnedAVAXCalculator)(nil)
+
+	errAVAXMinted = errors.New("AVAX minted")
+)
+
+// BurnedAVAX returns the amount of AVAX consumed by [tx] that is not produced
+// by [tx].
+func BurnedAVAX(tx *txs.Tx, avaxAssetID ids.ID) (uint64, error) {
+	c := &burnedAVAXCalculator{
+		avaxAssetID: avaxAssetID,
+	}
+	if err := tx.Unsigned.Visit(c); err != nil {
+		return 0, err
+	}
+	if c.produced > c.consumed {
+		return 0, errAVAXMinted
+	}
+	return c.consumed - c.produced, nil
+}
+
+type burnedAVAXCalculator struct {
+	avaxAssetID ids.ID
+	consumed    uint64
+	produced    uint64
+}
+
+func (c *burnedAVAXCalculator) BaseTx(tx *txs.BaseTx) error {
+	if err := c.consume(tx.Ins); err != nil {
+		return err
+	}
+	return c.produce(tx.Outs)
+}
+
+func (c *burnedAVAXCalculator) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *burnedAVAXCalculator) OperationTx(tx *txs.OperationTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *burnedAVAXCalculator) ImportTx(tx *txs.ImportTx) error {
+	if err := c.consume(tx.ImportedIns); err != nil {
+		return err
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *burnedAVAXCalculator) ExportTx(tx *txs.ExportTx) error {
+	if err := c.produce(tx.ExportedOuts); err != nil {
+		return err
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *burnedAVAXCalculator) consume(ins []*avax.TransferableInput) error {
+	for _, in := range ins {
+		if in.AssetID() != c.avaxAssetID {
+			continue
+		}
+
+		var err error
+		c.consumed, err = safemath.Add(c.consumed, in.In.Amount())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *burnedAVAXCalculator) produce(outs []*avax.TransferableOutput) error {
+	for _, out := range outs {
+		if out.AssetID() != c.avaxAssetID {
+			continue
+		}
+
+		var err error
+		c.produced, err = safemath.Add(c.produced, out.Out.Amount())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestBurnedAVAX(t *testing.T) {
+	var (
+		avaxAssetID  = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+	)
+	in := func(assetID ids.ID, amount uint64) *avax.TransferableInput {
+		return &avax.TransferableInput{
+			Asset: avax.Asset{ID: assetID},
+			In:    &secp256k1fx.TransferInput{Amt: amount},
+		}
+	}
+	out := func(assetID ids.ID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	baseTx := func(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) txs.BaseTx {
+		return txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins:  ins,
+			Outs: outs,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		tx          txs.UnsignedTx
+		expected    uint64
+		expectedErr error
+	}{
+		{
+			name: "base tx",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{in(avaxAssetID, 10), in(otherAssetID, 100)},
+				Outs: []*avax.TransferableOutput{out(avaxAssetID, 7), out(otherAssetID, 100)},
+			}},
+			expected: 3,
+		},
+		{
+			name: "import tx",
+			tx: &txs.ImportTx{
+				BaseTx:      baseTx(nil, []*avax.TransferableOutput{out(avaxAssetID, 7)}),
+				ImportedIns: []*avax.TransferableInput{in(avaxAssetID, 10)},
+			},
+			expected: 3,
+		},
+		{
+			name: "export tx",
+			tx: &txs.ExportTx{
+				BaseTx:       baseTx([]*avax.TransferableInput{in(avaxAssetID, 10)}, nil),
+				ExportedOuts: []*avax.TransferableOutput{out(avaxAssetID, 7)},
+			},
+			expected: 3,
+		},
+		{
+			name: "AVAX minted",
+			tx: &txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins:  []*avax.TransferableInput{in(avaxAssetID, 7)},
+				Outs: []*avax.TransferableOutput{out(avaxAssetID, 10)},
+			}},
+			expectedErr: errAVAXMinted,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			burned, err := BurnedAVAX(&txs.Tx{Unsigned: test.tx}, avaxAssetID)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, burned)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package mempool
 import (
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 )
@@ -20,3 +21,25 @@ func New(
 	}
 	return mempool.New[*txs.Tx](metrics), nil
 }
+
+// NewPrioritized returns a mempool that orders txs by the amount of AVAX they
+// burn per unit of gas and allows conflicting txs to be replaced by txs that
+// burn more AVAX.
+func NewPrioritized(
+	namespace string,
+	avaxAssetID ids.ID,
+	registerer prometheus.Registerer,
+) (mempool.Mempool[*txs.Tx], error) {
+	metrics, err := mempool.NewPriorityMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	return mempool.NewPrioritized(metrics, func(tx *txs.Tx) (uint64, uint64, error) {
+		burned, err := BurnedAVAX(tx, avaxAssetID)
+		if err != nil {
+			return 0, 0, err
+		}
+		complexity, err := Complexity(tx, Weights)
+		return burned, uint64(complexity), err
+	}), nil
+}
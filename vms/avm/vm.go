@@ -17,6 +17,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -34,6 +35,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/utxo"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 
@@ -51,6 +53,8 @@ var (
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 
 	_ vertex.LinearizableVMWithEngine = (*VM)(nil)
+
+	addressIndexPrefix = []byte("addressIndex")
 )
 
 type VM struct {
@@ -100,11 +104,15 @@ type VM struct {
 	onShutdownCtxCancel context.CancelFunc
 	awaitShutdown       sync.WaitGroup
 
-	networkConfig network.Config
+	networkConfig          network.Config
+	indexAddressTxs        bool
+	mempoolPriorityEnabled bool
 	// These values are only initialized after the chain has been linearized.
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
 	network      *network.Network
+	// addressIndex is nil if the address index is disabled.
+	addressIndex *addressindex.Index
 }
 
 func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, version *version.Application) error {
@@ -237,6 +245,8 @@ func (vm *VM) Initialize(
 
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	vm.networkConfig = avmConfig.Network
+	vm.indexAddressTxs = avmConfig.IndexAddressTxs
+	vm.mempoolPriorityEnabled = avmConfig.MempoolPriorityEnabled
 	return vm.state.Commit()
 }
 
@@ -364,7 +374,15 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID) error {
 		return fmt.Errorf("failed to initialize chain state: %w", err)
 	}
 
-	mempool, err := xmempool.New("mempool", vm.registerer)
+	var (
+		mempool mempool.Mempool[*txs.Tx]
+		err     error
+	)
+	if vm.mempoolPriorityEnabled {
+		mempool, err = xmempool.NewPrioritized("mempool", vm.feeAssetID, vm.registerer)
+	} else {
+		mempool, err = xmempool.New("mempool", vm.registerer)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
@@ -432,6 +450,30 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID) error {
 		vm.network.PullGossip(vm.onShutdownCtx)
 	}()
 
+	if vm.indexAddressTxs {
+		vm.addressIndex, err = addressindex.New(
+			vm.ctx.Log,
+			&vm.ctx.Lock,
+			prefixdb.New(addressIndexPrefix, vm.baseDB),
+			&addressIndexChain{
+				state: vm.state,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to initialize address index: %w", err)
+		}
+
+		// Incrementing [awaitShutdown] would cause a deadlock since [Build]
+		// grabs the context lock.
+		go func() {
+			if err := vm.addressIndex.Build(vm.onShutdownCtx); err != nil {
+				vm.ctx.Log.Error("building address index failed",
+					zap.Error(err),
+				)
+			}
+		}()
+	}
+
 	return nil
 }
 
@@ -119,6 +119,22 @@ func TestIssueTx(t *testing.T) {
 	issueAndAccept(require, env.vm, tx)
 }
 
+func TestIssueTxPriorityMempool(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		fork: upgradetest.Latest,
+		vmDynamicConfig: &Config{
+			Network:                DefaultConfig.Network,
+			MempoolPriorityEnabled: true,
+		},
+	})
+	env.vm.ctx.Lock.Unlock()
+
+	tx := newTx(t, env.genesisBytes, env.vm.ctx.ChainID, env.vm.parser, "AVAX")
+	issueAndAccept(require, env.vm, tx)
+}
+
 // Test issuing a transaction that creates an NFT family
 func TestIssueNFT(t *testing.T) {
 	require := require.New(t)
@@ -0,0 +1,308 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package addressindex maps addresses to the accepted transactions that
+// consumed or produced UTXOs owned by them.
+package addressindex
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+const (
+	// maxBlocksPerBatch is the maximum number of blocks indexed while holding
+	// the lock.
+	maxBlocksPerBatch = 1024
+	syncFrequency     = 5 * time.Second
+	logFrequency      = 30 * time.Second
+
+	heightLen = 8
+	keyLen    = ids.ShortIDLen + heightLen + ids.IDLen
+)
+
+var (
+	ErrNotBuilt = errors.New("address index is still being built")
+
+	txPrefix       = []byte("tx")
+	metadataPrefix = []byte("metadata")
+
+	nextHeightKey = []byte("nextHeight")
+)
+
+// Tx is an accepted transaction and the addresses that own the UTXOs it
+// consumed or produced.
+type Tx struct {
+	ID        ids.ID
+	Addresses set.Set[ids.ShortID]
+}
+
+// AddAddresses adds the addresses that own [out], if any, to [addrs].
+func AddAddresses(addrs *set.Set[ids.ShortID], out any) {
+	addressable, ok := out.(avax.Addressable)
+	if !ok {
+		return
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			continue
+		}
+		addrs.Add(addr)
+	}
+}
+
+// Entry is a transaction accepted at Height.
+type Entry struct {
+	Height uint64
+	TxID   ids.ID
+}
+
+// Chain provides the accepted blocks to index.
+//
+// Methods are called while holding the lock provided to [New].
+type Chain interface {
+	// LastAcceptedHeight returns the height of the last accepted block that
+	// was persisted.
+	LastAcceptedHeight() (uint64, error)
+	// Txs returns the transactions accepted in the block at [height].
+	Txs(height uint64) ([]Tx, error)
+}
+
+type Index struct {
+	log   logging.Logger
+	lock  sync.Locker
+	chain Chain
+
+	db         *versiondb.Database
+	txDB       database.Database
+	metadataDB database.Database
+
+	// nextHeight is the height of the next block to index.
+	nextHeight uint64
+	// built is true once every block accepted before [Build] was called has
+	// been indexed.
+	built bool
+}
+
+// New returns an index that is persisted in [db].
+//
+// The index is only updated by [Build], which must be called for the index to
+// be usable.
+func New(
+	log logging.Logger,
+	lock sync.Locker,
+	db database.Database,
+	chain Chain,
+) (*Index, error) {
+	vdb := versiondb.New(db)
+	i := &Index{
+		log:        log,
+		lock:       lock,
+		chain:      chain,
+		db:         vdb,
+		txDB:       prefixdb.New(txPrefix, vdb),
+		metadataDB: prefixdb.New(metadataPrefix, vdb),
+	}
+
+	var err error
+	i.nextHeight, err = database.WithDefault(database.GetUInt64, i.metadataDB, nextHeightKey, 0)
+	return i, err
+}
+
+// Build indexes every accepted block that hasn't been indexed yet and then
+// keeps indexing newly accepted blocks until [ctx] is cancelled.
+//
+// Build must not be called while holding the lock.
+func (i *Index) Build(ctx context.Context) error {
+	i.lock.Lock()
+	startHeight := i.nextHeight
+	i.lock.Unlock()
+
+	var (
+		startTime  = time.Now()
+		nextUpdate = startTime.Add(logFrequency)
+		ticker     = time.NewTicker(syncFrequency)
+	)
+	defer ticker.Stop()
+
+	i.log.Info("starting to build address index",
+		zap.Uint64("height", startHeight),
+	)
+
+	for {
+		caughtUp, err := i.indexBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !caughtUp {
+			now := time.Now()
+			if now.After(nextUpdate) {
+				nextUpdate = now.Add(logFrequency)
+
+				i.lock.Lock()
+				nextHeight := i.nextHeight
+				lastAcceptedHeight, err := i.chain.LastAcceptedHeight()
+				i.lock.Unlock()
+				if err != nil {
+					return err
+				}
+
+				i.log.Info("building address index",
+					zap.Uint64("height", nextHeight),
+					zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+					zap.Duration("eta", timer.EstimateETA(
+						startTime,
+						nextHeight-startHeight,
+						lastAcceptedHeight+1-startHeight,
+					)),
+				)
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// indexBatch indexes up to [maxBlocksPerBatch] blocks and returns true if
+// every accepted block has been indexed.
+func (i *Index) indexBatch(ctx context.Context) (bool, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	// The database may have been closed while waiting for the lock.
+	if ctx.Err() != nil {
+		return true, nil
+	}
+
+	caughtUp, err := i.sync(maxBlocksPerBatch)
+	if err != nil || !caughtUp || i.built {
+		return caughtUp, err
+	}
+
+	i.built = true
+	i.log.Info("finished building address index",
+		zap.Uint64("height", i.nextHeight-1),
+	)
+	return true, nil
+}
+
+// sync indexes up to [maxBlocks] blocks and returns true if every accepted
+// block has been indexed.
+//
+// Invariant: The lock is held.
+func (i *Index) sync(maxBlocks int) (bool, error) {
+	lastAcceptedHeight, err := i.chain.LastAcceptedHeight()
+	if err != nil {
+		return false, fmt.Errorf("failed to get last accepted height: %w", err)
+	}
+
+	// The index and the indexed height are committed atomically so that the
+	// index resumes from the right height after an unclean shutdown.
+	defer i.db.Abort()
+
+	nextHeight := i.nextHeight
+	for ; nextHeight <= lastAcceptedHeight && maxBlocks > 0; nextHeight++ {
+		txs, err := i.chain.Txs(nextHeight)
+		if err != nil {
+			return false, fmt.Errorf("failed to get txs at height %d: %w", nextHeight, err)
+		}
+
+		for _, tx := range txs {
+			for addr := range tx.Addresses {
+				if err := i.txDB.Put(makeKey(addr, nextHeight, tx.ID), nil); err != nil {
+					return false, err
+				}
+			}
+		}
+		maxBlocks--
+	}
+	if nextHeight == i.nextHeight {
+		return true, nil
+	}
+
+	if err := database.PutUInt64(i.metadataDB, nextHeightKey, nextHeight); err != nil {
+		return false, err
+	}
+	if err := i.db.Commit(); err != nil {
+		return false, fmt.Errorf("failed to write address index: %w", err)
+	}
+
+	i.nextHeight = nextHeight
+	return nextHeight > lastAcceptedHeight, nil
+}
+
+// GetTxs returns up to [limit] transactions that consumed or produced UTXOs
+// owned by [addr], ordered by height and then by ID. Only transactions after
+// [start] are returned.
+//
+// Invariant: The lock is held.
+func (i *Index) GetTxs(addr ids.ShortID, start Entry, limit int) ([]Entry, error) {
+	if !i.built {
+		return nil, ErrNotBuilt
+	}
+
+	// Include the blocks accepted since the index was last synced.
+	for {
+		caughtUp, err := i.sync(maxBlocksPerBatch)
+		if err != nil {
+			return nil, err
+		}
+		if caughtUp {
+			break
+		}
+	}
+
+	startKey := makeKey(addr, start.Height, start.TxID)
+	it := i.txDB.NewIteratorWithStartAndPrefix(startKey, addr[:])
+	defer it.Release()
+
+	var entries []Entry
+	for len(entries) < limit && it.Next() {
+		key := it.Key()
+		if len(key) != keyLen {
+			return nil, fmt.Errorf("unexpected key length %d", len(key))
+		}
+
+		entry := Entry{
+			Height: binary.BigEndian.Uint64(key[ids.ShortIDLen:]),
+			TxID:   ids.ID(key[ids.ShortIDLen+heightLen:]),
+		}
+		if entry == start {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, it.Error()
+}
+
+// makeKey returns addr | height | txID so that the transactions of an address
+// are ordered by height.
+func makeKey(addr ids.ShortID, height uint64, txID ids.ID) []byte {
+	key := make([]byte, keyLen)
+	copy(key, addr[:])
+	binary.BigEndian.PutUint64(key[ids.ShortIDLen:], height)
+	copy(key[ids.ShortIDLen+heightLen:], txID[:])
+	return key
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addressindex
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var _ Chain = (*testChain)(nil)
+
+type testChain struct {
+	blocks [][]Tx
+}
+
+func (c *testChain) LastAcceptedHeight() (uint64, error) {
+	return uint64(len(c.blocks) - 1), nil
+}
+
+func (c *testChain) Txs(height uint64) ([]Tx, error) {
+	if height >= uint64(len(c.blocks)) {
+		return nil, database.ErrNotFound
+	}
+	return c.blocks[height], nil
+}
+
+func newTestIndex(t *testing.T, db database.Database, chain Chain) *Index {
+	t.Helper()
+
+	i, err := New(logging.NoLog{}, &sync.Mutex{}, db, chain)
+	require.NoError(t, err)
+	return i
+}
+
+func TestAddAddresses(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr0 = ids.GenerateTestShortID()
+		addr1 = ids.GenerateTestShortID()
+		addrs set.Set[ids.ShortID]
+	)
+	AddAddresses(&addrs, &secp256k1fx.TransferOutput{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{addr0, addr1},
+		},
+	})
+	AddAddresses(&addrs, &secp256k1fx.MintOutput{
+		OutputOwners: secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{addr0},
+		},
+	})
+	AddAddresses(&addrs, nil)
+	require.Equal(set.Of(addr0, addr1), addrs)
+}
+
+func TestIndexGetTxs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr0 = ids.GenerateTestShortID()
+		addr1 = ids.GenerateTestShortID()
+		txID0 = ids.GenerateTestID()
+		txID1 = ids.GenerateTestID()
+		txID2 = ids.GenerateTestID()
+		txID3 = ids.GenerateTestID()
+		chain = &testChain{
+			blocks: [][]Tx{
+				{
+					{ID: txID0, Addresses: set.Of(addr0)},
+				},
+				{},
+				{
+					{ID: txID1, Addresses: set.Of(addr0, addr1)},
+					{ID: txID2, Addresses: set.Of(addr0)},
+				},
+			},
+		}
+		i = newTestIndex(t, memdb.New(), chain)
+	)
+
+	_, err := i.GetTxs(addr0, Entry{}, 10)
+	require.ErrorIs(err, ErrNotBuilt)
+
+	caughtUp, err := i.indexBatch(context.Background())
+	require.NoError(err)
+	require.True(caughtUp)
+
+	txs, err := i.GetTxs(addr1, Entry{}, 10)
+	require.NoError(err)
+	require.Equal([]Entry{{Height: 2, TxID: txID1}}, txs)
+
+	// Paginate through the txs of addr0.
+	txs, err = i.GetTxs(addr0, Entry{}, 2)
+	require.NoError(err)
+	require.Len(txs, 2)
+	require.Equal(Entry{Height: 0, TxID: txID0}, txs[0])
+	require.Equal(uint64(2), txs[1].Height)
+
+	lastTxs, err := i.GetTxs(addr0, txs[1], 2)
+	require.NoError(err)
+	require.Len(lastTxs, 1)
+	require.Equal(uint64(2), lastTxs[0].Height)
+	require.ElementsMatch([]ids.ID{txID1, txID2}, []ids.ID{txs[1].TxID, lastTxs[0].TxID})
+
+	// Blocks accepted after the last sync are included.
+	chain.blocks = append(chain.blocks, []Tx{
+		{ID: txID3, Addresses: set.Of(addr1)},
+	})
+	txs, err = i.GetTxs(addr1, Entry{Height: 2, TxID: txID1}, 10)
+	require.NoError(err)
+	require.Equal([]Entry{{Height: 3, TxID: txID3}}, txs)
+}
+
+func TestIndexResumes(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db    = memdb.New()
+		addr  = ids.GenerateTestShortID()
+		chain = &testChain{}
+	)
+	for range maxBlocksPerBatch + 1 {
+		chain.blocks = append(chain.blocks, []Tx{
+			{ID: ids.GenerateTestID(), Addresses: set.Of(addr)},
+		})
+	}
+
+	i := newTestIndex(t, db, chain)
+	caughtUp, err := i.indexBatch(context.Background())
+	require.NoError(err)
+	require.False(caughtUp)
+	require.False(i.built)
+
+	// The index resumes from the last indexed height after a restart.
+	i = newTestIndex(t, db, chain)
+	require.Equal(uint64(maxBlocksPerBatch), i.nextHeight)
+
+	caughtUp, err = i.indexBatch(context.Background())
+	require.NoError(err)
+	require.True(caughtUp)
+	require.True(i.built)
+
+	txs, err := i.GetTxs(addr, Entry{}, len(chain.blocks)+1)
+	require.NoError(err)
+	require.Len(txs, len(chain.blocks))
+}
+
+func TestIndexBuildCancelled(t *testing.T) {
+	require := require.New(t)
+
+	chain := &testChain{
+		blocks: [][]Tx{
+			{
+				{ID: ids.GenerateTestID(), Addresses: set.Of(ids.GenerateTestShortID())},
+			},
+		},
+	}
+	i := newTestIndex(t, memdb.New(), chain)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(i.Build(ctx))
+	require.False(i.built)
+	require.Zero(i.nextHeight)
+}
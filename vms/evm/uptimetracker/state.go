@@ -130,6 +130,15 @@ func (s *state) GetStartTime(nodeID ids.NodeID) (time.Time, error) {
 	return time.Unix(int64(v.LastUpdated), 0), nil
 }
 
+// AddUptimeInterval doesn't persist anything, as the connection history of L1
+// validators isn't served by the EVM.
+func (s *state) AddUptimeInterval(nodeID ids.NodeID, _ uptime.Interval) error {
+	if _, ok := s.getValidatorByNodeID(nodeID); !ok {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
 // addNewValidator adds a new validator to the state and marks it for
 // persistence to the database. This should be used when adding validators
 // during runtime operations.
@@ -0,0 +1,210 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ addressindex.Chain = (*addressIndexChain)(nil)
+
+// stakeOutputer is implemented by the staker transactions that lock UTXOs
+// until the staker is removed.
+type stakeOutputer interface {
+	Stake() []*avax.TransferableOutput
+}
+
+// addressIndexChain provides the accepted blocks of the P-chain to the address
+// index.
+type addressIndexChain struct {
+	state *state.State
+	// genesisUTXOs are the UTXOs allocated in the genesis, keyed by their
+	// input ID.
+	genesisUTXOs map[ids.ID]*avax.UTXO
+}
+
+func newAddressIndexChain(state *state.State, genesisBytes []byte) (*addressIndexChain, error) {
+	gen, err := genesis.Parse(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisUTXOs := make(map[ids.ID]*avax.UTXO, len(gen.UTXOs))
+	for _, utxo := range gen.UTXOs {
+		genesisUTXOs[utxo.InputID()] = &utxo.UTXO
+	}
+	return &addressIndexChain{
+		state:        state,
+		genesisUTXOs: genesisUTXOs,
+	}, nil
+}
+
+func (c *addressIndexChain) LastAcceptedHeight() (uint64, error) {
+	blk, err := c.state.GetStatelessBlock(c.state.GetLastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+func (c *addressIndexChain) Txs(height uint64) ([]addressindex.Tx, error) {
+	blkID, err := c.state.GetBlockIDAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := c.state.GetStatelessBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	blkTxs := blk.Txs()
+	indexedTxs := make([]addressindex.Tx, 0, len(blkTxs))
+	for _, tx := range blkTxs {
+		txID := tx.ID()
+		_, txStatus, err := c.state.GetTx(txID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tx %s: %w", txID, err)
+		}
+
+		// Aborted proposals don't consume or produce any UTXOs, except for
+		// reward txs, which return the stake either way.
+		_, isRewardTx := tx.Unsigned.(txs.RewardTx)
+		if txStatus == status.Aborted && !isRewardTx {
+			continue
+		}
+
+		addrs, err := c.addresses(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get addresses of tx %s: %w", txID, err)
+		}
+		if addrs.Len() == 0 {
+			continue
+		}
+		indexedTxs = append(indexedTxs, addressindex.Tx{
+			ID:        txID,
+			Addresses: addrs,
+		})
+	}
+	return indexedTxs, nil
+}
+
+// addresses returns the addresses that own the UTXOs consumed or produced by
+// [tx].
+func (c *addressIndexChain) addresses(tx *txs.Tx) (set.Set[ids.ShortID], error) {
+	var addrs set.Set[ids.ShortID]
+	for _, out := range tx.Unsigned.Outputs() {
+		addressindex.AddAddresses(&addrs, out.Out)
+	}
+	if staker, ok := tx.Unsigned.(stakeOutputer); ok {
+		for _, out := range staker.Stake() {
+			addressindex.AddAddresses(&addrs, out.Out)
+		}
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case *txs.ExportTx:
+		for _, out := range utx.ExportedOutputs {
+			addressindex.AddAddresses(&addrs, out.Out)
+		}
+	case txs.RewardTx:
+		// Reward txs return the stake of the staker and produce its rewards.
+		stakerTxID := utx.StakerTxID()
+		stakerTx, _, err := c.state.GetTx(stakerTxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staker tx %s: %w", stakerTxID, err)
+		}
+		if staker, ok := stakerTx.Unsigned.(stakeOutputer); ok {
+			for _, out := range staker.Stake() {
+				addressindex.AddAddresses(&addrs, out.Out)
+			}
+		}
+
+		rewardUTXOs, err := c.state.GetRewardUTXOs(stakerTxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reward UTXOs of %s: %w", stakerTxID, err)
+		}
+		for _, utxo := range rewardUTXOs {
+			addressindex.AddAddresses(&addrs, utxo.Out)
+		}
+	}
+
+	for _, utxoID := range inputUTXOs(tx.Unsigned) {
+		out, err := c.producedOutput(utxoID)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", utxoID, err)
+		}
+		addressindex.AddAddresses(&addrs, out)
+	}
+	return addrs, nil
+}
+
+// producedOutput returns the output of the UTXO [utxoID], even if the UTXO
+// has already been consumed. If the output can't be found,
+// [database.ErrNotFound] is returned.
+func (c *addressIndexChain) producedOutput(utxoID *avax.UTXOID) (any, error) {
+	tx, _, err := c.state.GetTx(utxoID.TxID)
+	switch {
+	case err == nil:
+		outs := tx.Unsigned.Outputs()
+		index := int(utxoID.OutputIndex)
+		if index < len(outs) {
+			return outs[index].Out, nil
+		}
+
+		// Stake is returned in UTXOs that follow the outputs of the staker tx.
+		if staker, ok := tx.Unsigned.(stakeOutputer); ok {
+			stake := staker.Stake()
+			if stakeIndex := index - len(outs); stakeIndex < len(stake) {
+				return stake[stakeIndex].Out, nil
+			}
+		}
+
+		rewardUTXOs, err := c.state.GetRewardUTXOs(utxoID.TxID)
+		if err != nil {
+			return nil, err
+		}
+		for _, utxo := range rewardUTXOs {
+			if utxo.OutputIndex == utxoID.OutputIndex {
+				return utxo.Out, nil
+			}
+		}
+	case !errors.Is(err, database.ErrNotFound):
+		return nil, err
+	}
+
+	if utxo, ok := c.genesisUTXOs[utxoID.InputID()]; ok {
+		return utxo.Out, nil
+	}
+
+	// The remaining balance refunded when an L1 validator is removed can't be
+	// derived from the tx that removed it.
+	return nil, database.ErrNotFound
+}
+
+// inputUTXOs returns the UTXOs of this chain consumed by [utx].
+func inputUTXOs(utx txs.UnsignedTx) []*avax.UTXOID {
+	switch utx := utx.(type) {
+	case *txs.ImportTx:
+		// Imported UTXOs were produced on another chain.
+		return utx.BaseTx.InputUTXOs()
+	case interface{ InputUTXOs() []*avax.UTXOID }:
+		return utx.InputUTXOs()
+	default:
+		return nil
+	}
+}
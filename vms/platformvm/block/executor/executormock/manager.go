@@ -17,6 +17,7 @@ import (
 	block "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	set "github.com/ava-labs/avalanchego/utils/set"
 	block0 "github.com/ava-labs/avalanchego/vms/platformvm/block"
+	executor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	state "github.com/ava-labs/avalanchego/vms/platformvm/state"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	gomock "go.uber.org/mock/gomock"
@@ -145,6 +146,21 @@ func (mr *ManagerMockRecorder) SetPreference(blkID, blockCtx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*Manager)(nil).SetPreference), blkID, blockCtx)
 }
 
+// SimulateTx mocks base method.
+func (m *Manager) SimulateTx(tx *txs.Tx) (*executor.Simulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateTx", tx)
+	ret0, _ := ret[0].(*executor.Simulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateTx indicates an expected call of SimulateTx.
+func (mr *ManagerMockRecorder) SimulateTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateTx", reflect.TypeOf((*Manager)(nil).SimulateTx), tx)
+}
+
 // VerifyTx mocks base method.
 func (m *Manager) VerifyTx(tx *txs.Tx) error {
 	m.ctrl.T.Helper()
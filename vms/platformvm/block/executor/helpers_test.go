@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/upgrade/upgradetest"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -253,6 +254,7 @@ func defaultConfig(f upgradetest.Fork) *config.Internal {
 
 	return &config.Internal{
 		Chains:                 chains.TestManager,
+		TrackedSubnets:         subnets.NewTracked(nil),
 		UptimeLockedCalculator: uptime.NewLockedCalculator(),
 		Validators:             validators.NewManager(),
 		MinValidatorStake:      5 * units.MilliAvax,
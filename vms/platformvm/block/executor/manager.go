@@ -11,6 +11,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
@@ -47,11 +49,41 @@ type Manager interface {
 	// preferred state. This should *not* be used to verify transactions in a block.
 	VerifyTx(tx *txs.Tx) error
 
+	// SimulateTx executes the transaction on top of the currently preferred
+	// state without modifying it. If the transaction fails verification, the
+	// reason is reported in [Simulation.Err].
+	SimulateTx(tx *txs.Tx) (*Simulation, error)
+
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
 }
 
+// Simulation is the result of executing a transaction without modifying the
+// state.
+type Simulation struct {
+	// Err is the reason the transaction failed verification, or nil if the
+	// transaction is valid.
+	Err error
+
+	// Complexity and Gas are only populated after Etna is activated.
+	Complexity gas.Dimensions
+	Gas        gas.Gas
+	Fee        uint64
+
+	// The following fields are only populated if the transaction is valid.
+
+	// ConsumedUTXOs contains the IDs of the UTXOs consumed from this chain and
+	// from shared memory.
+	ConsumedUTXOs set.Set[ids.ID]
+	// ProducedUTXOs contains the UTXOs produced on this chain. UTXOs exported
+	// to other chains aren't included.
+	ProducedUTXOs        []*avax.UTXO
+	AddedStakers         []*state.Staker
+	RemovedStakers       []*state.Staker
+	ModifiedL1Validators []state.L1Validator
+}
+
 func NewManager(
 	mempool *mempool.Mempool,
 	metrics metrics.Metrics,
@@ -123,16 +155,34 @@ func (m *manager) Preferred() ids.ID {
 }
 
 func (m *manager) VerifyTx(tx *txs.Tx) error {
+	simulation, err := m.simulateTx(tx, false)
+	if err != nil {
+		return err
+	}
+	return simulation.Err
+}
+
+func (m *manager) SimulateTx(tx *txs.Tx) (*Simulation, error) {
+	return m.simulateTx(tx, true)
+}
+
+// simulateTx executes [tx] on top of the currently preferred state. If
+// [trackChanges] is true and [tx] is valid, the changes [tx] would make to the
+// state are populated in the returned [Simulation].
+func (m *manager) simulateTx(tx *txs.Tx, trackChanges bool) (*Simulation, error) {
 	if !m.txExecutorBackend.Bootstrapped.Get() {
-		return ErrChainNotSynced
+		return nil, ErrChainNotSynced
 	}
 
+	simulation := &Simulation{}
+
 	// If partial sync is enabled, this node isn't guaranteed to have the full
 	// UTXO set from shared memory. To avoid issuing invalid transactions,
 	// issuance of an ImportTx during this state is completely disallowed.
 	if m.txExecutorBackend.Config.PartialSyncPrimaryNetwork {
 		if _, isImportTx := tx.Unsigned.(*txs.ImportTx); isImportTx {
-			return ErrImportTxWhilePartialSyncing
+			simulation.Err = ErrImportTxWhilePartialSyncing
+			return simulation, nil
 		}
 	}
 
@@ -145,7 +195,7 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 	} else {
 		recommendedPChainHeight, err = m.ctx.ValidatorState.GetMinimumHeight(context.TODO())
 		if err != nil {
-			return fmt.Errorf("failed to fetch P-chain height: %w", err)
+			return nil, fmt.Errorf("failed to fetch P-chain height: %w", err)
 		}
 	}
 	err = executor.VerifyWarpMessages(
@@ -156,7 +206,8 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		tx.Unsigned,
 	)
 	if err != nil {
-		return fmt.Errorf("failed verifying warp messages: %w", err)
+		simulation.Err = fmt.Errorf("failed verifying warp messages: %w", err)
+		return simulation, nil
 	}
 
 	isAddingStakerAfterDeletionAllowed := state.StakerAdditionAfterDeletionLegality(
@@ -164,7 +215,7 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 	)
 	stateDiff, err := state.NewDiff(m.preferred, m, isAddingStakerAfterDeletionAllowed)
 	if err != nil {
-		return fmt.Errorf("failed creating state diff: %w", err)
+		return nil, fmt.Errorf("failed creating state diff: %w", err)
 	}
 
 	nextBlkTime, _, err := state.NextBlockTime(
@@ -173,33 +224,73 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		m.txExecutorBackend.Clk,
 	)
 	if err != nil {
-		return fmt.Errorf("failed selecting next block time: %w", err)
+		return nil, fmt.Errorf("failed selecting next block time: %w", err)
 	}
 
 	_, err = executor.AdvanceTimeTo(m.txExecutorBackend, stateDiff, nextBlkTime)
 	if err != nil {
-		return fmt.Errorf("failed to advance the chain time: %w", err)
+		return nil, fmt.Errorf("failed to advance the chain time: %w", err)
 	}
 
 	if timestamp := stateDiff.GetTimestamp(); m.txExecutorBackend.Config.UpgradeConfig.IsEtnaActivated(timestamp) {
-		complexity, err := fee.TxComplexity(tx.Unsigned)
+		simulation.Complexity, err = fee.TxComplexity(tx.Unsigned)
 		if err != nil {
-			return fmt.Errorf("failed to calculate tx complexity: %w", err)
+			simulation.Err = fmt.Errorf("failed to calculate tx complexity: %w", err)
+			return simulation, nil
 		}
-		gas, err := complexity.ToGas(m.txExecutorBackend.Config.DynamicFeeConfig.Weights)
+		simulation.Gas, err = simulation.Complexity.ToGas(m.txExecutorBackend.Config.DynamicFeeConfig.Weights)
 		if err != nil {
-			return fmt.Errorf("failed to calculate tx gas: %w", err)
+			simulation.Err = fmt.Errorf("failed to calculate tx gas: %w", err)
+			return simulation, nil
 		}
 
 		// TODO: After the mempool is updated, convert this check to use the
 		// maximum mempool capacity.
 		feeState := stateDiff.GetFeeState()
-		if gas > feeState.Capacity {
-			return fmt.Errorf("tx exceeds current gas capacity: %d > %d", gas, feeState.Capacity)
+		if simulation.Gas > feeState.Capacity {
+			simulation.Err = fmt.Errorf("tx exceeds current gas capacity: %d > %d", simulation.Gas, feeState.Capacity)
+			return simulation, nil
 		}
 	}
 
 	feeCalculator := state.PickFeeCalculator(m.txExecutorBackend.Config, stateDiff)
+	simulation.Fee, err = feeCalculator.CalculateFee(tx.Unsigned)
+	if err != nil {
+		simulation.Err = fmt.Errorf("failed to calculate tx fee: %w", err)
+		return simulation, nil
+	}
+
+	if trackChanges {
+		// The tx is executed on a separate diff so that the changes made by
+		// advancing the chain time aren't attributed to the tx. The diff must
+		// be populated before [stateDiff] is modified by the tx.
+		txDiff, err := state.NewDiffOn(stateDiff, isAddingStakerAfterDeletionAllowed)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating state diff: %w", err)
+		}
+		inputs, _, _, err := executor.StandardTx(
+			m.txExecutorBackend,
+			feeCalculator,
+			tx,
+			txDiff,
+		)
+		if err != nil {
+			simulation.Err = fmt.Errorf("failed execution: %w", err)
+			return simulation, nil
+		}
+
+		// [inputs] only contains the UTXOs consumed from shared memory.
+		producedUTXOs, consumedUTXOs := txDiff.ModifiedUTXOs()
+		simulation.ConsumedUTXOs = inputs
+		simulation.ConsumedUTXOs.Add(consumedUTXOs...)
+		simulation.ProducedUTXOs = producedUTXOs
+		simulation.AddedStakers, simulation.RemovedStakers = txDiff.ModifiedStakers()
+		simulation.ModifiedL1Validators = txDiff.ModifiedL1Validators()
+	}
+
+	// The tx is verified against [stateDiff] because some checks, such as
+	// adding a staker that was removed when advancing the chain time, depend
+	// on the changes made to [stateDiff].
 	_, _, _, err = executor.StandardTx(
 		m.txExecutorBackend,
 		feeCalculator,
@@ -207,9 +298,15 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		stateDiff,
 	)
 	if err != nil {
-		return fmt.Errorf("failed execution: %w", err)
+		// Changes are only reported for valid txs.
+		*simulation = Simulation{
+			Err:        fmt.Errorf("failed execution: %w", err),
+			Complexity: simulation.Complexity,
+			Gas:        simulation.Gas,
+			Fee:        simulation.Fee,
+		}
 	}
-	return nil
+	return simulation, nil
 }
 
 func (m *manager) VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error {
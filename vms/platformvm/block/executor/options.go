@@ -6,6 +6,7 @@ package executor
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -168,7 +169,7 @@ func (o *options) prefersCommit(tx *txs.Tx) (bool, error) {
 		return false, fmt.Errorf("%w: %w", errFailedFetchingPrimaryStaker, err)
 	}
 
-	expectedUptimePercentage := o.primaryUptimePercentage
+	var expectedUptimePercentage float64
 	if subnetID := staker.SubnetID(); subnetID != constants.PrimaryNetworkID {
 		transformSubnet, err := executor.GetTransformSubnetTx(o.state, subnetID)
 		if err != nil {
@@ -176,10 +177,12 @@ func (o *options) prefersCommit(tx *txs.Tx) (bool, error) {
 		}
 
 		expectedUptimePercentage = float64(transformSubnet.UptimeRequirement) / reward.PercentDenominator
-	} else if o.upgradeConfig.IsHeliconActivated(primaryNetworkValidator.StartTime) {
-		// ACP-267 requires 90% uptime for Primary Network validators that start at
-		// or after Helicon activation.
-		expectedUptimePercentage = genesis.ACP267UptimeRequirement
+	} else {
+		expectedUptimePercentage = RequiredUptime(
+			o.upgradeConfig,
+			o.primaryUptimePercentage,
+			primaryNetworkValidator.StartTime,
+		)
 	}
 
 	uptime, err := o.uptimes.CalculateUptimePercentFrom(
@@ -192,3 +195,15 @@ func (o *options) prefersCommit(tx *txs.Tx) (bool, error) {
 
 	return uptime >= expectedUptimePercentage, nil
 }
+
+// RequiredUptime returns the minimum uptime, in the range [0, 1], that a
+// Primary Network validator that started at [startTime] must have to be
+// rewarded. [primaryUptimePercentage] is the requirement prior to ACP-267.
+func RequiredUptime(upgradeConfig upgrade.Config, primaryUptimePercentage float64, startTime time.Time) float64 {
+	if upgradeConfig.IsHeliconActivated(startTime) {
+		// ACP-267 requires 90% uptime for Primary Network validators that start
+		// at or after Helicon activation.
+		return genesis.ACP267UptimeRequirement
+	}
+	return primaryUptimePercentage
+}
@@ -478,7 +478,7 @@ func TestBanffProposalBlockUpdateStakers(t *testing.T) {
 			env := newEnvironment(t, upgradetest.Banff)
 
 			subnetID := testSubnet1.ID()
-			env.config.TrackedSubnets.Add(subnetID)
+			env.config.TrackedSubnets.Track(subnetID)
 
 			for _, staker := range test.stakers {
 				wallet := newWallet(t, env, walletConfig{})
@@ -650,7 +650,7 @@ func TestBanffProposalBlockRemoveSubnetValidator(t *testing.T) {
 		subnetIDs: []ids.ID{subnetID},
 	})
 
-	env.config.TrackedSubnets.Add(subnetID)
+	env.config.TrackedSubnets.Track(subnetID)
 
 	// Add a subnet validator to the staker set
 	subnetValidatorNodeID := genesistest.DefaultNodeIDs[0]
@@ -803,7 +803,7 @@ func TestBanffProposalBlockTrackedSubnet(t *testing.T) {
 
 			subnetID := testSubnet1.ID()
 			if tracked {
-				env.config.TrackedSubnets.Add(subnetID)
+				env.config.TrackedSubnets.Track(subnetID)
 			}
 
 			wallet := newWallet(t, env, walletConfig{
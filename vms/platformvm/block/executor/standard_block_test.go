@@ -476,7 +476,7 @@ func TestBanffStandardBlockUpdateStakers(t *testing.T) {
 			env := newEnvironment(t, upgradetest.Banff)
 
 			subnetID := testSubnet1.ID()
-			env.config.TrackedSubnets.Add(subnetID)
+			env.config.TrackedSubnets.Track(subnetID)
 
 			for _, staker := range test.stakers {
 				addPendingValidator(
@@ -580,7 +580,7 @@ func TestBanffStandardBlockRemoveSubnetValidator(t *testing.T) {
 	env := newEnvironment(t, upgradetest.Banff)
 
 	subnetID := testSubnet1.ID()
-	env.config.TrackedSubnets.Add(subnetID)
+	env.config.TrackedSubnets.Track(subnetID)
 
 	wallet := newWallet(t, env, walletConfig{
 		subnetIDs: []ids.ID{subnetID},
@@ -685,7 +685,7 @@ func TestBanffStandardBlockTrackedSubnet(t *testing.T) {
 
 			subnetID := testSubnet1.ID()
 			if tracked {
-				env.config.TrackedSubnets.Add(subnetID)
+				env.config.TrackedSubnets.Track(subnetID)
 			}
 
 			wallet := newWallet(t, env, walletConfig{
@@ -7,8 +7,12 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -30,12 +34,16 @@ import (
 
 type Client struct {
 	Requester rpc.EndpointRequester
+
+	uri string
 }
 
 func NewClient(uri string) *Client {
-	return &Client{Requester: rpc.NewEndpointRequester(
-		uri + "/ext/P",
-	)}
+	uri += "/ext/P"
+	return &Client{
+		Requester: rpc.NewEndpointRequester(uri),
+		uri:       uri,
+	}
 }
 
 // GetHeight returns the current block height.
@@ -117,6 +125,29 @@ func (c *Client) GetAtomicUTXOs(
 	return utxos, endAddr, endUTXOID, err
 }
 
+// GetAddressTxs returns the accepted txs that consumed or produced UTXOs owned
+// by addr. Only txs after the tx startTxID, accepted at startHeight, are
+// returned.
+func (c *Client) GetAddressTxs(
+	ctx context.Context,
+	addr ids.ShortID,
+	limit uint32,
+	startHeight uint64,
+	startTxID ids.ID,
+	options ...rpc.Option,
+) (*api.GetAddressTxsReply, error) {
+	res := &api.GetAddressTxsReply{}
+	err := c.Requester.SendRequest(ctx, "platform.getAddressTxs", &api.GetAddressTxsArgs{
+		Address: addr.String(),
+		Limit:   json.Uint32(limit),
+		StartIndex: api.AddressTx{
+			Height: json.Uint64(startHeight),
+			TxID:   startTxID,
+		},
+	}, res, options...)
+	return res, err
+}
+
 // GetSubnetClientResponse is the response from calling GetSubnet on the client
 type GetSubnetClientResponse struct {
 	// whether it is permissioned or not
@@ -225,6 +256,21 @@ func (c *Client) GetCurrentValidators(
 	return getClientPermissionlessValidators(res.Validators)
 }
 
+// GetValidatorUptimeHistory returns the connection history of the current
+// Primary Network validator nodeID, as observed by the node, and the reward
+// decision the node would make based on it.
+func (c *Client) GetValidatorUptimeHistory(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	options ...rpc.Option,
+) (*GetValidatorUptimeHistoryReply, error) {
+	res := &GetValidatorUptimeHistoryReply{}
+	err := c.Requester.SendRequest(ctx, "platform.getValidatorUptimeHistory", &GetValidatorUptimeHistoryArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	return res, err
+}
+
 // L1Validator is the response from calling GetL1Validator on the API client.
 type L1Validator struct {
 	SubnetID              ids.ID
@@ -313,6 +359,14 @@ func (c *Client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options
 	return uint64(res.Supply), uint64(res.Height), err
 }
 
+// EstimateReward returns the rewards a validator, and the delegators of the
+// validator, described by args would receive.
+func (c *Client) EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.Requester.SendRequest(ctx, "platform.estimateReward", args, res, options...)
+	return res, err
+}
+
 // SampleValidators returns the nodeIDs of a sample of sampleSize validators
 // from the current validator set for subnetID.
 func (c *Client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
@@ -375,6 +429,22 @@ func (c *Client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+// SimulateTx executes the transaction without issuing it. The produced UTXOs
+// in the reply are hex encoded.
+func (c *Client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.Requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 // GetTx returns the byte representation of txID.
 func (c *Client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
@@ -695,3 +765,63 @@ func (c *Client) GetOwners(
 	maps.Copy(owners, validatorAuthorities)
 	return owners, nil
 }
+
+// SubscribeValidatorSetChanges calls [onChanges] with the changes made to the
+// validator sets of [subnetIDs] by each accepted height, starting at
+// [startHeight]. If [subnetIDs] is empty, the changes to all validator sets are
+// provided. Heights that did not change any of the requested validator sets
+// are skipped.
+//
+// SubscribeValidatorSetChanges blocks until [ctx] is cancelled, the connection
+// is closed, or [onChanges] returns an error. To resume a subscription, the
+// height after the last height provided to [onChanges] should be used as
+// [startHeight].
+func (c *Client) SubscribeValidatorSetChanges(
+	ctx context.Context,
+	startHeight uint64,
+	subnetIDs []ids.ID,
+	onChanges func(changes *ValidatorSetChanges) error,
+) error {
+	uri, err := url.Parse(c.uri + validatorSubscriptionEndpoint)
+	if err != nil {
+		return err
+	}
+	switch uri.Scheme {
+	case "https":
+		uri.Scheme = "wss"
+	default:
+		uri.Scheme = "ws"
+	}
+	query := url.Values{
+		"startHeight": []string{strconv.FormatUint(startHeight, 10)},
+	}
+	for _, subnetID := range subnetIDs {
+		query.Add("subnetID", subnetID.String())
+	}
+	uri.RawQuery = query.Encode()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, uri.String(), nil)
+	if err != nil {
+		return fmt.Errorf("couldn't subscribe to %s: %w", uri, err)
+	}
+	defer conn.Close()
+
+	// Unblock reading from the connection once [ctx] is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	for {
+		var changes ValidatorSetChanges
+		if err := conn.ReadJSON(&changes); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if err := onChanges(&changes); err != nil {
+			return err
+		}
+	}
+}
@@ -28,6 +28,7 @@ var Default = Config{
 	ChecksumsEnabled:              false,
 	MempoolPruneFrequency:         30 * time.Minute,
 	MempoolGasCapacity:            1_000_000,
+	IndexAddressTxs:               false,
 }
 
 // Config contains all of the user-configurable parameters of the PlatformVM.
@@ -48,6 +49,7 @@ type Config struct {
 	ChecksumsEnabled              bool          `json:"checksums-enabled"`
 	MempoolPruneFrequency         time.Duration `json:"mempool-prune-frequency"`
 	MempoolGasCapacity            gas.Gas       `json:"mempool-gas-capacity"`
+	IndexAddressTxs               bool          `json:"index-address-txs"`
 }
 
 // GetConfig returns a Config from the provided json encoded bytes. If a
@@ -93,6 +93,7 @@ func TestConfigUnmarshal(t *testing.T) {
 			ChecksumsEnabled:              true,
 			MempoolPruneFrequency:         time.Minute,
 			MempoolGasCapacity:            14,
+			IndexAddressTxs:               true,
 		}
 		verifyInitializedStruct(t, *expected)
 		verifyInitializedStruct(t, expected.Network)
@@ -10,9 +10,9 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils/constants"
-	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -49,7 +49,7 @@ type Internal struct {
 	PartialSyncPrimaryNetwork bool
 
 	// Set of subnets that this node is validating
-	TrackedSubnets set.Set[ids.ID]
+	TrackedSubnets *subnets.Tracked
 
 	// The minimum amount of tokens one must bond to be a validator
 	MinValidatorStake uint64
@@ -26,7 +26,7 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		return nil, fmt.Errorf("couldn't get current local validator: %w", err)
 	}
 
-	for subnetID := range vm.TrackedSubnets {
+	for _, subnetID := range vm.TrackedSubnets.List() {
 		localSubnetValidator, err := vm.state.GetCurrentValidator(
 			subnetID,
 			vm.ctx.NodeID,
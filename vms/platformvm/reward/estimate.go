@@ -0,0 +1,123 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+// Stake describes a validator, and the stake delegated to it, whose rewards
+// are estimated by [Estimate].
+type Stake struct {
+	// StartTime is the time the first staking period starts.
+	StartTime time.Time
+	// Duration is the duration of every staking period.
+	Duration time.Duration
+	// ValidatorStake is the stake of the validator in the first staking
+	// period.
+	ValidatorStake uint64
+	// DelegatorStake is the stake delegated to the validator in every staking
+	// period.
+	DelegatorStake uint64
+	// DelegationShares is the portion of the delegator rewards paid to the
+	// validator.
+	DelegationShares uint32
+	// AutoCompoundRewardShares is the portion of the validator's rewards that
+	// an auto-renewed validator restakes at the end of every staking period.
+	AutoCompoundRewardShares uint32
+	// MaxValidatorStake is the maximum stake of a validator, which caps the
+	// restaked rewards.
+	MaxValidatorStake uint64
+}
+
+// Cycle is the estimated outcome of a staking period.
+type Cycle struct {
+	StartTime time.Time
+	EndTime   time.Time
+	// ValidatorStake is the stake of the validator during the period.
+	ValidatorStake uint64
+	// ValidationReward is the reward for the stake of the validator.
+	ValidationReward uint64
+	// DelegateeReward is the portion of the delegator rewards paid to the
+	// validator.
+	DelegateeReward uint64
+	// DelegatorReward is the portion of the delegator rewards paid to the
+	// delegators.
+	DelegatorReward uint64
+	// RestakedReward is the portion of the validator's rewards that is
+	// restaked for the next period.
+	RestakedReward uint64
+	// WithdrawnReward is the portion of the validator's rewards that is paid
+	// out at the end of the period.
+	WithdrawnReward uint64
+	// Supply is the supply after the rewards of the period were minted.
+	Supply uint64
+}
+
+// Estimate returns the outcome of [numCycles] consecutive staking cycles of
+// [stake], starting with [currentSupply].
+//
+// Every cycle after the first is a renewal of the validator, which restakes
+// its rewards as an auto-renewed validator does, and the same stake is
+// delegated to it again. The supply is assumed to only grow by the rewards of
+// [stake] and all rewards are assumed to be paid.
+//
+// Invariant: [stake.DelegationShares] <= [PercentDenominator]
+// Invariant: [stake.AutoCompoundRewardShares] <= [PercentDenominator]
+func Estimate(c Calculator, stake Stake, currentSupply uint64, numCycles int) ([]Cycle, error) {
+	var (
+		cycles         = make([]Cycle, numCycles)
+		startTime      = stake.StartTime
+		validatorStake = stake.ValidatorStake
+		supply         = currentSupply
+	)
+	for i := range cycles {
+		// The supply is increased by the potential reward of a staker when it
+		// is added.
+		validationReward := c.Calculate(startTime, stake.Duration, validatorStake, supply)
+		supply += validationReward
+
+		var delegatorRewards uint64
+		if stake.DelegatorStake > 0 {
+			delegatorRewards = c.Calculate(startTime, stake.Duration, stake.DelegatorStake, supply)
+			supply += delegatorRewards
+		}
+		delegateeReward, delegatorReward := Split(delegatorRewards, stake.DelegationShares)
+
+		capacity, err := math.Sub(stake.MaxValidatorStake, validatorStake)
+		if err != nil {
+			return nil, err
+		}
+		restakedValidationReward, restakedDelegateeReward, err := Restake(
+			validationReward,
+			delegateeReward,
+			stake.AutoCompoundRewardShares,
+			capacity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		restakedReward := restakedValidationReward + restakedDelegateeReward
+		endTime := startTime.Add(stake.Duration)
+		cycles[i] = Cycle{
+			StartTime:        startTime,
+			EndTime:          endTime,
+			ValidatorStake:   validatorStake,
+			ValidationReward: validationReward,
+			DelegateeReward:  delegateeReward,
+			DelegatorReward:  delegatorReward,
+			RestakedReward:   restakedReward,
+			WithdrawnReward:  validationReward + delegateeReward - restakedReward,
+			Supply:           supply,
+		}
+
+		// A renewed staking period starts when the current period ends.
+		startTime = endTime
+		validatorStake += restakedReward
+	}
+	return cycles, nil
+}
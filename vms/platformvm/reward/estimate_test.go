@@ -0,0 +1,75 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestEstimate(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		c     = NewCalculator(defaultConfig)
+		stake = Stake{
+			StartTime:                time.Unix(1_000_000, 0),
+			Duration:                 defaultMinStakingDuration,
+			ValidatorStake:           2_000 * units.Avax,
+			DelegatorStake:           1_000 * units.Avax,
+			DelegationShares:         PercentDenominator / 10,
+			AutoCompoundRewardShares: PercentDenominator,
+			MaxValidatorStake:        3_000 * units.MegaAvax,
+		}
+		currentSupply uint64 = 360 * units.MegaAvax
+	)
+
+	cycles, err := Estimate(c, stake, currentSupply, 2)
+	require.NoError(err)
+	require.Len(cycles, 2)
+
+	// The first cycle matches rewarding each staker individually.
+	first := cycles[0]
+	validationReward := c.Calculate(stake.StartTime, stake.Duration, stake.ValidatorStake, currentSupply)
+	delegatorRewards := c.Calculate(stake.StartTime, stake.Duration, stake.DelegatorStake, currentSupply+validationReward)
+	delegateeReward, delegatorReward := Split(delegatorRewards, stake.DelegationShares)
+	require.Equal(
+		Cycle{
+			StartTime:        stake.StartTime,
+			EndTime:          stake.StartTime.Add(stake.Duration),
+			ValidatorStake:   stake.ValidatorStake,
+			ValidationReward: validationReward,
+			DelegateeReward:  delegateeReward,
+			DelegatorReward:  delegatorReward,
+			RestakedReward:   validationReward + delegateeReward,
+			Supply:           currentSupply + validationReward + delegatorRewards,
+		},
+		first,
+	)
+
+	// Every reward of the validator was restaked for the renewed period.
+	second := cycles[1]
+	require.Equal(first.EndTime, second.StartTime)
+	require.Equal(stake.ValidatorStake+first.RestakedReward, second.ValidatorStake)
+	require.Greater(second.ValidationReward, first.ValidationReward)
+	require.Zero(second.WithdrawnReward)
+}
+
+func TestEstimateExceedsMaxValidatorStake(t *testing.T) {
+	require := require.New(t)
+
+	stake := Stake{
+		Duration:          defaultMinStakingDuration,
+		ValidatorStake:    2 * defaultMinValidatorStake,
+		MaxValidatorStake: defaultMinValidatorStake,
+	}
+	_, err := Estimate(NewCalculator(defaultConfig), stake, 360*units.MegaAvax, 1)
+	require.ErrorIs(err, safemath.ErrUnderflow)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/math/intmath"
+)
+
+// Restake returns the portions of [validationRewards] and [delegateeRewards]
+// that an auto-renewed validator restakes at the end of a staking period,
+// given its [autoCompoundRewardShares].
+//
+// Restaking grows the validator's weight, which must never exceed the maximum
+// validator stake. If the restaked rewards wouldn't fit in the remaining
+// [capacity], only the remaining capacity is restaked, split proportionally
+// between validation and delegatee rewards.
+//
+// Invariant: [autoCompoundRewardShares] <= [PercentDenominator]
+func Restake(
+	validationRewards uint64,
+	delegateeRewards uint64,
+	autoCompoundRewardShares uint32,
+	capacity uint64,
+) (uint64, uint64, error) {
+	// Ignore the withdrawn portions from [Split] because the restaked amounts
+	// may be capped below.
+	restakingValidationRewards, _ := Split(validationRewards, autoCompoundRewardShares)
+	restakingDelegateeRewards, _ := Split(delegateeRewards, autoCompoundRewardShares)
+
+	totalRestakingRewards, err := math.Add(restakingValidationRewards, restakingDelegateeRewards)
+	if err != nil {
+		return 0, 0, err
+	}
+	if totalRestakingRewards <= capacity {
+		return restakingValidationRewards, restakingDelegateeRewards, nil
+	}
+
+	// Let V be the validation rewards, D be the delegatee rewards, C be the
+	// restaking capacity, and T = V + D. This branch means C < T. After
+	// computing V' = floor(V * C / T), the delegatee restake is D' = C - V'.
+	//
+	// Proving D' cannot exceed D:
+	//   D' - D = (C - V') - (T - V)
+	//          = (C - T) + (V - V')
+	// Because V' = floor(V * C / T):
+	//   V' > V * C / T - 1
+	//   -V' < -(V * C / T - 1)
+	//   -V' < -V * C / T + 1
+	//   V - V' < V - V * C / T + 1
+	//   V - V' < V * (1 - C / T) + 1
+	//   V - V' < V * (T / T - C / T) + 1
+	//   V - V' < V * (T - C) / T + 1
+	// Since V <= T:
+	//   V - V' < T - C + 1
+	//   V - V' <= T - C
+	// Substitute that bound into D' - D:
+	//   D' - D <= (C - T) + (T - C)
+	//          <= 0
+	// Therefore D' <= D.
+	//
+	// Therefore assigning the remaining capacity to delegatee rewards cannot
+	// increase them, and withdrawing the rest of the rewards cannot underflow.
+	restakingValidationRewards, _, err = intmath.MulDiv(restakingValidationRewards, capacity, totalRestakingRewards)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	restakingDelegateeRewards, err = math.Sub(capacity, restakingValidationRewards)
+	if err != nil {
+		return 0, 0, err
+	}
+	return restakingValidationRewards, restakingDelegateeRewards, nil
+}
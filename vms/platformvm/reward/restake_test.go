@@ -0,0 +1,74 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestake(t *testing.T) {
+	tests := []struct {
+		name                       string
+		validationRewards          uint64
+		delegateeRewards           uint64
+		autoCompoundRewardShares   uint32
+		capacity                   uint64
+		expectedValidationRestaked uint64
+		expectedDelegateeRestaked  uint64
+	}{
+		{
+			name:                       "nothing restaked",
+			validationRewards:          100,
+			delegateeRewards:           50,
+			autoCompoundRewardShares:   0,
+			capacity:                   1_000,
+			expectedValidationRestaked: 0,
+			expectedDelegateeRestaked:  0,
+		},
+		{
+			name:                       "half restaked",
+			validationRewards:          100,
+			delegateeRewards:           50,
+			autoCompoundRewardShares:   PercentDenominator / 2,
+			capacity:                   1_000,
+			expectedValidationRestaked: 50,
+			expectedDelegateeRestaked:  25,
+		},
+		{
+			name:                       "capped",
+			validationRewards:          100,
+			delegateeRewards:           50,
+			autoCompoundRewardShares:   PercentDenominator,
+			capacity:                   31,
+			expectedValidationRestaked: 20,
+			expectedDelegateeRestaked:  11,
+		},
+		{
+			name:                       "no capacity",
+			validationRewards:          100,
+			delegateeRewards:           50,
+			autoCompoundRewardShares:   PercentDenominator,
+			capacity:                   0,
+			expectedValidationRestaked: 0,
+			expectedDelegateeRestaked:  0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			validationRestaked, delegateeRestaked, err := Restake(
+				test.validationRewards,
+				test.delegateeRewards,
+				test.autoCompoundRewardShares,
+				test.capacity,
+			)
+			require.NoError(err)
+			require.Equal(test.expectedValidationRestaked, validationRestaked)
+			require.Equal(test.expectedDelegateeRestaked, delegateeRestaked)
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -44,6 +45,8 @@ import (
 	avajson "github.com/ava-labs/avalanchego/utils/json"
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
+	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 )
 
 const (
@@ -59,6 +62,12 @@ const (
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
+
+	// Max number of staking cycles that can be estimated by EstimateReward
+	maxEstimateRewardCycles = 100
+
+	// Max number of observed intervals returned by GetValidatorUptimeHistory
+	maxUptimeHistoryIntervals = 1024
 )
 
 var (
@@ -66,6 +75,12 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errAddressIndexDisabled       = errors.New("address index is disabled")
+	errNoStakeDuration            = errors.New("argument 'duration' must be positive")
+	errStakeDurationTooLong       = errors.New("argument 'duration' exceeds the maximum staking duration")
+	errTooManyCycles              = fmt.Errorf("argument 'cycles' must be at most %d", maxEstimateRewardCycles)
+	errTooManyShares              = fmt.Errorf("shares must be at most %d", reward.PercentDenominator)
+	errValidatorStakeTooLarge     = errors.New("validator stake exceeds the maximum validator stake")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -366,6 +381,60 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	return nil
 }
 
+// GetAddressTxs returns the accepted transactions that consumed or produced
+// UTXOs owned by the given address
+func (s *Service) GetAddressTxs(_ *http.Request, args *api.GetAddressTxsArgs, response *api.GetAddressTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAddressTxs"),
+		logging.UserString("address", args.Address),
+	)
+
+	if s.vm.addressIndex == nil {
+		return errAddressIndexDisabled
+	}
+
+	addr, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return err
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || maxPageSize < limit {
+		limit = maxPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	entries, err := s.vm.addressIndex.GetTxs(
+		addr,
+		addressindex.Entry{
+			Height: uint64(args.StartIndex.Height),
+			TxID:   args.StartIndex.TxID,
+		},
+		limit,
+	)
+	if err != nil {
+		return fmt.Errorf("problem retrieving txs: %w", err)
+	}
+
+	response.Txs = make([]api.AddressTx, len(entries))
+	for i, entry := range entries {
+		response.Txs[i] = api.AddressTx{
+			Height: avajson.Uint64(entry.Height),
+			TxID:   entry.TxID,
+		}
+	}
+
+	response.EndIndex = args.StartIndex
+	if len(response.Txs) > 0 {
+		response.EndIndex = response.Txs[len(response.Txs)-1]
+	}
+	response.NumFetched = avajson.Uint64(len(response.Txs))
+	return nil
+}
+
 // GetSubnetArgs are the arguments to GetSubnet
 type GetSubnetArgs struct {
 	// ID of the subnet to retrieve information about
@@ -995,6 +1064,100 @@ func (s *Service) getPrimaryOrSubnetValidators(subnetID ids.ID, nodeIDs set.Set[
 	return validators, nil
 }
 
+// GetValidatorUptimeHistoryArgs are the arguments for calling
+// GetValidatorUptimeHistory
+type GetValidatorUptimeHistoryArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// UptimeInterval is a period of time during which a validator was observed to
+// be connected.
+type UptimeInterval struct {
+	StartTime avajson.Uint64 `json:"startTime"`
+	// EndTime is omitted if the validator is still connected.
+	EndTime *avajson.Uint64 `json:"endTime,omitempty"`
+}
+
+// GetValidatorUptimeHistoryReply is the response from calling
+// GetValidatorUptimeHistory
+type GetValidatorUptimeHistoryReply struct {
+	// StartTime and EndTime are the bounds of the current staking period of
+	// the validator.
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	// Intervals are the periods of time, since the start of the staking
+	// period, during which this node observed the validator to be connected.
+	// At most [maxUptimeHistoryIntervals] observed intervals are included,
+	// followed by the current connection.
+	Intervals []UptimeInterval `json:"intervals"`
+	// Uptime is the percentage (0-100) of the staking period that this node
+	// considers the validator to have been online.
+	Uptime avajson.Float32 `json:"uptime"`
+	// RequiredUptime is the minimum percentage (0-100) of the staking period
+	// that the validator must be online to be rewarded.
+	RequiredUptime avajson.Float32 `json:"requiredUptime"`
+	// ExpectedReward is true if this node would currently vote to reward the
+	// validator.
+	ExpectedReward bool `json:"expectedReward"`
+}
+
+// GetValidatorUptimeHistory returns the connection history of a current
+// Primary Network validator, as observed by this node, and the reward decision
+// this node would make based on it.
+func (s *Service) GetValidatorUptimeHistory(_ *http.Request, args *GetValidatorUptimeHistoryArgs, reply *GetValidatorUptimeHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorUptimeHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	staker, err := s.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, args.NodeID)
+	if err != nil {
+		return fmt.Errorf("couldn't get current validator %s: %w", args.NodeID, err)
+	}
+
+	history, err := s.vm.state.GetUptimeHistory(args.NodeID, staker.StartTime, maxUptimeHistoryIntervals)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime history: %w", err)
+	}
+
+	startTime := staker.StartTime.Unix()
+	reply.StartTime = avajson.Uint64(startTime)
+	reply.EndTime = avajson.Uint64(staker.EndTime.Unix())
+	reply.Intervals = make([]UptimeInterval, 0, len(history)+1)
+	for _, interval := range history {
+		endTime := avajson.Uint64(interval.End.Unix())
+		reply.Intervals = append(reply.Intervals, UptimeInterval{
+			StartTime: avajson.Uint64(max(interval.Start.Unix(), startTime)),
+			EndTime:   &endTime,
+		})
+	}
+	if connectedSince, ok := s.vm.uptimeManager.ConnectedSince(args.NodeID); ok {
+		reply.Intervals = append(reply.Intervals, UptimeInterval{
+			StartTime: avajson.Uint64(max(connectedSince.Unix(), startTime)),
+		})
+	}
+
+	rawUptime, err := s.vm.uptimeManager.CalculateUptimePercentFrom(args.NodeID, staker.StartTime)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate uptime: %w", err)
+	}
+
+	requiredUptime := blockexecutor.RequiredUptime(
+		s.vm.Internal.UpgradeConfig,
+		s.vm.UptimePercentage,
+		staker.StartTime,
+	)
+
+	reply.Uptime = avajson.Float32(rawUptime * 100)
+	reply.RequiredUptime = avajson.Float32(requiredUptime * 100)
+	reply.ExpectedReward = rawUptime >= requiredUptime
+	return nil
+}
+
 type GetL1ValidatorArgs struct {
 	ValidationID ids.ID `json:"validationID"`
 }
@@ -1127,6 +1290,148 @@ func (s *Service) GetCurrentSupply(r *http.Request, args *GetCurrentSupplyArgs,
 	return nil
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// SubnetID of the staker. If omitted, defaults to the primary network.
+	SubnetID ids.ID `json:"subnetID"`
+	// StartTime is the Unix time, in seconds, at which the staking period
+	// starts. If omitted, defaults to the current chain time.
+	StartTime avajson.Uint64 `json:"startTime"`
+	// Duration of every staking period, in seconds.
+	Duration avajson.Uint64 `json:"duration"`
+	// ValidatorStake is the stake of the validator.
+	ValidatorStake avajson.Uint64 `json:"validatorStake"`
+	// DelegatorStake is the stake delegated to the validator.
+	DelegatorStake avajson.Uint64 `json:"delegatorStake"`
+	// DelegationShares is the portion of the delegator rewards, out of
+	// 1,000,000, paid to the validator.
+	DelegationShares avajson.Uint32 `json:"delegationShares"`
+	// AutoCompoundRewardShares is the portion of the validator's rewards, out
+	// of 1,000,000, that an auto-renewed validator restakes.
+	AutoCompoundRewardShares avajson.Uint32 `json:"autoCompoundRewardShares"`
+	// Cycles is the number of staking periods of an auto-renewed validator to
+	// estimate. If omitted, defaults to 1.
+	Cycles avajson.Uint32 `json:"cycles"`
+}
+
+// EstimatedRewardCycle is the estimated outcome of a staking period
+type EstimatedRewardCycle struct {
+	StartTime        avajson.Uint64 `json:"startTime"`
+	EndTime          avajson.Uint64 `json:"endTime"`
+	ValidatorStake   avajson.Uint64 `json:"validatorStake"`
+	ValidationReward avajson.Uint64 `json:"validationReward"`
+	DelegateeReward  avajson.Uint64 `json:"delegateeReward"`
+	DelegatorReward  avajson.Uint64 `json:"delegatorReward"`
+	RestakedReward   avajson.Uint64 `json:"restakedReward"`
+	WithdrawnReward  avajson.Uint64 `json:"withdrawnReward"`
+	Supply           avajson.Uint64 `json:"supply"`
+}
+
+// EstimateRewardReply are the results from calling EstimateReward
+type EstimateRewardReply struct {
+	// CurrentSupply is the supply the estimate started from.
+	CurrentSupply avajson.Uint64         `json:"currentSupply"`
+	Cycles        []EstimatedRewardCycle `json:"cycles"`
+}
+
+// EstimateReward returns the rewards a validator, and the delegators of the
+// validator, would receive if they started staking now.
+func (s *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateReward"),
+	)
+
+	cycles := int(args.Cycles)
+	switch {
+	case args.Duration == 0:
+		return errNoStakeDuration
+	case cycles > maxEstimateRewardCycles:
+		return errTooManyCycles
+	case args.DelegationShares > reward.PercentDenominator, args.AutoCompoundRewardShares > reward.PercentDenominator:
+		return errTooManyShares
+	case cycles == 0:
+		cycles = 1
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	calculator, err := txexecutor.GetRewardsCalculator(
+		s.vm.RewardConfig,
+		s.vm.Internal.UpgradeConfig,
+		s.vm.state,
+		args.SubnetID,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't get rewards calculator: %w", err)
+	}
+
+	var (
+		maxStakeDuration  = s.vm.MaxStakeDuration
+		maxValidatorStake = s.vm.MaxValidatorStake
+	)
+	if args.SubnetID != constants.PrimaryNetworkID {
+		transformSubnet, err := txexecutor.GetTransformSubnetTx(s.vm.state, args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get subnet transformation: %w", err)
+		}
+		maxStakeDuration = time.Duration(transformSubnet.MaxStakeDuration) * time.Second
+		maxValidatorStake = transformSubnet.MaxValidatorStake
+	}
+	if uint64(args.Duration) > uint64(maxStakeDuration/time.Second) {
+		return errStakeDurationTooLong
+	}
+	if uint64(args.ValidatorStake) > maxValidatorStake {
+		return errValidatorStakeTooLarge
+	}
+
+	currentSupply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("fetching current supply failed: %w", err)
+	}
+
+	startTime := s.vm.state.GetTimestamp()
+	if args.StartTime != 0 {
+		startTime = time.Unix(int64(args.StartTime), 0)
+	}
+
+	estimatedCycles, err := reward.Estimate(
+		calculator,
+		reward.Stake{
+			StartTime:                startTime,
+			Duration:                 time.Duration(args.Duration) * time.Second,
+			ValidatorStake:           uint64(args.ValidatorStake),
+			DelegatorStake:           uint64(args.DelegatorStake),
+			DelegationShares:         uint32(args.DelegationShares),
+			AutoCompoundRewardShares: uint32(args.AutoCompoundRewardShares),
+			MaxValidatorStake:        maxValidatorStake,
+		},
+		currentSupply,
+		cycles,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't estimate rewards: %w", err)
+	}
+
+	reply.CurrentSupply = avajson.Uint64(currentSupply)
+	reply.Cycles = make([]EstimatedRewardCycle, len(estimatedCycles))
+	for i, cycle := range estimatedCycles {
+		reply.Cycles[i] = EstimatedRewardCycle{
+			StartTime:        avajson.Uint64(cycle.StartTime.Unix()),
+			EndTime:          avajson.Uint64(cycle.EndTime.Unix()),
+			ValidatorStake:   avajson.Uint64(cycle.ValidatorStake),
+			ValidationReward: avajson.Uint64(cycle.ValidationReward),
+			DelegateeReward:  avajson.Uint64(cycle.DelegateeReward),
+			DelegatorReward:  avajson.Uint64(cycle.DelegatorReward),
+			RestakedReward:   avajson.Uint64(cycle.RestakedReward),
+			WithdrawnReward:  avajson.Uint64(cycle.WithdrawnReward),
+			Supply:           avajson.Uint64(cycle.Supply),
+		}
+	}
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -1455,6 +1760,127 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 	return nil
 }
 
+// SimulatedStaker is a staker that would be added or removed by a simulated tx.
+type SimulatedStaker struct {
+	platformapi.Staker
+	SubnetID ids.ID `json:"subnetID"`
+	// Pending is true if the staker is in the pending staker set rather than
+	// the current staker set.
+	Pending   bool `json:"pending"`
+	Delegator bool `json:"delegator"`
+}
+
+// SimulatedL1Validator is an L1 validator that would be modified by a
+// simulated tx. L1 validators that would be removed have a weight of 0.
+type SimulatedL1Validator struct {
+	platformapi.APIL1Validator
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// SimulateTxReply is the response from calling SimulateTx.
+type SimulateTxReply struct {
+	// Error is the reason the tx would fail verification. It is empty if the
+	// tx is valid.
+	Error string `json:"error,omitempty"`
+	// Complexity and Gas are only populated after Etna is activated.
+	Complexity gas.Dimensions `json:"complexity"`
+	Gas        gas.Gas        `json:"gas"`
+	Fee        avajson.Uint64 `json:"fee"`
+	// The following fields are only populated if the tx is valid.
+	ConsumedUTXOs        []ids.ID               `json:"consumedUTXOs"`
+	ProducedUTXOs        []string               `json:"producedUTXOs"`
+	AddedStakers         []SimulatedStaker      `json:"addedStakers"`
+	RemovedStakers       []SimulatedStaker      `json:"removedStakers"`
+	ModifiedL1Validators []SimulatedL1Validator `json:"modifiedL1Validators"`
+	Encoding             formatting.Encoding    `json:"encoding"`
+}
+
+// SimulateTx executes a tx on top of the preferred state without issuing it
+// and returns the result of the execution.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	simulation, err := s.vm.manager.SimulateTx(tx)
+	if err != nil {
+		return fmt.Errorf("couldn't simulate tx: %w", err)
+	}
+
+	if simulation.Err != nil {
+		reply.Error = simulation.Err.Error()
+	}
+	reply.Complexity = simulation.Complexity
+	reply.Gas = simulation.Gas
+	reply.Fee = avajson.Uint64(simulation.Fee)
+
+	reply.ConsumedUTXOs = simulation.ConsumedUTXOs.List()
+	utils.Sort(reply.ConsumedUTXOs)
+
+	reply.ProducedUTXOs = make([]string, len(simulation.ProducedUTXOs))
+	for i, utxo := range simulation.ProducedUTXOs {
+		bytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't serialize UTXO %q: %w", utxo.InputID(), err)
+		}
+		reply.ProducedUTXOs[i], err = formatting.Encode(args.Encoding, bytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as %s: %w", utxo.InputID(), args.Encoding, err)
+		}
+	}
+	reply.Encoding = args.Encoding
+
+	reply.AddedStakers = make([]SimulatedStaker, len(simulation.AddedStakers))
+	for i, staker := range simulation.AddedStakers {
+		reply.AddedStakers[i] = toSimulatedStaker(staker)
+	}
+	reply.RemovedStakers = make([]SimulatedStaker, len(simulation.RemovedStakers))
+	for i, staker := range simulation.RemovedStakers {
+		reply.RemovedStakers[i] = toSimulatedStaker(staker)
+	}
+
+	reply.ModifiedL1Validators = make([]SimulatedL1Validator, len(simulation.ModifiedL1Validators))
+	for i, l1Validator := range simulation.ModifiedL1Validators {
+		apiVdr, err := s.convertL1ValidatorToAPI(l1Validator)
+		if err != nil {
+			return err
+		}
+		reply.ModifiedL1Validators[i] = SimulatedL1Validator{
+			APIL1Validator: apiVdr,
+			SubnetID:       l1Validator.SubnetID,
+		}
+	}
+	return nil
+}
+
+func toSimulatedStaker(staker *state.Staker) SimulatedStaker {
+	return SimulatedStaker{
+		Staker: platformapi.Staker{
+			TxID:      staker.TxID,
+			StartTime: avajson.Uint64(staker.StartTime.Unix()),
+			EndTime:   avajson.Uint64(staker.EndTime.Unix()),
+			Weight:    avajson.Uint64(staker.Weight),
+			NodeID:    staker.NodeID,
+		},
+		SubnetID:  staker.SubnetID,
+		Pending:   staker.Priority.IsPending(),
+		Delegator: staker.Priority.IsDelegator(),
+	}
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
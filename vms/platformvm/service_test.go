@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/cache/lru"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -37,6 +39,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
@@ -231,6 +234,89 @@ func TestGetTxStatus(t *testing.T) {
 	require.Empty(resp.Reason)
 }
 
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	wallet := newWallet(t, service.vm, walletConfig{})
+
+	sk, err := localsigner.New()
+	require.NoError(err)
+	pop, err := signer.NewProofOfPossession(sk)
+	require.NoError(err)
+
+	var (
+		nodeID       = ids.GenerateTestNodeID()
+		rewardsOwner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		startTime = service.vm.clock.Time().Add(txexecutor.SyncBound)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	tx, err := wallet.IssueAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(endTime.Unix()),
+				Wght:   service.vm.MinValidatorStake,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		pop,
+		service.vm.ctx.AVAXAssetID,
+		rewardsOwner,
+		rewardsOwner,
+		0,
+	)
+	require.NoError(err)
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	var reply SimulateTxReply
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Empty(reply.Error)
+	require.NotZero(reply.Gas)
+	require.NotZero(reply.Fee)
+	require.ElementsMatch(tx.Unsigned.InputIDs().List(), reply.ConsumedUTXOs)
+	require.Len(reply.ProducedUTXOs, len(tx.Unsigned.Outputs()))
+	require.Equal(formatting.Hex, reply.Encoding)
+	require.Len(reply.AddedStakers, 1)
+	require.Equal(tx.ID(), reply.AddedStakers[0].TxID)
+	require.Equal(nodeID, reply.AddedStakers[0].NodeID)
+	require.Equal(constants.PrimaryNetworkID, reply.AddedStakers[0].SubnetID)
+	require.Equal(avajson.Uint64(service.vm.MinValidatorStake), reply.AddedStakers[0].Weight)
+	require.False(reply.AddedStakers[0].Pending)
+	require.False(reply.AddedStakers[0].Delegator)
+	require.Empty(reply.RemovedStakers)
+	require.Empty(reply.ModifiedL1Validators)
+
+	// Simulating the tx doesn't modify the state.
+	service.vm.ctx.Lock.Lock()
+	_, err = service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	service.vm.ctx.Lock.Unlock()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The inputs of an accepted tx have already been consumed.
+	txStr, err = formatting.Encode(formatting.Hex, testSubnet1.Bytes())
+	require.NoError(err)
+
+	reply = SimulateTxReply{}
+	require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.NotEmpty(reply.Error)
+	require.NotZero(reply.Fee)
+	require.Empty(reply.ConsumedUTXOs)
+	require.Empty(reply.ProducedUTXOs)
+}
+
 // Test issuing and then retrieving a transaction
 func TestGetTx(t *testing.T) {
 	type test struct {
@@ -427,6 +513,57 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	addr := genesistest.DefaultFundedKeys[0].Address()
+	args := api.GetAddressTxsArgs{
+		Address: addr.String(),
+	}
+	err := service.GetAddressTxs(nil, &args, &api.GetAddressTxsReply{})
+	require.ErrorIs(err, errAddressIndexDisabled)
+
+	service.vm.ctx.Lock.Lock()
+	chain, err := newAddressIndexChain(service.vm.state, genesistest.NewBytes(t, genesistest.Config{}))
+	require.NoError(err)
+	service.vm.addressIndex, err = addressindex.New(
+		logging.NoLog{},
+		&service.vm.ctx.Lock,
+		memdb.New(),
+		chain,
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go func() {
+		_ = service.vm.addressIndex.Build(ctx)
+	}()
+
+	// The index is built in the background.
+	reply := api.GetAddressTxsReply{}
+	require.Eventually(func() bool {
+		return service.GetAddressTxs(nil, &args, &reply) == nil
+	}, time.Minute, 10*time.Millisecond)
+
+	// The first funded key paid for the creation of [testSubnet1].
+	expectedTx := api.AddressTx{
+		Height: 1,
+		TxID:   testSubnet1.ID(),
+	}
+	require.Equal([]api.AddressTx{expectedTx}, reply.Txs)
+	require.Equal(expectedTx, reply.EndIndex)
+	require.Equal(avajson.Uint64(1), reply.NumFetched)
+
+	// Paginating past the last tx returns no txs.
+	args.StartIndex = reply.EndIndex
+	require.NoError(service.GetAddressTxs(nil, &args, &reply))
+	require.Empty(reply.Txs)
+	require.Equal(expectedTx, reply.EndIndex)
+}
+
 func TestGetStake(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -777,6 +914,176 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetValidatorUptimeHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	var (
+		nodeID    = genesistest.DefaultNodeIDs[0]
+		startTime = genesistest.DefaultValidatorStartTime
+		// Uptimes are tracked since the VM transitioned to normal operations.
+		connectTime    = latestForkTime
+		disconnectTime = connectTime.Add(time.Hour)
+		reconnectTime  = connectTime.Add(10 * time.Hour)
+	)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.UptimePercentage = .2
+	service.vm.clock.Set(connectTime)
+	require.NoError(service.vm.Connected(t.Context(), nodeID, version.Current))
+	service.vm.clock.Set(disconnectTime)
+	require.NoError(service.vm.Disconnected(t.Context(), nodeID))
+	service.vm.clock.Set(reconnectTime)
+	require.NoError(service.vm.Connected(t.Context(), nodeID, version.Current))
+	service.vm.ctx.Lock.Unlock()
+
+	var reply GetValidatorUptimeHistoryReply
+	require.NoError(service.GetValidatorUptimeHistory(
+		nil,
+		&GetValidatorUptimeHistoryArgs{NodeID: nodeID},
+		&reply,
+	))
+
+	disconnectTimeUnix := avajson.Uint64(disconnectTime.Unix())
+	require.Equal(avajson.Uint64(startTime.Unix()), reply.StartTime)
+	require.Equal(avajson.Uint64(genesistest.DefaultValidatorEndTime.Unix()), reply.EndTime)
+	require.Equal(
+		[]UptimeInterval{
+			{
+				StartTime: avajson.Uint64(connectTime.Unix()),
+				EndTime:   &disconnectTimeUnix,
+			},
+			{
+				StartTime: avajson.Uint64(reconnectTime.Unix()),
+			},
+		},
+		reply.Intervals,
+	)
+
+	// The validator is considered to have been online before uptimes were
+	// tracked.
+	expectedUptime := float64(disconnectTime.Sub(startTime)) / float64(reconnectTime.Sub(startTime))
+	require.InDelta(100*expectedUptime, float64(reply.Uptime), .001)
+	require.Equal(avajson.Float32(20), reply.RequiredUptime)
+	require.False(reply.ExpectedReward)
+
+	// Only current validators have a staking period.
+	err := service.GetValidatorUptimeHistory(
+		nil,
+		&GetValidatorUptimeHistoryArgs{NodeID: ids.GenerateTestNodeID()},
+		&reply,
+	)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	args := EstimateRewardArgs{
+		Duration:                 avajson.Uint64(defaultMinStakingDuration / time.Second),
+		ValidatorStake:           avajson.Uint64(defaultMinValidatorStake),
+		DelegatorStake:           avajson.Uint64(defaultMinDelegatorStake),
+		DelegationShares:         reward.PercentDenominator / 10,
+		AutoCompoundRewardShares: reward.PercentDenominator,
+		Cycles:                   2,
+	}
+	var reply EstimateRewardReply
+	require.NoError(service.EstimateReward(nil, &args, &reply))
+	require.Len(reply.Cycles, 2)
+
+	service.vm.ctx.Lock.Lock()
+	startTime := service.vm.state.GetTimestamp()
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	service.vm.ctx.Lock.Unlock()
+	require.NoError(err)
+
+	// The first cycle is rewarded as the executor rewards new stakers.
+	calculator := reward.NewPrimaryNetworkCalculator(service.vm.RewardConfig, service.vm.Internal.UpgradeConfig)
+	validationReward := calculator.Calculate(startTime, defaultMinStakingDuration, defaultMinValidatorStake, currentSupply)
+	delegatorRewards := calculator.Calculate(startTime, defaultMinStakingDuration, defaultMinDelegatorStake, currentSupply+validationReward)
+	delegateeReward, delegatorReward := reward.Split(delegatorRewards, reward.PercentDenominator/10)
+
+	first := reply.Cycles[0]
+	require.Equal(avajson.Uint64(currentSupply), reply.CurrentSupply)
+	require.Equal(avajson.Uint64(startTime.Unix()), first.StartTime)
+	require.Equal(avajson.Uint64(validationReward), first.ValidationReward)
+	require.Equal(avajson.Uint64(delegateeReward), first.DelegateeReward)
+	require.Equal(avajson.Uint64(delegatorReward), first.DelegatorReward)
+	require.Equal(avajson.Uint64(validationReward+delegateeReward), first.RestakedReward)
+	require.Zero(first.WithdrawnReward)
+
+	// The restaked rewards are staked in the next cycle.
+	second := reply.Cycles[1]
+	require.Equal(first.EndTime, second.StartTime)
+	require.Equal(first.ValidatorStake+first.RestakedReward, second.ValidatorStake)
+}
+
+func TestEstimateRewardInvalidArgs(t *testing.T) {
+	service, _ := defaultService(t)
+
+	args := EstimateRewardArgs{
+		Duration:       avajson.Uint64(defaultMinStakingDuration / time.Second),
+		ValidatorStake: avajson.Uint64(defaultMinValidatorStake),
+	}
+	tests := []struct {
+		name        string
+		modify      func(*EstimateRewardArgs)
+		expectedErr error
+	}{
+		{
+			name: "no duration",
+			modify: func(args *EstimateRewardArgs) {
+				args.Duration = 0
+			},
+			expectedErr: errNoStakeDuration,
+		},
+		{
+			name: "duration too long",
+			modify: func(args *EstimateRewardArgs) {
+				args.Duration = avajson.Uint64(defaultMaxStakingDuration/time.Second) + 1
+			},
+			expectedErr: errStakeDurationTooLong,
+		},
+		{
+			name: "too many cycles",
+			modify: func(args *EstimateRewardArgs) {
+				args.Cycles = maxEstimateRewardCycles + 1
+			},
+			expectedErr: errTooManyCycles,
+		},
+		{
+			name: "too many shares",
+			modify: func(args *EstimateRewardArgs) {
+				args.DelegationShares = reward.PercentDenominator + 1
+			},
+			expectedErr: errTooManyShares,
+		},
+		{
+			name: "validator stake too large",
+			modify: func(args *EstimateRewardArgs) {
+				args.ValidatorStake = avajson.Uint64(defaultMaxValidatorStake) + 1
+			},
+			expectedErr: errValidatorStakeTooLarge,
+		},
+		{
+			name: "subnet isn't transformed",
+			modify: func(args *EstimateRewardArgs) {
+				args.SubnetID = testSubnet1.ID()
+			},
+			expectedErr: database.ErrNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			invalidArgs := args
+			test.modify(&invalidArgs)
+			err := service.EstimateReward(nil, &invalidArgs, &EstimateRewardReply{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestGetValidatorsAt(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -6,6 +6,7 @@ package state
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -609,6 +610,64 @@ func (d *Diff) DeleteUTXO(utxoID ids.ID) {
 	}
 }
 
+// ModifiedUTXOs returns the UTXOs added by this diff and the IDs of the UTXOs
+// deleted by this diff.
+func (d *Diff) ModifiedUTXOs() ([]*avax.UTXO, []ids.ID) {
+	var (
+		added   []*avax.UTXO
+		deleted []ids.ID
+	)
+	for utxoID, utxo := range d.modifiedUTXOs {
+		if utxo != nil {
+			added = append(added, utxo)
+		} else {
+			deleted = append(deleted, utxoID)
+		}
+	}
+	slices.SortFunc(added, func(a, b *avax.UTXO) int {
+		return a.InputID().Compare(b.InputID())
+	})
+	slices.SortFunc(deleted, ids.ID.Compare)
+	return added, deleted
+}
+
+// ModifiedStakers returns the current and pending stakers added by this diff
+// and the current and pending stakers deleted by this diff.
+func (d *Diff) ModifiedStakers() ([]*Staker, []*Staker) {
+	var (
+		added   []*Staker
+		deleted []*Staker
+	)
+	for _, stakers := range []*diffStakers{&d.currentStakerDiffs, &d.pendingStakerDiffs} {
+		addedIterator := iterator.FromTree(stakers.addedStakers)
+		for addedIterator.Next() {
+			added = append(added, addedIterator.Value())
+		}
+		addedIterator.Release()
+
+		for _, staker := range stakers.deletedStakers {
+			deleted = append(deleted, staker)
+		}
+	}
+	slices.SortFunc(deleted, func(a, b *Staker) int {
+		return a.TxID.Compare(b.TxID)
+	})
+	return added, deleted
+}
+
+// ModifiedL1Validators returns the L1 validators modified by this diff. L1
+// validators deleted by this diff have a weight of 0.
+func (d *Diff) ModifiedL1Validators() []L1Validator {
+	l1Validators := make([]L1Validator, 0, len(d.l1ValidatorsDiff.modified))
+	for _, l1Validator := range d.l1ValidatorsDiff.modified {
+		l1Validators = append(l1Validators, l1Validator)
+	}
+	slices.SortFunc(l1Validators, func(a, b L1Validator) int {
+		return a.ValidationID.Compare(b.ValidationID)
+	})
+	return l1Validators
+}
+
 func (d *Diff) Apply(baseState Chain) error {
 	baseState.SetTimestamp(d.timestamp)
 	baseState.SetFeeState(d.feeState)
@@ -747,6 +747,69 @@ func TestDiffUTXO(t *testing.T) {
 	}
 }
 
+func TestDiffModifications(t *testing.T) {
+	require := require.New(t)
+
+	state := newTestState(t, memdb.New())
+
+	parentUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+	}
+	state.AddUTXO(parentUTXO)
+
+	parentValidator := &Staker{
+		TxID:     ids.GenerateTestID(),
+		SubnetID: ids.GenerateTestID(),
+		NodeID:   ids.GenerateTestNodeID(),
+		Priority: txs.SubnetPermissionedValidatorCurrentPriority,
+	}
+	require.NoError(state.PutCurrentValidator(parentValidator))
+
+	d, err := NewDiffOn(state, StakerAdditionAfterDeletionAllowed)
+	require.NoError(err)
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+	}
+	d.AddUTXO(utxo)
+	d.DeleteUTXO(parentUTXO.InputID())
+
+	currentValidator := &Staker{
+		TxID:     ids.GenerateTestID(),
+		SubnetID: ids.GenerateTestID(),
+		NodeID:   ids.GenerateTestNodeID(),
+		Priority: txs.SubnetPermissionedValidatorCurrentPriority,
+	}
+	require.NoError(d.PutCurrentValidator(currentValidator))
+	require.NoError(d.DeleteCurrentValidator(parentValidator))
+
+	pendingValidator := &Staker{
+		TxID:     ids.GenerateTestID(),
+		SubnetID: ids.GenerateTestID(),
+		NodeID:   ids.GenerateTestNodeID(),
+		Priority: txs.SubnetPermissionedValidatorPendingPriority,
+	}
+	require.NoError(d.PutPendingValidator(pendingValidator))
+
+	l1Validator := L1Validator{
+		ValidationID: ids.GenerateTestID(),
+		SubnetID:     ids.GenerateTestID(),
+		NodeID:       ids.GenerateTestNodeID(),
+		Weight:       1,
+	}
+	require.NoError(d.PutL1Validator(l1Validator))
+
+	addedUTXOs, deletedUTXOs := d.ModifiedUTXOs()
+	require.Equal([]*avax.UTXO{utxo}, addedUTXOs)
+	require.Equal([]ids.ID{parentUTXO.InputID()}, deletedUTXOs)
+
+	addedStakers, deletedStakers := d.ModifiedStakers()
+	require.ElementsMatch([]*Staker{currentValidator, pendingValidator}, addedStakers)
+	require.Equal([]*Staker{parentValidator}, deletedStakers)
+
+	require.Equal([]L1Validator{l1Validator}, d.ModifiedL1Validators())
+}
+
 func assertChainsEqual(t *testing.T, expected, actual Chain) {
 	require := require.New(t)
 
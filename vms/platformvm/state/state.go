@@ -6,9 +6,11 @@ package state
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -57,6 +60,12 @@ const (
 	indexIterationSleepMultiplier = 5
 	indexIterationSleepCap        = 10 * time.Second
 	indexLogFrequency             = 30 * time.Second
+
+	// uptimeHistoryRetention is how long, relative to the chain time, the
+	// connection history of a validator is kept. It exceeds the maximum
+	// staking period so that the history of a staking period can be inspected
+	// after its reward was decided.
+	uptimeHistoryRetention = 2 * 365 * 24 * time.Hour
 )
 
 var (
@@ -94,6 +103,8 @@ var (
 	ActivePrefix                            = []byte("active")
 	InactivePrefix                          = []byte("inactive")
 	SingletonPrefix                         = []byte("singleton")
+	UptimeHistoryPrefix                     = []byte("uptimeHistory")
+	UptimeHistoryExpiryPrefix               = []byte("uptimeHistoryExpiry")
 
 	TimestampKey         = []byte("timestamp")
 	FeeStateKey          = []byte("fee state")
@@ -233,6 +244,10 @@ type stateBlk struct {
  * |     '-- txID -> nil
  * |-. expiryReplayProtection
  * | '-- timestamp + validationID -> nil
+ * |-. uptimeHistory
+ * | '-- nodeID + end -> start
+ * |-. uptimeHistoryExpiry
+ * | '-- end + nodeID -> nil
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- blocksReindexedKey -> nil
@@ -362,6 +377,10 @@ type State struct {
 	// TODO: Remove indexedHeights once v1.11.3 has been released.
 	indexedHeights *heightRange
 	singletonDB    database.Database
+
+	addedUptimeIntervals  map[ids.NodeID][]uptime.Interval // map of nodeID -> newly observed connection intervals
+	uptimeHistoryDB       database.Database
+	uptimeHistoryExpiryDB database.Database
 }
 
 // heightRange is used to track which heights are safe to use the native DB
@@ -731,6 +750,10 @@ func New(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+
+		addedUptimeIntervals:  make(map[ids.NodeID][]uptime.Interval),
+		uptimeHistoryDB:       prefixdb.New(UptimeHistoryPrefix, baseDB),
+		uptimeHistoryExpiryDB: prefixdb.New(UptimeHistoryExpiryPrefix, baseDB),
 	}
 
 	if err := s.sync(genesisBytes); err != nil {
@@ -1698,6 +1721,98 @@ func (s *State) ApplyValidatorPublicKeyDiffs(
 	return diffIter.Error()
 }
 
+// ValidatorDiff is the change to a validator that was applied at a height.
+type ValidatorDiff struct {
+	SubnetID ids.ID
+	NodeID   ids.NodeID
+	// WeightDiff is the change to the validator's weight. The amount is 0 if
+	// only the public key of the validator changed.
+	WeightDiff ValidatorWeightDiff
+	// PublicKeyChanged is true if the public key of the validator changed.
+	PublicKeyChanged bool
+	// PrevPublicKey is the uncompressed public key of the validator prior to
+	// this height. It is only populated if [PublicKeyChanged] is true and the
+	// validator previously had a public key.
+	PrevPublicKey []byte
+}
+
+// GetValidatorDiffs returns the validator diffs that were applied at `height`,
+// ordered by subnetID and then by nodeID.
+func (s *State) GetValidatorDiffs(height uint64) ([]*ValidatorDiff, error) {
+	var (
+		prefix  = marshalStartDiffKeyByHeight(height)
+		diffs   []*ValidatorDiff
+		indices = make(map[subnetIDNodeID]int)
+	)
+	getOrAddDiff := func(key []byte) (*ValidatorDiff, error) {
+		_, subnetID, nodeID, err := unmarshalDiffKeyByHeight(key)
+		if err != nil {
+			return nil, err
+		}
+
+		subnetIDNodeID := subnetIDNodeID{
+			subnetID: subnetID,
+			nodeID:   nodeID,
+		}
+		if i, ok := indices[subnetIDNodeID]; ok {
+			return diffs[i], nil
+		}
+
+		diff := &ValidatorDiff{
+			SubnetID: subnetID,
+			NodeID:   nodeID,
+		}
+		indices[subnetIDNodeID] = len(diffs)
+		diffs = append(diffs, diff)
+		return diff, nil
+	}
+
+	weightIter := s.validatorWeightDiffsByHeightDB.NewIteratorWithStartAndPrefix(prefix, prefix)
+	defer weightIter.Release()
+
+	for weightIter.Next() {
+		diff, err := getOrAddDiff(weightIter.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		weightDiff, err := unmarshalWeightDiff(weightIter.Value())
+		if err != nil {
+			return nil, err
+		}
+		diff.WeightDiff = *weightDiff
+	}
+	if err := weightIter.Error(); err != nil {
+		return nil, err
+	}
+
+	pkIter := s.validatorPublicKeyDiffsByHeightDB.NewIteratorWithStartAndPrefix(prefix, prefix)
+	defer pkIter.Release()
+
+	for pkIter.Next() {
+		diff, err := getOrAddDiff(pkIter.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		diff.PublicKeyChanged = true
+		if pkBytes := pkIter.Value(); len(pkBytes) != 0 {
+			diff.PrevPublicKey = slices.Clone(pkBytes)
+		}
+	}
+	if err := pkIter.Error(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(diffs, func(a, b *ValidatorDiff) int {
+		if c := a.SubnetID.Compare(b.SubnetID); c != 0 {
+			return c
+		}
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return diffs, nil
+}
+
 func (s *State) syncGenesis(genesisBlk block.Block, genesis *genesis.Genesis) error {
 	genesisBlkID := genesisBlk.ID()
 	s.SetLastAccepted(genesisBlkID)
@@ -2300,6 +2415,7 @@ func (s *State) write(updateValidators bool, height uint64) error {
 		s.writeSubnetSupplies(),
 		s.writeChains(),
 		s.writeMetadata(),
+		s.writeUptimeHistory(),
 	)
 }
 
@@ -2344,6 +2460,8 @@ func (s *State) Close() error {
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
+		s.uptimeHistoryDB.Close(),
+		s.uptimeHistoryExpiryDB.Close(),
 	)
 }
 
@@ -3500,6 +3618,140 @@ func (s *State) SetUptime(vdrID ids.NodeID, upDuration time.Duration, lastUpdate
 	return s.validatorState.SetUptime(vdrID, constants.PrimaryNetworkID, upDuration, lastUpdated)
 }
 
+func (s *State) AddUptimeInterval(vdrID ids.NodeID, interval uptime.Interval) error {
+	if _, _, err := s.validatorState.GetUptime(vdrID, constants.PrimaryNetworkID); err != nil {
+		return err
+	}
+	s.addedUptimeIntervals[vdrID] = append(s.addedUptimeIntervals[vdrID], interval)
+	return nil
+}
+
+// GetUptimeHistory returns the first [limit] intervals during which [vdrID]
+// was observed to be connected that ended after [start], ordered by their start
+// time.
+//
+// The history is kept for [uptimeHistoryRetention] after each interval ended,
+// regardless of whether [vdrID] is still a validator.
+func (s *State) GetUptimeHistory(vdrID ids.NodeID, start time.Time, limit int) ([]uptime.Interval, error) {
+	// Intervals are keyed by their end time, so the iteration starts at the
+	// first interval that ended after [start].
+	var seekEnd uint64
+	if unix := start.Unix(); unix >= 0 {
+		seekEnd = uint64(unix) + 1
+	}
+	it := s.uptimeHistoryDB.NewIteratorWithStartAndPrefix(
+		marshalUptimeIntervalKey(vdrID, seekEnd),
+		vdrID.Bytes(),
+	)
+	defer it.Release()
+
+	var intervals []uptime.Interval
+	for len(intervals) < limit && it.Next() {
+		interval, err := parseUptimeInterval(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, interval)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	for _, interval := range s.addedUptimeIntervals[vdrID] {
+		if len(intervals) >= limit {
+			break
+		}
+		if interval.End.After(start) {
+			intervals = append(intervals, interval)
+		}
+	}
+	return intervals, nil
+}
+
+func (s *State) writeUptimeHistory() error {
+	for nodeID, intervals := range s.addedUptimeIntervals {
+		delete(s.addedUptimeIntervals, nodeID)
+
+		for _, interval := range intervals {
+			end := uint64(interval.End.Unix())
+			key := marshalUptimeIntervalKey(nodeID, end)
+			if err := database.PutUInt64(s.uptimeHistoryDB, key, uint64(interval.Start.Unix())); err != nil {
+				return fmt.Errorf("failed to write uptime interval: %w", err)
+			}
+			if err := s.uptimeHistoryExpiryDB.Put(marshalUptimeExpiryKey(end, nodeID), nil); err != nil {
+				return fmt.Errorf("failed to write uptime interval expiry: %w", err)
+			}
+		}
+	}
+
+	if err := s.pruneUptimeHistory(s.timestamp.Add(-uptimeHistoryRetention)); err != nil {
+		return fmt.Errorf("failed to prune uptime history: %w", err)
+	}
+	return nil
+}
+
+// pruneUptimeHistory deletes the intervals of every node that ended before
+// [cutoff].
+func (s *State) pruneUptimeHistory(cutoff time.Time) error {
+	it := s.uptimeHistoryExpiryDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != database.Uint64Size+ids.NodeIDLen {
+			return fmt.Errorf("unexpected uptime interval expiry key length %d", len(key))
+		}
+		end := binary.BigEndian.Uint64(key)
+		if !time.Unix(int64(end), 0).Before(cutoff) {
+			break
+		}
+
+		nodeID, err := ids.ToNodeID(key[database.Uint64Size:])
+		if err != nil {
+			return err
+		}
+		if err := s.uptimeHistoryDB.Delete(marshalUptimeIntervalKey(nodeID, end)); err != nil {
+			return err
+		}
+		if err := s.uptimeHistoryExpiryDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// marshalUptimeIntervalKey returns nodeID + end so that the intervals of a node
+// are ordered by their end time, and therefore by their start time.
+func marshalUptimeIntervalKey(nodeID ids.NodeID, end uint64) []byte {
+	key := make([]byte, ids.NodeIDLen+database.Uint64Size)
+	copy(key, nodeID.Bytes())
+	binary.BigEndian.PutUint64(key[ids.NodeIDLen:], end)
+	return key
+}
+
+// marshalUptimeExpiryKey returns end + nodeID so that the intervals of all
+// nodes are ordered by their end time.
+func marshalUptimeExpiryKey(end uint64, nodeID ids.NodeID) []byte {
+	key := make([]byte, database.Uint64Size+ids.NodeIDLen)
+	binary.BigEndian.PutUint64(key, end)
+	copy(key[database.Uint64Size:], nodeID.Bytes())
+	return key
+}
+
+func parseUptimeInterval(key []byte, value []byte) (uptime.Interval, error) {
+	if len(key) != ids.NodeIDLen+database.Uint64Size {
+		return uptime.Interval{}, fmt.Errorf("unexpected uptime interval key length %d", len(key))
+	}
+	start, err := database.ParseUInt64(value)
+	if err != nil {
+		return uptime.Interval{}, err
+	}
+	return uptime.Interval{
+		Start: time.Unix(int64(start), 0),
+		End:   time.Unix(int64(binary.BigEndian.Uint64(key[ids.NodeIDLen:])), 0),
+	}, nil
+}
+
 func markInitialized(db database.KeyValueWriter) error {
 	return db.Put(InitializedKey, nil)
 }
@@ -8,6 +8,7 @@ import (
 	"maps"
 	"math"
 	"math/rand"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/upgrade/upgradetest"
@@ -2963,6 +2965,134 @@ func TestDiffMultipleValidatorsSameBlock(t *testing.T) {
 	require.NotContains(historicalVdrs, nodeC)
 }
 
+func TestGetValidatorDiffs(t *testing.T) {
+	require := require.New(t)
+
+	state := newTestState(t, memdb.New())
+
+	skA, err := localsigner.New()
+	require.NoError(err)
+	skB, err := localsigner.New()
+	require.NoError(err)
+	skB2, err := localsigner.New()
+	require.NoError(err)
+	skC, err := localsigner.New()
+	require.NoError(err)
+
+	var (
+		nodeA     = ids.GenerateTestNodeID()
+		nodeB     = ids.GenerateTestNodeID()
+		nodeC     = ids.GenerateTestNodeID()
+		subnetID  = constants.PrimaryNetworkID
+		startTime = genesistest.DefaultValidatorStartTime
+		endTime   = startTime.Add(24 * time.Hour)
+	)
+
+	stakerA := Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    nodeA,
+		PublicKey: skA.PublicKey(),
+		SubnetID:  subnetID,
+		Weight:    10,
+		StartTime: startTime,
+		EndTime:   endTime,
+		NextTime:  endTime,
+		Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+	}
+	stakerB := Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    nodeB,
+		PublicKey: skB.PublicKey(),
+		SubnetID:  subnetID,
+		Weight:    15,
+		StartTime: startTime,
+		EndTime:   endTime,
+		NextTime:  endTime,
+		Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+	}
+
+	// Block 1: Add validators A and B.
+	d, err := NewDiffOn(state, StakerAdditionAfterDeletionAllowed)
+	require.NoError(err)
+	require.NoError(d.PutCurrentValidator(&stakerA))
+	require.NoError(d.PutCurrentValidator(&stakerB))
+	require.NoError(d.Apply(state))
+	state.SetHeight(1)
+	require.NoError(state.Commit())
+
+	// Block 2: Remove A, replace B with a new key and weight, and add C.
+	replacementB := stakerB
+	replacementB.TxID = ids.GenerateTestID()
+	replacementB.PublicKey = skB2.PublicKey()
+	replacementB.Weight = 25
+	stakerC := Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    nodeC,
+		PublicKey: skC.PublicKey(),
+		SubnetID:  subnetID,
+		Weight:    30,
+		StartTime: startTime,
+		EndTime:   endTime,
+		NextTime:  endTime,
+		Priority:  txs.PrimaryNetworkValidatorCurrentPriority,
+	}
+
+	d, err = NewDiffOn(state, StakerAdditionAfterDeletionAllowed)
+	require.NoError(err)
+	require.NoError(d.DeleteCurrentValidator(&stakerA))
+	require.NoError(d.DeleteCurrentValidator(&stakerB))
+	require.NoError(d.PutCurrentValidator(&replacementB))
+	require.NoError(d.PutCurrentValidator(&stakerC))
+	require.NoError(d.Apply(state))
+	state.SetHeight(2)
+	require.NoError(state.Commit())
+
+	// Block 3: No validator changes.
+	state.SetHeight(3)
+	require.NoError(state.Commit())
+
+	expected := []*ValidatorDiff{
+		{
+			SubnetID: subnetID,
+			NodeID:   nodeA,
+			WeightDiff: ValidatorWeightDiff{
+				Decrease: true,
+				Amount:   10,
+			},
+			PublicKeyChanged: true,
+			PrevPublicKey:    bls.PublicKeyToUncompressedBytes(skA.PublicKey()),
+		},
+		{
+			SubnetID: subnetID,
+			NodeID:   nodeB,
+			WeightDiff: ValidatorWeightDiff{
+				Amount: 10,
+			},
+			PublicKeyChanged: true,
+			PrevPublicKey:    bls.PublicKeyToUncompressedBytes(skB.PublicKey()),
+		},
+		{
+			SubnetID: subnetID,
+			NodeID:   nodeC,
+			WeightDiff: ValidatorWeightDiff{
+				Amount: 30,
+			},
+			PublicKeyChanged: true,
+		},
+	}
+	slices.SortFunc(expected, func(a, b *ValidatorDiff) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+
+	diffs, err := state.GetValidatorDiffs(2)
+	require.NoError(err)
+	require.Equal(expected, diffs)
+
+	diffs, err = state.GetValidatorDiffs(3)
+	require.NoError(err)
+	require.Empty(diffs)
+}
+
 func TestDiffRemoveValidatorNoPriorState(t *testing.T) {
 	require := require.New(t)
 
@@ -4497,3 +4627,71 @@ func TestAutoRenewedValidatorRestakeStateReload(t *testing.T) {
 	require.Equal(autoCompoundRewardShares, gotStakingInfo.AutoCompoundRewardShares)
 	require.Equal(period, gotStakingInfo.NextPeriod)
 }
+
+func TestUptimeHistory(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db     = memdb.New()
+		state  = newTestState(t, db)
+		nodeID = defaultValidatorNodeID
+		start  = state.GetTimestamp()
+		first  = uptime.Interval{
+			Start: start,
+			End:   start.Add(time.Hour),
+		}
+		second = uptime.Interval{
+			Start: start.Add(2 * time.Hour),
+			End:   start.Add(3 * time.Hour),
+		}
+	)
+
+	// The history of non-validators isn't recorded.
+	require.ErrorIs(state.AddUptimeInterval(ids.GenerateTestNodeID(), first), database.ErrNotFound)
+
+	require.NoError(state.AddUptimeInterval(nodeID, first))
+	history, err := state.GetUptimeHistory(nodeID, time.Time{}, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]uptime.Interval{first}, history)
+
+	require.NoError(state.Commit())
+	require.NoError(state.AddUptimeInterval(nodeID, second))
+	require.NoError(state.Commit())
+
+	state = newTestState(t, db)
+	history, err = state.GetUptimeHistory(nodeID, time.Time{}, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]uptime.Interval{first, second}, history)
+
+	// Only intervals that ended after the requested start are returned.
+	history, err = state.GetUptimeHistory(nodeID, first.End, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]uptime.Interval{second}, history)
+
+	// The interval covering the requested start is returned.
+	history, err = state.GetUptimeHistory(nodeID, second.Start.Add(time.Minute), math.MaxInt)
+	require.NoError(err)
+	require.Equal([]uptime.Interval{second}, history)
+
+	// At most [limit] intervals are returned.
+	history, err = state.GetUptimeHistory(nodeID, time.Time{}, 1)
+	require.NoError(err)
+	require.Equal([]uptime.Interval{first}, history)
+
+	// Intervals that ended before the retention period are pruned as the chain
+	// time advances, even if no new intervals are recorded.
+	state.SetTimestamp(first.End.Add(uptimeHistoryRetention).Add(time.Second))
+	require.NoError(state.Commit())
+
+	state = newTestState(t, db)
+	history, err = state.GetUptimeHistory(nodeID, time.Time{}, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]uptime.Interval{second}, history)
+
+	state.SetTimestamp(second.End.Add(uptimeHistoryRetention).Add(time.Second))
+	require.NoError(state.Commit())
+
+	history, err = state.GetUptimeHistory(nodeID, time.Time{}, math.MaxInt)
+	require.NoError(err)
+	require.Empty(history)
+}
@@ -369,7 +369,7 @@ func TestAdvanceTimeTxUpdateStakers(t *testing.T) {
 			dummyHeight := uint64(1)
 
 			subnetID := testSubnet1.ID()
-			env.config.TrackedSubnets.Add(subnetID)
+			env.config.TrackedSubnets.Track(subnetID)
 
 			for _, staker := range test.stakers {
 				addPendingValidator(
@@ -477,7 +477,7 @@ func TestAdvanceTimeTxRemoveSubnetValidator(t *testing.T) {
 	defer env.ctx.Lock.Unlock()
 
 	subnetID := testSubnet1.ID()
-	env.config.TrackedSubnets.Add(subnetID)
+	env.config.TrackedSubnets.Track(subnetID)
 
 	wallet := newWallet(t, env, walletConfig{
 		subnetIDs: []ids.ID{subnetID},
@@ -592,7 +592,7 @@ func TestTrackedSubnet(t *testing.T) {
 
 			subnetID := testSubnet1.ID()
 			if tracked {
-				env.config.TrackedSubnets.Add(subnetID)
+				env.config.TrackedSubnets.Track(subnetID)
 			}
 
 			wallet := newWallet(t, env, walletConfig{
@@ -10,7 +10,6 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/math/intmath"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -788,63 +787,24 @@ func (e *proposalTxExecutor) restakeAutoRenewedValidatorOnCommit(
 	validator *state.Staker,
 	stakingInfo state.StakingInfo,
 ) error {
-	// Ignore the withdrawn portions from [reward.Split] because the restaked
-	// amounts may be capped below. Withdrawn rewards are computed later from the
-	// difference between total rewards and the amounts actually restaked.
-	restakingValidationRewards, _ := reward.Split(validator.PotentialReward, stakingInfo.AutoCompoundRewardShares)
-	restakingDelegateeRewards, _ := reward.Split(stakingInfo.DelegateeReward, stakingInfo.AutoCompoundRewardShares)
-
 	// Restaking grows the validator's weight, which must never exceed
 	// MaxValidatorStake. If the restaked rewards wouldn't fit, only the remaining
-	// capacity is restaked (split proportionally between validation and delegatee
-	// rewards) and the rest is withdrawn below.
+	// capacity is restaked and the rest is withdrawn below.
 	restakingCapacity, err := safemath.Sub(e.backend.Config.MaxValidatorStake, validator.Weight)
 	if err != nil {
 		return err
 	}
 
-	totalRestakingRewards, err := safemath.Add(restakingValidationRewards, restakingDelegateeRewards)
+	restakingValidationRewards, restakingDelegateeRewards, err := reward.Restake(
+		validator.PotentialReward,
+		stakingInfo.DelegateeReward,
+		stakingInfo.AutoCompoundRewardShares,
+		restakingCapacity,
+	)
 	if err != nil {
 		return err
 	}
 
-	if totalRestakingRewards > restakingCapacity {
-		// Let V be the validation rewards, D be the delegatee rewards, C be the
-		// restaking capacity, and T = V + D. This branch means C < T. After
-		// computing V' = floor(V * C / T), the delegatee restake is D' = C - V'.
-		//
-		// Proving D' cannot exceed D:
-		//   D' - D = (C - V') - (T - V)
-		//          = (C - T) + (V - V')
-		// Because V' = floor(V * C / T):
-		//   V' > V * C / T - 1
-		//   -V' < -(V * C / T - 1)
-		//   -V' < -V * C / T + 1
-		//   V - V' < V - V * C / T + 1
-		//   V - V' < V * (1 - C / T) + 1
-		//   V - V' < V * (T / T - C / T) + 1
-		//   V - V' < V * (T - C) / T + 1
-		// Since V <= T:
-		//   V - V' < T - C + 1
-		//   V - V' <= T - C
-		// Substitute that bound into D' - D:
-		//   D' - D <= (C - T) + (T - C)
-		//          <= 0
-		// Therefore D' <= D.
-		//
-		// Therefore assigning the remaining capacity to delegatee rewards cannot
-		// increase them, and the later withdrawal subtraction cannot underflow.
-		restakingValidationRewards, _, err = intmath.MulDiv(restakingValidationRewards, restakingCapacity, totalRestakingRewards)
-		if err != nil {
-			return err
-		}
-
-		restakingDelegateeRewards, err = safemath.Sub(restakingCapacity, restakingValidationRewards)
-		if err != nil {
-			return err
-		}
-	}
-
 	// Withdraw everything that isn't being restaked.
 	withdrawingRewards, err := safemath.Sub(validator.PotentialReward, restakingValidationRewards)
 	if err != nil {
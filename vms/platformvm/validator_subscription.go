@@ -0,0 +1,503 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/subscription"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/types"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	validatorSubscriptionEndpoint = "/validators/subscribe"
+
+	// Maximum number of heights before the next accepted height that a
+	// subscription may start at. This is also the maximum number of heights
+	// whose changes are read at once.
+	validatorSubscriptionMaxLookback = 4096
+	// Maximum number of concurrent subscribers.
+	validatorSubscriptionMaxSubscribers = 64
+
+	ValidatorAdded            ValidatorSetChangeType = "added"
+	ValidatorRemoved          ValidatorSetChangeType = "removed"
+	ValidatorWeightChanged    ValidatorSetChangeType = "weightChanged"
+	ValidatorPublicKeyChanged ValidatorSetChangeType = "publicKeyChanged"
+)
+
+var (
+	_ http.Handler                       = (*validatorSubscriptionHandler)(nil)
+	_ validators.ManagerCallbackListener = (*validatorSetNotifier)(nil)
+
+	errStartHeightTooHigh = errors.New("start height is greater than the next accepted height")
+	errStartHeightTooLow  = errors.New("start height is too far behind the next accepted height")
+	errTooManySubscribers = errors.New("too many validator subscribers")
+	errShuttingDown       = errors.New("shutting down")
+)
+
+// ValidatorSetChangeType describes how a validator changed.
+type ValidatorSetChangeType string
+
+// ValidatorSetChange is the change to a single validator of a subnet.
+type ValidatorSetChange struct {
+	SubnetID       ids.ID                 `json:"subnetID"`
+	NodeID         ids.NodeID             `json:"nodeID"`
+	Type           ValidatorSetChangeType `json:"type"`
+	PreviousWeight avajson.Uint64         `json:"previousWeight"`
+	Weight         avajson.Uint64         `json:"weight"`
+	// Public keys are compressed. They are omitted if the validator did not
+	// have a public key.
+	PreviousPublicKey types.JSONByteSlice `json:"previousPublicKey,omitempty"`
+	PublicKey         types.JSONByteSlice `json:"publicKey,omitempty"`
+}
+
+// ValidatorSetChanges are the changes to the validator sets that were applied
+// by the block at [Height].
+type ValidatorSetChanges struct {
+	Height  avajson.Uint64       `json:"height"`
+	Changes []ValidatorSetChange `json:"changes"`
+}
+
+// validatorSetNotifier wakes up subscribers whenever the validator manager
+// reports a change to a validator set.
+//
+// The validator manager is updated while the block that changed it is being
+// accepted. Because subscribers read the last accepted height while holding
+// the context lock, they will only observe the changes once the block has been
+// fully accepted.
+type validatorSetNotifier struct {
+	lock sync.Mutex
+	// changed is closed, and replaced, whenever a validator set changes.
+	changed chan struct{}
+}
+
+func newValidatorSetNotifier() *validatorSetNotifier {
+	return &validatorSetNotifier{
+		changed: make(chan struct{}),
+	}
+}
+
+// Changed returns a channel that is closed the next time a validator set
+// changes.
+func (n *validatorSetNotifier) Changed() <-chan struct{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.changed
+}
+
+func (n *validatorSetNotifier) OnValidatorAdded(ids.ID, ids.NodeID, *bls.PublicKey, ids.ID, uint64) {
+	n.notify()
+}
+
+func (n *validatorSetNotifier) OnValidatorRemoved(ids.ID, ids.NodeID, uint64) {
+	n.notify()
+}
+
+func (n *validatorSetNotifier) OnValidatorWeightChanged(ids.ID, ids.NodeID, uint64, uint64) {
+	n.notify()
+}
+
+func (n *validatorSetNotifier) notify() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	close(n.changed)
+	n.changed = make(chan struct{})
+}
+
+// validatorSubscriptionHandler streams validator set changes to WebSocket
+// clients.
+//
+// Changes are read from the validator diffs that are persisted for every
+// accepted height, starting at the requested height. Because each subscriber
+// reads from the database at its own pace, subscribers that fall behind do not
+// cause changes to be buffered in memory. Subscribers that take longer than
+// [subscription.WriteTimeout] to read the changes of a height are
+// disconnected and may resume from the next height they have not received.
+//
+// At most [validatorSubscriptionMaxSubscribers] subscribers are served at
+// once, and subscriptions may start at most
+// [validatorSubscriptionMaxLookback] heights in the past.
+type validatorSubscriptionHandler struct {
+	vm       *VM
+	upgrader websocket.Upgrader
+
+	lock           sync.Mutex
+	numSubscribers int
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and streams
+// validator set changes to it. A message is only sent for heights that changed
+// at least one of the requested validator sets.
+//
+// Supported query parameters:
+//   - startHeight: first height to send the changes of. Defaults to the height
+//     of the next accepted block.
+//   - subnetID: subnet to send the changes of. May be provided multiple times.
+//     Defaults to all subnets.
+func (h *validatorSubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.addSubscriber() {
+		http.Error(w, errTooManySubscribers.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer h.removeSubscriber()
+
+	startHeight, subnetIDs, err := h.parseArgs(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already responded to the client.
+		h.vm.ctx.Log.Debug("failed to upgrade validator subscription",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := subscription.KeepAlive(r.Context(), conn)
+	defer cancel()
+
+	for height := startHeight; ; {
+		heightChanges, err := h.waitForChanges(ctx, height, subnetIDs)
+		if err != nil {
+			h.closeConn(conn, err)
+			return
+		}
+
+		for _, changes := range heightChanges {
+			if len(changes.Changes) == 0 {
+				continue
+			}
+
+			if err := subscription.WriteJSON(conn, changes); err != nil {
+				h.vm.ctx.Log.Debug("dropping validator subscriber",
+					zap.Uint64("height", uint64(changes.Height)),
+					zap.Error(err),
+				)
+				return
+			}
+		}
+		height += uint64(len(heightChanges))
+	}
+}
+
+// addSubscriber returns false if the maximum number of subscribers has been
+// reached. Otherwise, the subscriber is counted until [removeSubscriber] is
+// called.
+func (h *validatorSubscriptionHandler) addSubscriber() bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.numSubscribers >= validatorSubscriptionMaxSubscribers {
+		return false
+	}
+	h.numSubscribers++
+	return true
+}
+
+func (h *validatorSubscriptionHandler) removeSubscriber() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.numSubscribers--
+}
+
+func (h *validatorSubscriptionHandler) parseArgs(r *http.Request) (uint64, set.Set[ids.ID], error) {
+	query := r.URL.Query()
+
+	h.vm.ctx.Lock.Lock()
+	lastAcceptedHeight, err := h.vm.GetCurrentHeight(r.Context())
+	h.vm.ctx.Lock.Unlock()
+	if err != nil {
+		return 0, nil, fmt.Errorf("couldn't get last accepted height: %w", err)
+	}
+
+	startHeight := lastAcceptedHeight + 1
+	if startHeightStr := query.Get("startHeight"); startHeightStr != "" {
+		startHeight, err = strconv.ParseUint(startHeightStr, 10, 64)
+		if err != nil {
+			return 0, nil, fmt.Errorf("couldn't parse startHeight: %w", err)
+		}
+		if err := verifyStartHeight(startHeight, lastAcceptedHeight); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var subnetIDs set.Set[ids.ID]
+	for _, subnetIDStr := range query["subnetID"] {
+		subnetID, err := ids.FromString(subnetIDStr)
+		if err != nil {
+			return 0, nil, fmt.Errorf("couldn't parse subnetID: %w", err)
+		}
+		subnetIDs.Add(subnetID)
+	}
+	return startHeight, subnetIDs, nil
+}
+
+// verifyStartHeight returns an error if a subscription can't start at
+// [startHeight] when [lastAcceptedHeight] is the last accepted height.
+func verifyStartHeight(startHeight, lastAcceptedHeight uint64) error {
+	nextHeight := lastAcceptedHeight + 1
+	switch {
+	case startHeight > nextHeight:
+		return fmt.Errorf("%w: %d > %d", errStartHeightTooHigh, startHeight, nextHeight)
+	case nextHeight-startHeight > validatorSubscriptionMaxLookback:
+		return fmt.Errorf("%w: %d < %d - %d",
+			errStartHeightTooLow,
+			startHeight,
+			nextHeight,
+			validatorSubscriptionMaxLookback,
+		)
+	default:
+		return nil
+	}
+}
+
+// waitForChanges blocks until [height] has been accepted and then returns the
+// changes made to the validator sets of [subnetIDs] by every accepted height
+// from [height], up to [validatorSubscriptionMaxLookback] heights. If
+// [subnetIDs] is empty, the changes to all validator sets are returned.
+func (h *validatorSubscriptionHandler) waitForChanges(
+	ctx context.Context,
+	height uint64,
+	subnetIDs set.Set[ids.ID],
+) ([]*ValidatorSetChanges, error) {
+	for {
+		// The channel must be fetched before checking the last accepted height
+		// to avoid missing a notification.
+		changed := h.vm.validatorSetNotifier.Changed()
+
+		changes, err := h.getChanges(ctx, height, subnetIDs)
+		if err != nil || len(changes) != 0 {
+			return changes, err
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-h.vm.onShutdownCtx.Done():
+			return nil, errShuttingDown
+		}
+	}
+}
+
+// getChanges returns the changes made to the validator sets of [subnetIDs] by
+// the accepted heights starting at [startHeight]. No changes are returned if
+// [startHeight] has not been accepted.
+//
+// The diffs only record the state of a validator prior to a change, so the
+// validator sets are fetched once at the last height and the diffs are then
+// reverted from the last height down to [startHeight]. The context lock is
+// only held while fetching the validator sets.
+//
+// Invariant: The context lock must not be held.
+func (h *validatorSubscriptionHandler) getChanges(
+	ctx context.Context,
+	startHeight uint64,
+	subnetIDs set.Set[ids.ID],
+) ([]*ValidatorSetChanges, error) {
+	h.vm.ctx.Lock.Lock()
+	if err := h.vm.onShutdownCtx.Err(); err != nil {
+		h.vm.ctx.Lock.Unlock()
+		return nil, errShuttingDown
+	}
+	lastAcceptedHeight, err := h.vm.GetCurrentHeight(ctx)
+	h.vm.ctx.Lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if startHeight > lastAcceptedHeight {
+		return nil, nil
+	}
+	endHeight := min(lastAcceptedHeight, startHeight+validatorSubscriptionMaxLookback-1)
+
+	// Accepted diffs are never modified, so they can be read without holding
+	// the context lock.
+	var (
+		numHeights     = endHeight - startHeight + 1
+		diffs          = make([][]*state.ValidatorDiff, numHeights)
+		changedSubnets set.Set[ids.ID]
+	)
+	for i := range diffs {
+		heightDiffs, err := h.vm.state.GetValidatorDiffs(startHeight + uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range heightDiffs {
+			if subnetIDs.Len() != 0 && !subnetIDs.Contains(diff.SubnetID) {
+				continue
+			}
+			diffs[i] = append(diffs[i], diff)
+			changedSubnets.Add(diff.SubnetID)
+		}
+	}
+
+	validatorSets, err := h.getValidatorSets(ctx, endHeight, changedSubnets)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*ValidatorSetChanges, numHeights)
+	for i := len(diffs) - 1; i >= 0; i-- {
+		height := startHeight + uint64(i)
+		changes[i] = &ValidatorSetChanges{
+			Height: avajson.Uint64(height),
+		}
+		for _, diff := range diffs[i] {
+			validatorSet := validatorSets[diff.SubnetID]
+			change, previousVdr, err := newValidatorSetChange(diff, validatorSet[diff.NodeID])
+			if err != nil {
+				return nil, err
+			}
+			changes[i].Changes = append(changes[i].Changes, change)
+
+			// Revert the diff so that the validator set is at the previous
+			// height.
+			if previousVdr == nil {
+				delete(validatorSet, diff.NodeID)
+			} else {
+				validatorSet[diff.NodeID] = previousVdr
+			}
+		}
+	}
+	return changes, nil
+}
+
+// getValidatorSets returns copies of the validator sets of [subnetIDs] at
+// [height], which may be modified.
+//
+// Invariant: The context lock must not be held.
+func (h *validatorSubscriptionHandler) getValidatorSets(
+	ctx context.Context,
+	height uint64,
+	subnetIDs set.Set[ids.ID],
+) (map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	h.vm.ctx.Lock.Lock()
+	defer h.vm.ctx.Lock.Unlock()
+
+	validatorSets := make(map[ids.ID]map[ids.NodeID]*validators.GetValidatorOutput, subnetIDs.Len())
+	for subnetID := range subnetIDs {
+		validatorSet, err := h.vm.GetValidatorSet(ctx, height, subnetID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get validator set of %s at %d: %w", subnetID, height, err)
+		}
+		// The returned validator set may be cached, so it must not be
+		// modified.
+		validatorSets[subnetID] = maps.Clone(validatorSet)
+	}
+	return validatorSets, nil
+}
+
+// newValidatorSetChange returns the change described by [diff] to a validator
+// whose state after the change is [vdr], along with the state of the validator
+// prior to the change. If the validator was removed, [vdr] is nil. If the
+// validator was added, the returned previous state is nil.
+func newValidatorSetChange(
+	diff *state.ValidatorDiff,
+	vdr *validators.GetValidatorOutput,
+) (ValidatorSetChange, *validators.GetValidatorOutput, error) {
+	var (
+		weight    uint64
+		publicKey *bls.PublicKey
+	)
+	if vdr != nil {
+		weight = vdr.Weight
+		publicKey = vdr.PublicKey
+	}
+
+	var (
+		previousWeight uint64
+		err            error
+	)
+	if diff.WeightDiff.Decrease {
+		previousWeight, err = safemath.Add(weight, diff.WeightDiff.Amount)
+	} else {
+		previousWeight, err = safemath.Sub(weight, diff.WeightDiff.Amount)
+	}
+	if err != nil {
+		return ValidatorSetChange{}, nil, fmt.Errorf("invalid weight diff of %s on %s: %w", diff.NodeID, diff.SubnetID, err)
+	}
+
+	previousPublicKey := publicKey
+	if diff.PublicKeyChanged {
+		previousPublicKey = nil
+		if len(diff.PrevPublicKey) != 0 {
+			previousPublicKey = bls.PublicKeyFromValidUncompressedBytes(diff.PrevPublicKey)
+		}
+	}
+
+	change := ValidatorSetChange{
+		SubnetID:       diff.SubnetID,
+		NodeID:         diff.NodeID,
+		PreviousWeight: avajson.Uint64(previousWeight),
+		Weight:         avajson.Uint64(weight),
+	}
+	switch {
+	case previousWeight == 0:
+		change.Type = ValidatorAdded
+	case weight == 0:
+		change.Type = ValidatorRemoved
+	case previousWeight != weight:
+		change.Type = ValidatorWeightChanged
+	default:
+		change.Type = ValidatorPublicKeyChanged
+	}
+	if previousPublicKey != nil {
+		change.PreviousPublicKey = bls.PublicKeyToCompressedBytes(previousPublicKey)
+	}
+	if publicKey != nil {
+		change.PublicKey = bls.PublicKeyToCompressedBytes(publicKey)
+	}
+
+	var previousVdr *validators.GetValidatorOutput
+	if previousWeight != 0 {
+		previousVdr = &validators.GetValidatorOutput{
+			NodeID:    diff.NodeID,
+			PublicKey: previousPublicKey,
+			Weight:    previousWeight,
+		}
+	}
+	return change, previousVdr, nil
+}
+
+// closeConn attempts to notify the subscriber of why the subscription ended.
+func (h *validatorSubscriptionHandler) closeConn(conn *websocket.Conn, err error) {
+	if errors.Is(err, context.Canceled) {
+		// The subscriber disconnected.
+		return
+	}
+
+	code := websocket.CloseInternalServerErr
+	if errors.Is(err, errShuttingDown) {
+		code = websocket.CloseGoingAway
+	} else {
+		h.vm.ctx.Log.Warn("closing validator subscription",
+			zap.Error(err),
+		)
+	}
+	subscription.Close(conn, code, err)
+}
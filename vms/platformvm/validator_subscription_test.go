@@ -0,0 +1,436 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/upgrade/upgradetest"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/signer/localsigner"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis/genesistest"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+var errStopSubscription = errors.New("stop subscription")
+
+func newValidatorSubscriptionTest(t *testing.T) (*VM, *Client) {
+	vm, _, _ := defaultVM(t, upgradetest.Latest)
+
+	mux := http.NewServeMux()
+	mux.Handle("/ext/P"+validatorSubscriptionEndpoint, &validatorSubscriptionHandler{vm: vm})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return vm, NewClient(server.URL)
+}
+
+// acceptSubnetValidator accepts a block that adds [nodeID] as a validator of
+// [testSubnet1] and returns the height of the block.
+//
+// Invariant: The context lock must not be held.
+func acceptSubnetValidator(t *testing.T, vm *VM, nodeID ids.NodeID) uint64 {
+	require := require.New(t)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	subnetID := testSubnet1.ID()
+	wallet := newWallet(t, vm, walletConfig{
+		subnetIDs: []ids.ID{subnetID},
+	})
+
+	var (
+		startTime = vm.clock.Time().Add(txexecutor.SyncBound).Add(time.Second)
+		endTime   = startTime.Add(defaultMinStakingDuration)
+	)
+	tx, err := wallet.IssueAddSubnetValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				Start:  uint64(startTime.Unix()),
+				End:    uint64(endTime.Unix()),
+				Wght:   genesistest.DefaultValidatorWeight,
+			},
+			Subnet: subnetID,
+		},
+	)
+	require.NoError(err)
+
+	vm.ctx.Lock.Unlock()
+	require.NoError(vm.issueTxFromRPC(tx))
+	vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(vm))
+
+	height, err := vm.GetCurrentHeight(t.Context())
+	require.NoError(err)
+	return height
+}
+
+// expectedSubnetValidatorAdded returns the change that adding [nodeID] as a
+// validator of [testSubnet1] is expected to produce.
+func expectedSubnetValidatorAdded(t *testing.T, vm *VM, nodeID ids.NodeID) ValidatorSetChange {
+	require := require.New(t)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	primaryValidator, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+
+	change := ValidatorSetChange{
+		SubnetID: testSubnet1.ID(),
+		NodeID:   nodeID,
+		Type:     ValidatorAdded,
+		Weight:   avajson.Uint64(genesistest.DefaultValidatorWeight),
+	}
+	// Subnet validators inherit the public key of their primary network
+	// validator, if any.
+	if primaryValidator.PublicKey != nil {
+		change.PublicKey = bls.PublicKeyToCompressedBytes(primaryValidator.PublicKey)
+	}
+	return change
+}
+
+func TestSubscribeValidatorSetChanges(t *testing.T) {
+	require := require.New(t)
+
+	vm, client := newValidatorSubscriptionTest(t)
+
+	var (
+		nodeID0 = genesistest.DefaultNodeIDs[0]
+		nodeID1 = genesistest.DefaultNodeIDs[1]
+	)
+
+	// Accept the first change before subscribing
+	height0 := acceptSubnetValidator(t, vm, nodeID0)
+	expected := []*ValidatorSetChanges{
+		{
+			Height:  avajson.Uint64(height0),
+			Changes: []ValidatorSetChange{expectedSubnetValidatorAdded(t, vm, nodeID0)},
+		},
+	}
+
+	var received []*ValidatorSetChanges
+	err := client.SubscribeValidatorSetChanges(t.Context(), height0, nil, func(changes *ValidatorSetChanges) error {
+		received = append(received, changes)
+		if len(received) == 2 {
+			return errStopSubscription
+		}
+
+		// The subscriber has caught up, so the next change must be pushed as
+		// it is accepted.
+		height1 := acceptSubnetValidator(t, vm, nodeID1)
+		expected = append(expected, &ValidatorSetChanges{
+			Height:  avajson.Uint64(height1),
+			Changes: []ValidatorSetChange{expectedSubnetValidatorAdded(t, vm, nodeID1)},
+		})
+		return nil
+	})
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(expected, received)
+
+	// Resume from the second change
+	received = nil
+	err = client.SubscribeValidatorSetChanges(t.Context(), height0+1, []ids.ID{testSubnet1.ID()}, func(changes *ValidatorSetChanges) error {
+		received = append(received, changes)
+		return errStopSubscription
+	})
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(expected[1:], received)
+}
+
+func TestValidatorSubscriptionSubnetFilter(t *testing.T) {
+	require := require.New(t)
+
+	vm, _ := newValidatorSubscriptionTest(t)
+	height := acceptSubnetValidator(t, vm, genesistest.DefaultNodeIDs[0])
+
+	h := &validatorSubscriptionHandler{vm: vm}
+	changes, err := h.waitForChanges(t.Context(), height, set.Of(constants.PrimaryNetworkID))
+	require.NoError(err)
+	require.Len(changes, 1)
+	require.Empty(changes[0].Changes)
+
+	changes, err = h.waitForChanges(t.Context(), height, set.Of(testSubnet1.ID()))
+	require.NoError(err)
+	require.Len(changes, 1)
+	require.Len(changes[0].Changes, 1)
+}
+
+func TestSubscribeValidatorSetChangesCatchUp(t *testing.T) {
+	require := require.New(t)
+
+	vm, client := newValidatorSubscriptionTest(t)
+
+	// Accept multiple changes before subscribing, so that they are read in a
+	// single batch.
+	var expected []*ValidatorSetChanges
+	for _, nodeID := range genesistest.DefaultNodeIDs[:3] {
+		height := acceptSubnetValidator(t, vm, nodeID)
+		expected = append(expected, &ValidatorSetChanges{
+			Height:  avajson.Uint64(height),
+			Changes: []ValidatorSetChange{expectedSubnetValidatorAdded(t, vm, nodeID)},
+		})
+	}
+
+	var received []*ValidatorSetChanges
+	err := client.SubscribeValidatorSetChanges(t.Context(), uint64(expected[0].Height), nil, func(changes *ValidatorSetChanges) error {
+		received = append(received, changes)
+		if len(received) == len(expected) {
+			return errStopSubscription
+		}
+		return nil
+	})
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(expected, received)
+}
+
+func TestSubscribeValidatorSetChangesTooManySubscribers(t *testing.T) {
+	require := require.New(t)
+
+	vm, _ := newValidatorSubscriptionTest(t)
+
+	h := &validatorSubscriptionHandler{vm: vm}
+	for range validatorSubscriptionMaxSubscribers {
+		require.True(h.addSubscriber())
+	}
+	require.False(h.addSubscriber())
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, validatorSubscriptionEndpoint, nil))
+	require.Equal(http.StatusServiceUnavailable, w.Code)
+
+	h.removeSubscriber()
+	require.True(h.addSubscriber())
+}
+
+func TestSubscribeValidatorSetChangesCancel(t *testing.T) {
+	require := require.New(t)
+
+	_, client := newValidatorSubscriptionTest(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go cancel()
+
+	err := client.SubscribeValidatorSetChanges(ctx, 0, nil, func(*ValidatorSetChanges) error {
+		return nil
+	})
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestSubscribeValidatorSetChangesInvalidStartHeight(t *testing.T) {
+	require := require.New(t)
+
+	vm, client := newValidatorSubscriptionTest(t)
+
+	vm.ctx.Lock.Lock()
+	height, err := vm.GetCurrentHeight(t.Context())
+	vm.ctx.Lock.Unlock()
+	require.NoError(err)
+
+	err = client.SubscribeValidatorSetChanges(t.Context(), height+2, nil, func(*ValidatorSetChanges) error {
+		return nil
+	})
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+}
+
+func TestVerifyStartHeight(t *testing.T) {
+	tests := []struct {
+		name               string
+		startHeight        uint64
+		lastAcceptedHeight uint64
+		expectedErr        error
+	}{
+		{
+			name:               "next height",
+			startHeight:        11,
+			lastAcceptedHeight: 10,
+		},
+		{
+			name:               "genesis",
+			startHeight:        0,
+			lastAcceptedHeight: 10,
+		},
+		{
+			name:               "max lookback",
+			startHeight:        1,
+			lastAcceptedHeight: validatorSubscriptionMaxLookback,
+		},
+		{
+			name:               "after next height",
+			startHeight:        12,
+			lastAcceptedHeight: 10,
+			expectedErr:        errStartHeightTooHigh,
+		},
+		{
+			name:               "before max lookback",
+			startHeight:        0,
+			lastAcceptedHeight: validatorSubscriptionMaxLookback,
+			expectedErr:        errStartHeightTooLow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifyStartHeight(test.startHeight, test.lastAcceptedHeight)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewValidatorSetChange(t *testing.T) {
+	sk0, err := localsigner.New()
+	require.NoError(t, err)
+	sk1, err := localsigner.New()
+	require.NoError(t, err)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+		pk0      = sk0.PublicKey()
+		pk1      = sk1.PublicKey()
+	)
+	tests := []struct {
+		name        string
+		diff        *state.ValidatorDiff
+		vdr         *validators.GetValidatorOutput
+		expected    ValidatorSetChange
+		expectedVdr *validators.GetValidatorOutput
+		expectedErr error
+	}{
+		{
+			name: "added",
+			diff: &state.ValidatorDiff{
+				WeightDiff: state.ValidatorWeightDiff{
+					Amount: 10,
+				},
+				PublicKeyChanged: true,
+			},
+			vdr: &validators.GetValidatorOutput{
+				PublicKey: pk0,
+				Weight:    10,
+			},
+			expected: ValidatorSetChange{
+				Type:      ValidatorAdded,
+				Weight:    10,
+				PublicKey: bls.PublicKeyToCompressedBytes(pk0),
+			},
+		},
+		{
+			name: "removed",
+			diff: &state.ValidatorDiff{
+				WeightDiff: state.ValidatorWeightDiff{
+					Decrease: true,
+					Amount:   10,
+				},
+				PublicKeyChanged: true,
+				PrevPublicKey:    bls.PublicKeyToUncompressedBytes(pk0),
+			},
+			expected: ValidatorSetChange{
+				Type:              ValidatorRemoved,
+				PreviousWeight:    10,
+				PreviousPublicKey: bls.PublicKeyToCompressedBytes(pk0),
+			},
+			expectedVdr: &validators.GetValidatorOutput{
+				PublicKey: pk0,
+				Weight:    10,
+			},
+		},
+		{
+			name: "weight changed",
+			diff: &state.ValidatorDiff{
+				WeightDiff: state.ValidatorWeightDiff{
+					Decrease: true,
+					Amount:   5,
+				},
+			},
+			vdr: &validators.GetValidatorOutput{
+				PublicKey: pk0,
+				Weight:    10,
+			},
+			expected: ValidatorSetChange{
+				Type:              ValidatorWeightChanged,
+				PreviousWeight:    15,
+				Weight:            10,
+				PreviousPublicKey: bls.PublicKeyToCompressedBytes(pk0),
+				PublicKey:         bls.PublicKeyToCompressedBytes(pk0),
+			},
+			expectedVdr: &validators.GetValidatorOutput{
+				PublicKey: pk0,
+				Weight:    15,
+			},
+		},
+		{
+			name: "public key changed",
+			diff: &state.ValidatorDiff{
+				PublicKeyChanged: true,
+				PrevPublicKey:    bls.PublicKeyToUncompressedBytes(pk0),
+			},
+			vdr: &validators.GetValidatorOutput{
+				PublicKey: pk1,
+				Weight:    10,
+			},
+			expected: ValidatorSetChange{
+				Type:              ValidatorPublicKeyChanged,
+				PreviousWeight:    10,
+				Weight:            10,
+				PreviousPublicKey: bls.PublicKeyToCompressedBytes(pk0),
+				PublicKey:         bls.PublicKeyToCompressedBytes(pk1),
+			},
+			expectedVdr: &validators.GetValidatorOutput{
+				PublicKey: pk0,
+				Weight:    10,
+			},
+		},
+		{
+			name: "invalid weight diff",
+			diff: &state.ValidatorDiff{
+				WeightDiff: state.ValidatorWeightDiff{
+					Amount: 15,
+				},
+			},
+			vdr: &validators.GetValidatorOutput{
+				Weight: 10,
+			},
+			expectedErr: safemath.ErrUnderflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			test.diff.SubnetID = subnetID
+			test.diff.NodeID = nodeID
+			change, previousVdr, err := newValidatorSetChange(test.diff, test.vdr)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			test.expected.SubnetID = subnetID
+			test.expected.NodeID = nodeID
+			require.Equal(test.expected, change)
+			if test.expectedVdr != nil {
+				test.expectedVdr.NodeID = nodeID
+			}
+			require.Equal(test.expectedVdr, previousVdr)
+		})
+	}
+}
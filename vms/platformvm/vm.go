@@ -13,12 +13,16 @@ import (
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/api/aggregator"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/cache/lru"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/acp118"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -30,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/addressindex"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
@@ -56,6 +61,11 @@ var (
 	_ snowmanblock.SetPreferenceWithContextChainVM = (*VM)(nil)
 	_ secp256k1fx.VM                               = (*VM)(nil)
 	_ validators.State                             = (*VM)(nil)
+
+	// Prefix of the signatures collected by the aggregator API
+	warpSignaturesPrefix = []byte("warpSignatures")
+	// Prefix of the address index
+	addressIndexPrefix = []byte("addressIndex")
 )
 
 type VM struct {
@@ -85,6 +95,12 @@ type VM struct {
 
 	manager blockexecutor.Manager
 
+	// addressIndex is nil if the address index is disabled.
+	addressIndex *addressindex.Index
+
+	// Wakes up validator set subscribers when a validator set changes.
+	validatorSetNotifier *validatorSetNotifier
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -149,6 +165,8 @@ func (vm *VM) Initialize(
 
 	validatorManager := pvalidators.NewManager(vm.Internal, vm.state, vm.metrics, &vm.clock)
 	vm.State = validatorManager
+	vm.validatorSetNotifier = newValidatorSetNotifier()
+	vm.Internal.Validators.RegisterCallbackListener(vm.validatorSetNotifier)
 	utxoVerifier := utxo.NewVerifier(vm.ctx, &vm.clock, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state, &vm.clock)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
@@ -246,6 +264,32 @@ func (vm *VM) Initialize(
 		}
 	}()
 
+	if execConfig.IndexAddressTxs {
+		chain, err := newAddressIndexChain(vm.state, genesisBytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse genesis UTXOs: %w", err)
+		}
+		vm.addressIndex, err = addressindex.New(
+			vm.ctx.Log,
+			&vm.ctx.Lock,
+			prefixdb.New(addressIndexPrefix, vm.db),
+			chain,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to initialize address index: %w", err)
+		}
+
+		// Incrementing [awaitShutdown] would cause a deadlock since [Build]
+		// grabs the context lock.
+		go func() {
+			if err := vm.addressIndex.Build(vm.onShutdownCtx); err != nil {
+				vm.ctx.Log.Error("building address index failed",
+					zap.Error(err),
+				)
+			}
+		}()
+	}
+
 	return nil
 }
 
@@ -301,11 +345,12 @@ func (vm *VM) initBlockchains() error {
 	}
 
 	if vm.SybilProtectionEnabled {
-		for subnetID := range vm.TrackedSubnets {
+		for _, subnetID := range vm.TrackedSubnets.List() {
 			if err := vm.createSubnet(subnetID); err != nil {
 				return err
 			}
 		}
+		vm.TrackedSubnets.OnTracked(vm.onSubnetTracked)
 	} else {
 		subnetIDs, err := vm.state.GetSubnetIDs()
 		if err != nil {
@@ -336,6 +381,24 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 	return nil
 }
 
+// onSubnetTracked creates the chains of [subnetID] after this node started
+// tracking it.
+func (vm *VM) onSubnetTracked(subnetID ids.ID) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.onShutdownCtx.Err() != nil {
+		return
+	}
+
+	if err := vm.createSubnet(subnetID); err != nil {
+		vm.ctx.Log.Error("failed to create chains of tracked subnet",
+			zap.Stringer("subnetID", subnetID),
+			zap.Error(err),
+		)
+	}
+}
+
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
 	vm.bootstrapped.Set(false)
@@ -363,7 +426,7 @@ func (vm *VM) onNormalOperationsStarted() error {
 	vl := validators.NewLogger(vm.ctx.Log, constants.PrimaryNetworkID, vm.ctx.NodeID)
 	vm.Validators.RegisterSetCallbackListener(constants.PrimaryNetworkID, vl)
 
-	for subnetID := range vm.TrackedSubnets {
+	for _, subnetID := range vm.TrackedSubnets.List() {
 		vl := validators.NewLogger(vm.ctx.Log, subnetID, vm.ctx.NodeID)
 		vm.Validators.RegisterSetCallbackListener(subnetID, vl)
 	}
@@ -455,10 +518,35 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		addrManager:           avax.NewAddressManager(vm.ctx),
 		stakerAttributesCache: lru.NewCache[ids.ID, *stakerAttributes](stakerAttributesCacheSize),
 	}
-	err := server.RegisterService(service, "platform")
+	if err := server.RegisterService(service, "platform"); err != nil {
+		return nil, err
+	}
+
+	signatureStore, err := acp118.NewDBSignatureStore(
+		prefixdb.New(warpSignaturesPrefix, vm.db),
+		acp118.DefaultMaxStoredMessages,
+	)
+	if err != nil {
+		return nil, err
+	}
+	signatureAggregator := acp118.NewCachedSignatureAggregator(
+		vm.ctx.Log,
+		vm.Network.NewClient(acp118.HandlerID, p2p.PeerSampler{Peers: vm.Network.Peers()}),
+		signatureStore,
+	)
+	aggregatorService, err := aggregator.NewService(
+		vm.ctx.Log,
+		validators.NewLockedState(&vm.ctx.Lock, vm.State),
+		signatureAggregator,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return map[string]http.Handler{
-		"": server,
-	}, err
+		"":                            server,
+		"/aggregator":                 aggregatorService,
+		validatorSubscriptionEndpoint: &validatorSubscriptionHandler{vm: vm},
+	}, nil
 }
 
 func (*VM) NewHTTPHandler(context.Context) (http.Handler, error) {
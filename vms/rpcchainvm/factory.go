@@ -72,5 +72,5 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 
 	f.processTracker.TrackProcess(status.Pid)
 	f.runtimeTracker.TrackRuntime(stopper)
-	return NewClient(clientConn, stopper, status.Pid, f.processTracker, f.metricsGatherer, log), nil
+	return NewClient(clientConn, stopper, status.Pid, status.Exited, f.processTracker, f.metricsGatherer, log), nil
 }
@@ -10,13 +10,14 @@ package subprocess
 
 import (
 	"context"
+	"errors"
+	"os"
 	"os/exec"
 	"syscall"
 
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
-	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 )
 
@@ -26,31 +27,27 @@ func NewCmd(path string, args ...string) *exec.Cmd {
 	return cmd
 }
 
-func stop(ctx context.Context, log logging.Logger, cmd *exec.Cmd) {
-	waitChan := make(chan error)
-	go func() {
-		// attempt graceful shutdown
-		errs := wrappers.Errs{}
-		err := cmd.Process.Signal(syscall.SIGTERM)
-		errs.Add(err)
-		_, err = cmd.Process.Wait()
-		errs.Add(err)
-		waitChan <- errs.Err
-		close(waitChan)
-	}()
+func stop(ctx context.Context, log logging.Logger, cmd *exec.Cmd, exited <-chan struct{}) {
+	select {
+	case <-exited:
+		log.Debug("subprocess already exited")
+		return
+	default:
+	}
+
+	// attempt graceful shutdown
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		log.Error("failed to signal subprocess",
+			zap.Error(err),
+		)
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, runtime.DefaultGracefulTimeout)
 	defer cancel()
 
 	select {
-	case err := <-waitChan:
-		if err == nil {
-			log.Debug("subprocess gracefully shutdown")
-		} else {
-			log.Error("subprocess graceful shutdown failed",
-				zap.Error(err),
-			)
-		}
+	case <-exited:
+		log.Debug("subprocess gracefully shutdown")
 	case <-ctx.Done():
 		// force kill
 		err := cmd.Process.Kill()
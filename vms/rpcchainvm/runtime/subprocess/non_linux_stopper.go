@@ -7,6 +7,8 @@ package subprocess
 
 import (
 	"context"
+	"errors"
+	"os"
 	"os/exec"
 
 	"go.uber.org/zap"
@@ -18,9 +20,9 @@ func NewCmd(path string, args ...string) *exec.Cmd {
 	return exec.Command(path, args...)
 }
 
-func stop(_ context.Context, log logging.Logger, cmd *exec.Cmd) {
+func stop(_ context.Context, log logging.Logger, cmd *exec.Cmd, _ <-chan struct{}) {
 	err := cmd.Process.Kill()
-	if err == nil {
+	if err == nil || errors.Is(err, os.ErrProcessDone) {
 		log.Debug("subprocess was killed")
 	} else {
 		log.Error("subprocess was killed",
@@ -23,6 +23,10 @@ import (
 	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
 )
 
+// outputWaitDelay is how long the output of the VM process is collected for
+// after the process exits.
+const outputWaitDelay = time.Second
+
 type Config struct {
 	// Stderr of the VM process written to this writer.
 	Stderr io.Writer
@@ -38,13 +42,16 @@ type Status struct {
 	Pid int
 	// Address of the VM gRPC service.
 	Addr string
+	// Exited is closed once the process has exited, either because it was
+	// stopped or because it exited unexpectedly.
+	Exited <-chan struct{}
 }
 
 // Bootstrap starts a VM as a subprocess after initialization completes and
 // pipes the IO to the appropriate writers.
 //
 // The subprocess is expected to be stopped by the caller if a non-nil error is
-// returned. If piping the IO fails then the subprocess will be stopped.
+// returned.
 //
 // TODO: create the listener inside this method once we refactor the tests
 func Bootstrap(
@@ -81,14 +88,13 @@ func Bootstrap(
 		}
 	}
 
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
+	// The output of the process is collected by cmd so that it is fully
+	// written by the time the process is reaped. Processes spawned by the VM
+	// may hold the output pipes open after the VM exits, so waiting on them is
+	// bounded.
+	cmd.Stdout = config.Stdout
+	cmd.Stderr = config.Stderr
+	cmd.WaitDelay = outputWaitDelay
 
 	// start subproccess
 	if err := cmd.Start(); err != nil {
@@ -96,33 +102,7 @@ func Bootstrap(
 	}
 
 	log := config.Log
-	stopper := NewStopper(log, cmd)
-
-	// start stdout collector
-	go func() {
-		_, err := io.Copy(config.Stdout, stdoutPipe)
-		if err != nil {
-			log.Error("stdout collector failed",
-				zap.Error(err),
-			)
-		}
-		stopper.Stop(context.TODO())
-
-		log.Info("stdout collector shutdown")
-	}()
-
-	// start stderr collector
-	go func() {
-		_, err := io.Copy(config.Stderr, stderrPipe)
-		if err != nil {
-			log.Error("stderr collector failed",
-				zap.Error(err),
-			)
-		}
-		stopper.Stop(context.TODO())
-
-		log.Info("stderr collector shutdown")
-	}()
+	stopper := newStopper(log, cmd)
 
 	// wait for handshake success
 	timeout := time.NewTimer(config.HandshakeTimeout)
@@ -145,8 +125,9 @@ func Bootstrap(
 	)
 
 	status := &Status{
-		Pid:  cmd.Process.Pid,
-		Addr: intitializer.vmAddr,
+		Pid:    cmd.Process.Pid,
+		Addr:   intitializer.vmAddr,
+		Exited: stopper.exited,
 	}
 	return status, stopper, nil
 }
@@ -8,25 +8,48 @@ import (
 	"os/exec"
 	"sync"
 
+	"go.uber.org/zap"
+
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 )
 
+// NewStopper returns a Stopper of [cmd], which must have been started. The
+// returned Stopper reaps the process once it exits.
 func NewStopper(logger logging.Logger, cmd *exec.Cmd) runtime.Stopper {
-	return &stopper{
+	return newStopper(logger, cmd)
+}
+
+func newStopper(logger logging.Logger, cmd *exec.Cmd) *stopper {
+	s := &stopper{
 		cmd:    cmd,
 		logger: logger,
+		exited: make(chan struct{}),
 	}
+	go s.wait()
+	return s
 }
 
 type stopper struct {
 	once   sync.Once
 	cmd    *exec.Cmd
 	logger logging.Logger
+	// exited is closed once the process has exited and been reaped.
+	exited chan struct{}
+}
+
+// wait reaps the process once it exits.
+func (s *stopper) wait() {
+	err := s.cmd.Wait()
+	s.logger.Debug("subprocess exited",
+		zap.Error(err),
+	)
+	close(s.exited)
 }
 
 func (s *stopper) Stop(ctx context.Context) {
 	s.once.Do(func() {
-		stop(ctx, s.logger, s.cmd)
+		stop(ctx, s.logger, s.cmd, s.exited)
+		<-s.exited
 	})
 }
@@ -294,7 +294,7 @@ func buildClientHelper(require *require.Assertions, testKey string) *VMClient {
 	clientConn, err := grpcutils.Dial(status.Addr)
 	require.NoError(err)
 
-	return NewClient(clientConn, stopper, status.Pid, nil, metrics.NewPrefixGatherer(), &logging.NoLog{})
+	return NewClient(clientConn, stopper, status.Pid, status.Exited, nil, metrics.NewPrefixGatherer(), &logging.NoLog{})
 }
 
 func TestStateSyncEnabled(t *testing.T) {
@@ -87,6 +87,7 @@ type VMClient struct {
 	client          vmpb.VMClient
 	runtime         runtime.Stopper
 	pid             int
+	exited          <-chan struct{}
 	processTracker  resource.ProcessTracker
 	metricsGatherer metrics.MultiGatherer
 
@@ -107,6 +108,7 @@ func NewClient(
 	clientConn *grpc.ClientConn,
 	runtime runtime.Stopper,
 	pid int,
+	exited <-chan struct{},
 	processTracker resource.ProcessTracker,
 	metricsGatherer metrics.MultiGatherer,
 	logger logging.Logger,
@@ -115,6 +117,7 @@ func NewClient(
 		client:          vmpb.NewVMClient(clientConn),
 		runtime:         runtime,
 		pid:             pid,
+		exited:          exited,
 		processTracker:  processTracker,
 		metricsGatherer: metricsGatherer,
 		conns:           []*grpc.ClientConn{clientConn},
@@ -376,6 +379,12 @@ func (vm *VMClient) Shutdown(ctx context.Context) error {
 	return errs.Err
 }
 
+// Exited returns a channel that is closed once the plugin process has exited.
+// The process exits when the VM is shut down, or if the plugin crashes.
+func (vm *VMClient) Exited() <-chan struct{} {
+	return vm.exited
+}
+
 func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]http.Handler, error) {
 	resp, err := vm.client.CreateHandlers(ctx, &emptypb.Empty{})
 	if err != nil {
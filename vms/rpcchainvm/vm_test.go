@@ -22,10 +22,13 @@ import (
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/enginetest"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/blockmock"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/blocktest"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -48,6 +51,7 @@ const (
 	lastAcceptedBlockPostStateSummaryAcceptTestKey = "lastAcceptedBlockPostStateSummaryAcceptTest"
 	contextTestKey                                 = "contextTest"
 	batchedParseBlockCachingTestKey                = "batchedParseBlockCachingTest"
+	crashTestKey                                   = "crashTest"
 )
 
 var TestServerPluginMap = map[string]func(*testing.T, bool) block.ChainVM{
@@ -60,6 +64,7 @@ var TestServerPluginMap = map[string]func(*testing.T, bool) block.ChainVM{
 	lastAcceptedBlockPostStateSummaryAcceptTestKey: lastAcceptedBlockPostStateSummaryAcceptTestPlugin,
 	contextTestKey:                                 contextEnabledTestPlugin,
 	batchedParseBlockCachingTestKey:                batchedParseBlockCachingTestPlugin,
+	crashTestKey:                                   crashTestPlugin,
 }
 
 // helperProcess helps with creating the subnet binary for testing.
@@ -110,6 +115,56 @@ func TestHelperProcess(t *testing.T) {
 	os.Exit(0)
 }
 
+// crashTestPlugin is a VM whose process exits when a block is built.
+func crashTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	ctrl := gomock.NewController(t)
+	vm := blockmock.NewChainVM(ctrl)
+
+	if loadExpectations {
+		gomock.InOrder(
+			vm.EXPECT().Initialize(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			).Return(nil).Times(1),
+			vm.EXPECT().LastAccepted(gomock.Any()).Return(preSummaryBlk.ID(), nil).Times(1),
+			vm.EXPECT().GetBlock(gomock.Any(), gomock.Any()).Return(preSummaryBlk, nil).Times(1),
+			vm.EXPECT().BuildBlock(gomock.Any()).DoAndReturn(
+				func(context.Context) (snowman.Block, error) {
+					os.Exit(1)
+					return nil, nil
+				},
+			).Times(1),
+		)
+	}
+
+	return vm
+}
+
+func TestVMClientExitedOnCrash(t *testing.T) {
+	require := require.New(t)
+
+	vm := buildClientHelper(require, crashTestKey)
+	defer vm.runtime.Stop(t.Context())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(vm.Initialize(t.Context(), ctx, memdb.New(), nil, nil, nil, nil, nil))
+
+	select {
+	case <-vm.Exited():
+		require.FailNow("plugin exited before crashing")
+	default:
+	}
+
+	// The plugin crashes while building the block.
+	_, _ = vm.BuildBlock(t.Context())
+
+	select {
+	case <-vm.Exited():
+	case <-time.After(10 * time.Second):
+		require.FailNow("plugin crash wasn't detected")
+	}
+}
+
 // TestVMServerInterface ensures that the RPCs methods defined by VMServer
 // interface are implemented.
 func TestVMServerInterface(t *testing.T) {
@@ -246,6 +301,7 @@ func TestNewHTTPHandler(t *testing.T) {
 		runtime.NewManager(),
 		123,
 		nil,
+		nil,
 		metrics.NewLabelGatherer(""),
 		logging.NoLog{},
 	)
@@ -54,10 +54,12 @@ type Mempool[T Tx] interface {
 	// Remove [txs] and any conflicts of [txs] from the mempool.
 	Remove(txs ...T)
 
-	// Peek returns the oldest tx in the mempool.
+	// Peek returns the next tx to be issued: the oldest tx, or the tx paying
+	// the highest fee per unit of complexity if the mempool is prioritized.
 	Peek() (tx T, exists bool)
 
-	// Iterate iterates over the txs until f returns false
+	// Iterate iterates over the txs, in the order they would be issued, until
+	// f returns false
 	Iterate(f func(tx T) bool)
 
 	// Note: dropped txs are added to droppedTxIDs but are not evicted from
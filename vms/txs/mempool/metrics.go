@@ -42,3 +42,47 @@ func (m *metrics) Update(numTxs, bytesAvailable int) {
 	m.numTxs.Set(float64(numTxs))
 	m.bytesAvailableMetric.Set(float64(bytesAvailable))
 }
+
+var _ PriorityMetrics = (*priorityMetrics)(nil)
+
+type priorityMetrics struct {
+	*metrics
+	numEvicted  prometheus.Counter
+	numReplaced prometheus.Counter
+}
+
+func NewPriorityMetrics(namespace string, registerer prometheus.Registerer) (*priorityMetrics, error) {
+	baseMetrics, err := NewMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &priorityMetrics{
+		metrics: baseMetrics,
+		numEvicted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "evicted",
+			Help:      "Number of transactions evicted to make space for higher priority transactions",
+		}),
+		numReplaced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "replaced",
+			Help:      "Number of transactions replaced by conflicting transactions paying a higher fee",
+		}),
+	}
+
+	err = errors.Join(
+		registerer.Register(m.numEvicted),
+		registerer.Register(m.numReplaced),
+	)
+
+	return m, err
+}
+
+func (m *priorityMetrics) Evicted() {
+	m.numEvicted.Inc()
+}
+
+func (m *priorityMetrics) Replaced() {
+	m.numReplaced.Inc()
+}
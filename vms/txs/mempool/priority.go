@@ -0,0 +1,373 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/google/btree"
+
+	"github.com/ava-labs/avalanchego/cache/lru"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/lock"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/setmap"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// replacementBumpPercent is the minimum percentage by which the fee of a tx
+// must exceed the combined fee of the txs it conflicts with in order to replace
+// them.
+const replacementBumpPercent = 10
+
+var (
+	_ Mempool[Tx] = (*prioritizedMempool[Tx])(nil)
+
+	ErrInsufficientReplacementFee = errors.New("insufficient fee to replace conflicting txs")
+	ErrNoComplexity               = errors.New("tx has no complexity")
+	ErrEvicted                    = errors.New("evicted by higher priority tx")
+	ErrReplaced                   = errors.New("replaced by conflicting tx")
+)
+
+// MeterFunc returns the fee paid by a tx and the complexity of the tx, expressed
+// in units of gas. Txs that pay a higher fee per unit of complexity are
+// prioritized.
+type MeterFunc[T Tx] func(tx T) (fee uint64, complexity uint64, err error)
+
+type PriorityMetrics interface {
+	Metrics
+	// Evicted records that a tx was evicted to make space for a higher
+	// priority tx.
+	Evicted()
+	// Replaced records that a tx was replaced by a conflicting tx paying a
+	// higher fee.
+	Replaced()
+}
+
+type prioritizedTx[T Tx] struct {
+	tx T
+	// fee and complexity are cached so that the ordering of the tree can not
+	// change while the tx is in the mempool.
+	fee        uint64
+	complexity uint64
+	txID       ids.ID
+}
+
+// compareFeeRate compares the fee per unit of complexity paid by a and b
+// without loss of precision.
+func compareFeeRate[T Tx](a, b prioritizedTx[T]) int {
+	aHi, aLo := bits.Mul64(a.fee, b.complexity)
+	bHi, bLo := bits.Mul64(b.fee, a.complexity)
+	if c := cmp.Compare(aHi, bHi); c != 0 {
+		return c
+	}
+	return cmp.Compare(aLo, bLo)
+}
+
+func lessPrioritizedTx[T Tx](a, b prioritizedTx[T]) bool {
+	if c := compareFeeRate(a, b); c != 0 {
+		return c < 0
+	}
+
+	// Break ties with txID
+	return a.txID.Compare(b.txID) < 0
+}
+
+// prioritizedMempool orders txs by the fee they pay per unit of complexity
+// rather than by their arrival time. When full, the lowest priced txs are evicted to make room
+// for higher priced txs. A tx that conflicts with txs already in the mempool
+// replaces them if it pays a sufficiently higher fee than all of them combined.
+type prioritizedMempool[T Tx] struct {
+	lock           sync.RWMutex
+	cond           *lock.Cond
+	tree           *btree.BTreeG[prioritizedTx[T]]
+	unissuedTxs    map[ids.ID]prioritizedTx[T]
+	consumedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Consumed UTXOs
+	bytesAvailable int
+	droppedTxIDs   *lru.Cache[ids.ID, error] // TxID -> Verification error
+
+	meter   MeterFunc[T]
+	metrics PriorityMetrics
+}
+
+// NewPrioritized returns a mempool that orders txs by the fee they pay per unit
+// of complexity, as reported by [meter].
+func NewPrioritized[T Tx](
+	metrics PriorityMetrics,
+	meter MeterFunc[T],
+) *prioritizedMempool[T] {
+	m := &prioritizedMempool[T]{
+		tree:           btree.NewG[prioritizedTx[T]](2, lessPrioritizedTx[T]),
+		unissuedTxs:    make(map[ids.ID]prioritizedTx[T]),
+		consumedUTXOs:  setmap.New[ids.ID, ids.ID](),
+		bytesAvailable: maxMempoolSize,
+		droppedTxIDs:   lru.NewCache[ids.ID, error](droppedTxIDsCacheSize),
+		meter:          meter,
+		metrics:        metrics,
+	}
+	m.cond = lock.NewCond(&m.lock)
+	m.updateMetrics()
+	return m
+}
+
+func (m *prioritizedMempool[T]) updateMetrics() {
+	m.metrics.Update(len(m.unissuedTxs), m.bytesAvailable)
+}
+
+func (m *prioritizedMempool[T]) Add(tx T) error {
+	txID := tx.ID()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.unissuedTxs[txID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTx, txID)
+	}
+
+	txSize := tx.Size()
+	if txSize > MaxTxSize {
+		return fmt.Errorf("%w: %s size (%d) > max size (%d)",
+			ErrTxTooLarge,
+			txID,
+			txSize,
+			MaxTxSize,
+		)
+	}
+
+	fee, complexity, err := m.meter(tx)
+	if err != nil {
+		return fmt.Errorf("failed to meter %s: %w", txID, err)
+	}
+	if complexity == 0 {
+		return fmt.Errorf("%w: %s", ErrNoComplexity, txID)
+	}
+
+	newTx := prioritizedTx[T]{
+		tx:         tx,
+		fee:        fee,
+		complexity: complexity,
+		txID:       txID,
+	}
+
+	// Any txs that consume the same UTXOs must be replaced by this tx.
+	var (
+		inputs         = tx.InputIDs()
+		conflicts      set.Set[ids.ID]
+		conflictsFee   uint64
+		bytesAvailable = m.bytesAvailable
+	)
+	for input := range inputs {
+		conflictID, ok := m.consumedUTXOs.GetKey(input)
+		if !ok || conflicts.Contains(conflictID) {
+			continue
+		}
+
+		conflict := m.unissuedTxs[conflictID]
+		if compareFeeRate(newTx, conflict) <= 0 {
+			return fmt.Errorf("%w: %s fee rate (%d/%d) does not exceed %s fee rate (%d/%d)",
+				ErrInsufficientReplacementFee,
+				txID,
+				newTx.fee,
+				newTx.complexity,
+				conflictID,
+				conflict.fee,
+				conflict.complexity,
+			)
+		}
+
+		conflictsFee, err = safemath.Add(conflictsFee, conflict.fee)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInsufficientReplacementFee, err)
+		}
+		conflicts.Add(conflictID)
+		bytesAvailable += conflict.tx.Size()
+	}
+	if conflicts.Len() != 0 && !canReplace(newTx.fee, conflictsFee) {
+		return fmt.Errorf("%w: %s fee (%d) does not exceed the combined fee (%d) of %d conflicting txs by %d%%",
+			ErrInsufficientReplacementFee,
+			txID,
+			newTx.fee,
+			conflictsFee,
+			conflicts.Len(),
+			replacementBumpPercent,
+		)
+	}
+
+	// Evict the lowest priced txs that are priced below this tx until there
+	// is enough space for it.
+	var toEvict []ids.ID
+	if txSize > bytesAvailable {
+		m.tree.Ascend(func(item prioritizedTx[T]) bool {
+			if compareFeeRate(item, newTx) >= 0 {
+				return false
+			}
+			// Conflicts have already been accounted for.
+			if conflicts.Contains(item.txID) {
+				return true
+			}
+
+			toEvict = append(toEvict, item.txID)
+			bytesAvailable += item.tx.Size()
+			return txSize > bytesAvailable
+		})
+	}
+	if txSize > bytesAvailable {
+		return fmt.Errorf("%w: %s size (%d) > available space (%d)",
+			ErrMempoolFull,
+			txID,
+			txSize,
+			bytesAvailable,
+		)
+	}
+
+	for conflictID := range conflicts {
+		m.remove(conflictID)
+		m.droppedTxIDs.Put(conflictID, ErrReplaced)
+		m.metrics.Replaced()
+	}
+	for _, evictID := range toEvict {
+		m.remove(evictID)
+		m.droppedTxIDs.Put(evictID, ErrEvicted)
+		m.metrics.Evicted()
+	}
+
+	m.bytesAvailable -= txSize
+	m.tree.ReplaceOrInsert(newTx)
+	m.unissuedTxs[txID] = newTx
+	m.updateMetrics()
+
+	// Mark these UTXOs as consumed in the mempool
+	m.consumedUTXOs.Put(txID, inputs)
+
+	// An added tx must not be marked as dropped.
+	m.droppedTxIDs.Evict(txID)
+	m.cond.Broadcast()
+	return nil
+}
+
+// canReplace returns true if a tx paying [newFee] is allowed to replace txs
+// paying a combined fee of [oldFee].
+func canReplace(newFee, oldFee uint64) bool {
+	if newFee <= oldFee {
+		return false
+	}
+
+	// newFee * 100 >= oldFee * (100 + replacementBumpPercent)
+	newHi, newLo := bits.Mul64(newFee, 100)
+	oldHi, oldLo := bits.Mul64(oldFee, 100+replacementBumpPercent)
+	if newHi != oldHi {
+		return newHi > oldHi
+	}
+	return newLo >= oldLo
+}
+
+func (m *prioritizedMempool[T]) Get(txID ids.ID) (T, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tx, ok := m.unissuedTxs[txID]
+	return tx.tx, ok
+}
+
+func (m *prioritizedMempool[T]) Remove(txs ...T) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, tx := range txs {
+		txID := tx.ID()
+		// If the transaction is in the mempool, remove it.
+		if _, ok := m.unissuedTxs[txID]; ok {
+			m.remove(txID)
+			continue
+		}
+
+		// If the transaction isn't in the mempool, remove any conflicts it has.
+		inputs := tx.InputIDs()
+		for _, removed := range m.consumedUTXOs.DeleteOverlapping(inputs) {
+			m.remove(removed.Key)
+		}
+	}
+	m.updateMetrics()
+}
+
+// remove assumes the lock is held. The caller is responsible for updating the
+// metrics.
+func (m *prioritizedMempool[T]) remove(txID ids.ID) {
+	tx, ok := m.unissuedTxs[txID]
+	if !ok {
+		return
+	}
+
+	delete(m.unissuedTxs, txID)
+	m.tree.Delete(tx)
+	m.consumedUTXOs.DeleteKey(txID)
+	m.bytesAvailable += tx.tx.Size()
+}
+
+// Peek returns the tx paying the highest fee per unit of complexity in the
+// mempool.
+func (m *prioritizedMempool[T]) Peek() (T, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tx, exists := m.tree.Max()
+	return tx.tx, exists
+}
+
+// Iterate iterates over the txs, from highest to lowest fee per unit of
+// complexity, until f returns false.
+func (m *prioritizedMempool[T]) Iterate(f func(T) bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	m.tree.Descend(func(item prioritizedTx[T]) bool {
+		return f(item.tx)
+	})
+}
+
+func (m *prioritizedMempool[_]) MarkDropped(txID ids.ID, reason error) {
+	if errors.Is(reason, ErrMempoolFull) {
+		return
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if _, ok := m.unissuedTxs[txID]; ok {
+		return
+	}
+
+	m.droppedTxIDs.Put(txID, reason)
+}
+
+func (m *prioritizedMempool[_]) GetDropReason(txID ids.ID) error {
+	err, _ := m.droppedTxIDs.Get(txID)
+	return err
+}
+
+func (m *prioritizedMempool[_]) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return len(m.unissuedTxs)
+}
+
+func (m *prioritizedMempool[_]) WaitForEvent(ctx context.Context) (common.Message, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for len(m.unissuedTxs) == 0 {
+		if err := m.cond.Wait(ctx); err != nil {
+			return 0, err
+		}
+	}
+	return common.PendingTxs, nil
+}
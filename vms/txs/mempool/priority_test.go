@@ -0,0 +1,334 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type dummyPrioritizedTx struct {
+	dummyTx
+	fee        uint64
+	complexity uint64
+}
+
+func dummyMeter(tx *dummyPrioritizedTx) (uint64, uint64, error) {
+	return tx.fee, tx.complexity, nil
+}
+
+type countingMetrics struct {
+	noMetrics
+	numEvicted  int
+	numReplaced int
+}
+
+func (m *countingMetrics) Evicted() {
+	m.numEvicted++
+}
+
+func (m *countingMetrics) Replaced() {
+	m.numReplaced++
+}
+
+func newPrioritizedTx(index uint64, size int, fee uint64) *dummyPrioritizedTx {
+	return &dummyPrioritizedTx{
+		dummyTx: dummyTx{
+			size:     size,
+			id:       ids.GenerateTestID(),
+			inputIDs: []ids.ID{ids.Empty.Prefix(index)},
+		},
+		fee:        fee,
+		complexity: uint64(size),
+	}
+}
+
+func TestPrioritizedMempoolOrdering(t *testing.T) {
+	require := require.New(t)
+
+	m := NewPrioritized(&countingMetrics{}, dummyMeter)
+
+	_, exists := m.Peek()
+	require.False(exists)
+
+	tx0 := newPrioritizedTx(0, 32, 1)
+	tx1 := newPrioritizedTx(1, 32, 3)
+	tx2 := newPrioritizedTx(2, 32, 2)
+	require.NoError(m.Add(tx0))
+	require.NoError(m.Add(tx1))
+	require.NoError(m.Add(tx2))
+
+	tx, exists := m.Peek()
+	require.True(exists)
+	require.Equal(tx1, tx)
+
+	var iteratedTxs []*dummyPrioritizedTx
+	m.Iterate(func(tx *dummyPrioritizedTx) bool {
+		iteratedTxs = append(iteratedTxs, tx)
+		return true
+	})
+	require.Equal([]*dummyPrioritizedTx{tx1, tx2, tx0}, iteratedTxs)
+
+	m.Remove(tx1)
+
+	tx, exists = m.Peek()
+	require.True(exists)
+	require.Equal(tx2, tx)
+	require.Equal(2, m.Len())
+}
+
+func TestPrioritizedMempoolOrdersByComplexity(t *testing.T) {
+	require := require.New(t)
+
+	m := NewPrioritized(&countingMetrics{}, dummyMeter)
+
+	// Both txs have the same size and fee, but tx1 is less complex and
+	// therefore pays a higher fee per unit of complexity.
+	tx0 := newPrioritizedTx(0, 32, 100)
+	tx0.complexity = 20
+	tx1 := newPrioritizedTx(1, 32, 100)
+	tx1.complexity = 10
+	require.NoError(m.Add(tx0))
+	require.NoError(m.Add(tx1))
+
+	tx, exists := m.Peek()
+	require.True(exists)
+	require.Equal(tx1, tx)
+
+	tx2 := newPrioritizedTx(2, 32, 100)
+	tx2.complexity = 0
+	err := m.Add(tx2)
+	require.ErrorIs(err, ErrNoComplexity)
+}
+
+func TestPrioritizedMempoolEviction(t *testing.T) {
+	tests := []struct {
+		name        string
+		initialFee  uint64
+		newFee      uint64
+		expectedErr error
+	}{
+		{
+			name:        "evict lower priced tx",
+			initialFee:  1,
+			newFee:      2,
+			expectedErr: nil,
+		},
+		{
+			name:        "do not evict equally priced tx",
+			initialFee:  1,
+			newFee:      1,
+			expectedErr: ErrMempoolFull,
+		},
+		{
+			name:        "do not evict higher priced tx",
+			initialFee:  2,
+			newFee:      1,
+			expectedErr: ErrMempoolFull,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			metrics := &countingMetrics{}
+			m := NewPrioritized(metrics, dummyMeter)
+
+			const numTxs = maxMempoolSize / MaxTxSize
+			initialTxs := make([]*dummyPrioritizedTx, numTxs)
+			for i := range initialTxs {
+				initialTxs[i] = newPrioritizedTx(uint64(i), MaxTxSize, test.initialFee)
+				require.NoError(m.Add(initialTxs[i]))
+			}
+
+			tx := newPrioritizedTx(numTxs, MaxTxSize, test.newFee)
+			err := m.Add(tx)
+			require.ErrorIs(err, test.expectedErr)
+
+			_, exists := m.Get(tx.ID())
+			require.Equal(err == nil, exists)
+			require.Equal(numTxs, m.Len())
+			if err != nil {
+				require.Zero(metrics.numEvicted)
+				return
+			}
+
+			require.Equal(1, metrics.numEvicted)
+
+			// Exactly one of the initial txs should have been evicted.
+			var numEvicted int
+			for _, initialTx := range initialTxs {
+				if _, ok := m.Get(initialTx.ID()); ok {
+					continue
+				}
+				numEvicted++
+				require.ErrorIs(m.GetDropReason(initialTx.ID()), ErrEvicted)
+			}
+			require.Equal(1, numEvicted)
+		})
+	}
+}
+
+func TestPrioritizedMempoolReplaceByFee(t *testing.T) {
+	tests := []struct {
+		name        string
+		initialFee  uint64
+		newFee      uint64
+		expectedErr error
+	}{
+		{
+			name:        "replace with sufficient bump",
+			initialFee:  100,
+			newFee:      100 + replacementBumpPercent,
+			expectedErr: nil,
+		},
+		{
+			name:        "insufficient bump",
+			initialFee:  100,
+			newFee:      100 + replacementBumpPercent - 1,
+			expectedErr: ErrInsufficientReplacementFee,
+		},
+		{
+			name:        "zero priced tx is not replaced by zero priced tx",
+			initialFee:  0,
+			newFee:      0,
+			expectedErr: ErrInsufficientReplacementFee,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			metrics := &countingMetrics{}
+			m := NewPrioritized(metrics, dummyMeter)
+
+			initialTx := newPrioritizedTx(0, 32, test.initialFee)
+			require.NoError(m.Add(initialTx))
+
+			tx := newPrioritizedTx(0, 32, test.newFee)
+			err := m.Add(tx)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(1, m.Len())
+
+			_, exists := m.Get(initialTx.ID())
+			require.Equal(err != nil, exists)
+			if err != nil {
+				require.Zero(metrics.numReplaced)
+				return
+			}
+
+			require.Equal(1, metrics.numReplaced)
+			require.ErrorIs(m.GetDropReason(initialTx.ID()), ErrReplaced)
+
+			returnedTx, exists := m.Peek()
+			require.True(exists)
+			require.Equal(tx, returnedTx)
+		})
+	}
+}
+
+func TestPrioritizedMempoolReplaceMultipleConflicts(t *testing.T) {
+	tests := []struct {
+		name        string
+		newFee      uint64
+		expectedErr error
+	}{
+		{
+			name:   "exceeds combined fee by bump",
+			newFee: 220,
+		},
+		{
+			name:        "exceeds each fee but not combined fee",
+			newFee:      219,
+			expectedErr: ErrInsufficientReplacementFee,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			metrics := &countingMetrics{}
+			m := NewPrioritized(metrics, dummyMeter)
+
+			tx0 := newPrioritizedTx(0, 32, 100)
+			tx1 := newPrioritizedTx(1, 32, 100)
+			require.NoError(m.Add(tx0))
+			require.NoError(m.Add(tx1))
+
+			// The new tx conflicts with both tx0 and tx1.
+			tx := newPrioritizedTx(0, 32, test.newFee)
+			tx.inputIDs = append(tx.inputIDs, tx1.inputIDs...)
+			err := m.Add(tx)
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				require.Equal(2, m.Len())
+				require.Zero(metrics.numReplaced)
+				return
+			}
+
+			require.Equal(1, m.Len())
+			require.Equal(2, metrics.numReplaced)
+			require.ErrorIs(m.GetDropReason(tx0.ID()), ErrReplaced)
+			require.ErrorIs(m.GetDropReason(tx1.ID()), ErrReplaced)
+		})
+	}
+}
+
+func TestCompareFeeRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        prioritizedTx[*dummyPrioritizedTx]
+		b        prioritizedTx[*dummyPrioritizedTx]
+		expected int
+	}{
+		{
+			name:     "higher fee per unit of complexity",
+			a:        prioritizedTx[*dummyPrioritizedTx]{fee: 10, complexity: 10},
+			b:        prioritizedTx[*dummyPrioritizedTx]{fee: 15, complexity: 20},
+			expected: 1,
+		},
+		{
+			name:     "equal fee per unit of complexity",
+			a:        prioritizedTx[*dummyPrioritizedTx]{fee: 10, complexity: 10},
+			b:        prioritizedTx[*dummyPrioritizedTx]{fee: 20, complexity: 20},
+			expected: 0,
+		},
+		{
+			name:     "does not overflow",
+			a:        prioritizedTx[*dummyPrioritizedTx]{fee: math.MaxUint64, complexity: math.MaxUint64},
+			b:        prioritizedTx[*dummyPrioritizedTx]{fee: math.MaxUint64 - 1, complexity: math.MaxUint64},
+			expected: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(test.expected, compareFeeRate(test.a, test.b))
+			require.Equal(-test.expected, compareFeeRate(test.b, test.a))
+		})
+	}
+}
+
+func TestPrioritizedMempoolRemoveConflict(t *testing.T) {
+	require := require.New(t)
+
+	m := NewPrioritized(&countingMetrics{}, dummyMeter)
+
+	tx := newPrioritizedTx(0, 32, 1)
+	txConflict := newPrioritizedTx(0, 32, 1)
+
+	require.NoError(m.Add(tx))
+
+	m.Remove(txConflict)
+
+	_, exists := m.Peek()
+	require.False(exists)
+	require.Zero(m.Len())
+	require.Equal(maxMempoolSize, m.bytesAvailable)
+}
@@ -56,3 +56,21 @@ func NewContextFromClients(
 		GasPrice:          gasPriceMultiplier * gasPrice,
 	}, nil
 }
+
+// WithCurrentGasPrice returns a copy of [builderContext] whose gas price is
+// based on the current gas price reported by [chainClient]. This allows
+// transactions to be rebuilt after the dynamic fee has increased.
+func WithCurrentGasPrice(
+	ctx context.Context,
+	chainClient *platformvm.Client,
+	builderContext *builder.Context,
+) (*builder.Context, error) {
+	_, gasPrice, _, err := chainClient.GetFeeState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newContext := *builderContext
+	newContext.GasPrice = gasPriceMultiplier * gasPrice
+	return &newContext, nil
+}
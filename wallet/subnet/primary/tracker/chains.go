@@ -0,0 +1,173 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+var (
+	_ Chain            = (*pChain)(nil)
+	_ Chain            = (*xChain)(nil)
+	_ pbuilder.Backend = (*inputsBackend)(nil)
+)
+
+type pChain struct {
+	client *platformvm.Client
+}
+
+// NewPChain returns a Chain that issues txs to the P-chain.
+func NewPChain(client *platformvm.Client) Chain {
+	return &pChain{client: client}
+}
+
+func (*pChain) Alias() string {
+	return pbuilder.Alias
+}
+
+func (c *pChain) IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	return c.client.IssueTx(ctx, txBytes)
+}
+
+func (c *pChain) GetTxStatus(ctx context.Context, txID ids.ID) (Status, string, error) {
+	res, err := c.client.GetTxStatus(ctx, txID)
+	if err != nil {
+		return Unknown, "", err
+	}
+
+	switch res.Status {
+	case status.Committed:
+		return Accepted, "", nil
+	case status.Aborted:
+		return Rejected, "", nil
+	case status.Processing:
+		return Processing, "", nil
+	case status.Dropped:
+		return Dropped, res.Reason, nil
+	default:
+		return Unknown, "", nil
+	}
+}
+
+// NewPRebuilder returns a Rebuilder that builds a tx with [build], under the
+// current P-chain gas price, and signs it with [signer].
+//
+// The builder passed to [build] spends UTXOs of [addrs] from [backend], but
+// only those consumed by the dropped tx. The rebuilt tx therefore conflicts
+// with the dropped tx, so at most one of them can be accepted. If the inputs
+// of the dropped tx can not pay the current fee, the rebuild fails.
+func NewPRebuilder(
+	client *platformvm.Client,
+	builderContext *pbuilder.Context,
+	addrs set.Set[ids.ShortID],
+	backend pbuilder.Backend,
+	signer psigner.Signer,
+	build func(pbuilder.Builder) (txs.UnsignedTx, error),
+) Rebuilder {
+	return func(ctx context.Context, droppedTxBytes []byte) ([]byte, error) {
+		droppedTx, err := txs.Parse(txs.Codec, droppedTxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dropped tx: %w", err)
+		}
+
+		newContext, err := p.WithCurrentGasPrice(ctx, client, builderContext)
+		if err != nil {
+			return nil, err
+		}
+
+		builder := pbuilder.New(
+			addrs,
+			newContext,
+			&inputsBackend{
+				Backend:  backend,
+				inputIDs: droppedTx.InputIDs(),
+			},
+		)
+		utx, err := build(builder)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := psigner.SignUnsigned(ctx, signer, utx)
+		if err != nil {
+			return nil, err
+		}
+		return tx.Bytes(), nil
+	}
+}
+
+// inputsBackend only exposes the UTXOs with the provided input IDs.
+type inputsBackend struct {
+	pbuilder.Backend
+	inputIDs set.Set[ids.ID]
+}
+
+func (b *inputsBackend) UTXOs(ctx context.Context, sourceChainID ids.ID) ([]*avax.UTXO, error) {
+	utxos, err := b.Backend.UTXOs(ctx, sourceChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := utxos[:0:0]
+	for _, utxo := range utxos {
+		if b.inputIDs.Contains(utxo.InputID()) {
+			filtered = append(filtered, utxo)
+		}
+	}
+	return filtered, nil
+}
+
+type xChain struct {
+	client *avm.Client
+}
+
+// NewXChain returns a Chain that issues txs to the X-chain.
+//
+// The X-chain does not report processing txs, so txs are only considered
+// dropped after Config.UnknownTimeout.
+func NewXChain(client *avm.Client) Chain {
+	return &xChain{client: client}
+}
+
+func (*xChain) Alias() string {
+	return xbuilder.Alias
+}
+
+func (c *xChain) IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	return c.client.IssueTx(ctx, txBytes)
+}
+
+func (c *xChain) GetTxStatus(ctx context.Context, txID ids.ID) (Status, string, error) {
+	//nolint:staticcheck // Only whether the tx was accepted is needed
+	txStatus, err := c.client.GetTxStatus(ctx, txID)
+	if err != nil {
+		return Unknown, "", err
+	}
+
+	switch txStatus {
+	case choices.Accepted:
+		return Accepted, "", nil
+	case choices.Rejected:
+		return Rejected, "", nil
+	case choices.Processing:
+		return Processing, "", nil
+	default:
+		return Unknown, "", nil
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+type testBackend struct {
+	utxos []*avax.UTXO
+}
+
+func (b *testBackend) UTXOs(context.Context, ids.ID) ([]*avax.UTXO, error) {
+	return b.utxos, nil
+}
+
+func (*testBackend) GetOwner(context.Context, ids.ID) (fx.Owner, error) {
+	return nil, nil
+}
+
+func TestInputsBackend(t *testing.T) {
+	require := require.New(t)
+
+	utxos := []*avax.UTXO{
+		{UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()}},
+		{UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()}},
+		{UTXOID: avax.UTXOID{TxID: ids.GenerateTestID(), OutputIndex: 1}},
+	}
+	original := slices.Clone(utxos)
+	backend := &inputsBackend{
+		Backend:  &testBackend{utxos: utxos},
+		inputIDs: set.Of(utxos[0].InputID(), utxos[2].InputID()),
+	}
+
+	filtered, err := backend.UTXOs(t.Context(), ids.Empty)
+	require.NoError(err)
+	require.Equal([]*avax.UTXO{utxos[0], utxos[2]}, filtered)
+
+	// The UTXOs of the underlying backend must not be modified.
+	require.Equal(original, utxos)
+}
@@ -0,0 +1,485 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package tracker follows issued transactions until they are decided,
+// re-issuing or rebuilding transactions that are dropped by the network.
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	DefaultMaxAttempts    = 5
+	DefaultUnknownTimeout = 30 * time.Second
+)
+
+var (
+	ErrUnknownChain    = errors.New("unknown chain")
+	ErrDuplicateTx     = errors.New("tx is already tracked")
+	ErrTooManyAttempts = errors.New("too many attempts")
+	ErrRejected        = errors.New("tx was rejected")
+)
+
+// Status is the status of a tx as reported by a Chain.
+type Status uint8
+
+const (
+	// Unknown means the node does not know about the tx. Because some chains
+	// do not report processing txs, an Unknown tx is only considered dropped
+	// once Config.UnknownTimeout has passed since it was issued. As the tx may
+	// still be processing, it is then re-issued rather than rebuilt.
+	Unknown Status = iota
+	Processing
+	Accepted
+	Rejected
+	Dropped
+)
+
+func (s Status) String() string {
+	switch s {
+	case Unknown:
+		return "Unknown"
+	case Processing:
+		return "Processing"
+	case Accepted:
+		return "Accepted"
+	case Rejected:
+		return "Rejected"
+	case Dropped:
+		return "Dropped"
+	default:
+		return "Invalid status"
+	}
+}
+
+// Chain issues txs to, and fetches the status of txs from, a chain.
+type Chain interface {
+	// Alias identifies the chain ("P" or "X").
+	Alias() string
+	// IssueTx issues the signed tx.
+	IssueTx(ctx context.Context, txBytes []byte) (ids.ID, error)
+	// GetTxStatus returns the status of [txID]. If the tx was dropped, the
+	// reason may be returned.
+	GetTxStatus(ctx context.Context, txID ids.ID) (Status, string, error)
+}
+
+// Rebuilder builds and signs a replacement for the dropped tx [droppedTxBytes].
+// The replacement should be built under the current fee state of the chain.
+//
+// The replacement must conflict with the dropped tx, by spending the same
+// inputs, so that at most one of them can be accepted if the dropped tx is
+// later issued by another node.
+type Rebuilder func(ctx context.Context, droppedTxBytes []byte) ([]byte, error)
+
+// EventType identifies a change in the lifecycle of a tracked tx.
+type EventType uint8
+
+const (
+	// TxIssued is reported when a tx is first issued.
+	TxIssued EventType = iota
+	// TxReissued is reported when a dropped tx is issued again.
+	TxReissued
+	// TxRebuilt is reported when a dropped tx is replaced by a rebuilt tx.
+	TxRebuilt
+	// TxAccepted is reported when a tx is accepted. The tx is no longer
+	// tracked.
+	TxAccepted
+	// TxFailed is reported when a tx is rejected, or when a dropped tx could
+	// not be issued again. The tx is no longer tracked.
+	TxFailed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case TxIssued:
+		return "Issued"
+	case TxReissued:
+		return "Reissued"
+	case TxRebuilt:
+		return "Rebuilt"
+	case TxAccepted:
+		return "Accepted"
+	case TxFailed:
+		return "Failed"
+	default:
+		return "Invalid event type"
+	}
+}
+
+// Event describes a change in the lifecycle of a tracked tx.
+type Event struct {
+	Type EventType
+	// Identifies the chain ("P" or "X")
+	ChainAlias string
+	// ID of the tracked tx
+	TxID ids.ID
+	// ID of the dropped tx that was replaced. Only set for TxRebuilt events.
+	ReplacedTxID ids.ID
+	// Number of times the tx has been re-issued or rebuilt
+	Attempts int
+	// Reason the tx failed. Only set for TxFailed events.
+	Err error
+}
+
+type Config struct {
+	// MaxAttempts is the number of times a dropped tx is re-issued or rebuilt
+	// before it is reported as failed.
+	MaxAttempts int
+	// UnknownTimeout is the duration after a tx is issued that it is
+	// considered dropped if the chain does not know about it.
+	UnknownTimeout time.Duration
+	// OnEvent, if non-nil, is called with every lifecycle event. It is never
+	// called while the Tracker's lock is held, so it may call back into the
+	// Tracker.
+	OnEvent func(Event)
+}
+
+// DefaultConfig returns the default tracker config.
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:    DefaultMaxAttempts,
+		UnknownTimeout: DefaultUnknownTimeout,
+	}
+}
+
+// txRecord is the persisted state of a tracked tx.
+type txRecord struct {
+	ChainAlias string    `json:"chainAlias"`
+	Bytes      []byte    `json:"bytes"`
+	Attempts   int       `json:"attempts"`
+	IssuedAt   time.Time `json:"issuedAt"`
+}
+
+type trackedTx struct {
+	txRecord
+	txID    ids.ID
+	rebuild Rebuilder
+}
+
+// Tracker follows issued txs until they are decided.
+//
+// Every tracked tx is persisted, so txs that were pending when the process
+// stopped are tracked again when the Tracker is recreated. Because Rebuilders
+// can not be persisted, such txs are only re-issued, not rebuilt, if they are
+// dropped.
+type Tracker struct {
+	config Config
+	db     database.Database
+	chains map[string]Chain
+	clock  mockable.Clock
+
+	lock    sync.Mutex
+	pending map[ids.ID]*trackedTx
+}
+
+// New returns a tracker that persists tracked txs to [db] and issues txs to
+// [chains]. Any txs that were tracked in [db] are loaded.
+func New(config Config, db database.Database, chains ...Chain) (*Tracker, error) {
+	t := &Tracker{
+		config:  config,
+		db:      db,
+		chains:  make(map[string]Chain, len(chains)),
+		pending: make(map[ids.ID]*trackedTx),
+	}
+	for _, chain := range chains {
+		t.chains[chain.Alias()] = chain
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		txID, err := ids.ToID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		var record txRecord
+		if err := json.Unmarshal(it.Value(), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse tracked tx %s: %w", txID, err)
+		}
+		if _, ok := t.chains[record.ChainAlias]; !ok {
+			return nil, fmt.Errorf("%w %q for tracked tx %s", ErrUnknownChain, record.ChainAlias, txID)
+		}
+
+		t.pending[txID] = &trackedTx{
+			txRecord: record,
+			txID:     txID,
+		}
+	}
+	return t, it.Error()
+}
+
+// IssueTx issues [txBytes] to the chain identified by [chainAlias] and tracks
+// the tx until it is decided. If the tx is dropped, it is replaced by the tx
+// returned by [rebuild]. If [rebuild] is nil, the tx is re-issued instead.
+func (t *Tracker) IssueTx(
+	ctx context.Context,
+	chainAlias string,
+	txBytes []byte,
+	rebuild Rebuilder,
+) (ids.ID, error) {
+	chain, ok := t.chains[chainAlias]
+	if !ok {
+		return ids.Empty, fmt.Errorf("%w %q", ErrUnknownChain, chainAlias)
+	}
+
+	txID, err := chain.IssueTx(ctx, txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	tx := &trackedTx{
+		txRecord: txRecord{
+			ChainAlias: chainAlias,
+			Bytes:      txBytes,
+			IssuedAt:   t.clock.Time(),
+		},
+		txID:    txID,
+		rebuild: rebuild,
+	}
+	if err := t.add(tx); err != nil {
+		return txID, err
+	}
+	t.notify(Event{
+		Type:       TxIssued,
+		ChainAlias: chainAlias,
+		TxID:       txID,
+	})
+	return txID, nil
+}
+
+// add starts tracking [tx].
+func (t *Tracker) add(tx *trackedTx) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.pending[tx.txID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTx, tx.txID)
+	}
+	return t.put(tx)
+}
+
+// Pending returns the IDs of the txs that are currently tracked.
+func (t *Tracker) Pending() []ids.ID {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	txIDs := make([]ids.ID, 0, len(t.pending))
+	for txID := range t.pending {
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs
+}
+
+// Run polls the status of the tracked txs every [frequency] until [ctx] is
+// cancelled. Txs whose status could not be fetched are retried on the next
+// poll.
+func (t *Tracker) Run(ctx context.Context, frequency time.Duration) error {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		_ = t.Poll(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll fetches the status of every tracked tx once. Decided txs stop being
+// tracked and dropped txs are re-issued or rebuilt.
+//
+// Poll must not be called concurrently.
+//
+// Returns an error if the status of a tx could not be fetched or if the
+// database could not be updated.
+func (t *Tracker) Poll(ctx context.Context) error {
+	// Each tx is polled with a copy of its state, so that the tracked state is
+	// only modified while holding the lock.
+	t.lock.Lock()
+	pending := make([]trackedTx, 0, len(t.pending))
+	for _, tx := range t.pending {
+		pending = append(pending, *tx)
+	}
+	t.lock.Unlock()
+
+	var errs []error
+	for _, tx := range pending {
+		if err := t.poll(ctx, &tx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to poll %s: %w", tx.txID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// poll fetches the status of [tx]. [tx] must be a copy of the tracked state,
+// which is updated by persisting [tx].
+func (t *Tracker) poll(ctx context.Context, tx *trackedTx) error {
+	chain := t.chains[tx.ChainAlias]
+	status, reason, err := chain.GetTxStatus(ctx, tx.txID)
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case Processing:
+		return nil
+	case Accepted:
+		return t.finish(tx, TxAccepted, nil)
+	case Rejected:
+		return t.finish(tx, TxFailed, ErrRejected)
+	case Unknown:
+		if t.clock.Time().Sub(tx.IssuedAt) < t.config.UnknownTimeout {
+			return nil
+		}
+	}
+
+	// The tx was dropped, so it must be issued again.
+	if tx.Attempts >= t.config.MaxAttempts {
+		var dropErr error
+		if len(reason) > 0 {
+			dropErr = errors.New(reason)
+		}
+		return t.finish(tx, TxFailed, errors.Join(ErrTooManyAttempts, dropErr))
+	}
+
+	// Only txs that the chain reported as dropped are rebuilt. An Unknown tx
+	// may still be processing, so re-issuing the same tx is the only safe
+	// action.
+	rebuild := status == Dropped && tx.rebuild != nil
+	return t.retry(ctx, chain, tx, rebuild)
+}
+
+// retry re-issues [tx], or rebuilds it if [rebuild] is true.
+func (t *Tracker) retry(ctx context.Context, chain Chain, tx *trackedTx, rebuild bool) error {
+	tx.Attempts++
+
+	eventType := TxReissued
+	txBytes := tx.Bytes
+	if rebuild {
+		eventType = TxRebuilt
+
+		var err error
+		txBytes, err = tx.rebuild(ctx, tx.Bytes)
+		if err != nil {
+			return t.failAttempt(tx, fmt.Errorf("failed to rebuild tx: %w", err))
+		}
+	}
+
+	txID, err := chain.IssueTx(ctx, txBytes)
+	if err != nil {
+		return t.failAttempt(tx, fmt.Errorf("failed to issue tx: %w", err))
+	}
+
+	replacedTxID := tx.txID
+	if err := t.replace(tx, txID, txBytes); err != nil {
+		return err
+	}
+
+	event := Event{
+		Type:       eventType,
+		ChainAlias: tx.ChainAlias,
+		TxID:       txID,
+		Attempts:   tx.Attempts,
+	}
+	if eventType == TxRebuilt {
+		event.ReplacedTxID = replacedTxID
+	}
+	t.notify(event)
+	return nil
+}
+
+// replace updates [tx] to track the newly issued [txID] with [txBytes].
+func (t *Tracker) replace(tx *trackedTx, txID ids.ID, txBytes []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if replacedTxID := tx.txID; txID != replacedTxID {
+		if err := t.db.Delete(replacedTxID[:]); err != nil {
+			return err
+		}
+		delete(t.pending, replacedTxID)
+	}
+
+	tx.txID = txID
+	tx.Bytes = txBytes
+	tx.IssuedAt = t.clock.Time()
+	return t.put(tx)
+}
+
+// failAttempt records that an attempt to re-issue [tx] failed. If no attempts
+// remain, [tx] is reported as failed.
+func (t *Tracker) failAttempt(tx *trackedTx, err error) error {
+	if tx.Attempts >= t.config.MaxAttempts {
+		return t.finish(tx, TxFailed, errors.Join(ErrTooManyAttempts, err))
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// The tx will be retried on the next poll.
+	return t.put(tx)
+}
+
+// finish stops tracking [tx] and reports [eventType].
+func (t *Tracker) finish(tx *trackedTx, eventType EventType, err error) error {
+	if err := t.remove(tx.txID); err != nil {
+		return err
+	}
+
+	t.notify(Event{
+		Type:       eventType,
+		ChainAlias: tx.ChainAlias,
+		TxID:       tx.txID,
+		Attempts:   tx.Attempts,
+		Err:        err,
+	})
+	return nil
+}
+
+// remove stops tracking [txID].
+func (t *Tracker) remove(txID ids.ID) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.db.Delete(txID[:]); err != nil {
+		return err
+	}
+	delete(t.pending, txID)
+	return nil
+}
+
+// put persists [tx]. Assumes the lock is held.
+func (t *Tracker) put(tx *trackedTx) error {
+	recordBytes, err := json.Marshal(tx.txRecord)
+	if err != nil {
+		return err
+	}
+	if err := t.db.Put(tx.txID[:], recordBytes); err != nil {
+		return err
+	}
+	t.pending[tx.txID] = tx
+	return nil
+}
+
+// notify reports [event] to the OnEvent callback. Assumes the lock is not held,
+// so that the callback may call back into the Tracker.
+func (t *Tracker) notify(event Event) {
+	if t.config.OnEvent != nil {
+		t.config.OnEvent(event)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const testChainAlias = "P"
+
+var errTest = errors.New("non-nil error")
+
+type testChain struct {
+	statuses map[ids.ID]Status
+	issued   [][]byte
+	issueErr error
+}
+
+func newTestChain() *testChain {
+	return &testChain{
+		statuses: make(map[ids.ID]Status),
+	}
+}
+
+func (*testChain) Alias() string {
+	return testChainAlias
+}
+
+func (c *testChain) IssueTx(_ context.Context, txBytes []byte) (ids.ID, error) {
+	if c.issueErr != nil {
+		return ids.Empty, c.issueErr
+	}
+
+	txID := hashing.ComputeHash256Array(txBytes)
+	c.statuses[txID] = Processing
+	c.issued = append(c.issued, txBytes)
+	return txID, nil
+}
+
+func (c *testChain) GetTxStatus(_ context.Context, txID ids.ID) (Status, string, error) {
+	status := c.statuses[txID]
+	if status == Dropped {
+		return Dropped, "dropped", nil
+	}
+	return status, "", nil
+}
+
+type testTracker struct {
+	*Tracker
+	chain  *testChain
+	events []Event
+}
+
+func newTestTracker(t *testing.T, config Config) *testTracker {
+	tt := &testTracker{
+		chain: newTestChain(),
+	}
+	config.OnEvent = func(e Event) {
+		tt.events = append(tt.events, e)
+	}
+
+	var err error
+	tt.Tracker, err = New(config, memdb.New(), tt.chain)
+	require.NoError(t, err)
+	return tt
+}
+
+func (tt *testTracker) eventTypes() []EventType {
+	eventTypes := make([]EventType, len(tt.events))
+	for i, e := range tt.events {
+		eventTypes[i] = e.Type
+	}
+	return eventTypes
+}
+
+func TestTrackerAccepted(t *testing.T) {
+	require := require.New(t)
+
+	tt := newTestTracker(t, DefaultConfig())
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, []byte{1}, nil)
+	require.NoError(err)
+	require.Equal([]ids.ID{txID}, tt.Pending())
+
+	require.NoError(tt.Poll(t.Context()))
+	require.Equal([]ids.ID{txID}, tt.Pending())
+
+	tt.chain.statuses[txID] = Accepted
+	require.NoError(tt.Poll(t.Context()))
+	require.Empty(tt.Pending())
+	require.Equal([]EventType{TxIssued, TxAccepted}, tt.eventTypes())
+
+	// Decided txs should no longer be persisted.
+	tracker, err := New(DefaultConfig(), tt.db, tt.chain)
+	require.NoError(err)
+	require.Empty(tracker.Pending())
+}
+
+func TestTrackerRejected(t *testing.T) {
+	require := require.New(t)
+
+	tt := newTestTracker(t, DefaultConfig())
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, []byte{1}, nil)
+	require.NoError(err)
+
+	tt.chain.statuses[txID] = Rejected
+	require.NoError(tt.Poll(t.Context()))
+	require.Empty(tt.Pending())
+	require.Equal([]EventType{TxIssued, TxFailed}, tt.eventTypes())
+	require.ErrorIs(tt.events[1].Err, ErrRejected)
+}
+
+func TestTrackerOnEventCallsTracker(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		tracker *Tracker
+		pending [][]ids.ID
+	)
+	config := DefaultConfig()
+	config.OnEvent = func(Event) {
+		// The callback must not be called while the lock is held.
+		pending = append(pending, tracker.Pending())
+	}
+
+	chain := newTestChain()
+	tracker, err := New(config, memdb.New(), chain)
+	require.NoError(err)
+
+	txID, err := tracker.IssueTx(t.Context(), testChainAlias, []byte{1}, nil)
+	require.NoError(err)
+
+	chain.statuses[txID] = Accepted
+	require.NoError(tracker.Poll(t.Context()))
+	require.Equal([][]ids.ID{{txID}, {}}, pending)
+}
+
+func TestTrackerRebuild(t *testing.T) {
+	require := require.New(t)
+
+	tt := newTestTracker(t, DefaultConfig())
+
+	var numRebuilds byte
+	rebuild := func(_ context.Context, droppedTxBytes []byte) ([]byte, error) {
+		numRebuilds++
+		return append(droppedTxBytes, numRebuilds), nil
+	}
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, []byte{1}, rebuild)
+	require.NoError(err)
+
+	tt.chain.statuses[txID] = Dropped
+	require.NoError(tt.Poll(t.Context()))
+
+	rebuiltTxID := ids.ID(hashing.ComputeHash256Array([]byte{1, 1}))
+	require.Equal([]ids.ID{rebuiltTxID}, tt.Pending())
+	require.Equal([]EventType{TxIssued, TxRebuilt}, tt.eventTypes())
+	require.Equal(txID, tt.events[1].ReplacedTxID)
+	require.Equal(rebuiltTxID, tt.events[1].TxID)
+	require.Equal(1, tt.events[1].Attempts)
+
+	tt.chain.statuses[rebuiltTxID] = Accepted
+	require.NoError(tt.Poll(t.Context()))
+	require.Empty(tt.Pending())
+	require.Equal([]EventType{TxIssued, TxRebuilt, TxAccepted}, tt.eventTypes())
+}
+
+func TestTrackerReissueUnknown(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig()
+	tt := newTestTracker(t, config)
+	now := time.Unix(1_000, 0)
+	tt.clock.Set(now)
+
+	txBytes := []byte{1}
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, txBytes, nil)
+	require.NoError(err)
+
+	// Unknown txs are not dropped until the timeout has passed.
+	tt.chain.statuses[txID] = Unknown
+	require.NoError(tt.Poll(t.Context()))
+	require.Equal([]EventType{TxIssued}, tt.eventTypes())
+
+	tt.clock.Set(now.Add(config.UnknownTimeout))
+	require.NoError(tt.Poll(t.Context()))
+	require.Equal([]ids.ID{txID}, tt.Pending())
+	require.Equal([]EventType{TxIssued, TxReissued}, tt.eventTypes())
+	require.Equal([][]byte{txBytes, txBytes}, tt.chain.issued)
+}
+
+func TestTrackerUnknownNotRebuilt(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig()
+	tt := newTestTracker(t, config)
+	now := time.Unix(1_000, 0)
+	tt.clock.Set(now)
+
+	rebuild := func(context.Context, []byte) ([]byte, error) {
+		return nil, errTest
+	}
+	txBytes := []byte{1}
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, txBytes, rebuild)
+	require.NoError(err)
+
+	// An Unknown tx may still be processing, so it must be re-issued rather
+	// than rebuilt.
+	tt.chain.statuses[txID] = Unknown
+	tt.clock.Set(now.Add(config.UnknownTimeout))
+	require.NoError(tt.Poll(t.Context()))
+	require.Equal([]ids.ID{txID}, tt.Pending())
+	require.Equal([]EventType{TxIssued, TxReissued}, tt.eventTypes())
+	require.Equal([][]byte{txBytes, txBytes}, tt.chain.issued)
+}
+
+func TestTrackerTooManyAttempts(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig()
+	config.MaxAttempts = 2
+	tt := newTestTracker(t, config)
+
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, []byte{1}, nil)
+	require.NoError(err)
+
+	tt.chain.statuses[txID] = Dropped
+	tt.chain.issueErr = errTest
+	for range config.MaxAttempts {
+		require.NoError(tt.Poll(t.Context()))
+	}
+	require.Empty(tt.Pending())
+	require.Equal([]EventType{TxIssued, TxFailed}, tt.eventTypes())
+	require.ErrorIs(tt.events[1].Err, ErrTooManyAttempts)
+	require.ErrorIs(tt.events[1].Err, errTest)
+	require.Equal(config.MaxAttempts, tt.events[1].Attempts)
+}
+
+func TestTrackerPersistence(t *testing.T) {
+	require := require.New(t)
+
+	tt := newTestTracker(t, DefaultConfig())
+	txBytes := []byte{1}
+	txID, err := tt.IssueTx(t.Context(), testChainAlias, txBytes, nil)
+	require.NoError(err)
+
+	tracker, err := New(DefaultConfig(), tt.db, tt.chain)
+	require.NoError(err)
+	require.Equal([]ids.ID{txID}, tracker.Pending())
+
+	// Loaded txs can not be rebuilt, so they are re-issued.
+	tt.chain.statuses[txID] = Dropped
+	require.NoError(tracker.Poll(t.Context()))
+	require.Equal([]ids.ID{txID}, tracker.Pending())
+	require.Equal([][]byte{txBytes, txBytes}, tt.chain.issued)
+
+	_, err = New(DefaultConfig(), tt.db)
+	require.ErrorIs(err, ErrUnknownChain)
+}
@@ -7,6 +7,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database"
@@ -57,14 +61,48 @@ var (
 // foo was deleted at height 1000. When calling `reader.GetHeight(foo)` at
 // height 99 it will return a tuple `("foo's value is bar", 10)` returning the
 // value of `foo` at height 99 (which was set at height 10).
+//
+// Old versions can be removed with Prune. After pruning to a height, reads at
+// lower heights return ErrPruned.
 type Database struct {
-	db database.Database
+	db      database.Database
+	metrics *metrics
+
+	// minHeightLock ensures that minHeight is loaded from disk before it is
+	// first used.
+	minHeightLock sync.Mutex
+	// minHeightLoaded is set once minHeight has been successfully loaded from
+	// disk. If loading fails, it is retried on the next call to MinHeight.
+	minHeightLoaded atomic.Bool
+	// minHeight is the lowest height that can be read.
+	minHeight atomic.Uint64
+	// pruneLock ensures that only one prune is performed at a time.
+	pruneLock sync.Mutex
 }
 
+// New returns an ArchiveDB on top of [db] that does not report metrics.
 func New(db database.Database) *Database {
 	return &Database{
-		db: db,
+		db:      db,
+		metrics: newMetrics(""),
+	}
+}
+
+// NewWithMetrics returns an ArchiveDB on top of [db] that registers its metrics
+// under [namespace] with [registerer].
+func NewWithMetrics(
+	db database.Database,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Database, error) {
+	metrics := newMetrics(namespace)
+	if err := metrics.register(registerer); err != nil {
+		return nil, err
 	}
+	return &Database{
+		db:      db,
+		metrics: metrics,
+	}, nil
 }
 
 // Height returns the last written height.
@@ -72,7 +110,33 @@ func (db *Database) Height() (uint64, error) {
 	return database.GetUInt64(db.db, heightKey)
 }
 
+// MinHeight returns the lowest height that can be read. Heights below this
+// height have been pruned.
+func (db *Database) MinHeight() (uint64, error) {
+	if db.minHeightLoaded.Load() {
+		return db.minHeight.Load(), nil
+	}
+
+	db.minHeightLock.Lock()
+	defer db.minHeightLock.Unlock()
+
+	if db.minHeightLoaded.Load() {
+		return db.minHeight.Load(), nil
+	}
+
+	minHeight, err := database.WithDefault(database.GetUInt64, db.db, minHeightKey, 0)
+	if err != nil {
+		return 0, err
+	}
+	db.minHeight.Store(minHeight)
+	db.metrics.minHeight.Set(float64(minHeight))
+	db.minHeightLoaded.Store(true)
+	return minHeight, nil
+}
+
 // Open returns a reader for the state at the given height.
+//
+// If the height is below MinHeight, all reads will return ErrPruned.
 func (db *Database) Open(height uint64) *Reader {
 	return &Reader{
 		db:     db,
@@ -0,0 +1,225 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ database.Iterator = (*iterator)(nil)
+	_ database.Iterator = (*prefixIterator)(nil)
+)
+
+// iterator yields the value of every user key as of a given height.
+//
+// Because database keys are prefixed with the length of the user key, keys are
+// yielded in the order they are stored on disk rather than in lexicographical
+// order. Keys of the same length are yielded in lexicographical order.
+type iterator struct {
+	it     database.Iterator
+	height uint64
+	prefix []byte
+
+	// lastKey is the last user key whose entry at [height] was resolved. Any
+	// remaining entries of this key must be skipped.
+	lastKey []byte
+
+	key   []byte
+	value []byte
+	err   error
+}
+
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.it.Next() {
+		key, height, err := parseDBKeyFromUser(it.it.Key())
+		if errors.Is(err, ErrIncorrectKeyLength) {
+			// Metadata keys can not be parsed as user keys.
+			continue
+		}
+		if err != nil {
+			it.setError(err)
+			return false
+		}
+
+		// Entries of a user key are sorted by decreasing height, so only the
+		// first entry at or below [height] is relevant.
+		if it.lastKey != nil && bytes.Equal(key, it.lastKey) {
+			continue
+		}
+		if height > it.height {
+			continue
+		}
+		it.lastKey = slices.Clone(key)
+
+		if !bytes.HasPrefix(key, it.prefix) {
+			continue
+		}
+
+		value, exists := parseDBValue(it.it.Value())
+		if !exists {
+			// The key was deleted at or before [height].
+			continue
+		}
+
+		it.key = it.lastKey
+		it.value = slices.Clone(value)
+		return true
+	}
+
+	if err := it.it.Error(); err != nil {
+		it.setError(err)
+		return false
+	}
+
+	it.key = nil
+	it.value = nil
+	return false
+}
+
+func (it *iterator) setError(err error) {
+	it.err = err
+	it.key = nil
+	it.value = nil
+}
+
+func (it *iterator) Error() error {
+	return it.err
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.it.Release()
+}
+
+// prefixIterator yields every database entry whose user key starts with
+// [prefix], starting at the database key [start].
+//
+// Database keys are grouped by the length of their user key, so the entries
+// with a given user key prefix are spread across one contiguous range per key
+// length. Rather than scanning the whole database, prefixIterator seeks
+// directly to the matching range of each key length. The cost of iteration is
+// therefore proportional to the number of matching entries plus the number of
+// distinct key lengths stored after [start].
+type prefixIterator struct {
+	db     database.Iteratee
+	prefix []byte
+	// start is the database key to search for the next key length from. If
+	// nil, the search starts at the beginning of the database.
+	start []byte
+	// it iterates over the matching entries of the current key length. If
+	// nil, the next key length must be found.
+	it   database.Iterator
+	done bool
+	err  error
+}
+
+func (it *prefixIterator) Next() bool {
+	for {
+		if it.it != nil {
+			if it.it.Next() {
+				return true
+			}
+			if err := it.it.Error(); err != nil {
+				it.err = err
+				return false
+			}
+			it.it.Release()
+			it.it = nil
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		it.seekNextKeyLength()
+	}
+}
+
+// seekNextKeyLength finds the first key length stored at or after [it.start]
+// and, if user keys of that length can contain [it.prefix], opens an iterator
+// over the matching entries.
+func (it *prefixIterator) seekNextKeyLength() {
+	peek := it.db.NewIteratorWithStart(it.start)
+	hasNext := peek.Next()
+	var lengthPrefix []byte
+	if hasNext {
+		_, offset := binary.Uvarint(peek.Key())
+		if offset > 0 {
+			lengthPrefix = slices.Clone(peek.Key()[:offset])
+		}
+	}
+	err := peek.Error()
+	peek.Release()
+
+	switch {
+	case err != nil:
+		it.err = err
+		return
+	case !hasNext:
+		it.done = true
+		return
+	case lengthPrefix == nil:
+		it.err = ErrParsingKeyLength
+		return
+	}
+
+	keyLen, _ := binary.Uvarint(lengthPrefix)
+	if keyLen >= uint64(len(it.prefix)) {
+		dbPrefix := make([]byte, 0, len(lengthPrefix)+len(it.prefix))
+		dbPrefix = append(dbPrefix, lengthPrefix...)
+		dbPrefix = append(dbPrefix, it.prefix...)
+
+		dbStart := dbPrefix
+		if bytes.Compare(it.start, dbPrefix) > 0 {
+			dbStart = it.start
+		}
+		it.it = it.db.NewIteratorWithStartAndPrefix(dbStart, dbPrefix)
+	}
+
+	// The last byte of a uvarint is always less than 0x80, so incrementing it
+	// can not overflow. The result sorts after every key of this length.
+	nextStart := lengthPrefix
+	nextStart[len(nextStart)-1]++
+	it.start = nextStart
+}
+
+func (it *prefixIterator) Error() error {
+	return it.err
+}
+
+func (it *prefixIterator) Key() []byte {
+	if it.it == nil {
+		return nil
+	}
+	return it.it.Key()
+}
+
+func (it *prefixIterator) Value() []byte {
+	if it.it == nil {
+		return nil
+	}
+	return it.it.Value()
+}
+
+func (it *prefixIterator) Release() {
+	if it.it != nil {
+		it.it.Release()
+		it.it = nil
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+type keyValue struct {
+	key   string
+	value string
+}
+
+func TestIterator(t *testing.T) {
+	db := New(memdb.New())
+
+	writes := []struct {
+		height  uint64
+		puts    []keyValue
+		deletes []string
+	}{
+		{
+			height: 1,
+			puts: []keyValue{
+				{key: "a1", value: "a1@1"},
+				{key: "a2", value: "a2@1"},
+				{key: "b1", value: "b1@1"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+		{
+			height: 2,
+			puts: []keyValue{
+				{key: "a1", value: "a1@2"},
+			},
+			deletes: []string{"a2"},
+		},
+		{
+			height: 3,
+			puts: []keyValue{
+				{key: "a2", value: "a2@3"},
+				{key: "a3", value: "a3@3"},
+			},
+			deletes: []string{"b1"},
+		},
+	}
+	for _, write := range writes {
+		batch := db.NewBatch(write.height)
+		for _, put := range write.puts {
+			require.NoError(t, batch.Put([]byte(put.key), []byte(put.value)))
+		}
+		for _, key := range write.deletes {
+			require.NoError(t, batch.Delete([]byte(key)))
+		}
+		require.NoError(t, batch.Write())
+	}
+
+	tests := []struct {
+		name     string
+		height   uint64
+		start    []byte
+		prefix   []byte
+		expected []keyValue
+	}{
+		{
+			name:     "before any writes",
+			height:   0,
+			expected: nil,
+		},
+		{
+			name:   "all keys at height 1",
+			height: 1,
+			expected: []keyValue{
+				{key: "a1", value: "a1@1"},
+				{key: "a2", value: "a2@1"},
+				{key: "b1", value: "b1@1"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+		{
+			name:   "deleted key is skipped",
+			height: 2,
+			expected: []keyValue{
+				{key: "a1", value: "a1@2"},
+				{key: "b1", value: "b1@1"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+		{
+			name:   "re-added key is included",
+			height: 3,
+			expected: []keyValue{
+				{key: "a1", value: "a1@2"},
+				{key: "a2", value: "a2@3"},
+				{key: "a3", value: "a3@3"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+		{
+			name:   "height above last write",
+			height: 100,
+			prefix: []byte("a"),
+			expected: []keyValue{
+				{key: "a1", value: "a1@2"},
+				{key: "a2", value: "a2@3"},
+				{key: "a3", value: "a3@3"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+		{
+			name:   "prefix",
+			height: 1,
+			prefix: []byte("b"),
+			expected: []keyValue{
+				{key: "b1", value: "b1@1"},
+			},
+		},
+		{
+			name:   "start",
+			height: 1,
+			start:  []byte("a2"),
+			expected: []keyValue{
+				{key: "a2", value: "a2@1"},
+				{key: "b1", value: "b1@1"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+		{
+			name:   "start and prefix",
+			height: 3,
+			start:  []byte("a2"),
+			prefix: []byte("a"),
+			expected: []keyValue{
+				{key: "a2", value: "a2@3"},
+				{key: "a3", value: "a3@3"},
+				{key: "abc", value: "abc@1"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			it := db.Open(test.height).NewIteratorWithStartAndPrefix(test.start, test.prefix)
+			defer it.Release()
+
+			var actual []keyValue
+			for it.Next() {
+				actual = append(actual, keyValue{
+					key:   string(it.Key()),
+					value: string(it.Value()),
+				})
+			}
+			require.NoError(it.Error())
+			require.Equal(test.expected, actual)
+			require.Nil(it.Key())
+			require.Nil(it.Value())
+		})
+	}
+}
+
+func TestIteratorClosed(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	it := db.Open(1).NewIterator()
+	defer it.Release()
+
+	require.NoError(db.Close())
+
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), database.ErrClosed)
+}
+
+type countIterationDB struct {
+	database.Database
+	numNext int
+}
+
+func (db *countIterationDB) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *countIterationDB) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *countIterationDB) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *countIterationDB) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &countIterationIterator{
+		Iterator: db.Database.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+type countIterationIterator struct {
+	database.Iterator
+	db *countIterationDB
+}
+
+func (it *countIterationIterator) Next() bool {
+	it.db.numNext++
+	return it.Iterator.Next()
+}
+
+func TestIteratorPrefixSeeks(t *testing.T) {
+	require := require.New(t)
+
+	countDB := &countIterationDB{Database: memdb.New()}
+	db := New(countDB)
+
+	// Keys with a length of at least 128 bytes have a multi-byte length
+	// prefix.
+	var (
+		longKeyA = append([]byte("a"), make([]byte, 200)...)
+		longKeyB = append([]byte("b"), make([]byte, 200)...)
+	)
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("a"), []byte("a@1")))
+	require.NoError(batch.Put([]byte("a1"), []byte("a1@1")))
+	require.NoError(batch.Put(longKeyA, []byte("longA@1")))
+	require.NoError(batch.Put(longKeyB, []byte("longB@1")))
+	for i := 0; i < 1000; i++ {
+		require.NoError(batch.Put([]byte{'b', byte(i), byte(i >> 8)}, nil))
+	}
+	require.NoError(batch.Write())
+
+	countDB.numNext = 0
+
+	it := db.Open(1).NewIteratorWithPrefix([]byte("a"))
+	defer it.Release()
+
+	var actual []keyValue
+	for it.Next() {
+		actual = append(actual, keyValue{
+			key:   string(it.Key()),
+			value: string(it.Value()),
+		})
+	}
+	require.NoError(it.Error())
+	require.Equal(
+		[]keyValue{
+			{key: "a", value: "a@1"},
+			{key: "a1", value: "a1@1"},
+			{key: string(longKeyA), value: "longA@1"},
+		},
+		actual,
+	)
+
+	// The entries of the 1000 keys starting with "b" must not be read.
+	require.Less(countDB.numNext, 20)
+}
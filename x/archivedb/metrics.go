@@ -0,0 +1,54 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+)
+
+type metrics struct {
+	minHeight           prometheus.Gauge
+	pruneTargetHeight   prometheus.Gauge
+	pruneKeysScanned    prometheus.Counter
+	pruneEntriesDeleted prometheus.Counter
+}
+
+func newMetrics(prefix string) *metrics {
+	namespace := metric.AppendNamespace(prefix, "archivedb")
+	return &metrics{
+		minHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "min_height",
+			Help:      "Lowest height that can be read from the database",
+		}),
+		pruneTargetHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "prune_target_height",
+			Help:      "Height that the database is currently being pruned to, or 0 if no prune is in progress",
+		}),
+		pruneKeysScanned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prune_keys_scanned",
+			Help:      "Number of keys scanned while pruning",
+		}),
+		pruneEntriesDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "prune_entries_deleted",
+			Help:      "Number of historical entries deleted while pruning",
+		}),
+	}
+}
+
+func (m *metrics) register(registerer prometheus.Registerer) error {
+	return errors.Join(
+		registerer.Register(m.minHeight),
+		registerer.Register(m.pruneTargetHeight),
+		registerer.Register(m.pruneKeysScanned),
+		registerer.Register(m.pruneEntriesDeleted),
+	)
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// pruneBatchSize is the number of bytes of deletions to buffer before writing
+// them, along with the current progress, to disk.
+const pruneBatchSize = units.MiB
+
+var (
+	ErrPruned           = errors.New("height has been pruned")
+	ErrPruneAboveHeight = errors.New("prune height is above the last written height")
+
+	minHeightKey     = newDBKeyFromMetadata([]byte("minHeight"))
+	pruneProgressKey = newDBKeyFromMetadata([]byte("pruneProgress"))
+)
+
+// RunPruner prunes the database every [frequency] so that only the
+// [retainedHeights] heights below the last written height, and the last written
+// height itself, remain readable. Failed prunes are logged and retried on the
+// next tick.
+//
+// RunPruner blocks until [ctx] is cancelled.
+func (db *Database) RunPruner(
+	ctx context.Context,
+	log logging.Logger,
+	retainedHeights uint64,
+	frequency time.Duration,
+) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		height, err := db.Height()
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			log.Warn("failed to read archivedb height",
+				zap.Error(err),
+			)
+			continue
+		}
+		if height <= retainedHeights {
+			continue
+		}
+
+		pruneHeight := height - retainedHeights
+		if err := db.Prune(ctx, pruneHeight); err != nil && ctx.Err() == nil {
+			log.Warn("failed to prune archivedb",
+				zap.Uint64("height", pruneHeight),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// Prune removes every entry below [height], other than the newest entry of each
+// key at or below [height]. After Prune is called, reads below [height] return
+// ErrPruned.
+//
+// Pruning is performed incrementally, so Prune may be called in the background
+// while the database is being read from and written to. If pruning is
+// interrupted, calling Prune again will resume from where it was stopped.
+//
+// If [height] is less than or equal to MinHeight, any interrupted prune is
+// resumed and no additional entries are pruned. If [height] is greater than the
+// last written height, ErrPruneAboveHeight is returned.
+func (db *Database) Prune(ctx context.Context, height uint64) error {
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
+	lastHeight, err := database.WithDefault(database.GetUInt64, db.db, heightKey, 0)
+	if err != nil {
+		return err
+	}
+	if height > lastHeight {
+		return fmt.Errorf("%w: height %d > last written height %d", ErrPruneAboveHeight, height, lastHeight)
+	}
+
+	minHeight, err := db.MinHeight()
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.db.Get(pruneProgressKey)
+	switch {
+	case err == database.ErrNotFound:
+		cursor = nil
+	case err != nil:
+		return err
+	}
+	inProgress := err == nil
+
+	switch {
+	case height > minHeight:
+		// Any previous progress was made with a lower target height, so all
+		// keys must be scanned again.
+		batch := db.db.NewBatch()
+		if err := database.PutUInt64(batch, minHeightKey, height); err != nil {
+			return err
+		}
+		if err := batch.Put(pruneProgressKey, nil); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		db.minHeight.Store(height)
+		db.metrics.minHeight.Set(float64(height))
+		cursor = nil
+	case inProgress:
+		height = minHeight
+	default:
+		return nil
+	}
+
+	db.metrics.pruneTargetHeight.Set(float64(height))
+	defer db.metrics.pruneTargetHeight.Set(0)
+
+	if err := db.prune(ctx, height, cursor); err != nil {
+		return fmt.Errorf("failed to prune to height %d: %w", height, err)
+	}
+	return db.db.Delete(pruneProgressKey)
+}
+
+// prune deletes the entries that are no longer needed to serve reads at or
+// above [height], starting from the database key [cursor].
+func (db *Database) prune(ctx context.Context, height uint64, cursor []byte) error {
+	var (
+		batch = db.db.NewBatch()
+		it    = db.db.NewIteratorWithStart(cursor)
+
+		// lastKey is the last user key that has been scanned.
+		lastKey []byte
+		// retainedEntry is true if an entry at or below [height] has already
+		// been retained for [lastKey].
+		retainedEntry bool
+	)
+	// Defer the release of the iterator inside a closure to guarantee that the
+	// latest, not the first, iterator is released on return.
+	defer func() {
+		it.Release()
+	}()
+
+	for it.Next() {
+		dbKey := it.Key()
+		key, entryHeight, err := parseDBKeyFromUser(dbKey)
+		if errors.Is(err, ErrIncorrectKeyLength) {
+			// Metadata keys can not be parsed as user keys.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if lastKey == nil || !bytes.Equal(key, lastKey) {
+			// Only persist progress between user keys, so that a resumed prune
+			// retains the correct entry of the current key.
+			if batch.Size() >= pruneBatchSize {
+				_, keyPrefix := newDBKeyFromUser(key, 0)
+				if err := batch.Put(pruneProgressKey, keyPrefix); err != nil {
+					return err
+				}
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				// Reset the iterator to release references to now deleted
+				// keys.
+				if err := it.Error(); err != nil {
+					return err
+				}
+				it.Release()
+				it = db.db.NewIteratorWithStart(keyPrefix)
+				lastKey = nil
+				continue
+			}
+
+			lastKey = slices.Clone(key)
+			retainedEntry = false
+			db.metrics.pruneKeysScanned.Inc()
+		}
+
+		// Entries of a user key are sorted by decreasing height, so the first
+		// entry at or below [height] is the one that must be retained.
+		if entryHeight > height {
+			continue
+		}
+		if !retainedEntry {
+			retainedEntry = true
+			continue
+		}
+
+		if err := batch.Delete(dbKey); err != nil {
+			return err
+		}
+		db.metrics.pruneEntriesDeleted.Inc()
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2019, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	minHeight, err := db.MinHeight()
+	require.NoError(err)
+	require.Zero(minHeight)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@2")))
+	require.NoError(batch.Delete([]byte("key2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@3")))
+	require.NoError(batch.Write())
+
+	require.NoError(db.Prune(t.Context(), 2))
+	minHeight, err = db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), minHeight)
+
+	// Reads below the pruned height must fail.
+	reader := db.Open(1)
+	_, err = reader.Get([]byte("key1"))
+	require.ErrorIs(err, ErrPruned)
+
+	_, err = reader.Has([]byte("key1"))
+	require.ErrorIs(err, ErrPruned)
+
+	it := reader.NewIterator()
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrPruned)
+	it.Release()
+
+	// Reads at or above the pruned height must be unchanged.
+	reader = db.Open(2)
+	value, height, exists, err := reader.GetEntry([]byte("key1"))
+	require.NoError(err)
+	require.True(exists)
+	require.Equal(uint64(2), height)
+	require.Equal([]byte("value1@2"), value)
+
+	_, height, exists, err = reader.GetEntry([]byte("key2"))
+	require.NoError(err)
+	require.False(exists)
+	require.Equal(uint64(2), height)
+
+	value, height, exists, err = reader.GetEntry([]byte("key3"))
+	require.NoError(err)
+	require.True(exists)
+	require.Equal(uint64(1), height)
+	require.Equal([]byte("value3@1"), value)
+
+	value, err = db.Open(3).Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1@3"), value)
+
+	// Only the versions below the pruned height, that were not the newest
+	// version at the pruned height, should have been removed.
+	for _, entry := range []struct {
+		key    string
+		height uint64
+		exists bool
+	}{
+		{key: "key1", height: 1, exists: false},
+		{key: "key1", height: 2, exists: true},
+		{key: "key1", height: 3, exists: true},
+		{key: "key2", height: 1, exists: false},
+		{key: "key2", height: 2, exists: true},
+		{key: "key3", height: 1, exists: true},
+	} {
+		dbKey, _ := newDBKeyFromUser([]byte(entry.key), entry.height)
+		has, err := db.db.Has(dbKey)
+		require.NoError(err)
+		require.Equal(entry.exists, has, "%s@%d", entry.key, entry.height)
+	}
+
+	has, err := db.db.Has(pruneProgressKey)
+	require.NoError(err)
+	require.False(has)
+
+	// Pruning to a lower height is a noop.
+	require.NoError(db.Prune(t.Context(), 1))
+	minHeight, err = db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), minHeight)
+}
+
+func TestPruneResume(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@2")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@2")))
+	require.NoError(batch.Write())
+
+	// Simulate a prune to height 2 that was interrupted after processing
+	// key1.
+	_, key2Prefix := newDBKeyFromUser([]byte("key2"), 0)
+	require.NoError(database.PutUInt64(baseDB, minHeightKey, 2))
+	require.NoError(baseDB.Put(pruneProgressKey, key2Prefix))
+
+	db = New(baseDB)
+	minHeight, err := db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), minHeight)
+
+	_, err = db.Open(1).Get([]byte("key2"))
+	require.ErrorIs(err, ErrPruned)
+
+	require.NoError(db.Prune(t.Context(), 0))
+
+	key1Entry, _ := newDBKeyFromUser([]byte("key1"), 1)
+	has, err := baseDB.Has(key1Entry)
+	require.NoError(err)
+	require.True(has, "key1 should not have been pruned by the resumed prune")
+
+	key2Entry, _ := newDBKeyFromUser([]byte("key2"), 1)
+	has, err = baseDB.Has(key2Entry)
+	require.NoError(err)
+	require.False(has)
+
+	has, err = baseDB.Has(pruneProgressKey)
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPruneAboveHeight(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	require.ErrorIs(db.Prune(t.Context(), 1), ErrPruneAboveHeight)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	require.ErrorIs(db.Prune(t.Context(), 2), ErrPruneAboveHeight)
+	require.NoError(db.Prune(t.Context(), 1))
+
+	minHeight, err := db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(1), minHeight)
+}
+
+// failGetDB fails the next Get call if fail is set.
+type failGetDB struct {
+	database.Database
+	fail bool
+}
+
+func (db *failGetDB) Get(key []byte) ([]byte, error) {
+	if db.fail {
+		db.fail = false
+		return nil, errTest
+	}
+	return db.Database.Get(key)
+}
+
+func TestMinHeightRetriesAfterError(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	require.NoError(database.PutUInt64(baseDB, minHeightKey, 5))
+
+	failDB := &failGetDB{
+		Database: baseDB,
+		fail:     true,
+	}
+	db := New(failDB)
+
+	_, err := db.MinHeight()
+	require.ErrorIs(err, errTest)
+
+	minHeight, err := db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(5), minHeight)
+}
+
+func TestRunPruner(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	for height := uint64(1); height <= 10; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), []byte{byte(height)}))
+		require.NoError(batch.Write())
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		db.RunPruner(ctx, logging.NoLog{}, 3, time.Millisecond)
+	}()
+
+	require.Eventually(
+		func() bool {
+			minHeight, err := db.MinHeight()
+			return err == nil && minHeight == 7
+		},
+		time.Minute,
+		time.Millisecond,
+	)
+	cancel()
+	<-done
+
+	_, err := db.Open(6).Get([]byte("key"))
+	require.ErrorIs(err, ErrPruned)
+
+	value, err := db.Open(7).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte{7}, value)
+}
+
+func TestNewWithMetricsNamespace(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	db, err := NewWithMetrics(memdb.New(), "test", registry)
+	require.NoError(err)
+
+	batch := db.NewBatch(2)
+	require.NoError(batch.Write())
+	require.NoError(db.Prune(t.Context(), 2))
+
+	families, err := registry.Gather()
+	require.NoError(err)
+
+	minHeights := make(map[string]float64)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			if m.GetGauge() != nil {
+				minHeights[family.GetName()] = m.GetGauge().GetValue()
+			}
+		}
+	}
+	require.Equal(float64(2), minHeights["test_archivedb_min_height"])
+
+	// Registering a second database under the same namespace must fail.
+	_, err = NewWithMetrics(memdb.New(), "test", registry)
+	require.ErrorAs(err, &prometheus.AlreadyRegisteredError{})
+}
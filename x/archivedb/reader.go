@@ -3,9 +3,16 @@
 
 package archivedb
 
-import "github.com/ava-labs/avalanchego/database"
+import (
+	"fmt"
 
-var _ database.KeyValueReader = (*Reader)(nil)
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ database.KeyValueReader = (*Reader)(nil)
+	_ database.Iteratee       = (*Reader)(nil)
+)
 
 type Reader struct {
 	db     *Database
@@ -34,7 +41,12 @@ func (r *Reader) Get(key []byte) ([]byte, error) {
 // GetEntry retrieves the value of the provided key, the height it was last
 // modified at, and a boolean to indicate if the last modification was an
 // insertion. If the key has never been modified, ErrNotFound will be returned.
+// If the reader's height has been pruned, ErrPruned will be returned.
 func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
+	if err := r.checkPruned(); err != nil {
+		return nil, 0, false, err
+	}
+
 	it := r.db.db.NewIteratorWithStartAndPrefix(newDBKeyFromUser(key, r.height))
 	defer it.Release()
 
@@ -59,3 +71,68 @@ func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
 	}
 	return value, height, true, nil
 }
+
+// NewIterator iterates over the value of every key as of the reader's height.
+// Keys which were deleted at or below the reader's height are skipped.
+//
+// Note: Keys are sorted by their length before being sorted lexicographically.
+func (r *Reader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *Reader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *Reader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix iterates over the value of every key with
+// [prefix] as of the reader's height, starting at [start]. Keys are compared to
+// [start] using the same ordering that the iterator yields them in.
+//
+// Every historical entry of a visited key is read, not only the entry at the
+// reader's height. If [prefix] is empty, every entry in the database after
+// [start] is read. Otherwise, the iterator seeks directly to the matching keys
+// of each distinct key length, so the entries of keys without [prefix] are not
+// read.
+func (r *Reader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if err := r.checkPruned(); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+
+	var dbStart []byte
+	if start != nil {
+		_, dbStart = newDBKeyFromUser(start, 0)
+	}
+
+	var it database.Iterator
+	if len(prefix) == 0 {
+		it = r.db.db.NewIteratorWithStart(dbStart)
+	} else {
+		it = &prefixIterator{
+			db:     r.db.db,
+			prefix: prefix,
+			start:  dbStart,
+		}
+	}
+	return &iterator{
+		it:     it,
+		height: r.height,
+		prefix: prefix,
+	}
+}
+
+func (r *Reader) checkPruned() error {
+	minHeight, err := r.db.MinHeight()
+	if err != nil {
+		return err
+	}
+	if r.height < minHeight {
+		return fmt.Errorf("%w: height %d < min height %d", ErrPruned, r.height, minHeight)
+	}
+	return nil
+}